@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+
+	"github.com/marcelofabianov/redtogreen/internal/app"
+	"github.com/marcelofabianov/redtogreen/internal/platform/config"
+)
+
+func main() {
+	output := flag.String("o", "", "write the AsyncAPI document to this file instead of stdout")
+	flag.Parse()
+
+	if err := run(*output); err != nil {
+		log.Fatalf("asyncapi generation failed: %v", err)
+	}
+}
+
+func run(output string) error {
+	_ = godotenv.Load()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	catalog, err := app.NewEventCatalog()
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(app.NewAsyncAPIDocument(catalog, cfg))
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/joho/godotenv"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/bus/tail"
+	"github.com/marcelofabianov/redtogreen/internal/platform/config"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] subject [subject...]\n\nSubjects accept NATS wildcards, e.g. 'user.*' or '>'.\n\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	url := flag.String("url", "", "NATS URLs; defaults to APP_NATS_URLS")
+	jsonLines := flag.Bool("json", false, "print one JSON envelope per line instead of the pretty format")
+	all := flag.Bool("all", false, "replay the stored events before following new ones")
+	correlationID := flag.String("correlation-id", "", "only events with this correlation ID")
+	traceID := flag.String("trace-id", "", "only events with this trace ID")
+	userID := flag.String("user-id", "", "only events caused by this user ID")
+	flag.Parse()
+
+	filter, err := parseFilter(*correlationID, *traceID, *userID)
+	if err != nil {
+		log.Fatalf("invalid filter: %v", err)
+	}
+
+	format := tail.FormatPretty
+	if *jsonLines {
+		format = tail.FormatJSON
+	}
+
+	opts := tail.Options{
+		Subjects: flag.Args(),
+		Filter:   filter,
+		Format:   format,
+		All:      *all,
+		Out:      os.Stdout,
+		Errors:   os.Stderr,
+	}
+	if err := run(*url, opts); err != nil {
+		log.Fatalf("event tail failed: %v", err)
+	}
+}
+
+func run(url string, opts tail.Options) error {
+	if url == "" {
+		_ = godotenv.Load()
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			return err
+		}
+		url = cfg.NATS.URLs
+	}
+
+	nc, err := nats.Connect(url)
+	if err != nil {
+		return err
+	}
+	defer nc.Close()
+
+	js, err := jetstream.New(nc)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	return tail.Run(ctx, js, opts)
+}
+
+func parseFilter(correlationID, traceID, userID string) (tail.Filter, error) {
+	var filter tail.Filter
+	for _, f := range []struct {
+		name  string
+		value string
+		dest  *types.NullableUUID
+	}{
+		{"correlation-id", correlationID, &filter.CorrelationID},
+		{"trace-id", traceID, &filter.TraceID},
+		{"user-id", userID, &filter.UserID},
+	} {
+		if f.value == "" {
+			continue
+		}
+		id, err := types.ParseUUID(f.value)
+		if err != nil {
+			return filter, fmt.Errorf("%s: %w", f.name, err)
+		}
+		*f.dest = types.NewValidNullableUUID(id)
+	}
+	return filter, nil
+}
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE subject_keys (
+    subject_id UUID PRIMARY KEY,
+    encrypted_key BYTEA,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    destroyed_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_subject_keys_destroyed_at ON subject_keys (destroyed_at);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_subject_keys_destroyed_at;
+
+DROP TABLE IF EXISTS subject_keys;
+
+-- +goose StatementEnd
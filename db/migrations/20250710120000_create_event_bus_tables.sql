@@ -0,0 +1,54 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Events are read in commit order (xact_id, position), so publishers need no
+-- global lock.
+CREATE TABLE bus_events (
+    position BIGSERIAL PRIMARY KEY,
+    xact_id XID8 NOT NULL DEFAULT pg_current_xact_id(),
+    event_id UUID NOT NULL UNIQUE,
+    event_type VARCHAR(255) NOT NULL,
+    data JSONB NOT NULL,
+    headers JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_bus_events_event_type_xact_position ON bus_events (event_type, xact_id, position);
+
+CREATE INDEX idx_bus_events_created_at ON bus_events (created_at);
+
+CREATE TABLE bus_consumers (
+    name VARCHAR(255) PRIMARY KEY,
+    event_type VARCHAR(255) NOT NULL,
+    xact_id XID8 NOT NULL DEFAULT '0',
+    position BIGINT NOT NULL DEFAULT 0,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT,
+    claim_id UUID,
+    claimed_until TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE bus_dead_letters (
+    consumer VARCHAR(255) NOT NULL,
+    position BIGINT NOT NULL REFERENCES bus_events (position),
+    attempts INT NOT NULL,
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (consumer, position)
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS bus_dead_letters;
+
+DROP TABLE IF EXISTS bus_consumers;
+
+DROP INDEX IF EXISTS idx_bus_events_created_at;
+
+DROP INDEX IF EXISTS idx_bus_events_event_type_xact_position;
+
+DROP TABLE IF EXISTS bus_events;
+
+-- +goose StatementEnd
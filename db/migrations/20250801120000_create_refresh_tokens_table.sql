@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE refresh_tokens (
+    id UUID PRIMARY KEY,
+    family_id UUID NOT NULL,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    secret_hash VARCHAR(254) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL,
+    rotated_at TIMESTAMPTZ,
+    replaced_by_id UUID,
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens (family_id)
+WHERE
+    revoked_at IS NULL;
+
+CREATE INDEX idx_refresh_tokens_user_id ON refresh_tokens (user_id)
+WHERE
+    revoked_at IS NULL;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_refresh_tokens_user_id;
+
+DROP INDEX IF EXISTS idx_refresh_tokens_family_id;
+
+DROP TABLE IF EXISTS refresh_tokens;
+
+-- +goose StatementEnd
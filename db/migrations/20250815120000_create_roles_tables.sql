@@ -0,0 +1,63 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE roles (
+    id UUID PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE,
+    description VARCHAR(254) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE role_permissions (
+    role_id UUID NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
+    permission VARCHAR(100) NOT NULL,
+    PRIMARY KEY (role_id, permission)
+);
+
+CREATE TABLE user_roles (
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    role_id UUID NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
+    assigned_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    assigned_by UUID,
+    PRIMARY KEY (user_id, role_id)
+);
+
+CREATE INDEX idx_user_roles_role_id ON user_roles (role_id);
+
+INSERT INTO
+    roles (id, name, description)
+VALUES
+    (
+        '0198b000-0000-7000-8000-000000000001',
+        'admin',
+        'Manages users and their roles.'
+    ),
+    (
+        '0198b000-0000-7000-8000-000000000002',
+        'viewer',
+        'Reads users.'
+    );
+
+INSERT INTO
+    role_permissions (role_id, permission)
+VALUES
+    ('0198b000-0000-7000-8000-000000000001', 'users:read'),
+    ('0198b000-0000-7000-8000-000000000001', 'users:update'),
+    ('0198b000-0000-7000-8000-000000000001', 'users:archive'),
+    ('0198b000-0000-7000-8000-000000000001', 'users:delete'),
+    ('0198b000-0000-7000-8000-000000000001', 'users:restore'),
+    ('0198b000-0000-7000-8000-000000000001', 'users:forget'),
+    ('0198b000-0000-7000-8000-000000000001', 'roles:assign'),
+    ('0198b000-0000-7000-8000-000000000002', 'users:read');
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_user_roles_role_id;
+
+DROP TABLE IF EXISTS user_roles;
+
+DROP TABLE IF EXISTS role_permissions;
+
+DROP TABLE IF EXISTS roles;
+
+-- +goose StatementEnd
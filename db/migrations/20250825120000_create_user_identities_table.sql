@@ -0,0 +1,56 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Users created from an external identity have neither phone nor password.
+ALTER TABLE users
+ALTER COLUMN phone DROP NOT NULL,
+ALTER COLUMN password DROP NOT NULL;
+
+CREATE TABLE user_identities (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(254) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (provider, subject)
+);
+
+CREATE INDEX idx_user_identities_user_id ON user_identities (user_id);
+
+CREATE TABLE external_login_states (
+    state VARCHAR(64) PRIMARY KEY,
+    provider VARCHAR(50) NOT NULL,
+    nonce VARCHAR(64) NOT NULL,
+    code_verifier VARCHAR(128) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_external_login_states_expires_at ON external_login_states (expires_at);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_external_login_states_expires_at;
+
+DROP TABLE IF EXISTS external_login_states;
+
+DROP INDEX IF EXISTS idx_user_identities_user_id;
+
+DROP TABLE IF EXISTS user_identities;
+
+-- Users that only sign in externally cannot satisfy the constraints again.
+-- Refuse to roll back rather than delete them.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM users WHERE phone IS NULL OR password IS NULL) THEN
+        RAISE EXCEPTION 'users without phone or password exist; remove or complete them before rolling back';
+    END IF;
+END
+$$;
+
+ALTER TABLE users
+ALTER COLUMN phone SET NOT NULL,
+ALTER COLUMN password SET NOT NULL;
+
+-- +goose StatementEnd
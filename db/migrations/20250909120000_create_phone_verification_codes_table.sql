@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+ADD COLUMN phone_verified_at TIMESTAMPTZ;
+
+CREATE TABLE phone_verification_codes (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    phone VARCHAR(30) NOT NULL,
+    code_hash VARCHAR(254) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_phone_verification_codes_user_id_created_at ON phone_verification_codes (user_id, created_at DESC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_phone_verification_codes_user_id_created_at;
+
+DROP TABLE IF EXISTS phone_verification_codes;
+
+ALTER TABLE users
+DROP COLUMN IF EXISTS phone_verified_at;
+
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Users forgotten before this column existed had their key destroyed while
+-- still active; mark them so they are not restored.
+ALTER TABLE users
+ADD COLUMN forgotten_at TIMESTAMPTZ;
+
+UPDATE users
+SET
+    forgotten_at = subject_keys.destroyed_at
+FROM
+    subject_keys
+WHERE
+    subject_keys.subject_id = users.id
+    AND subject_keys.destroyed_at IS NOT NULL;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+DROP COLUMN IF EXISTS forgotten_at;
+
+-- +goose StatementEnd
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"os"
@@ -16,10 +17,15 @@ import (
 	"go.uber.org/dig"
 
 	auditContainer "github.com/marcelofabianov/redtogreen/internal/contexts/audit/container"
+	identityJob "github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/job"
 	identityContainer "github.com/marcelofabianov/redtogreen/internal/contexts/identity/container"
 	identityHttp "github.com/marcelofabianov/redtogreen/internal/contexts/identity/infra/http"
+	notificationContainer "github.com/marcelofabianov/redtogreen/internal/contexts/notification/container"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/asyncapi"
 	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/web"
 	"github.com/marcelofabianov/redtogreen/internal/platform/config"
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	platformBus "github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
 )
 
 type App struct {
@@ -31,20 +37,8 @@ type App struct {
 
 func New() (*App, error) {
 	container := dig.New()
-
-	if err := providePlatformDependencies(container); err != nil {
-		return nil, fmt.Errorf("failed to provide platform dependencies: %w", err)
-	}
-
-	if err := identityContainer.Register(container); err != nil {
-		return nil, fmt.Errorf("failed to register identity context: %w", err)
-	}
-	if err := auditContainer.Register(container); err != nil {
-		return nil, fmt.Errorf("failed to register audit context: %w", err)
-	}
-
-	if err := setupEventSubscriptions(container); err != nil {
-		return nil, fmt.Errorf("failed to setup event subscriptions: %w", err)
+	if err := registerDependencies(container); err != nil {
+		return nil, err
 	}
 
 	app := &App{container: container}
@@ -65,12 +59,62 @@ func New() (*App, error) {
 	return app, nil
 }
 
+// registerDependencies provides every dependency of the app and wires the
+// subscriptions, query responders and command handlers.
+func registerDependencies(container *dig.Container) error {
+	if err := providePlatformDependencies(container); err != nil {
+		return fmt.Errorf("failed to provide platform dependencies: %w", err)
+	}
+
+	if err := identityContainer.Register(container); err != nil {
+		return fmt.Errorf("failed to register identity context: %w", err)
+	}
+	if err := auditContainer.Register(container); err != nil {
+		return fmt.Errorf("failed to register audit context: %w", err)
+	}
+	if err := notificationContainer.Register(container); err != nil {
+		return fmt.Errorf("failed to register notification context: %w", err)
+	}
+
+	if err := container.Provide(NewEventCatalog); err != nil {
+		return fmt.Errorf("failed to provide event catalog: %w", err)
+	}
+
+	if err := setupEventSubscriptions(container); err != nil {
+		return fmt.Errorf("failed to setup event subscriptions: %w", err)
+	}
+
+	if err := setupQueryResponders(container); err != nil {
+		return fmt.Errorf("failed to setup query responders: %w", err)
+	}
+
+	if err := setupCommandHandlers(container); err != nil {
+		return fmt.Errorf("failed to setup command handlers: %w", err)
+	}
+
+	return nil
+}
+
 func (a *App) Run() error {
 	mainRouter := web.NewPlatformRouter(a.config, a.logger)
 	mainRouter.Use(otelchi.Middleware(a.config.Otel.ServiceName))
 
 	mainRouter.Get("/", DefaultHandler)
 
+	if err := a.container.Invoke(func(eventBus platformBus.EventBus) {
+		if checker, ok := eventBus.(platformBus.HealthChecker); ok {
+			mainRouter.Get("/health/bus", BusHealthHandler(checker))
+		}
+	}); err != nil {
+		return fmt.Errorf("failed to mount bus health check: %w", err)
+	}
+
+	if err := a.container.Invoke(func(catalog *event.Catalog) {
+		mainRouter.Get("/api/v1/events/asyncapi.json", asyncapi.Handler(NewAsyncAPIDocument(catalog, a.config)))
+	}); err != nil {
+		return fmt.Errorf("failed to mount event catalog: %w", err)
+	}
+
 	if err := a.container.Invoke(func(identityRouter *identityHttp.Router) {
 		mainRouter.Route("/api/v1", func(r chi.Router) {
 			r.Mount("/identity", identityRouter)
@@ -87,6 +131,15 @@ func (a *App) Run() error {
 		IdleTimeout:  120 * time.Second,
 	}
 
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	if err := a.container.Invoke(func(purgeDeletedUsers *identityJob.PurgeDeletedUsersJob) {
+		go purgeDeletedUsers.Run(jobsCtx)
+	}); err != nil {
+		return fmt.Errorf("failed to start background jobs: %w", err)
+	}
+
 	stopChan := make(chan os.Signal, 1)
 	signal.Notify(stopChan, syscall.SIGINT, syscall.SIGTERM)
 
@@ -101,6 +154,7 @@ func (a *App) Run() error {
 	<-stopChan
 
 	a.logger.Info("shutting down server gracefully")
+	stopJobs()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -113,6 +167,19 @@ func (a *App) Run() error {
 		return fmt.Errorf("server shutdown failed: %w", err)
 	}
 
+	if err := a.container.Invoke(func(eventBus platformBus.EventBus, queryBus platformBus.QueryBus) error {
+		for _, b := range []any{queryBus, eventBus} {
+			if closer, ok := b.(io.Closer); ok {
+				if err := closer.Close(); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}); err != nil {
+		a.logger.Error("bus shutdown failed", "error", err)
+	}
+
 	a.logger.Info("server stopped gracefully")
 	return nil
 }
@@ -124,3 +191,19 @@ func DefaultHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	web.Respond(w, r, http.StatusOK, response)
 }
+
+// BusHealthHandler answers 503 while the event bus is unhealthy so load
+// balancers and orchestrators can act on it.
+func BusHealthHandler(checker platformBus.HealthChecker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
+		defer cancel()
+
+		health := checker.Health(ctx)
+		status := http.StatusOK
+		if !health.Healthy() {
+			status = http.StatusServiceUnavailable
+		}
+		web.Respond(w, r, status, health)
+	}
+}
@@ -0,0 +1,37 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+
+	identityJob "github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/job"
+	identityHttp "github.com/marcelofabianov/redtogreen/internal/contexts/identity/infra/http"
+	"github.com/marcelofabianov/redtogreen/internal/platform/config"
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	platformBus "github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+)
+
+func TestRegisterDependencies(t *testing.T) {
+	t.Run("Success: should resolve every dependency New and Run invoke", func(t *testing.T) {
+		// A dry run checks the graph without calling constructors, so no
+		// database or broker is needed.
+		container := dig.New(dig.DryRun(true))
+		require.NoError(t, registerDependencies(container))
+
+		invokes := map[string]any{
+			"app":           func(*config.AppConfig, *slog.Logger, func(context.Context) error) {},
+			"bus health":    func(platformBus.EventBus) {},
+			"event catalog": func(*event.Catalog) {},
+			"identity":      func(*identityHttp.Router) {},
+			"jobs":          func(*identityJob.PurgeDeletedUsersJob) {},
+			"shutdown":      func(platformBus.EventBus, platformBus.QueryBus) error { return nil },
+		}
+		for name, fn := range invokes {
+			require.NoError(t, container.Invoke(fn), name)
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	auditContainer "github.com/marcelofabianov/redtogreen/internal/contexts/audit/container"
+	identityContainer "github.com/marcelofabianov/redtogreen/internal/contexts/identity/container"
+	notificationContainer "github.com/marcelofabianov/redtogreen/internal/contexts/notification/container"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/asyncapi"
+	"github.com/marcelofabianov/redtogreen/internal/platform/config"
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+)
+
+// NewEventCatalog collects the events published and consumed by every bounded context.
+// Producers register before consumers so subscriptions always point at a known event.
+func NewEventCatalog() (*event.Catalog, error) {
+	catalog := event.NewCatalog()
+
+	if err := identityContainer.RegisterEvents(catalog); err != nil {
+		return nil, fmt.Errorf("failed to register identity events: %w", err)
+	}
+	if err := auditContainer.RegisterEvents(catalog); err != nil {
+		return nil, fmt.Errorf("failed to register audit events: %w", err)
+	}
+	if err := notificationContainer.RegisterEvents(catalog); err != nil {
+		return nil, fmt.Errorf("failed to register notification events: %w", err)
+	}
+
+	return catalog, nil
+}
+
+func NewAsyncAPIDocument(catalog *event.Catalog, cfg *config.AppConfig) asyncapi.Document {
+	info := asyncapi.Info{
+		Title:       cfg.Otel.ServiceName,
+		Version:     cfg.Otel.ServiceVersion,
+		Description: "Events exchanged between the bounded contexts through NATS JetStream.",
+	}
+	if info.Version == "" {
+		info.Version = "dev"
+	}
+
+	servers := make(map[string]asyncapi.Server)
+	for i, url := range strings.Split(cfg.NATS.URLs, ",") {
+		host := strings.TrimPrefix(strings.TrimSpace(url), "nats://")
+		if host == "" {
+			continue
+		}
+		servers[fmt.Sprintf("nats-%d", i)] = asyncapi.Server{Host: host, Protocol: "nats"}
+	}
+
+	return asyncapi.Generate(catalog, info, servers)
+}
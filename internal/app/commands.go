@@ -0,0 +1,23 @@
+package app
+
+import (
+	"fmt"
+
+	"go.uber.org/dig"
+
+	identityContainer "github.com/marcelofabianov/redtogreen/internal/contexts/identity/container"
+	platformBus "github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+)
+
+func setupCommandHandlers(container *dig.Container) error {
+	return container.Invoke(func(
+		registry platformBus.CommandRegistry,
+		identityCommands identityContainer.Commands,
+	) error {
+		if err := identityContainer.RegisterCommands(registry, identityCommands); err != nil {
+			return fmt.Errorf("failed to register identity commands: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 
 	"go.uber.org/dig"
@@ -10,12 +11,19 @@ import (
 	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/database"
 	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/hasher"
 	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/logger"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/oidc"
 	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/otel"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/pii"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/token"
 	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/validator"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/web"
 	"github.com/marcelofabianov/redtogreen/internal/platform/config"
 	platformBus "github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
 	platformDB "github.com/marcelofabianov/redtogreen/internal/platform/port/database"
 	platformHasher "github.com/marcelofabianov/redtogreen/internal/platform/port/hasher"
+	platformOIDC "github.com/marcelofabianov/redtogreen/internal/platform/port/oidc"
+	platformPII "github.com/marcelofabianov/redtogreen/internal/platform/port/pii"
+	platformToken "github.com/marcelofabianov/redtogreen/internal/platform/port/token"
 )
 
 func providePlatformDependencies(container *dig.Container) error {
@@ -28,6 +36,18 @@ func providePlatformDependencies(container *dig.Container) error {
 	if err := provideEventBus(container); err != nil {
 		return err
 	}
+	if err := provideQueryBus(container); err != nil {
+		return err
+	}
+	if err := provideCommandBus(container); err != nil {
+		return err
+	}
+	if err := providePII(container); err != nil {
+		return err
+	}
+	if err := provideToken(container); err != nil {
+		return err
+	}
 	if err := provideOtel(container); err != nil {
 		return err
 	}
@@ -57,9 +77,36 @@ func provideConfig(container *dig.Container) error {
 	if err := container.Provide(func(cfg *config.AppConfig) config.NATSConfig { return cfg.NATS }); err != nil {
 		return err
 	}
+	if err := container.Provide(func(cfg *config.AppConfig) config.EventBusConfig { return cfg.EventBus }); err != nil {
+		return err
+	}
 	if err := container.Provide(func(cfg *config.AppConfig) config.OtelConfig { return cfg.Otel }); err != nil {
 		return err
 	}
+	if err := container.Provide(func(cfg *config.AppConfig) config.PIIConfig { return cfg.PII }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(cfg *config.AppConfig) config.IdentityConfig { return cfg.Identity }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(cfg *config.AppConfig) config.JWTConfig { return cfg.Auth.JWT }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(cfg *config.AppConfig) config.RefreshTokenConfig { return cfg.Auth.Refresh }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(cfg *config.AppConfig) config.PasswordResetConfig { return cfg.Auth.PasswordReset }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(cfg *config.AppConfig) config.EmailVerificationConfig { return cfg.Auth.EmailVerification }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(cfg *config.AppConfig) config.PhoneVerificationConfig { return cfg.Auth.PhoneVerification }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(cfg *config.AppConfig) config.GoogleConfig { return cfg.Auth.Google }); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -105,12 +152,23 @@ func provideAdapters(container *dig.Container) error {
 }
 
 func provideEventBus(container *dig.Container) error {
-	if err := container.Provide(func(cfg config.NATSConfig, logger *slog.Logger) (*bus.NatsEventBus, error) {
-		return bus.NewNatsEventBus(&cfg, logger)
-	}); err != nil {
-		return err
+	type eventBusParams struct {
+		dig.In
+		Config     config.EventBusConfig
+		NATSConfig config.NATSConfig
+		DB         platformDB.DB `name:"mainDB"`
+		Logger     *slog.Logger
 	}
-	if err := container.Provide(func(b *bus.NatsEventBus) platformBus.EventBus { return b }); err != nil {
+	if err := container.Provide(func(p eventBusParams) (platformBus.EventBus, error) {
+		switch p.Config.Driver {
+		case config.EventBusDriverPostgres:
+			return bus.NewPostgresEventBus(p.DB, p.Config, p.Logger), nil
+		case config.EventBusDriverNATS, "":
+			return bus.NewNatsEventBus(&p.NATSConfig, p.Logger)
+		default:
+			return nil, fmt.Errorf("unknown event bus driver %q", p.Config.Driver)
+		}
+	}); err != nil {
 		return err
 	}
 	if err := container.Provide(func(b platformBus.EventBus) platformBus.EventBusPublisher { return b }); err != nil {
@@ -122,6 +180,109 @@ func provideEventBus(container *dig.Container) error {
 	return nil
 }
 
+func provideQueryBus(container *dig.Container) error {
+	type queryBusParams struct {
+		dig.In
+		Config     config.EventBusConfig
+		NATSConfig config.NATSConfig
+		Logger     *slog.Logger
+	}
+	if err := container.Provide(func(p queryBusParams) (platformBus.QueryBus, error) {
+		// Deployments without NATS run as a single process, so queries are
+		// answered in process.
+		if p.Config.Driver == config.EventBusDriverPostgres {
+			return bus.NewInProcessQueryBus(), nil
+		}
+		return bus.NewNatsQueryBus(&p.NATSConfig, p.Logger)
+	}); err != nil {
+		return err
+	}
+	if err := container.Provide(func(b platformBus.QueryBus) platformBus.QueryRequester { return b }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(b platformBus.QueryBus) platformBus.QueryResponder { return b }); err != nil {
+		return err
+	}
+	return nil
+}
+
+func provideCommandBus(container *dig.Container) error {
+	type commandBusParams struct {
+		dig.In
+		DB        platformDB.DB `name:"mainDB"`
+		Validator *validator.Validator
+		Logger    *slog.Logger
+	}
+	if err := container.Provide(func(p commandBusParams) platformBus.CommandBus {
+		return bus.NewInProcessCommandBus(
+			bus.CommandTracing(),
+			bus.CommandLogging(p.Logger),
+			bus.CommandAuthorization(),
+			bus.CommandValidation(p.Validator),
+			bus.CommandIdempotency(bus.NewMemoryIdempotencyStore(bus.DefaultIdempotencyTTL)),
+			bus.CommandTransaction(p.DB),
+		)
+	}); err != nil {
+		return err
+	}
+	if err := container.Provide(func(b platformBus.CommandBus) platformBus.CommandDispatcher { return b }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(b platformBus.CommandBus) platformBus.CommandRegistry { return b }); err != nil {
+		return err
+	}
+	return nil
+}
+
+func providePII(container *dig.Container) error {
+	type keyStoreParams struct {
+		dig.In
+		DB     platformDB.DB `name:"mainDB"`
+		Config config.PIIConfig
+	}
+	if err := container.Provide(func(p keyStoreParams) (platformPII.KeyStore, error) {
+		return pii.NewPostgresKeyStore(p.DB, p.Config.MasterKey)
+	}); err != nil {
+		return err
+	}
+	if err := container.Provide(pii.NewAESPayloadCipher); err != nil {
+		return err
+	}
+	if err := container.Provide(func(c *pii.AESPayloadCipher) platformPII.PayloadCipher { return c }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(c platformPII.PayloadCipher) platformPII.PayloadEncrypter { return c }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(c platformPII.PayloadCipher) platformPII.PayloadDecrypter { return c }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(c *pii.AESPayloadCipher) platformPII.Shredder { return c }); err != nil {
+		return err
+	}
+	return nil
+}
+
+func provideToken(container *dig.Container) error {
+	if err := container.Provide(token.NewJWT); err != nil {
+		return err
+	}
+	if err := container.Provide(func(t *token.JWT) platformToken.Issuer { return t }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(t *token.JWT) platformToken.Verifier { return t }); err != nil {
+		return err
+	}
+	if err := container.Provide(web.NewAuthenticator); err != nil {
+		return err
+	}
+	// Google is the only identity provider for now, so it backs the port.
+	if err := container.Provide(func(cfg config.GoogleConfig) platformOIDC.Provider { return oidc.NewGoogle(cfg) }); err != nil {
+		return err
+	}
+	return nil
+}
+
 func provideOtel(container *dig.Container) error {
 	if err := container.Provide(func(cfg config.OtelConfig, logger *slog.Logger) (func(context.Context) error, error) {
 		return otel.InitTracerProvider(cfg, logger)
@@ -0,0 +1,23 @@
+package app
+
+import (
+	"fmt"
+
+	"go.uber.org/dig"
+
+	identityDomain "github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	platformBus "github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+)
+
+func setupQueryResponders(container *dig.Container) error {
+	return container.Invoke(func(
+		responder platformBus.QueryResponder,
+		userStatusHandler identityDomain.UserStatusQueryHandler,
+	) error {
+		if err := platformBus.Answer(responder, identityDomain.UserStatusQuery, userStatusHandler.Handle); err != nil {
+			return fmt.Errorf("failed to answer %s query: %w", identityDomain.UserStatusQueryType, err)
+		}
+
+		return nil
+	})
+}
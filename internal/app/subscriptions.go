@@ -7,22 +7,36 @@ import (
 
 	auditSubscriber "github.com/marcelofabianov/redtogreen/internal/contexts/audit/app/subscriber"
 	identityDomain "github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	notificationSubscriber "github.com/marcelofabianov/redtogreen/internal/contexts/notification/app/subscriber"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/pii"
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
 	platformBus "github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+	platformPII "github.com/marcelofabianov/redtogreen/internal/platform/port/pii"
 )
 
 func setupEventSubscriptions(container *dig.Container) error {
 	return container.Invoke(func(
 		busSubscriber platformBus.EventBusSubscriber,
+		decrypter platformPII.PayloadDecrypter,
 		userCreatedSubscriber *auditSubscriber.UserCreatedSubscriber,
+		userNotificationSubscriber *notificationSubscriber.UserNotificationSubscriber,
 	) error {
 		if err := subscribeToUserEvents(busSubscriber, userCreatedSubscriber); err != nil {
 			return err
 		}
+		if err := subscribeToNotificationEvents(busSubscriber, decrypter, userNotificationSubscriber); err != nil {
+			return err
+		}
 
 		return nil
 	})
 }
 
+// subscribeToUserEvents hands the audit context the payload as published: the
+// audit log keeps personal data encrypted, so readers decrypt it with the
+// PayloadDecrypter and forgetting a user makes it unreadable there too. A user
+// has a single user.created event, so there is no per-key order to keep and the
+// subscription stays on the unordered consumer.
 func subscribeToUserEvents(
 	busSubscriber platformBus.EventBusSubscriber,
 	userCreatedSubscriber *auditSubscriber.UserCreatedSubscriber,
@@ -33,3 +47,25 @@ func subscribeToUserEvents(
 
 	return nil
 }
+
+// subscribeToNotificationEvents hands the notification context the decrypted
+// payload: the recipient and the secret it delivers are encrypted on the bus.
+func subscribeToNotificationEvents(
+	busSubscriber platformBus.EventBusSubscriber,
+	decrypter platformPII.PayloadDecrypter,
+	userNotificationSubscriber *notificationSubscriber.UserNotificationSubscriber,
+) error {
+	handlers := map[event.EventType]platformBus.EventHandler{
+		identityDomain.UserPasswordResetRequestedEventType:     userNotificationSubscriber.HandlePasswordResetRequested,
+		identityDomain.UserEmailVerificationRequestedEventType: userNotificationSubscriber.HandleEmailVerificationRequested,
+		identityDomain.UserPhoneVerificationRequestedEventType: userNotificationSubscriber.HandlePhoneVerificationRequested,
+	}
+
+	for eventType, handler := range handlers {
+		if err := busSubscriber.Subscribe(eventType, pii.DecryptingHandler(decrypter, handler)); err != nil {
+			return fmt.Errorf("failed to subscribe to %s event: %w", eventType, err)
+		}
+	}
+
+	return nil
+}
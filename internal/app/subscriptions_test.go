@@ -0,0 +1,169 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	auditSubscriber "github.com/marcelofabianov/redtogreen/internal/contexts/audit/app/subscriber"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/audit/domain/audit"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/publisher"
+	identityDomain "github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	notificationSubscriber "github.com/marcelofabianov/redtogreen/internal/contexts/notification/app/subscriber"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/notification/domain/notification"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/bus/natstest"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/pii"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type memoryKeyStore struct {
+	mu   sync.Mutex
+	keys map[types.UUID][]byte
+}
+
+func (s *memoryKeyStore) GetOrCreateKey(ctx context.Context, subjectID types.UUID) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if key, ok := s.keys[subjectID]; ok {
+		return key, nil
+	}
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	s.keys[subjectID] = key
+	return key, nil
+}
+
+func (s *memoryKeyStore) GetKey(ctx context.Context, subjectID types.UUID) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if key, ok := s.keys[subjectID]; ok {
+		return key, nil
+	}
+	return nil, msg.NewMessageError(nil, pii.ErrDataKeyNotFound, msg.CodeNotFound, nil)
+}
+
+func (s *memoryKeyStore) DestroyKey(ctx context.Context, subjectID types.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.keys, subjectID)
+	return nil
+}
+
+type recordingAuditRepository struct {
+	logs chan *audit.AuditLog
+}
+
+func (r *recordingAuditRepository) RegisterAuditLog(ctx context.Context, input audit.RegisterAuditLogRepoInput) error {
+	r.logs <- input.AuditLog
+	return nil
+}
+
+type recordingSender struct {
+	messages chan notification.Message
+}
+
+func (s *recordingSender) SendEmail(ctx context.Context, message notification.Message) error {
+	s.messages <- message
+	return nil
+}
+
+func (s *recordingSender) SendSMS(ctx context.Context, message notification.Message) error {
+	s.messages <- message
+	return nil
+}
+
+func TestSubscribeToUserEvents(t *testing.T) {
+	t.Run("Success: audit should store user.created with its personal data encrypted", func(t *testing.T) {
+		h := natstest.Start(t)
+		cipher := pii.NewAESPayloadCipher(&memoryKeyStore{keys: map[types.UUID][]byte{}})
+		repo := &recordingAuditRepository{logs: make(chan *audit.AuditLog, 1)}
+		subscriber := auditSubscriber.NewUserCreatedSubscriber(repo, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		require.NoError(t, subscribeToUserEvents(h.Bus, subscriber))
+
+		userID := types.MustNewUUID()
+		err := publisher.NewUserPublisher(h.Bus, cipher).PublishUserCreatedEvent(context.Background(), identityDomain.USerCreatedEventInput{
+			CorrelationID: types.MustNewUUID(),
+			TraceID:       types.MustNewUUID(),
+			Payload: identityDomain.UserCreatedPayload{
+				UserID: userID,
+				Name:   "Audited User",
+				Email:  "audited@example.com",
+				Phone:  "5562999998888",
+			},
+		})
+		require.NoError(t, err)
+
+		var log *audit.AuditLog
+		select {
+		case log = <-repo.logs:
+		case <-time.After(natstest.DefaultTimeout):
+			t.Fatal("user.created was not audited")
+		}
+		assert.NotContains(t, string(log.Payload), "audited@example.com", "The audit log should only keep ciphertext")
+		assert.NotContains(t, string(log.Payload), "5562999998888", "The audit log should only keep ciphertext")
+
+		decrypted, err := cipher.DecryptPayload(context.Background(), log.Payload)
+		require.NoError(t, err)
+		var payload identityDomain.UserCreatedPayload
+		require.NoError(t, json.Unmarshal(decrypted, &payload))
+		assert.Equal(t, userID, payload.UserID)
+		assert.Equal(t, "audited@example.com", payload.Email, "Readers should decrypt the audit payload")
+
+		require.NoError(t, cipher.ForgetSubject(context.Background(), userID))
+		forgotten, err := cipher.DecryptPayload(context.Background(), log.Payload)
+		require.NoError(t, err)
+		var fields map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(forgotten, &fields))
+		assert.Equal(t, "null", string(fields["email"]), "Forgetting the user should make the audit log unreadable")
+		assert.Equal(t, "null", string(fields["phone"]), "Forgetting the user should make the audit log unreadable")
+	})
+}
+
+func TestSubscribeToNotificationEvents(t *testing.T) {
+	t.Run("Success: notification should receive the reset token decrypted through the bus", func(t *testing.T) {
+		h := natstest.Start(t)
+		cipher := pii.NewAESPayloadCipher(&memoryKeyStore{keys: map[types.UUID][]byte{}})
+		sender := &recordingSender{messages: make(chan notification.Message, 1)}
+		subscriber := notificationSubscriber.NewUserNotificationSubscriber(sender, sender, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		require.NoError(t, subscribeToNotificationEvents(h.Bus, cipher, subscriber))
+
+		userID := types.MustNewUUID()
+		err := publisher.NewUserPublisher(h.Bus, cipher).PublishUserPasswordResetRequestedEvent(context.Background(), identityDomain.UserPasswordResetRequestedEventInput{
+			CorrelationID: types.MustNewUUID(),
+			TraceID:       types.MustNewUUID(),
+			Payload: identityDomain.UserPasswordResetRequestedPayload{
+				UserID:     userID,
+				Name:       "Reset User",
+				Email:      "reset@example.com",
+				ResetToken: "reset-secret",
+				ExpiresAt:  time.Now().Add(30 * time.Minute).UTC(),
+			},
+		})
+		require.NoError(t, err)
+
+		stored := h.StreamEvents(t, "identity-stream")
+		require.Len(t, stored, 1)
+		assert.NotContains(t, string(stored[0].Payload), "reset-secret", "The bus should only carry ciphertext")
+
+		select {
+		case message := <-sender.messages:
+			assert.Equal(t, notification.TemplatePasswordReset, message.Template)
+			assert.Equal(t, userID, message.UserID)
+			assert.Equal(t, "reset@example.com", message.Recipient)
+			assert.Equal(t, "reset-secret", message.Secret)
+		case <-time.After(natstest.DefaultTimeout):
+			t.Fatal("user.password_reset_requested was not delivered")
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package subscriber_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	identityUser "github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/event/contract"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+func TestUserCreatedSubscriber_Contract(t *testing.T) {
+	root, err := contract.RepositoryRoot()
+	require.NoError(t, err, "Setup: failed to locate repository root")
+
+	evt, err := identityUser.NewUserCreatedEvent(identityUser.USerCreatedEventInput{
+		CorrelationID: types.MustNewUUID(),
+		TraceID:       types.MustNewUUID(),
+		Payload: identityUser.UserCreatedPayload{
+			UserID: types.MustNewUUID(),
+			Name:   "Contract User",
+			Email:  "contract@example.com",
+			Phone:  "+5562999998888",
+		},
+	})
+	require.NoError(t, err, "Setup: failed to build sample user.created event")
+
+	c, err := contract.Record("audit", evt,
+		"header.eventId",
+		"header.eventType",
+		"header.jsonSchemaVersion",
+		"context.correlationId",
+		"context.userId",
+		"metadata.traceId",
+		"payload.userId",
+		"payload.name",
+		"payload.email",
+		"payload.phone",
+	)
+	require.NoError(t, err, "Recording the audit contract should not fail")
+
+	contract.AssertRecorded(t, filepath.Join(root, "internal/contexts/audit/contracts"), c)
+}
@@ -2,7 +2,6 @@ package subscriber
 
 import (
 	"context"
-	"encoding/json"
 	"log/slog"
 
 	"go.opentelemetry.io/otel"
@@ -49,9 +48,7 @@ func (s *UserCreatedSubscriber) Handle(ctx context.Context, e *event.Event) erro
 		logger.UserID(e.Context.UserID),
 	)
 
-	var payload identityUser.UserCreatedPayload
-
-	if err := json.Unmarshal(e.Payload, &payload); err != nil {
+	if _, err := identityUser.UserCreatedEvent.Decode(e); err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "Failed to unmarshal event payload")
 		loggerWithTrace.Error("failed to unmarshal user.created event payload for auditing",
@@ -172,7 +172,8 @@ func TestUserCreatedSubscriber_Handle(t *testing.T) {
 
 		mockEventWithInvalidAuditInput := &event.Event{
 			Header: event.EventHeader{
-				EventType: "",
+				EventType:     identityUser.UserCreatedEventType,
+				SchemaVersion: identityUser.UserCreatedEventVersion,
 			},
 			Context:  mockEvent.Context,
 			Metadata: mockEvent.Metadata,
@@ -0,0 +1,16 @@
+package container
+
+import (
+	identityUser "github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+)
+
+const contextName = "audit"
+
+func RegisterEvents(catalog *event.Catalog) error {
+	return catalog.RegisterSubscription(event.Subscription{
+		EventType:   identityUser.UserCreatedEventType,
+		Context:     contextName,
+		Description: "Stores the event in the audit log.",
+	})
+}
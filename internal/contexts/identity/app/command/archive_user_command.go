@@ -0,0 +1,122 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/logger"
+)
+
+type archiveUserCommand struct {
+	useCase   user.ArchiveUserUseCase
+	publisher user.UserArchivedEventPublisher
+	logger    *slog.Logger
+	tracer    trace.Tracer
+}
+
+func NewArchiveUserCommand(
+	uc user.ArchiveUserUseCase,
+	pub user.UserArchivedEventPublisher,
+	logger *slog.Logger,
+) user.ArchiveUserCommand {
+	return &archiveUserCommand{
+		useCase:   uc,
+		publisher: pub,
+		logger:    logger,
+		tracer:    otel.Tracer("identity-command"),
+	}
+}
+
+func (c *archiveUserCommand) Execute(ctx context.Context, input user.ArchiveUserCommandInput) (user.UserStatusOutput, error) {
+	return runUserStatusCommand(ctx, c.tracer, c.logger, "ArchiveUser", input.UserStatusCommandInput,
+		c.useCase.Execute, c.publisher.PublishUserArchivedEvent)
+}
+
+type unarchiveUserCommand struct {
+	useCase   user.UnarchiveUserUseCase
+	publisher user.UserArchivedEventPublisher
+	logger    *slog.Logger
+	tracer    trace.Tracer
+}
+
+func NewUnarchiveUserCommand(
+	uc user.UnarchiveUserUseCase,
+	pub user.UserArchivedEventPublisher,
+	logger *slog.Logger,
+) user.UnarchiveUserCommand {
+	return &unarchiveUserCommand{
+		useCase:   uc,
+		publisher: pub,
+		logger:    logger,
+		tracer:    otel.Tracer("identity-command"),
+	}
+}
+
+func (c *unarchiveUserCommand) Execute(ctx context.Context, input user.UnarchiveUserCommandInput) (user.UserStatusOutput, error) {
+	return runUserStatusCommand(ctx, c.tracer, c.logger, "UnarchiveUser", input.UserStatusCommandInput,
+		c.useCase.Execute, c.publisher.PublishUserUnarchivedEvent)
+}
+
+// runUserStatusCommand runs a lifecycle use case and publishes its event only
+// when the user actually changed state.
+func runUserStatusCommand(
+	ctx context.Context,
+	tracer trace.Tracer,
+	baseLogger *slog.Logger,
+	name string,
+	input user.UserStatusCommandInput,
+	execute func(context.Context, user.UserStatusUseCaseInput) (user.UserStatusOutput, error),
+	publish func(context.Context, user.UserStatusEventInput) error,
+) (user.UserStatusOutput, error) {
+	ctx, span := tracer.Start(ctx, name+"Command.Execute",
+		trace.WithAttributes(
+			attribute.String("user.id", input.UserID.String()),
+			attribute.Int("user.expected_version", input.ExpectedVersion.Int()),
+			attribute.String("command.type", name),
+		),
+	)
+	defer span.End()
+
+	loggerWithTrace := baseLogger.With(logger.TraceID(input.TraceID.String()), "command", name)
+	loggerWithTrace.Info("starting user status command", "user_id", input.UserID.String())
+
+	output, err := execute(ctx, user.UserStatusUseCaseInput{
+		UserID:          input.UserID,
+		ExpectedVersion: input.ExpectedVersion,
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to execute use case")
+		loggerWithTrace.Error("failed to execute user status use case", "error", err)
+		return user.UserStatusOutput{}, err
+	}
+
+	if !output.Changed {
+		span.SetStatus(codes.Ok, "User already in the requested state")
+		loggerWithTrace.Info("user status command changed nothing", "user_id", input.UserID.String())
+		return output, nil
+	}
+
+	publishErr := publish(ctx, user.UserStatusEventInput{
+		CorrelationID: input.CorrelationID,
+		UserID:        input.UserAuthorID,
+		TraceID:       input.TraceID,
+		User:          output.User,
+	})
+	if publishErr != nil {
+		span.RecordError(publishErr)
+		span.SetStatus(codes.Error, "Failed to publish event")
+		loggerWithTrace.Error("failed to publish user status event", "error", publishErr)
+	}
+
+	span.SetStatus(codes.Ok, "Command finished successfully")
+	loggerWithTrace.Info("user status command finished successfully", "user_id", input.UserID.String())
+
+	return output, nil
+}
@@ -0,0 +1,115 @@
+package command_test
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/command"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+// --- Mocks for Dependencies ---
+
+type mockUserStatusUseCase struct {
+	ExecuteFunc func(ctx context.Context, input user.UserStatusUseCaseInput) (user.UserStatusOutput, error)
+}
+
+func (m *mockUserStatusUseCase) Execute(ctx context.Context, input user.UserStatusUseCaseInput) (user.UserStatusOutput, error) {
+	if m.ExecuteFunc != nil {
+		return m.ExecuteFunc(ctx, input)
+	}
+	return user.UserStatusOutput{}, nil
+}
+
+type mockUserArchivedPublisher struct {
+	PublishUserArchivedEventFunc   func(ctx context.Context, input user.UserStatusEventInput) error
+	PublishUserUnarchivedEventFunc func(ctx context.Context, input user.UserStatusEventInput) error
+}
+
+func (m *mockUserArchivedPublisher) PublishUserArchivedEvent(ctx context.Context, input user.UserStatusEventInput) error {
+	if m.PublishUserArchivedEventFunc != nil {
+		return m.PublishUserArchivedEventFunc(ctx, input)
+	}
+	return nil
+}
+
+func (m *mockUserArchivedPublisher) PublishUserUnarchivedEvent(ctx context.Context, input user.UserStatusEventInput) error {
+	if m.PublishUserUnarchivedEventFunc != nil {
+		return m.PublishUserUnarchivedEventFunc(ctx, input)
+	}
+	return nil
+}
+
+// --- Test Suite ---
+
+func TestArchiveUserCommand_Execute(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	archived := &user.User{ID: types.MustNewUUID(), Version: types.Version(2), ArchivedAt: types.NewArchivedAtNow()}
+	commandInput := user.ArchiveUserCommandInput{UserStatusCommandInput: user.UserStatusCommandInput{
+		CorrelationID:   types.MustNewUUID(),
+		TraceID:         types.MustNewUUID(),
+		UserAuthorID:    types.NewValidNullableUUID(types.MustNewUUID()),
+		UserID:          archived.ID,
+		ExpectedVersion: types.Version(1),
+	}}
+
+	t.Run("Success: should publish user archived when the user changed", func(t *testing.T) {
+		useCase := &mockUserStatusUseCase{
+			ExecuteFunc: func(ctx context.Context, input user.UserStatusUseCaseInput) (user.UserStatusOutput, error) {
+				assert.Equal(t, commandInput.UserID, input.UserID)
+				assert.Equal(t, commandInput.ExpectedVersion, input.ExpectedVersion)
+				return user.UserStatusOutput{User: archived, Changed: true}, nil
+			},
+		}
+
+		var published user.UserStatusEventInput
+		publisher := &mockUserArchivedPublisher{
+			PublishUserArchivedEventFunc: func(ctx context.Context, input user.UserStatusEventInput) error {
+				published = input
+				return nil
+			},
+			PublishUserUnarchivedEventFunc: func(ctx context.Context, input user.UserStatusEventInput) error {
+				t.Fatal("Archive should not publish user unarchived")
+				return nil
+			},
+		}
+
+		cmd := command.NewArchiveUserCommand(useCase, publisher, logger)
+
+		output, err := cmd.Execute(context.Background(), commandInput)
+
+		require.NoError(t, err)
+		assert.Same(t, archived, output.User)
+		assert.Same(t, archived, published.User)
+		assert.Equal(t, commandInput.UserAuthorID, published.UserID, "Event author should be the user who archived")
+	})
+
+	t.Run("Success: should not publish when the user already was archived", func(t *testing.T) {
+		useCase := &mockUserStatusUseCase{
+			ExecuteFunc: func(ctx context.Context, input user.UserStatusUseCaseInput) (user.UserStatusOutput, error) {
+				return user.UserStatusOutput{User: archived}, nil
+			},
+		}
+
+		publisherCalled := false
+		publisher := &mockUserArchivedPublisher{
+			PublishUserArchivedEventFunc: func(ctx context.Context, input user.UserStatusEventInput) error {
+				publisherCalled = true
+				return nil
+			},
+		}
+
+		cmd := command.NewArchiveUserCommand(useCase, publisher, logger)
+
+		_, err := cmd.Execute(context.Background(), commandInput)
+
+		require.NoError(t, err)
+		assert.False(t, publisherCalled)
+	})
+}
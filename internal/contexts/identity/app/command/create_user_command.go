@@ -15,14 +15,14 @@ import (
 
 type createUserCommand struct {
 	useCase   user.CreateUserUseCase
-	publisher user.UserCreatedEventPublisher
+	publisher user.CreateUserEventPublisher
 	logger    *slog.Logger
 	tracer    trace.Tracer
 }
 
 func NewCreateUserCommand(
 	uc user.CreateUserUseCase,
-	pub user.UserCreatedEventPublisher,
+	pub user.CreateUserEventPublisher,
 	logger *slog.Logger,
 ) user.CreateUserCommand {
 	return &createUserCommand{
@@ -79,6 +79,23 @@ func (c *createUserCommand) Execute(
 		loggerWithTrace.Error("failed to publish user created event", "error", publishErr)
 	}
 
+	publishErr = c.publisher.PublishUserEmailVerificationRequestedEvent(ctx, user.UserEmailVerificationRequestedEventInput{
+		CorrelationID: input.CorrelationID,
+		TraceID:       input.TraceID,
+		Payload: user.UserEmailVerificationRequestedPayload{
+			UserID:            output.User.ID,
+			Name:              output.User.Name,
+			Email:             output.User.Email.String(),
+			VerificationToken: output.EmailVerification.Value,
+			ExpiresAt:         output.EmailVerification.ExpiresAt,
+		},
+	})
+	if publishErr != nil {
+		span.RecordError(publishErr)
+		span.SetStatus(codes.Error, "Failed to publish event")
+		loggerWithTrace.Error("failed to publish user email verification requested event", "error", publishErr)
+	}
+
 	span.SetStatus(codes.Ok, "Command finished successfully")
 	loggerWithTrace.Info("create user command finished successfully", "user_id", output.User.ID.String())
 
@@ -31,6 +31,8 @@ func (m *mockCreateUserUseCase) Execute(ctx context.Context, input user.NewUserI
 
 type mockUserPublisher struct {
 	PublishUserCreatedEventFunc func(ctx context.Context, input user.USerCreatedEventInput) error
+	verificationRequested       []user.UserEmailVerificationRequestedEventInput
+	emailVerified               []user.UserEmailVerifiedEventInput
 }
 
 func (m *mockUserPublisher) PublishUserCreatedEvent(ctx context.Context, input user.USerCreatedEventInput) error {
@@ -40,6 +42,16 @@ func (m *mockUserPublisher) PublishUserCreatedEvent(ctx context.Context, input u
 	return nil
 }
 
+func (m *mockUserPublisher) PublishUserEmailVerificationRequestedEvent(ctx context.Context, input user.UserEmailVerificationRequestedEventInput) error {
+	m.verificationRequested = append(m.verificationRequested, input)
+	return nil
+}
+
+func (m *mockUserPublisher) PublishUserEmailVerifiedEvent(ctx context.Context, input user.UserEmailVerifiedEventInput) error {
+	m.emailVerified = append(m.emailVerified, input)
+	return nil
+}
+
 // --- Test Suite ---
 
 func TestCreateUserCommand_Execute(t *testing.T) {
@@ -72,7 +84,10 @@ func TestCreateUserCommand_Execute(t *testing.T) {
 
 		useCase := &mockCreateUserUseCase{
 			ExecuteFunc: func(ctx context.Context, input user.NewUserInput) (user.CreateUserOutput, error) {
-				return user.CreateUserOutput{User: mockUser}, nil
+				return user.CreateUserOutput{
+					User:              mockUser,
+					EmailVerification: user.IssuedEmailVerificationToken{Value: "id.secret", ExpiresAt: mockUser.CreatedAt.Time()},
+				}, nil
 			},
 		}
 
@@ -103,6 +118,10 @@ func TestCreateUserCommand_Execute(t *testing.T) {
 		require.NoError(t, err, "Command Execute should not return an error on success")
 		assert.Equal(t, mockUser.ID, output.User.ID, "Output should contain the user returned by the use case")
 		assert.True(t, publisherCalled, "Publisher's PublishUserCreatedEvent method should have been called")
+		require.Len(t, publisher.verificationRequested, 1, "The verification token should be published")
+		assert.Equal(t, mockUser.ID, publisher.verificationRequested[0].Payload.UserID)
+		assert.Equal(t, mockUser.Email.String(), publisher.verificationRequested[0].Payload.Email)
+		assert.Equal(t, "id.secret", publisher.verificationRequested[0].Payload.VerificationToken)
 	})
 
 	t.Run("Failure: should not publish event if use case returns an error", func(t *testing.T) {
@@ -128,6 +147,7 @@ func TestCreateUserCommand_Execute(t *testing.T) {
 		require.Error(t, err, "Command Execute should return an error when use case fails")
 		assert.Equal(t, useCaseError, err, "The error returned should be the one from the use case")
 		assert.False(t, publisherCalled, "Publisher's method should NOT be called when the use case fails")
+		assert.Empty(t, publisher.verificationRequested)
 	})
 
 	t.Run("Failure: should log error if event bus publish fails but not block command success", func(t *testing.T) {
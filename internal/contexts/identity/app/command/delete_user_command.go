@@ -0,0 +1,61 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+)
+
+type deleteUserCommand struct {
+	useCase   user.DeleteUserUseCase
+	publisher user.UserDeletedEventPublisher
+	logger    *slog.Logger
+	tracer    trace.Tracer
+}
+
+func NewDeleteUserCommand(
+	uc user.DeleteUserUseCase,
+	pub user.UserDeletedEventPublisher,
+	logger *slog.Logger,
+) user.DeleteUserCommand {
+	return &deleteUserCommand{
+		useCase:   uc,
+		publisher: pub,
+		logger:    logger,
+		tracer:    otel.Tracer("identity-command"),
+	}
+}
+
+func (c *deleteUserCommand) Execute(ctx context.Context, input user.DeleteUserCommandInput) (user.UserStatusOutput, error) {
+	return runUserStatusCommand(ctx, c.tracer, c.logger, "DeleteUser", input.UserStatusCommandInput,
+		c.useCase.Execute, c.publisher.PublishUserDeletedEvent)
+}
+
+type restoreUserCommand struct {
+	useCase   user.RestoreUserUseCase
+	publisher user.UserDeletedEventPublisher
+	logger    *slog.Logger
+	tracer    trace.Tracer
+}
+
+func NewRestoreUserCommand(
+	uc user.RestoreUserUseCase,
+	pub user.UserDeletedEventPublisher,
+	logger *slog.Logger,
+) user.RestoreUserCommand {
+	return &restoreUserCommand{
+		useCase:   uc,
+		publisher: pub,
+		logger:    logger,
+		tracer:    otel.Tracer("identity-command"),
+	}
+}
+
+func (c *restoreUserCommand) Execute(ctx context.Context, input user.RestoreUserCommandInput) (user.UserStatusOutput, error) {
+	return runUserStatusCommand(ctx, c.tracer, c.logger, "RestoreUser", input.UserStatusCommandInput,
+		c.useCase.Execute, c.publisher.PublishUserRestoredEvent)
+}
@@ -0,0 +1,146 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/logger"
+)
+
+type requestEmailVerificationCommand struct {
+	useCase   user.RequestEmailVerificationUseCase
+	publisher user.UserEmailVerificationEventPublisher
+	logger    *slog.Logger
+	tracer    trace.Tracer
+}
+
+func NewRequestEmailVerificationCommand(
+	uc user.RequestEmailVerificationUseCase,
+	pub user.UserEmailVerificationEventPublisher,
+	logger *slog.Logger,
+) user.RequestEmailVerificationCommand {
+	return &requestEmailVerificationCommand{
+		useCase:   uc,
+		publisher: pub,
+		logger:    logger,
+		tracer:    otel.Tracer("identity-command"),
+	}
+}
+
+func (c *requestEmailVerificationCommand) Execute(ctx context.Context, input user.RequestEmailVerificationCommandInput) error {
+	ctx, span := c.tracer.Start(ctx, "RequestEmailVerificationCommand.Execute",
+		trace.WithAttributes(
+			attribute.String("command.type", "RequestEmailVerification"),
+		),
+	)
+	defer span.End()
+
+	loggerWithTrace := c.logger.With(logger.TraceID(input.TraceID.String()))
+	loggerWithTrace.Info("starting request email verification command")
+
+	output, err := c.useCase.Execute(ctx, user.RequestEmailVerificationInput{Email: input.Email})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to execute use case")
+		loggerWithTrace.Error("failed to execute request email verification use case", "error", err)
+		return err
+	}
+
+	if output.User == nil {
+		span.SetStatus(codes.Ok, "Command finished successfully")
+		loggerWithTrace.Info("request email verification command finished without issuing a token")
+		return nil
+	}
+
+	span.SetAttributes(attribute.String("user.id", output.User.ID.String()))
+
+	publishErr := c.publisher.PublishUserEmailVerificationRequestedEvent(ctx, user.UserEmailVerificationRequestedEventInput{
+		CorrelationID: input.CorrelationID,
+		TraceID:       input.TraceID,
+		Payload: user.UserEmailVerificationRequestedPayload{
+			UserID:            output.User.ID,
+			Name:              output.User.Name,
+			Email:             output.User.Email.String(),
+			VerificationToken: output.Token.Value,
+			ExpiresAt:         output.Token.ExpiresAt,
+		},
+	})
+	if publishErr != nil {
+		span.RecordError(publishErr)
+		span.SetStatus(codes.Error, "Failed to publish event")
+		loggerWithTrace.Error("failed to publish user email verification requested event", "error", publishErr)
+	}
+
+	span.SetStatus(codes.Ok, "Command finished successfully")
+	loggerWithTrace.Info("request email verification command finished successfully", "user_id", output.User.ID.String())
+
+	return nil
+}
+
+type verifyEmailCommand struct {
+	useCase   user.VerifyEmailUseCase
+	publisher user.UserEmailVerificationEventPublisher
+	logger    *slog.Logger
+	tracer    trace.Tracer
+}
+
+func NewVerifyEmailCommand(
+	uc user.VerifyEmailUseCase,
+	pub user.UserEmailVerificationEventPublisher,
+	logger *slog.Logger,
+) user.VerifyEmailCommand {
+	return &verifyEmailCommand{
+		useCase:   uc,
+		publisher: pub,
+		logger:    logger,
+		tracer:    otel.Tracer("identity-command"),
+	}
+}
+
+func (c *verifyEmailCommand) Execute(ctx context.Context, input user.VerifyEmailCommandInput) (user.VerifyEmailOutput, error) {
+	ctx, span := c.tracer.Start(ctx, "VerifyEmailCommand.Execute",
+		trace.WithAttributes(
+			attribute.String("command.type", "VerifyEmail"),
+		),
+	)
+	defer span.End()
+
+	loggerWithTrace := c.logger.With(logger.TraceID(input.TraceID.String()))
+	loggerWithTrace.Info("starting verify email command")
+
+	output, err := c.useCase.Execute(ctx, user.VerifyEmailInput{Token: input.Token})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to execute use case")
+		loggerWithTrace.Error("failed to execute verify email use case", "error", err)
+		return user.VerifyEmailOutput{}, err
+	}
+
+	span.SetAttributes(attribute.String("user.id", output.User.ID.String()))
+
+	publishErr := c.publisher.PublishUserEmailVerifiedEvent(ctx, user.UserEmailVerifiedEventInput{
+		CorrelationID: input.CorrelationID,
+		TraceID:       input.TraceID,
+		Payload: user.UserEmailVerifiedPayload{
+			UserID:  output.User.ID,
+			Email:   output.User.Email.String(),
+			Version: output.User.Version.Int(),
+		},
+	})
+	if publishErr != nil {
+		span.RecordError(publishErr)
+		span.SetStatus(codes.Error, "Failed to publish event")
+		loggerWithTrace.Error("failed to publish user email verified event", "error", publishErr)
+	}
+
+	span.SetStatus(codes.Ok, "Command finished successfully")
+	loggerWithTrace.Info("verify email command finished successfully", "user_id", output.User.ID.String())
+
+	return output, nil
+}
@@ -0,0 +1,120 @@
+package command_test
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/command"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type mockRequestEmailVerificationUseCase struct {
+	ExecuteFunc func(ctx context.Context, input user.RequestEmailVerificationInput) (user.RequestEmailVerificationOutput, error)
+}
+
+func (m *mockRequestEmailVerificationUseCase) Execute(ctx context.Context, input user.RequestEmailVerificationInput) (user.RequestEmailVerificationOutput, error) {
+	return m.ExecuteFunc(ctx, input)
+}
+
+type mockVerifyEmailUseCase struct {
+	ExecuteFunc func(ctx context.Context, input user.VerifyEmailInput) (user.VerifyEmailOutput, error)
+}
+
+func (m *mockVerifyEmailUseCase) Execute(ctx context.Context, input user.VerifyEmailInput) (user.VerifyEmailOutput, error) {
+	return m.ExecuteFunc(ctx, input)
+}
+
+func TestRequestEmailVerificationCommand_Execute(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	t.Run("Success: should publish the verification token", func(t *testing.T) {
+		email, err := types.NewEmail("jane@example.com")
+		require.NoError(t, err)
+		requester := &user.User{ID: types.MustNewUUID(), Name: "Jane Doe", Email: email}
+		expiresAt := time.Now().Add(48 * time.Hour)
+		useCase := &mockRequestEmailVerificationUseCase{
+			ExecuteFunc: func(ctx context.Context, input user.RequestEmailVerificationInput) (user.RequestEmailVerificationOutput, error) {
+				return user.RequestEmailVerificationOutput{
+					User:  requester,
+					Token: user.IssuedEmailVerificationToken{Value: "id.secret", ExpiresAt: expiresAt},
+				}, nil
+			},
+		}
+		publisher := &mockUserPublisher{}
+		cmd := command.NewRequestEmailVerificationCommand(useCase, publisher, logger)
+
+		err = cmd.Execute(context.Background(), user.RequestEmailVerificationCommandInput{Email: "jane@example.com"})
+
+		require.NoError(t, err)
+		require.Len(t, publisher.verificationRequested, 1)
+		assert.Equal(t, user.UserEmailVerificationRequestedPayload{
+			UserID:            requester.ID,
+			Name:              "Jane Doe",
+			Email:             "jane@example.com",
+			VerificationToken: "id.secret",
+			ExpiresAt:         expiresAt,
+		}, publisher.verificationRequested[0].Payload)
+	})
+
+	t.Run("Success: should not publish when no token was issued", func(t *testing.T) {
+		useCase := &mockRequestEmailVerificationUseCase{
+			ExecuteFunc: func(ctx context.Context, input user.RequestEmailVerificationInput) (user.RequestEmailVerificationOutput, error) {
+				return user.RequestEmailVerificationOutput{}, nil
+			},
+		}
+		publisher := &mockUserPublisher{}
+		cmd := command.NewRequestEmailVerificationCommand(useCase, publisher, logger)
+
+		err := cmd.Execute(context.Background(), user.RequestEmailVerificationCommandInput{Email: "nobody@example.com"})
+
+		require.NoError(t, err)
+		assert.Empty(t, publisher.verificationRequested)
+	})
+}
+
+func TestVerifyEmailCommand_Execute(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	t.Run("Success: should publish user.email_verified", func(t *testing.T) {
+		email, err := types.NewEmail("jane@example.com")
+		require.NoError(t, err)
+		verified := &user.User{ID: types.MustNewUUID(), Email: email, Version: types.Version(2)}
+		useCase := &mockVerifyEmailUseCase{
+			ExecuteFunc: func(ctx context.Context, input user.VerifyEmailInput) (user.VerifyEmailOutput, error) {
+				return user.VerifyEmailOutput{User: verified}, nil
+			},
+		}
+		publisher := &mockUserPublisher{}
+		cmd := command.NewVerifyEmailCommand(useCase, publisher, logger)
+
+		_, err = cmd.Execute(context.Background(), user.VerifyEmailCommandInput{Token: "id.secret"})
+
+		require.NoError(t, err)
+		require.Len(t, publisher.emailVerified, 1)
+		assert.Equal(t, user.UserEmailVerifiedPayload{UserID: verified.ID, Email: "jane@example.com", Version: 2}, publisher.emailVerified[0].Payload)
+	})
+
+	t.Run("Failure: should not publish when the token is rejected", func(t *testing.T) {
+		unauthorized := msg.NewMessageError(nil, user.ErrEmailVerificationTokenInvalid, msg.CodeUnauthorized, nil)
+		useCase := &mockVerifyEmailUseCase{
+			ExecuteFunc: func(ctx context.Context, input user.VerifyEmailInput) (user.VerifyEmailOutput, error) {
+				return user.VerifyEmailOutput{}, unauthorized
+			},
+		}
+		publisher := &mockUserPublisher{}
+		cmd := command.NewVerifyEmailCommand(useCase, publisher, logger)
+
+		_, err := cmd.Execute(context.Background(), user.VerifyEmailCommandInput{Token: "bad"})
+
+		assert.Equal(t, unauthorized, err)
+		assert.Empty(t, publisher.emailVerified)
+	})
+}
@@ -0,0 +1,171 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/logger"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type startExternalLoginCommand struct {
+	useCase user.StartExternalLoginUseCase
+	logger  *slog.Logger
+	tracer  trace.Tracer
+}
+
+func NewStartExternalLoginCommand(uc user.StartExternalLoginUseCase, logger *slog.Logger) user.StartExternalLoginCommand {
+	return &startExternalLoginCommand{
+		useCase: uc,
+		logger:  logger,
+		tracer:  otel.Tracer("identity-command"),
+	}
+}
+
+func (c *startExternalLoginCommand) Execute(
+	ctx context.Context,
+	input user.StartExternalLoginCommandInput,
+) (user.StartExternalLoginOutput, error) {
+	ctx, span := c.tracer.Start(ctx, "StartExternalLoginCommand.Execute",
+		trace.WithAttributes(
+			attribute.String("command.type", "StartExternalLogin"),
+		),
+	)
+	defer span.End()
+
+	loggerWithTrace := c.logger.With(logger.TraceID(input.TraceID.String()))
+	loggerWithTrace.Info("starting external login")
+
+	output, err := c.useCase.Execute(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to execute use case")
+		loggerWithTrace.Error("failed to start external login", "error", err)
+		return user.StartExternalLoginOutput{}, err
+	}
+
+	span.SetStatus(codes.Ok, "Command finished successfully")
+	return output, nil
+}
+
+type externalLoginCommand struct {
+	useCase   user.ExternalLoginUseCase
+	publisher user.ExternalLoginEventPublisher
+	logger    *slog.Logger
+	tracer    trace.Tracer
+}
+
+func NewExternalLoginCommand(
+	uc user.ExternalLoginUseCase,
+	pub user.ExternalLoginEventPublisher,
+	logger *slog.Logger,
+) user.ExternalLoginCommand {
+	return &externalLoginCommand{
+		useCase:   uc,
+		publisher: pub,
+		logger:    logger,
+		tracer:    otel.Tracer("identity-command"),
+	}
+}
+
+func (c *externalLoginCommand) Execute(
+	ctx context.Context,
+	input user.ExternalLoginCommandInput,
+) (user.ExternalLoginOutput, error) {
+	ctx, span := c.tracer.Start(ctx, "ExternalLoginCommand.Execute",
+		trace.WithAttributes(
+			attribute.String("login.by", user.LoginMethodGoogle),
+			attribute.String("command.type", "ExternalLogin"),
+		),
+	)
+	defer span.End()
+
+	loggerWithTrace := c.logger.With(logger.TraceID(input.TraceID.String()))
+	loggerWithTrace.Info("starting external login command")
+
+	output, err := c.useCase.Execute(ctx, input.Callback)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Login rejected")
+
+		if output.FailureReason == "" {
+			loggerWithTrace.Error("failed to execute external login use case", "error", err)
+			return user.ExternalLoginOutput{}, err
+		}
+
+		payload := user.UserLoginFailedPayload{
+			UserID: types.NewNullUUID(),
+			Method: user.LoginMethodGoogle,
+			Reason: output.FailureReason,
+		}
+		if output.User != nil {
+			payload.UserID = types.NewValidNullableUUID(output.User.ID)
+		}
+
+		loggerWithTrace.Warn("external login rejected", "reason", output.FailureReason, "user_id", payload.UserID)
+
+		publishErr := c.publisher.PublishUserLoginFailedEvent(ctx, user.UserLoginFailedEventInput{
+			CorrelationID: input.CorrelationID,
+			TraceID:       input.TraceID,
+			Payload:       payload,
+		})
+		if publishErr != nil {
+			span.RecordError(publishErr)
+			loggerWithTrace.Error("failed to publish user login failed event", "error", publishErr)
+		}
+
+		return user.ExternalLoginOutput{}, err
+	}
+
+	span.SetAttributes(
+		attribute.String("user.id", output.User.ID.String()),
+		attribute.Bool("user.created", output.Created),
+	)
+
+	if output.Created {
+		// The user registered itself, so it is also the author.
+		publishErr := c.publisher.PublishUserCreatedEvent(ctx, user.USerCreatedEventInput{
+			CorrelationID: input.CorrelationID,
+			UserID:        types.NewValidNullableUUID(output.User.ID),
+			TraceID:       input.TraceID,
+			Payload: user.UserCreatedPayload{
+				UserID: output.User.ID,
+				Name:   output.User.Name,
+				Email:  output.User.Email.String(),
+				Phone:  output.User.Phone.String(),
+			},
+		})
+		if publishErr != nil {
+			span.RecordError(publishErr)
+			loggerWithTrace.Error("failed to publish user created event", "error", publishErr)
+		}
+	}
+
+	publishErr := c.publisher.PublishUserLoggedInEvent(ctx, user.UserLoggedInEventInput{
+		CorrelationID: input.CorrelationID,
+		TraceID:       input.TraceID,
+		Payload: user.UserLoggedInPayload{
+			UserID:    output.User.ID,
+			Method:    user.LoginMethodGoogle,
+			TokenID:   output.Session.AccessToken.Claims.ID,
+			ExpiresAt: output.Session.AccessToken.ExpiresAt,
+		},
+	})
+	if publishErr != nil {
+		span.RecordError(publishErr)
+		span.SetStatus(codes.Error, "Failed to publish event")
+		loggerWithTrace.Error("failed to publish user logged in event", "error", publishErr)
+	}
+
+	span.SetStatus(codes.Ok, "Command finished successfully")
+	loggerWithTrace.Info("external login command finished successfully",
+		"user_id", output.User.ID.String(), "created", output.Created)
+
+	return output, nil
+}
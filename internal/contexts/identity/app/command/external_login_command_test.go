@@ -0,0 +1,129 @@
+package command_test
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/command"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/token"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type mockExternalLoginUseCase struct {
+	ExecuteFunc func(ctx context.Context, input user.ExternalLoginInput) (user.ExternalLoginOutput, error)
+}
+
+func (m *mockExternalLoginUseCase) Execute(ctx context.Context, input user.ExternalLoginInput) (user.ExternalLoginOutput, error) {
+	if m.ExecuteFunc != nil {
+		return m.ExecuteFunc(ctx, input)
+	}
+	return user.ExternalLoginOutput{}, nil
+}
+
+type mockExternalLoginPublisher struct {
+	mockUserLoginPublisher
+	created []user.USerCreatedEventInput
+}
+
+func (m *mockExternalLoginPublisher) PublishUserCreatedEvent(ctx context.Context, input user.USerCreatedEventInput) error {
+	m.created = append(m.created, input)
+	return nil
+}
+
+func TestExternalLoginCommand_Execute(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	commandInput := user.ExternalLoginCommandInput{
+		CorrelationID: types.MustNewUUID(),
+		TraceID:       types.MustNewUUID(),
+		Callback:      user.ExternalLoginInput{State: "state", Code: "code"},
+	}
+	session := user.Session{AccessToken: token.AccessToken{Value: "signed", Claims: token.Claims{ID: "jti-1"}}}
+
+	t.Run("Success: should publish user.created and user.logged_in for a new user", func(t *testing.T) {
+		created, err := user.NewExternalUser(user.NewExternalUserInput{Name: "Jane Doe", Email: "jane@example.com"})
+		require.NoError(t, err)
+		useCase := &mockExternalLoginUseCase{
+			ExecuteFunc: func(ctx context.Context, input user.ExternalLoginInput) (user.ExternalLoginOutput, error) {
+				return user.ExternalLoginOutput{User: created, Session: session, Created: true}, nil
+			},
+		}
+		publisher := &mockExternalLoginPublisher{}
+		cmd := command.NewExternalLoginCommand(useCase, publisher, logger)
+
+		output, err := cmd.Execute(context.Background(), commandInput)
+
+		require.NoError(t, err)
+		assert.Equal(t, "signed", output.Session.AccessToken.Value)
+		require.Len(t, publisher.created, 1)
+		assert.Equal(t, created.ID, publisher.created[0].Payload.UserID)
+		assert.Equal(t, "jane@example.com", publisher.created[0].Payload.Email)
+		authorID, ok := publisher.created[0].UserID.GetUUID()
+		require.True(t, ok)
+		assert.Equal(t, created.ID, authorID)
+		require.Len(t, publisher.loggedIn, 1)
+		assert.Equal(t, user.LoginMethodGoogle, publisher.loggedIn[0].Payload.Method)
+		assert.Equal(t, "jti-1", publisher.loggedIn[0].Payload.TokenID)
+	})
+
+	t.Run("Success: should only publish user.logged_in for an existing user", func(t *testing.T) {
+		existing := &user.User{ID: types.MustNewUUID()}
+		useCase := &mockExternalLoginUseCase{
+			ExecuteFunc: func(ctx context.Context, input user.ExternalLoginInput) (user.ExternalLoginOutput, error) {
+				return user.ExternalLoginOutput{User: existing, Session: session}, nil
+			},
+		}
+		publisher := &mockExternalLoginPublisher{}
+		cmd := command.NewExternalLoginCommand(useCase, publisher, logger)
+
+		_, err := cmd.Execute(context.Background(), commandInput)
+
+		require.NoError(t, err)
+		assert.Empty(t, publisher.created)
+		require.Len(t, publisher.loggedIn, 1)
+		assert.Equal(t, existing.ID, publisher.loggedIn[0].Payload.UserID)
+	})
+
+	t.Run("Failure: should publish user.login_failed with the reason", func(t *testing.T) {
+		archived := &user.User{ID: types.MustNewUUID()}
+		forbidden := msg.NewMessageError(nil, user.ErrLoginUserArchived, msg.CodeForbidden, nil)
+		useCase := &mockExternalLoginUseCase{
+			ExecuteFunc: func(ctx context.Context, input user.ExternalLoginInput) (user.ExternalLoginOutput, error) {
+				return user.ExternalLoginOutput{User: archived, FailureReason: user.LoginFailureUserArchived}, forbidden
+			},
+		}
+		publisher := &mockExternalLoginPublisher{}
+		cmd := command.NewExternalLoginCommand(useCase, publisher, logger)
+
+		_, err := cmd.Execute(context.Background(), commandInput)
+
+		assert.Equal(t, forbidden, err)
+		require.Len(t, publisher.loginFailed, 1)
+		assert.Equal(t, user.LoginMethodGoogle, publisher.loginFailed[0].Payload.Method)
+		assert.Equal(t, user.LoginFailureUserArchived, publisher.loginFailed[0].Payload.Reason)
+		assert.Empty(t, publisher.loggedIn)
+	})
+
+	t.Run("Failure: should not publish when the state is invalid", func(t *testing.T) {
+		invalidState := msg.NewMessageError(nil, user.ErrExternalLoginStateInvalid, msg.CodeUnauthorized, nil)
+		useCase := &mockExternalLoginUseCase{
+			ExecuteFunc: func(ctx context.Context, input user.ExternalLoginInput) (user.ExternalLoginOutput, error) {
+				return user.ExternalLoginOutput{}, invalidState
+			},
+		}
+		publisher := &mockExternalLoginPublisher{}
+		cmd := command.NewExternalLoginCommand(useCase, publisher, logger)
+
+		_, err := cmd.Execute(context.Background(), commandInput)
+
+		assert.Equal(t, invalidState, err)
+		assert.Empty(t, publisher.loginFailed)
+		assert.Empty(t, publisher.created)
+	})
+}
@@ -0,0 +1,53 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/logger"
+)
+
+type forgetUserCommand struct {
+	useCase user.ForgetUserUseCase
+	logger  *slog.Logger
+	tracer  trace.Tracer
+}
+
+func NewForgetUserCommand(useCase user.ForgetUserUseCase, logger *slog.Logger) user.ForgetUserCommand {
+	return &forgetUserCommand{
+		useCase: useCase,
+		logger:  logger,
+		tracer:  otel.Tracer("identity-command"),
+	}
+}
+
+func (c *forgetUserCommand) Execute(ctx context.Context, input user.ForgetUserCommandInput) error {
+	ctx, span := c.tracer.Start(ctx, "ForgetUserCommand.Execute",
+		trace.WithAttributes(
+			attribute.String("user.id", input.UserID.String()),
+			attribute.String("command.type", "ForgetUser"),
+		),
+	)
+	defer span.End()
+
+	loggerWithTrace := c.logger.With(logger.TraceID(input.TraceID.String()))
+	loggerWithTrace.Info("starting forget user command", "user_id", input.UserID.String())
+
+	if err := c.useCase.Execute(ctx, user.ForgetUserUseCaseInput{UserID: input.UserID}); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to forget user")
+		loggerWithTrace.Error("failed to forget user", "error", err)
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "Command finished successfully")
+	loggerWithTrace.Info("forget user command finished successfully", "user_id", input.UserID.String())
+
+	return nil
+}
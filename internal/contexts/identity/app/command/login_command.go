@@ -0,0 +1,113 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/logger"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type loginCommand struct {
+	useCase   user.LoginUseCase
+	publisher user.UserLoginEventPublisher
+	logger    *slog.Logger
+	tracer    trace.Tracer
+}
+
+func NewLoginCommand(
+	uc user.LoginUseCase,
+	pub user.UserLoginEventPublisher,
+	logger *slog.Logger,
+) user.LoginCommand {
+	return &loginCommand{
+		useCase:   uc,
+		publisher: pub,
+		logger:    logger,
+		tracer:    otel.Tracer("identity-command"),
+	}
+}
+
+func (c *loginCommand) Execute(
+	ctx context.Context,
+	input user.LoginCommandInput,
+) (user.LoginOutput, error) {
+	loginBy := "email"
+	if input.Credentials.Email == "" {
+		loginBy = "phone"
+	}
+
+	ctx, span := c.tracer.Start(ctx, "LoginCommand.Execute",
+		trace.WithAttributes(
+			attribute.String("login.by", loginBy),
+			attribute.String("command.type", "Login"),
+		),
+	)
+	defer span.End()
+
+	loggerWithTrace := c.logger.With(logger.TraceID(input.TraceID.String()))
+	loggerWithTrace.Info("starting login command", "login_by", loginBy)
+
+	output, err := c.useCase.Execute(ctx, input.Credentials)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Login rejected")
+
+		if output.FailureReason == "" {
+			loggerWithTrace.Error("failed to execute login use case", "error", err)
+			return user.LoginOutput{}, err
+		}
+
+		payload := user.UserLoginFailedPayload{
+			UserID: types.NewNullUUID(),
+			Method: user.LoginMethodPassword,
+			Reason: output.FailureReason,
+		}
+		if output.User != nil {
+			payload.UserID = types.NewValidNullableUUID(output.User.ID)
+		}
+
+		loggerWithTrace.Warn("login rejected", "reason", output.FailureReason, "user_id", payload.UserID)
+
+		publishErr := c.publisher.PublishUserLoginFailedEvent(ctx, user.UserLoginFailedEventInput{
+			CorrelationID: input.CorrelationID,
+			TraceID:       input.TraceID,
+			Payload:       payload,
+		})
+		if publishErr != nil {
+			span.RecordError(publishErr)
+			loggerWithTrace.Error("failed to publish user login failed event", "error", publishErr)
+		}
+
+		return user.LoginOutput{}, err
+	}
+
+	span.SetAttributes(attribute.String("user.id", output.User.ID.String()))
+
+	publishErr := c.publisher.PublishUserLoggedInEvent(ctx, user.UserLoggedInEventInput{
+		CorrelationID: input.CorrelationID,
+		TraceID:       input.TraceID,
+		Payload: user.UserLoggedInPayload{
+			UserID:    output.User.ID,
+			Method:    user.LoginMethodPassword,
+			TokenID:   output.Session.AccessToken.Claims.ID,
+			ExpiresAt: output.Session.AccessToken.ExpiresAt,
+		},
+	})
+	if publishErr != nil {
+		span.RecordError(publishErr)
+		span.SetStatus(codes.Error, "Failed to publish event")
+		loggerWithTrace.Error("failed to publish user logged in event", "error", publishErr)
+	}
+
+	span.SetStatus(codes.Ok, "Command finished successfully")
+	loggerWithTrace.Info("login command finished successfully", "user_id", output.User.ID.String())
+
+	return output, nil
+}
@@ -0,0 +1,156 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/command"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/token"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+// --- Mocks for Dependencies ---
+
+type mockLoginUseCase struct {
+	ExecuteFunc func(ctx context.Context, input user.LoginInput) (user.LoginOutput, error)
+}
+
+func (m *mockLoginUseCase) Execute(ctx context.Context, input user.LoginInput) (user.LoginOutput, error) {
+	if m.ExecuteFunc != nil {
+		return m.ExecuteFunc(ctx, input)
+	}
+	return user.LoginOutput{}, nil
+}
+
+type mockUserLoginPublisher struct {
+	loggedIn    []user.UserLoggedInEventInput
+	loginFailed []user.UserLoginFailedEventInput
+}
+
+func (m *mockUserLoginPublisher) PublishUserLoggedInEvent(ctx context.Context, input user.UserLoggedInEventInput) error {
+	m.loggedIn = append(m.loggedIn, input)
+	return nil
+}
+
+func (m *mockUserLoginPublisher) PublishUserLoginFailedEvent(ctx context.Context, input user.UserLoginFailedEventInput) error {
+	m.loginFailed = append(m.loginFailed, input)
+	return nil
+}
+
+// --- Test Suite ---
+
+func TestLoginCommand_Execute(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	loggedUser := &user.User{ID: types.MustNewUUID()}
+	commandInput := user.LoginCommandInput{
+		CorrelationID: types.MustNewUUID(),
+		TraceID:       types.MustNewUUID(),
+		Credentials:   user.LoginInput{Email: "test@example.com", Password: "ValidPassword123!"},
+	}
+	invalidCredentials := msg.NewMessageError(nil, user.ErrLoginInvalidCredentials, msg.CodeUnauthorized, nil)
+
+	t.Run("Success: should publish user.logged_in with the token id", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Hour)
+		useCase := &mockLoginUseCase{
+			ExecuteFunc: func(ctx context.Context, input user.LoginInput) (user.LoginOutput, error) {
+				return user.LoginOutput{
+					User: loggedUser,
+					Session: user.Session{
+						AccessToken: token.AccessToken{Value: "signed", ExpiresAt: expiresAt, Claims: token.Claims{ID: "jti-1"}},
+					},
+				}, nil
+			},
+		}
+		publisher := &mockUserLoginPublisher{}
+		cmd := command.NewLoginCommand(useCase, publisher, logger)
+
+		output, err := cmd.Execute(context.Background(), commandInput)
+
+		require.NoError(t, err)
+		assert.Equal(t, "signed", output.Session.AccessToken.Value)
+		require.Len(t, publisher.loggedIn, 1)
+		assert.Empty(t, publisher.loginFailed)
+		payload := publisher.loggedIn[0].Payload
+		assert.Equal(t, loggedUser.ID, payload.UserID)
+		assert.Equal(t, user.LoginMethodPassword, payload.Method)
+		assert.Equal(t, "jti-1", payload.TokenID)
+		assert.Equal(t, expiresAt, payload.ExpiresAt)
+	})
+
+	t.Run("Failure: should publish user.login_failed with the user when known", func(t *testing.T) {
+		useCase := &mockLoginUseCase{
+			ExecuteFunc: func(ctx context.Context, input user.LoginInput) (user.LoginOutput, error) {
+				return user.LoginOutput{User: loggedUser, FailureReason: user.LoginFailureInvalidPassword}, invalidCredentials
+			},
+		}
+		publisher := &mockUserLoginPublisher{}
+		cmd := command.NewLoginCommand(useCase, publisher, logger)
+
+		output, err := cmd.Execute(context.Background(), commandInput)
+
+		require.ErrorIs(t, err, invalidCredentials)
+		assert.Nil(t, output.User, "A rejected login should not leak the user to the caller")
+		require.Len(t, publisher.loginFailed, 1)
+		payload := publisher.loginFailed[0].Payload
+		id, ok := payload.UserID.GetUUID()
+		require.True(t, ok)
+		assert.Equal(t, loggedUser.ID, id)
+		assert.Equal(t, user.LoginFailureInvalidPassword, payload.Reason)
+	})
+
+	t.Run("Failure: should publish user.login_failed without a user for unknown identifiers", func(t *testing.T) {
+		useCase := &mockLoginUseCase{
+			ExecuteFunc: func(ctx context.Context, input user.LoginInput) (user.LoginOutput, error) {
+				return user.LoginOutput{FailureReason: user.LoginFailureUnknownUser}, invalidCredentials
+			},
+		}
+		publisher := &mockUserLoginPublisher{}
+		cmd := command.NewLoginCommand(useCase, publisher, logger)
+
+		_, err := cmd.Execute(context.Background(), commandInput)
+
+		require.Error(t, err)
+		require.Len(t, publisher.loginFailed, 1)
+		assert.False(t, publisher.loginFailed[0].Payload.UserID.Valid)
+		assert.Equal(t, user.LoginFailureUnknownUser, publisher.loginFailed[0].Payload.Reason)
+	})
+
+	t.Run("Failure: should not publish anything on infrastructure errors", func(t *testing.T) {
+		useCase := &mockLoginUseCase{
+			ExecuteFunc: func(ctx context.Context, input user.LoginInput) (user.LoginOutput, error) {
+				return user.LoginOutput{}, msg.NewInternalError(errors.New("db down"), nil)
+			},
+		}
+		publisher := &mockUserLoginPublisher{}
+		cmd := command.NewLoginCommand(useCase, publisher, logger)
+
+		_, err := cmd.Execute(context.Background(), commandInput)
+
+		require.Error(t, err)
+		assert.Empty(t, publisher.loggedIn)
+		assert.Empty(t, publisher.loginFailed)
+	})
+}
+
+func TestLoginCommandInput_Validate(t *testing.T) {
+	t.Run("Failure: should require an email or a phone", func(t *testing.T) {
+		err := user.LoginCommandInput{Credentials: user.LoginInput{Password: "secret"}}.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), user.ErrLoginIdentifierRequired)
+	})
+
+	t.Run("Failure: should reject both email and phone", func(t *testing.T) {
+		err := user.LoginCommandInput{Credentials: user.LoginInput{Email: "a@b.com", Phone: "5562999998888", Password: "secret"}}.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), user.ErrLoginIdentifierAmbiguous)
+	})
+}
@@ -0,0 +1,95 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/logger"
+)
+
+type logoutCommand struct {
+	useCase user.LogoutUseCase
+	logger  *slog.Logger
+	tracer  trace.Tracer
+}
+
+func NewLogoutCommand(uc user.LogoutUseCase, logger *slog.Logger) user.LogoutCommand {
+	return &logoutCommand{
+		useCase: uc,
+		logger:  logger,
+		tracer:  otel.Tracer("identity-command"),
+	}
+}
+
+func (c *logoutCommand) Execute(ctx context.Context, input user.LogoutCommandInput) error {
+	ctx, span := c.tracer.Start(ctx, "LogoutCommand.Execute",
+		trace.WithAttributes(
+			attribute.String("command.type", "Logout"),
+		),
+	)
+	defer span.End()
+
+	loggerWithTrace := c.logger.With(logger.TraceID(input.TraceID.String()))
+	loggerWithTrace.Info("starting logout command")
+
+	if err := c.useCase.Execute(ctx, user.LogoutInput{RefreshToken: input.RefreshToken}); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to revoke session")
+		loggerWithTrace.Error("failed to execute logout use case", "error", err)
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "Command finished successfully")
+	loggerWithTrace.Info("logout command finished successfully")
+
+	return nil
+}
+
+type logoutAllCommand struct {
+	useCase user.LogoutAllUseCase
+	logger  *slog.Logger
+	tracer  trace.Tracer
+}
+
+func NewLogoutAllCommand(uc user.LogoutAllUseCase, logger *slog.Logger) user.LogoutAllCommand {
+	return &logoutAllCommand{
+		useCase: uc,
+		logger:  logger,
+		tracer:  otel.Tracer("identity-command"),
+	}
+}
+
+func (c *logoutAllCommand) Execute(ctx context.Context, input user.LogoutAllCommandInput) (user.LogoutAllOutput, error) {
+	userID, _ := input.UserAuthorID.GetUUID()
+
+	ctx, span := c.tracer.Start(ctx, "LogoutAllCommand.Execute",
+		trace.WithAttributes(
+			attribute.String("user.id", userID.String()),
+			attribute.String("command.type", "LogoutAll"),
+		),
+	)
+	defer span.End()
+
+	loggerWithTrace := c.logger.With(logger.TraceID(input.TraceID.String()))
+	loggerWithTrace.Info("starting logout all command", "user_id", userID.String())
+
+	output, err := c.useCase.Execute(ctx, user.LogoutAllInput{UserID: userID})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to revoke sessions")
+		loggerWithTrace.Error("failed to execute logout all use case", "error", err)
+		return user.LogoutAllOutput{}, err
+	}
+
+	span.SetAttributes(attribute.Int("sessions.revoked", output.Revoked))
+	span.SetStatus(codes.Ok, "Command finished successfully")
+	loggerWithTrace.Info("logout all command finished successfully", "user_id", userID.String(), "revoked", output.Revoked)
+
+	return output, nil
+}
@@ -0,0 +1,230 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/logger"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type changePasswordCommand struct {
+	useCase   user.ChangePasswordUseCase
+	publisher user.UserPasswordEventPublisher
+	logger    *slog.Logger
+	tracer    trace.Tracer
+}
+
+func NewChangePasswordCommand(
+	uc user.ChangePasswordUseCase,
+	pub user.UserPasswordEventPublisher,
+	logger *slog.Logger,
+) user.ChangePasswordCommand {
+	return &changePasswordCommand{
+		useCase:   uc,
+		publisher: pub,
+		logger:    logger,
+		tracer:    otel.Tracer("identity-command"),
+	}
+}
+
+func (c *changePasswordCommand) Execute(
+	ctx context.Context,
+	input user.ChangePasswordCommandInput,
+) (user.PasswordChangedOutput, error) {
+	userID, _ := input.UserAuthorID.GetUUID()
+
+	ctx, span := c.tracer.Start(ctx, "ChangePasswordCommand.Execute",
+		trace.WithAttributes(
+			attribute.String("user.id", userID.String()),
+			attribute.String("command.type", "ChangePassword"),
+		),
+	)
+	defer span.End()
+
+	loggerWithTrace := c.logger.With(logger.TraceID(input.TraceID.String()))
+	loggerWithTrace.Info("starting change password command", "user_id", userID.String())
+
+	output, err := c.useCase.Execute(ctx, user.ChangePasswordInput{
+		UserID:          userID,
+		CurrentPassword: input.CurrentPassword,
+		NewPassword:     input.NewPassword,
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to execute use case")
+		loggerWithTrace.Error("failed to execute change password use case", "error", err)
+		return user.PasswordChangedOutput{}, err
+	}
+
+	publishPasswordChanged(ctx, c.publisher, span, loggerWithTrace, input.CorrelationID, input.TraceID,
+		user.PasswordChangeMethodChange, output)
+
+	span.SetStatus(codes.Ok, "Command finished successfully")
+	loggerWithTrace.Info("change password command finished successfully",
+		"user_id", userID.String(), "revoked", output.SessionsRevoked)
+
+	return output, nil
+}
+
+type requestPasswordResetCommand struct {
+	useCase   user.RequestPasswordResetUseCase
+	publisher user.UserPasswordEventPublisher
+	logger    *slog.Logger
+	tracer    trace.Tracer
+}
+
+func NewRequestPasswordResetCommand(
+	uc user.RequestPasswordResetUseCase,
+	pub user.UserPasswordEventPublisher,
+	logger *slog.Logger,
+) user.RequestPasswordResetCommand {
+	return &requestPasswordResetCommand{
+		useCase:   uc,
+		publisher: pub,
+		logger:    logger,
+		tracer:    otel.Tracer("identity-command"),
+	}
+}
+
+func (c *requestPasswordResetCommand) Execute(ctx context.Context, input user.RequestPasswordResetCommandInput) error {
+	ctx, span := c.tracer.Start(ctx, "RequestPasswordResetCommand.Execute",
+		trace.WithAttributes(
+			attribute.String("command.type", "RequestPasswordReset"),
+		),
+	)
+	defer span.End()
+
+	loggerWithTrace := c.logger.With(logger.TraceID(input.TraceID.String()))
+	loggerWithTrace.Info("starting request password reset command")
+
+	output, err := c.useCase.Execute(ctx, user.RequestPasswordResetInput{Email: input.Email})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to execute use case")
+		loggerWithTrace.Error("failed to execute request password reset use case", "error", err)
+		return err
+	}
+
+	if output.User == nil {
+		// Unknown or inactive address: nothing to send, and the caller gets
+		// the same answer as for a real account.
+		span.SetStatus(codes.Ok, "Command finished successfully")
+		loggerWithTrace.Info("request password reset command finished without issuing a token")
+		return nil
+	}
+
+	span.SetAttributes(attribute.String("user.id", output.User.ID.String()))
+
+	publishErr := c.publisher.PublishUserPasswordResetRequestedEvent(ctx, user.UserPasswordResetRequestedEventInput{
+		CorrelationID: input.CorrelationID,
+		TraceID:       input.TraceID,
+		Payload: user.UserPasswordResetRequestedPayload{
+			UserID:     output.User.ID,
+			Name:       output.User.Name,
+			Email:      output.User.Email.String(),
+			ResetToken: output.Token.Value,
+			ExpiresAt:  output.Token.ExpiresAt,
+		},
+	})
+	if publishErr != nil {
+		span.RecordError(publishErr)
+		span.SetStatus(codes.Error, "Failed to publish event")
+		loggerWithTrace.Error("failed to publish user password reset requested event", "error", publishErr)
+	}
+
+	span.SetStatus(codes.Ok, "Command finished successfully")
+	loggerWithTrace.Info("request password reset command finished successfully", "user_id", output.User.ID.String())
+
+	return nil
+}
+
+type resetPasswordCommand struct {
+	useCase   user.ResetPasswordUseCase
+	publisher user.UserPasswordEventPublisher
+	logger    *slog.Logger
+	tracer    trace.Tracer
+}
+
+func NewResetPasswordCommand(
+	uc user.ResetPasswordUseCase,
+	pub user.UserPasswordEventPublisher,
+	logger *slog.Logger,
+) user.ResetPasswordCommand {
+	return &resetPasswordCommand{
+		useCase:   uc,
+		publisher: pub,
+		logger:    logger,
+		tracer:    otel.Tracer("identity-command"),
+	}
+}
+
+func (c *resetPasswordCommand) Execute(
+	ctx context.Context,
+	input user.ResetPasswordCommandInput,
+) (user.PasswordChangedOutput, error) {
+	ctx, span := c.tracer.Start(ctx, "ResetPasswordCommand.Execute",
+		trace.WithAttributes(
+			attribute.String("command.type", "ResetPassword"),
+		),
+	)
+	defer span.End()
+
+	loggerWithTrace := c.logger.With(logger.TraceID(input.TraceID.String()))
+	loggerWithTrace.Info("starting reset password command")
+
+	output, err := c.useCase.Execute(ctx, user.ResetPasswordInput{
+		Token:       input.Token,
+		NewPassword: input.NewPassword,
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to execute use case")
+		loggerWithTrace.Error("failed to execute reset password use case", "error", err)
+		return user.PasswordChangedOutput{}, err
+	}
+
+	span.SetAttributes(attribute.String("user.id", output.User.ID.String()))
+
+	publishPasswordChanged(ctx, c.publisher, span, loggerWithTrace, input.CorrelationID, input.TraceID,
+		user.PasswordChangeMethodReset, output)
+
+	span.SetStatus(codes.Ok, "Command finished successfully")
+	loggerWithTrace.Info("reset password command finished successfully",
+		"user_id", output.User.ID.String(), "revoked", output.SessionsRevoked)
+
+	return output, nil
+}
+
+// publishPasswordChanged is shared by the change and reset commands; a
+// publish failure is logged and recorded but does not fail the command.
+func publishPasswordChanged(
+	ctx context.Context,
+	pub user.UserPasswordEventPublisher,
+	span trace.Span,
+	log *slog.Logger,
+	correlationID, traceID types.UUID,
+	method string,
+	output user.PasswordChangedOutput,
+) {
+	publishErr := pub.PublishUserPasswordChangedEvent(ctx, user.UserPasswordChangedEventInput{
+		CorrelationID: correlationID,
+		TraceID:       traceID,
+		Payload: user.UserPasswordChangedPayload{
+			UserID:          output.User.ID,
+			Method:          method,
+			Version:         output.User.Version.Int(),
+			SessionsRevoked: output.SessionsRevoked,
+		},
+	})
+	if publishErr != nil {
+		span.RecordError(publishErr)
+		log.Error("failed to publish user password changed event", "error", publishErr)
+	}
+}
@@ -0,0 +1,197 @@
+package command_test
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/command"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type mockChangePasswordUseCase struct {
+	ExecuteFunc func(ctx context.Context, input user.ChangePasswordInput) (user.PasswordChangedOutput, error)
+}
+
+func (m *mockChangePasswordUseCase) Execute(ctx context.Context, input user.ChangePasswordInput) (user.PasswordChangedOutput, error) {
+	return m.ExecuteFunc(ctx, input)
+}
+
+type mockRequestPasswordResetUseCase struct {
+	ExecuteFunc func(ctx context.Context, input user.RequestPasswordResetInput) (user.RequestPasswordResetOutput, error)
+}
+
+func (m *mockRequestPasswordResetUseCase) Execute(ctx context.Context, input user.RequestPasswordResetInput) (user.RequestPasswordResetOutput, error) {
+	return m.ExecuteFunc(ctx, input)
+}
+
+type mockResetPasswordUseCase struct {
+	ExecuteFunc func(ctx context.Context, input user.ResetPasswordInput) (user.PasswordChangedOutput, error)
+}
+
+func (m *mockResetPasswordUseCase) Execute(ctx context.Context, input user.ResetPasswordInput) (user.PasswordChangedOutput, error) {
+	return m.ExecuteFunc(ctx, input)
+}
+
+type mockUserPasswordPublisher struct {
+	changed        []user.UserPasswordChangedEventInput
+	resetRequested []user.UserPasswordResetRequestedEventInput
+}
+
+func (m *mockUserPasswordPublisher) PublishUserPasswordChangedEvent(ctx context.Context, input user.UserPasswordChangedEventInput) error {
+	m.changed = append(m.changed, input)
+	return nil
+}
+
+func (m *mockUserPasswordPublisher) PublishUserPasswordResetRequestedEvent(ctx context.Context, input user.UserPasswordResetRequestedEventInput) error {
+	m.resetRequested = append(m.resetRequested, input)
+	return nil
+}
+
+func TestChangePasswordCommand_Execute(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	author := &user.User{ID: types.MustNewUUID(), Version: types.Version(3)}
+
+	t.Run("Success: should publish user.password_changed with the change method", func(t *testing.T) {
+		var received user.ChangePasswordInput
+		useCase := &mockChangePasswordUseCase{
+			ExecuteFunc: func(ctx context.Context, input user.ChangePasswordInput) (user.PasswordChangedOutput, error) {
+				received = input
+				return user.PasswordChangedOutput{User: author, SessionsRevoked: 2}, nil
+			},
+		}
+		publisher := &mockUserPasswordPublisher{}
+		cmd := command.NewChangePasswordCommand(useCase, publisher, logger)
+
+		output, err := cmd.Execute(context.Background(), user.ChangePasswordCommandInput{
+			CorrelationID:   types.MustNewUUID(),
+			TraceID:         types.MustNewUUID(),
+			UserAuthorID:    types.NewValidNullableUUID(author.ID),
+			CurrentPassword: "ValidPassword123!",
+			NewPassword:     "NewPassword456!",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, output.SessionsRevoked)
+		assert.Equal(t, author.ID, received.UserID)
+		require.Len(t, publisher.changed, 1)
+		assert.Equal(t, user.UserPasswordChangedPayload{
+			UserID:          author.ID,
+			Method:          user.PasswordChangeMethodChange,
+			Version:         3,
+			SessionsRevoked: 2,
+		}, publisher.changed[0].Payload)
+	})
+
+	t.Run("Failure: should not publish when the current password is wrong", func(t *testing.T) {
+		invalid := msg.NewValidationError(nil, nil, user.ErrPasswordCurrentInvalid)
+		useCase := &mockChangePasswordUseCase{
+			ExecuteFunc: func(ctx context.Context, input user.ChangePasswordInput) (user.PasswordChangedOutput, error) {
+				return user.PasswordChangedOutput{}, invalid
+			},
+		}
+		publisher := &mockUserPasswordPublisher{}
+		cmd := command.NewChangePasswordCommand(useCase, publisher, logger)
+
+		_, err := cmd.Execute(context.Background(), user.ChangePasswordCommandInput{
+			UserAuthorID: types.NewValidNullableUUID(author.ID),
+		})
+
+		assert.Equal(t, invalid, err)
+		assert.Empty(t, publisher.changed)
+	})
+}
+
+func TestRequestPasswordResetCommand_Execute(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	t.Run("Success: should publish the reset token for the notification subscriber", func(t *testing.T) {
+		email, err := types.NewEmail("jane@example.com")
+		require.NoError(t, err)
+		requester := &user.User{ID: types.MustNewUUID(), Name: "Jane Doe", Email: email}
+		expiresAt := time.Now().Add(30 * time.Minute)
+		useCase := &mockRequestPasswordResetUseCase{
+			ExecuteFunc: func(ctx context.Context, input user.RequestPasswordResetInput) (user.RequestPasswordResetOutput, error) {
+				return user.RequestPasswordResetOutput{
+					User:  requester,
+					Token: user.IssuedPasswordResetToken{Value: "id.secret", ExpiresAt: expiresAt},
+				}, nil
+			},
+		}
+		publisher := &mockUserPasswordPublisher{}
+		cmd := command.NewRequestPasswordResetCommand(useCase, publisher, logger)
+
+		err = cmd.Execute(context.Background(), user.RequestPasswordResetCommandInput{Email: "jane@example.com"})
+
+		require.NoError(t, err)
+		require.Len(t, publisher.resetRequested, 1)
+		assert.Equal(t, user.UserPasswordResetRequestedPayload{
+			UserID:     requester.ID,
+			Name:       "Jane Doe",
+			Email:      "jane@example.com",
+			ResetToken: "id.secret",
+			ExpiresAt:  expiresAt,
+		}, publisher.resetRequested[0].Payload)
+	})
+
+	t.Run("Success: should not publish when no token was issued", func(t *testing.T) {
+		useCase := &mockRequestPasswordResetUseCase{
+			ExecuteFunc: func(ctx context.Context, input user.RequestPasswordResetInput) (user.RequestPasswordResetOutput, error) {
+				return user.RequestPasswordResetOutput{}, nil
+			},
+		}
+		publisher := &mockUserPasswordPublisher{}
+		cmd := command.NewRequestPasswordResetCommand(useCase, publisher, logger)
+
+		err := cmd.Execute(context.Background(), user.RequestPasswordResetCommandInput{Email: "nobody@example.com"})
+
+		require.NoError(t, err)
+		assert.Empty(t, publisher.resetRequested)
+	})
+}
+
+func TestResetPasswordCommand_Execute(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	t.Run("Success: should publish user.password_changed with the reset method", func(t *testing.T) {
+		owner := &user.User{ID: types.MustNewUUID(), Version: types.Version(5)}
+		useCase := &mockResetPasswordUseCase{
+			ExecuteFunc: func(ctx context.Context, input user.ResetPasswordInput) (user.PasswordChangedOutput, error) {
+				return user.PasswordChangedOutput{User: owner, SessionsRevoked: 1}, nil
+			},
+		}
+		publisher := &mockUserPasswordPublisher{}
+		cmd := command.NewResetPasswordCommand(useCase, publisher, logger)
+
+		_, err := cmd.Execute(context.Background(), user.ResetPasswordCommandInput{Token: "id.secret", NewPassword: "NewPassword456!"})
+
+		require.NoError(t, err)
+		require.Len(t, publisher.changed, 1)
+		assert.Equal(t, user.PasswordChangeMethodReset, publisher.changed[0].Payload.Method)
+		assert.Equal(t, owner.ID, publisher.changed[0].Payload.UserID)
+		assert.Equal(t, 5, publisher.changed[0].Payload.Version)
+	})
+
+	t.Run("Failure: should not publish when the token is rejected", func(t *testing.T) {
+		unauthorized := msg.NewMessageError(nil, user.ErrPasswordResetTokenInvalid, msg.CodeUnauthorized, nil)
+		useCase := &mockResetPasswordUseCase{
+			ExecuteFunc: func(ctx context.Context, input user.ResetPasswordInput) (user.PasswordChangedOutput, error) {
+				return user.PasswordChangedOutput{}, unauthorized
+			},
+		}
+		publisher := &mockUserPasswordPublisher{}
+		cmd := command.NewResetPasswordCommand(useCase, publisher, logger)
+
+		_, err := cmd.Execute(context.Background(), user.ResetPasswordCommandInput{Token: "bad", NewPassword: "NewPassword456!"})
+
+		assert.Equal(t, unauthorized, err)
+		assert.Empty(t, publisher.changed)
+	})
+}
@@ -0,0 +1,142 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/logger"
+)
+
+type requestPhoneVerificationCommand struct {
+	useCase   user.RequestPhoneVerificationUseCase
+	publisher user.UserPhoneVerificationEventPublisher
+	logger    *slog.Logger
+	tracer    trace.Tracer
+}
+
+func NewRequestPhoneVerificationCommand(
+	uc user.RequestPhoneVerificationUseCase,
+	pub user.UserPhoneVerificationEventPublisher,
+	logger *slog.Logger,
+) user.RequestPhoneVerificationCommand {
+	return &requestPhoneVerificationCommand{
+		useCase:   uc,
+		publisher: pub,
+		logger:    logger,
+		tracer:    otel.Tracer("identity-command"),
+	}
+}
+
+func (c *requestPhoneVerificationCommand) Execute(ctx context.Context, input user.RequestPhoneVerificationCommandInput) error {
+	userID, _ := input.UserAuthorID.GetUUID()
+
+	ctx, span := c.tracer.Start(ctx, "RequestPhoneVerificationCommand.Execute",
+		trace.WithAttributes(
+			attribute.String("user.id", userID.String()),
+			attribute.String("command.type", "RequestPhoneVerification"),
+		),
+	)
+	defer span.End()
+
+	loggerWithTrace := c.logger.With(logger.TraceID(input.TraceID.String()))
+	loggerWithTrace.Info("starting request phone verification command", "user_id", userID.String())
+
+	output, err := c.useCase.Execute(ctx, user.RequestPhoneVerificationInput{UserID: userID})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to execute use case")
+		loggerWithTrace.Error("failed to execute request phone verification use case", "error", err)
+		return err
+	}
+
+	publishErr := c.publisher.PublishUserPhoneVerificationRequestedEvent(ctx, user.UserPhoneVerificationRequestedEventInput{
+		CorrelationID: input.CorrelationID,
+		TraceID:       input.TraceID,
+		Payload: user.UserPhoneVerificationRequestedPayload{
+			UserID:    output.User.ID,
+			Channel:   user.PhoneVerificationChannelSMS,
+			Phone:     output.User.Phone.String(),
+			Code:      output.Code.Code,
+			ExpiresAt: output.Code.ExpiresAt,
+		},
+	})
+	if publishErr != nil {
+		span.RecordError(publishErr)
+		span.SetStatus(codes.Error, "Failed to publish event")
+		loggerWithTrace.Error("failed to publish user phone verification requested event", "error", publishErr)
+	}
+
+	span.SetStatus(codes.Ok, "Command finished successfully")
+	loggerWithTrace.Info("request phone verification command finished successfully", "user_id", output.User.ID.String())
+
+	return nil
+}
+
+type verifyPhoneCommand struct {
+	useCase   user.VerifyPhoneUseCase
+	publisher user.UserPhoneVerificationEventPublisher
+	logger    *slog.Logger
+	tracer    trace.Tracer
+}
+
+func NewVerifyPhoneCommand(
+	uc user.VerifyPhoneUseCase,
+	pub user.UserPhoneVerificationEventPublisher,
+	logger *slog.Logger,
+) user.VerifyPhoneCommand {
+	return &verifyPhoneCommand{
+		useCase:   uc,
+		publisher: pub,
+		logger:    logger,
+		tracer:    otel.Tracer("identity-command"),
+	}
+}
+
+func (c *verifyPhoneCommand) Execute(ctx context.Context, input user.VerifyPhoneCommandInput) (user.VerifyPhoneOutput, error) {
+	userID, _ := input.UserAuthorID.GetUUID()
+
+	ctx, span := c.tracer.Start(ctx, "VerifyPhoneCommand.Execute",
+		trace.WithAttributes(
+			attribute.String("user.id", userID.String()),
+			attribute.String("command.type", "VerifyPhone"),
+		),
+	)
+	defer span.End()
+
+	loggerWithTrace := c.logger.With(logger.TraceID(input.TraceID.String()))
+	loggerWithTrace.Info("starting verify phone command", "user_id", userID.String())
+
+	output, err := c.useCase.Execute(ctx, user.VerifyPhoneInput{UserID: userID, Code: input.Code})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to execute use case")
+		loggerWithTrace.Error("failed to execute verify phone use case", "error", err)
+		return user.VerifyPhoneOutput{}, err
+	}
+
+	publishErr := c.publisher.PublishUserPhoneVerifiedEvent(ctx, user.UserPhoneVerifiedEventInput{
+		CorrelationID: input.CorrelationID,
+		TraceID:       input.TraceID,
+		Payload: user.UserPhoneVerifiedPayload{
+			UserID:  output.User.ID,
+			Phone:   output.User.Phone.String(),
+			Version: output.User.Version.Int(),
+		},
+	})
+	if publishErr != nil {
+		span.RecordError(publishErr)
+		span.SetStatus(codes.Error, "Failed to publish event")
+		loggerWithTrace.Error("failed to publish user phone verified event", "error", publishErr)
+	}
+
+	span.SetStatus(codes.Ok, "Command finished successfully")
+	loggerWithTrace.Info("verify phone command finished successfully", "user_id", output.User.ID.String())
+
+	return output, nil
+}
@@ -0,0 +1,152 @@
+package command_test
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/command"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type mockRequestPhoneVerificationUseCase struct {
+	ExecuteFunc func(ctx context.Context, input user.RequestPhoneVerificationInput) (user.RequestPhoneVerificationOutput, error)
+}
+
+func (m *mockRequestPhoneVerificationUseCase) Execute(ctx context.Context, input user.RequestPhoneVerificationInput) (user.RequestPhoneVerificationOutput, error) {
+	return m.ExecuteFunc(ctx, input)
+}
+
+type mockVerifyPhoneUseCase struct {
+	ExecuteFunc func(ctx context.Context, input user.VerifyPhoneInput) (user.VerifyPhoneOutput, error)
+}
+
+func (m *mockVerifyPhoneUseCase) Execute(ctx context.Context, input user.VerifyPhoneInput) (user.VerifyPhoneOutput, error) {
+	return m.ExecuteFunc(ctx, input)
+}
+
+type mockPhoneVerificationPublisher struct {
+	requested []user.UserPhoneVerificationRequestedEventInput
+	verified  []user.UserPhoneVerifiedEventInput
+}
+
+func (m *mockPhoneVerificationPublisher) PublishUserPhoneVerificationRequestedEvent(ctx context.Context, input user.UserPhoneVerificationRequestedEventInput) error {
+	m.requested = append(m.requested, input)
+	return nil
+}
+
+func (m *mockPhoneVerificationPublisher) PublishUserPhoneVerifiedEvent(ctx context.Context, input user.UserPhoneVerifiedEventInput) error {
+	m.verified = append(m.verified, input)
+	return nil
+}
+
+func newPhoneUser(t *testing.T) *user.User {
+	t.Helper()
+	phone, err := types.NewPhone("5511987654321")
+	require.NoError(t, err)
+	return &user.User{ID: types.MustNewUUID(), Phone: phone, Version: types.Version(3)}
+}
+
+func TestRequestPhoneVerificationCommand_Execute(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	t.Run("Success: should publish an SMS delivery request for the author", func(t *testing.T) {
+		requester := newPhoneUser(t)
+		expiresAt := time.Now().Add(10 * time.Minute)
+		var received user.RequestPhoneVerificationInput
+		useCase := &mockRequestPhoneVerificationUseCase{
+			ExecuteFunc: func(ctx context.Context, input user.RequestPhoneVerificationInput) (user.RequestPhoneVerificationOutput, error) {
+				received = input
+				return user.RequestPhoneVerificationOutput{
+					User: requester,
+					Code: user.IssuedPhoneVerificationCode{Code: "042917", ExpiresAt: expiresAt},
+				}, nil
+			},
+		}
+		publisher := &mockPhoneVerificationPublisher{}
+		cmd := command.NewRequestPhoneVerificationCommand(useCase, publisher, logger)
+
+		err := cmd.Execute(context.Background(), user.RequestPhoneVerificationCommandInput{
+			UserAuthorID: types.NewValidNullableUUID(requester.ID),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, requester.ID, received.UserID)
+		require.Len(t, publisher.requested, 1)
+		assert.Equal(t, user.UserPhoneVerificationRequestedPayload{
+			UserID:    requester.ID,
+			Channel:   user.PhoneVerificationChannelSMS,
+			Phone:     "5511987654321",
+			Code:      "042917",
+			ExpiresAt: expiresAt,
+		}, publisher.requested[0].Payload)
+	})
+
+	t.Run("Failure: should not publish when the use case fails", func(t *testing.T) {
+		tooSoon := msg.NewMessageError(nil, user.ErrPhoneVerificationResendTooSoon, msg.CodeConflict, nil)
+		useCase := &mockRequestPhoneVerificationUseCase{
+			ExecuteFunc: func(ctx context.Context, input user.RequestPhoneVerificationInput) (user.RequestPhoneVerificationOutput, error) {
+				return user.RequestPhoneVerificationOutput{}, tooSoon
+			},
+		}
+		publisher := &mockPhoneVerificationPublisher{}
+		cmd := command.NewRequestPhoneVerificationCommand(useCase, publisher, logger)
+
+		err := cmd.Execute(context.Background(), user.RequestPhoneVerificationCommandInput{
+			UserAuthorID: types.NewValidNullableUUID(types.MustNewUUID()),
+		})
+
+		assert.Equal(t, tooSoon, err)
+		assert.Empty(t, publisher.requested)
+	})
+}
+
+func TestVerifyPhoneCommand_Execute(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	t.Run("Success: should publish user.phone_verified", func(t *testing.T) {
+		verified := newPhoneUser(t)
+		useCase := &mockVerifyPhoneUseCase{
+			ExecuteFunc: func(ctx context.Context, input user.VerifyPhoneInput) (user.VerifyPhoneOutput, error) {
+				return user.VerifyPhoneOutput{User: verified}, nil
+			},
+		}
+		publisher := &mockPhoneVerificationPublisher{}
+		cmd := command.NewVerifyPhoneCommand(useCase, publisher, logger)
+
+		_, err := cmd.Execute(context.Background(), user.VerifyPhoneCommandInput{
+			UserAuthorID: types.NewValidNullableUUID(verified.ID),
+			Code:         "042917",
+		})
+
+		require.NoError(t, err)
+		require.Len(t, publisher.verified, 1)
+		assert.Equal(t, user.UserPhoneVerifiedPayload{UserID: verified.ID, Phone: "5511987654321", Version: 3}, publisher.verified[0].Payload)
+	})
+
+	t.Run("Failure: should not publish when the code is rejected", func(t *testing.T) {
+		invalid := msg.NewValidationError(nil, map[string]any{"field": "code"}, user.ErrPhoneVerificationCodeInvalid)
+		useCase := &mockVerifyPhoneUseCase{
+			ExecuteFunc: func(ctx context.Context, input user.VerifyPhoneInput) (user.VerifyPhoneOutput, error) {
+				return user.VerifyPhoneOutput{}, invalid
+			},
+		}
+		publisher := &mockPhoneVerificationPublisher{}
+		cmd := command.NewVerifyPhoneCommand(useCase, publisher, logger)
+
+		_, err := cmd.Execute(context.Background(), user.VerifyPhoneCommandInput{
+			UserAuthorID: types.NewValidNullableUUID(types.MustNewUUID()),
+			Code:         "000000",
+		})
+
+		assert.Equal(t, invalid, err)
+		assert.Empty(t, publisher.verified)
+	})
+}
@@ -0,0 +1,111 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/logger"
+)
+
+type purgeDeletedUsersCommand struct {
+	useCase   user.PurgeDeletedUsersUseCase
+	publisher user.UserPurgedEventPublisher
+	logger    *slog.Logger
+	tracer    trace.Tracer
+}
+
+func NewPurgeDeletedUsersCommand(
+	uc user.PurgeDeletedUsersUseCase,
+	pub user.UserPurgedEventPublisher,
+	logger *slog.Logger,
+) user.PurgeDeletedUsersCommand {
+	return &purgeDeletedUsersCommand{
+		useCase:   uc,
+		publisher: pub,
+		logger:    logger,
+		tracer:    otel.Tracer("identity-command"),
+	}
+}
+
+// Execute purges batch after batch until one comes back short. Every purged
+// user has lost its data key along with its row, which makes the personal data
+// in its past events unreadable, and gets a user.purged event.
+func (c *purgeDeletedUsersCommand) Execute(
+	ctx context.Context,
+	input user.PurgeDeletedUsersCommandInput,
+) (user.PurgeDeletedUsersOutput, error) {
+	ctx, span := c.tracer.Start(ctx, "PurgeDeletedUsersCommand.Execute",
+		trace.WithAttributes(
+			attribute.String("purge.deleted_before", input.DeletedBefore.Format(time.RFC3339)),
+			attribute.Int("purge.batch_size", input.BatchSize),
+			attribute.String("command.type", "PurgeDeletedUsers"),
+		),
+	)
+	defer span.End()
+
+	loggerWithTrace := c.logger.With(logger.TraceID(input.TraceID.String()))
+
+	if input.BatchSize <= 0 {
+		input.BatchSize = user.PurgeDefaultBatchSize
+	}
+
+	var output user.PurgeDeletedUsersOutput
+	for {
+		purged, err := c.useCase.Execute(ctx, user.PurgeDeletedUsersUseCaseInput{
+			DeletedBefore: input.DeletedBefore,
+			Limit:         input.BatchSize,
+		})
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "Failed to purge users")
+			loggerWithTrace.Error("failed to purge deleted users", "error", err, "purged", len(output.Purged))
+			return output, err
+		}
+
+		purgedAt := time.Now()
+		for _, p := range purged {
+			c.publishPurged(ctx, loggerWithTrace, input, p, purgedAt)
+		}
+		output.Purged = append(output.Purged, purged...)
+
+		if len(purged) < input.BatchSize {
+			break
+		}
+	}
+
+	span.SetAttributes(attribute.Int("purge.count", len(output.Purged)))
+	span.SetStatus(codes.Ok, "Command finished successfully")
+	if len(output.Purged) > 0 {
+		loggerWithTrace.Info("purged deleted users", "count", len(output.Purged))
+	}
+
+	return output, nil
+}
+
+func (c *purgeDeletedUsersCommand) publishPurged(
+	ctx context.Context,
+	log *slog.Logger,
+	input user.PurgeDeletedUsersCommandInput,
+	p user.PurgedUser,
+	purgedAt time.Time,
+) {
+	err := c.publisher.PublishUserPurgedEvent(ctx, user.UserPurgedEventInput{
+		CorrelationID: input.CorrelationID,
+		TraceID:       input.TraceID,
+		Payload: user.UserPurgedPayload{
+			UserID:    p.ID,
+			DeletedAt: p.DeletedAt,
+			PurgedAt:  purgedAt,
+		},
+	})
+	if err != nil {
+		log.Error("failed to publish user purged event", "user_id", p.ID.String(), "error", err)
+	}
+}
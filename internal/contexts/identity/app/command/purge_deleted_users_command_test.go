@@ -0,0 +1,109 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/command"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+// --- Mocks for Dependencies ---
+
+type mockPurgeDeletedUsersUseCase struct {
+	ExecuteFunc func(ctx context.Context, input user.PurgeDeletedUsersUseCaseInput) ([]user.PurgedUser, error)
+}
+
+func (m *mockPurgeDeletedUsersUseCase) Execute(ctx context.Context, input user.PurgeDeletedUsersUseCaseInput) ([]user.PurgedUser, error) {
+	if m.ExecuteFunc != nil {
+		return m.ExecuteFunc(ctx, input)
+	}
+	return nil, nil
+}
+
+type mockUserPurgedPublisher struct {
+	PublishUserPurgedEventFunc func(ctx context.Context, input user.UserPurgedEventInput) error
+}
+
+func (m *mockUserPurgedPublisher) PublishUserPurgedEvent(ctx context.Context, input user.UserPurgedEventInput) error {
+	if m.PublishUserPurgedEventFunc != nil {
+		return m.PublishUserPurgedEventFunc(ctx, input)
+	}
+	return nil
+}
+
+func purgedUsers(n int) []user.PurgedUser {
+	users := make([]user.PurgedUser, 0, n)
+	for range n {
+		users = append(users, user.PurgedUser{ID: types.MustNewUUID(), DeletedAt: time.Now().Add(-40 * 24 * time.Hour)})
+	}
+	return users
+}
+
+// --- Test Suite ---
+
+func TestPurgeDeletedUsersCommand_Execute(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	cutoff := time.Now().Add(-30 * 24 * time.Hour)
+
+	t.Run("Success: should purge batches until one comes back short", func(t *testing.T) {
+		batches := [][]user.PurgedUser{purgedUsers(2), purgedUsers(2), purgedUsers(1)}
+		calls := 0
+		useCase := &mockPurgeDeletedUsersUseCase{
+			ExecuteFunc: func(ctx context.Context, input user.PurgeDeletedUsersUseCaseInput) ([]user.PurgedUser, error) {
+				assert.Equal(t, cutoff, input.DeletedBefore)
+				assert.Equal(t, 2, input.Limit)
+				batch := batches[calls]
+				calls++
+				return batch, nil
+			},
+		}
+
+		var events []user.UserPurgedEventInput
+		publisher := &mockUserPurgedPublisher{
+			PublishUserPurgedEventFunc: func(ctx context.Context, input user.UserPurgedEventInput) error {
+				events = append(events, input)
+				return nil
+			},
+		}
+		cmd := command.NewPurgeDeletedUsersCommand(useCase, publisher, logger)
+
+		output, err := cmd.Execute(context.Background(), user.PurgeDeletedUsersCommandInput{DeletedBefore: cutoff, BatchSize: 2})
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls, "Purge should stop after the first short batch")
+		require.Len(t, output.Purged, 5)
+		require.Len(t, events, 5, "Every purged user should get a user.purged event")
+		assert.Equal(t, batches[0][0].ID, events[0].Payload.UserID)
+		assert.Equal(t, batches[0][0].DeletedAt, events[0].Payload.DeletedAt)
+		assert.False(t, events[0].Payload.PurgedAt.IsZero())
+	})
+
+	t.Run("Failure: should return the purged users so far when a batch fails", func(t *testing.T) {
+		calls := 0
+		useCase := &mockPurgeDeletedUsersUseCase{
+			ExecuteFunc: func(ctx context.Context, input user.PurgeDeletedUsersUseCaseInput) ([]user.PurgedUser, error) {
+				calls++
+				if calls == 1 {
+					return purgedUsers(user.PurgeDefaultBatchSize), nil
+				}
+				return nil, errors.New("db down")
+			},
+		}
+
+		cmd := command.NewPurgeDeletedUsersCommand(useCase, &mockUserPurgedPublisher{}, logger)
+
+		output, err := cmd.Execute(context.Background(), user.PurgeDeletedUsersCommandInput{DeletedBefore: cutoff})
+
+		require.Error(t, err)
+		assert.Len(t, output.Purged, user.PurgeDefaultBatchSize, "A zero batch size should fall back to the default")
+	})
+}
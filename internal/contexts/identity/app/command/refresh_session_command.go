@@ -0,0 +1,63 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/logger"
+)
+
+type refreshSessionCommand struct {
+	useCase user.RefreshSessionUseCase
+	logger  *slog.Logger
+	tracer  trace.Tracer
+}
+
+func NewRefreshSessionCommand(uc user.RefreshSessionUseCase, logger *slog.Logger) user.RefreshSessionCommand {
+	return &refreshSessionCommand{
+		useCase: uc,
+		logger:  logger,
+		tracer:  otel.Tracer("identity-command"),
+	}
+}
+
+func (c *refreshSessionCommand) Execute(
+	ctx context.Context,
+	input user.RefreshSessionCommandInput,
+) (user.RefreshSessionOutput, error) {
+	ctx, span := c.tracer.Start(ctx, "RefreshSessionCommand.Execute",
+		trace.WithAttributes(
+			attribute.String("command.type", "RefreshSession"),
+		),
+	)
+	defer span.End()
+
+	loggerWithTrace := c.logger.With(logger.TraceID(input.TraceID.String()))
+	loggerWithTrace.Info("starting refresh session command")
+
+	output, err := c.useCase.Execute(ctx, user.RefreshSessionInput{RefreshToken: input.RefreshToken})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Refresh rejected")
+		loggerWithTrace.Warn("failed to refresh session", "error", err)
+		return user.RefreshSessionOutput{}, err
+	}
+
+	span.SetAttributes(
+		attribute.String("user.id", output.User.ID.String()),
+		attribute.String("session.id", output.Session.RefreshToken.FamilyID.String()),
+	)
+	span.SetStatus(codes.Ok, "Command finished successfully")
+	loggerWithTrace.Info("refresh session command finished successfully",
+		"user_id", output.User.ID.String(),
+		"session_id", output.Session.RefreshToken.FamilyID.String(),
+	)
+
+	return output, nil
+}
@@ -0,0 +1,125 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/role"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/logger"
+)
+
+type assignRoleCommand struct {
+	useCase   role.AssignRoleUseCase
+	publisher role.RoleAssignmentEventPublisher
+	logger    *slog.Logger
+	tracer    trace.Tracer
+}
+
+func NewAssignRoleCommand(
+	uc role.AssignRoleUseCase,
+	pub role.RoleAssignmentEventPublisher,
+	logger *slog.Logger,
+) role.AssignRoleCommand {
+	return &assignRoleCommand{
+		useCase:   uc,
+		publisher: pub,
+		logger:    logger,
+		tracer:    otel.Tracer("identity-command"),
+	}
+}
+
+func (c *assignRoleCommand) Execute(ctx context.Context, input role.AssignRoleCommandInput) (role.RoleAssignmentOutput, error) {
+	return runRoleAssignmentCommand(ctx, c.tracer, c.logger, "AssignRole", input.RoleAssignmentCommandInput,
+		c.useCase.Execute, c.publisher.PublishRoleAssignedEvent)
+}
+
+type revokeRoleCommand struct {
+	useCase   role.RevokeRoleUseCase
+	publisher role.RoleAssignmentEventPublisher
+	logger    *slog.Logger
+	tracer    trace.Tracer
+}
+
+func NewRevokeRoleCommand(
+	uc role.RevokeRoleUseCase,
+	pub role.RoleAssignmentEventPublisher,
+	logger *slog.Logger,
+) role.RevokeRoleCommand {
+	return &revokeRoleCommand{
+		useCase:   uc,
+		publisher: pub,
+		logger:    logger,
+		tracer:    otel.Tracer("identity-command"),
+	}
+}
+
+func (c *revokeRoleCommand) Execute(ctx context.Context, input role.RevokeRoleCommandInput) (role.RoleAssignmentOutput, error) {
+	return runRoleAssignmentCommand(ctx, c.tracer, c.logger, "RevokeRole", input.RoleAssignmentCommandInput,
+		c.useCase.Execute, c.publisher.PublishRoleRevokedEvent)
+}
+
+// runRoleAssignmentCommand runs an assignment use case and publishes its event
+// only when the roles of the user actually changed.
+func runRoleAssignmentCommand(
+	ctx context.Context,
+	tracer trace.Tracer,
+	baseLogger *slog.Logger,
+	name string,
+	input role.RoleAssignmentCommandInput,
+	execute func(context.Context, role.RoleAssignmentUseCaseInput) (role.RoleAssignmentOutput, error),
+	publish func(context.Context, role.RoleAssignmentEventInput) error,
+) (role.RoleAssignmentOutput, error) {
+	roleName := role.NormalizeName(input.Role)
+
+	ctx, span := tracer.Start(ctx, name+"Command.Execute",
+		trace.WithAttributes(
+			attribute.String("user.id", input.UserID.String()),
+			attribute.String("role.name", roleName),
+			attribute.String("command.type", name),
+		),
+	)
+	defer span.End()
+
+	loggerWithTrace := baseLogger.With(logger.TraceID(input.TraceID.String()), "command", name)
+	loggerWithTrace.Info("starting role assignment command", "user_id", input.UserID.String(), "role", roleName)
+
+	output, err := execute(ctx, role.RoleAssignmentUseCaseInput{
+		UserID:     input.UserID,
+		Role:       roleName,
+		AssignedBy: input.UserAuthorID,
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to execute use case")
+		loggerWithTrace.Error("failed to execute role assignment use case", "error", err)
+		return role.RoleAssignmentOutput{}, err
+	}
+
+	if !output.Changed {
+		span.SetStatus(codes.Ok, "Roles already in the requested state")
+		loggerWithTrace.Info("role assignment command changed nothing", "user_id", input.UserID.String(), "role", roleName)
+		return output, nil
+	}
+
+	publishErr := publish(ctx, role.RoleAssignmentEventInput{
+		CorrelationID: input.CorrelationID,
+		UserID:        input.UserAuthorID,
+		TraceID:       input.TraceID,
+		Assignment:    output.Assignment,
+	})
+	if publishErr != nil {
+		span.RecordError(publishErr)
+		span.SetStatus(codes.Error, "Failed to publish event")
+		loggerWithTrace.Error("failed to publish role assignment event", "error", publishErr)
+	}
+
+	span.SetStatus(codes.Ok, "Command finished successfully")
+	loggerWithTrace.Info("role assignment command finished successfully", "user_id", input.UserID.String(), "role", roleName)
+
+	return output, nil
+}
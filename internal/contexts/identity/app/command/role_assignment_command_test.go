@@ -0,0 +1,118 @@
+package command_test
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/command"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/role"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+// --- Mocks for Dependencies ---
+
+type mockRoleAssignmentUseCase struct {
+	ExecuteFunc func(ctx context.Context, input role.RoleAssignmentUseCaseInput) (role.RoleAssignmentOutput, error)
+}
+
+func (m *mockRoleAssignmentUseCase) Execute(ctx context.Context, input role.RoleAssignmentUseCaseInput) (role.RoleAssignmentOutput, error) {
+	if m.ExecuteFunc != nil {
+		return m.ExecuteFunc(ctx, input)
+	}
+	return role.RoleAssignmentOutput{}, nil
+}
+
+type mockRoleAssignmentPublisher struct {
+	assigned []role.RoleAssignmentEventInput
+	revoked  []role.RoleAssignmentEventInput
+}
+
+func (m *mockRoleAssignmentPublisher) PublishRoleAssignedEvent(ctx context.Context, input role.RoleAssignmentEventInput) error {
+	m.assigned = append(m.assigned, input)
+	return nil
+}
+
+func (m *mockRoleAssignmentPublisher) PublishRoleRevokedEvent(ctx context.Context, input role.RoleAssignmentEventInput) error {
+	m.revoked = append(m.revoked, input)
+	return nil
+}
+
+// --- Test Suite ---
+
+func TestAssignRoleCommand_Execute(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	input := role.RoleAssignmentCommandInput{
+		CorrelationID: types.MustNewUUID(),
+		TraceID:       types.MustNewUUID(),
+		UserAuthorID:  types.NewValidNullableUUID(types.MustNewUUID()),
+		UserID:        types.MustNewUUID(),
+		Role:          " Admin ",
+	}
+	assignment := &role.Assignment{UserID: input.UserID, Role: &role.Role{ID: types.MustNewUUID(), Name: "admin"}}
+
+	t.Run("Success: should publish role.assigned with the author", func(t *testing.T) {
+		var received role.RoleAssignmentUseCaseInput
+		useCase := &mockRoleAssignmentUseCase{
+			ExecuteFunc: func(ctx context.Context, in role.RoleAssignmentUseCaseInput) (role.RoleAssignmentOutput, error) {
+				received = in
+				return role.RoleAssignmentOutput{Assignment: assignment, Changed: true}, nil
+			},
+		}
+		publisher := &mockRoleAssignmentPublisher{}
+		cmd := command.NewAssignRoleCommand(useCase, publisher, logger)
+
+		_, err := cmd.Execute(context.Background(), role.AssignRoleCommandInput{RoleAssignmentCommandInput: input})
+
+		require.NoError(t, err)
+		assert.Equal(t, "admin", received.Role, "Role name should be normalized")
+		assert.Equal(t, input.UserAuthorID, received.AssignedBy)
+		require.Len(t, publisher.assigned, 1)
+		assert.Equal(t, input.UserAuthorID, publisher.assigned[0].UserID)
+		assert.Equal(t, assignment, publisher.assigned[0].Assignment)
+		assert.Empty(t, publisher.revoked)
+	})
+
+	t.Run("Success: should not publish when the user already had the role", func(t *testing.T) {
+		useCase := &mockRoleAssignmentUseCase{
+			ExecuteFunc: func(ctx context.Context, in role.RoleAssignmentUseCaseInput) (role.RoleAssignmentOutput, error) {
+				return role.RoleAssignmentOutput{Assignment: assignment}, nil
+			},
+		}
+		publisher := &mockRoleAssignmentPublisher{}
+		cmd := command.NewAssignRoleCommand(useCase, publisher, logger)
+
+		_, err := cmd.Execute(context.Background(), role.AssignRoleCommandInput{RoleAssignmentCommandInput: input})
+
+		require.NoError(t, err)
+		assert.Empty(t, publisher.assigned)
+	})
+}
+
+func TestRevokeRoleCommand_Execute(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	t.Run("Success: should publish role.revoked", func(t *testing.T) {
+		assignment := &role.Assignment{UserID: types.MustNewUUID(), Role: &role.Role{ID: types.MustNewUUID(), Name: "viewer"}}
+		useCase := &mockRoleAssignmentUseCase{
+			ExecuteFunc: func(ctx context.Context, in role.RoleAssignmentUseCaseInput) (role.RoleAssignmentOutput, error) {
+				return role.RoleAssignmentOutput{Assignment: assignment, Changed: true}, nil
+			},
+		}
+		publisher := &mockRoleAssignmentPublisher{}
+		cmd := command.NewRevokeRoleCommand(useCase, publisher, logger)
+
+		_, err := cmd.Execute(context.Background(), role.RevokeRoleCommandInput{RoleAssignmentCommandInput: role.RoleAssignmentCommandInput{
+			UserID: assignment.UserID,
+			Role:   "viewer",
+		}})
+
+		require.NoError(t, err)
+		require.Len(t, publisher.revoked, 1)
+		assert.Empty(t, publisher.assigned)
+	})
+}
@@ -0,0 +1,88 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/logger"
+)
+
+type updateUserCommand struct {
+	useCase   user.UpdateUserUseCase
+	publisher user.UserUpdatedEventPublisher
+	logger    *slog.Logger
+	tracer    trace.Tracer
+}
+
+func NewUpdateUserCommand(
+	uc user.UpdateUserUseCase,
+	pub user.UserUpdatedEventPublisher,
+	logger *slog.Logger,
+) user.UpdateUserCommand {
+	return &updateUserCommand{
+		useCase:   uc,
+		publisher: pub,
+		logger:    logger,
+		tracer:    otel.Tracer("identity-command"),
+	}
+}
+
+func (c *updateUserCommand) Execute(
+	ctx context.Context,
+	input user.UpdateUserCommandInput,
+) (user.UpdateUserOutput, error) {
+	ctx, span := c.tracer.Start(ctx, "UpdateUserCommand.Execute",
+		trace.WithAttributes(
+			attribute.String("user.id", input.UserID.String()),
+			attribute.Int("user.expected_version", input.ExpectedVersion.Int()),
+			attribute.String("command.type", "UpdateUser"),
+		),
+	)
+	defer span.End()
+
+	loggerWithTrace := c.logger.With(logger.TraceID(input.TraceID.String()))
+	loggerWithTrace.Info("starting update user command", "user_id", input.UserID.String())
+
+	output, err := c.useCase.Execute(ctx, user.UpdateUserUseCaseInput{
+		UserID:          input.UserID,
+		ExpectedVersion: input.ExpectedVersion,
+		Changes:         input.Changes,
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to execute use case")
+		loggerWithTrace.Error("failed to execute update user use case", "error", err)
+		return user.UpdateUserOutput{}, err
+	}
+
+	if len(output.ChangedFields) == 0 {
+		span.SetStatus(codes.Ok, "Nothing to update")
+		loggerWithTrace.Info("update user command changed nothing", "user_id", input.UserID.String())
+		return output, nil
+	}
+
+	eventInput := user.UserUpdatedEventInput{
+		CorrelationID: input.CorrelationID,
+		UserID:        input.UserAuthorID,
+		TraceID:       input.TraceID,
+		Payload:       user.NewUserUpdatedPayload(output.User, output.ChangedFields),
+	}
+
+	publishErr := c.publisher.PublishUserUpdatedEvent(ctx, eventInput)
+	if publishErr != nil {
+		span.RecordError(publishErr)
+		span.SetStatus(codes.Error, "Failed to publish event")
+		loggerWithTrace.Error("failed to publish user updated event", "error", publishErr)
+	}
+
+	span.SetStatus(codes.Ok, "Command finished successfully")
+	loggerWithTrace.Info("update user command finished successfully", "user_id", input.UserID.String(), "changed_fields", output.ChangedFields)
+
+	return output, nil
+}
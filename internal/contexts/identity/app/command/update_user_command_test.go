@@ -0,0 +1,113 @@
+package command_test
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/command"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+// --- Mocks for Dependencies ---
+
+type mockUpdateUserUseCase struct {
+	ExecuteFunc func(ctx context.Context, input user.UpdateUserUseCaseInput) (user.UpdateUserOutput, error)
+}
+
+func (m *mockUpdateUserUseCase) Execute(ctx context.Context, input user.UpdateUserUseCaseInput) (user.UpdateUserOutput, error) {
+	if m.ExecuteFunc != nil {
+		return m.ExecuteFunc(ctx, input)
+	}
+	return user.UpdateUserOutput{}, nil
+}
+
+type mockUserUpdatedPublisher struct {
+	PublishUserUpdatedEventFunc func(ctx context.Context, input user.UserUpdatedEventInput) error
+}
+
+func (m *mockUserUpdatedPublisher) PublishUserUpdatedEvent(ctx context.Context, input user.UserUpdatedEventInput) error {
+	if m.PublishUserUpdatedEventFunc != nil {
+		return m.PublishUserUpdatedEventFunc(ctx, input)
+	}
+	return nil
+}
+
+// --- Test Suite ---
+
+func TestUpdateUserCommand_Execute(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	updated := &user.User{
+		ID:      types.MustNewUUID(),
+		Name:    "New Name",
+		Email:   types.MustNewEmail("new@example.com"),
+		Version: types.Version(2),
+	}
+	commandInput := user.UpdateUserCommandInput{
+		CorrelationID:   types.MustNewUUID(),
+		TraceID:         types.MustNewUUID(),
+		UserID:          updated.ID,
+		ExpectedVersion: types.Version(1),
+		Changes:         user.UpdateUserInput{Name: "New Name", Email: "new@example.com"},
+	}
+
+	t.Run("Success: should publish the changed fields with their new values", func(t *testing.T) {
+		useCase := &mockUpdateUserUseCase{
+			ExecuteFunc: func(ctx context.Context, input user.UpdateUserUseCaseInput) (user.UpdateUserOutput, error) {
+				assert.Equal(t, commandInput.ExpectedVersion, input.ExpectedVersion, "Expected version should be forwarded to the use case")
+				return user.UpdateUserOutput{User: updated, ChangedFields: []string{user.FieldName, user.FieldEmail}}, nil
+			},
+		}
+
+		var published user.UserUpdatedEventInput
+		publisher := &mockUserUpdatedPublisher{
+			PublishUserUpdatedEventFunc: func(ctx context.Context, input user.UserUpdatedEventInput) error {
+				published = input
+				return nil
+			},
+		}
+
+		cmd := command.NewUpdateUserCommand(useCase, publisher, logger)
+
+		output, err := cmd.Execute(context.Background(), commandInput)
+
+		require.NoError(t, err)
+		assert.Same(t, updated, output.User)
+		assert.Equal(t, commandInput.CorrelationID, published.CorrelationID)
+		assert.Equal(t, 2, published.Payload.Version)
+		assert.Equal(t, []string{user.FieldName, user.FieldEmail}, published.Payload.ChangedFields)
+		require.NotNil(t, published.Payload.Name)
+		assert.Equal(t, "New Name", *published.Payload.Name)
+		require.NotNil(t, published.Payload.Email)
+		assert.Equal(t, "new@example.com", *published.Payload.Email)
+		assert.Nil(t, published.Payload.Phone, "Unchanged fields should not be published")
+	})
+
+	t.Run("Success: should not publish when nothing changed", func(t *testing.T) {
+		useCase := &mockUpdateUserUseCase{
+			ExecuteFunc: func(ctx context.Context, input user.UpdateUserUseCaseInput) (user.UpdateUserOutput, error) {
+				return user.UpdateUserOutput{User: updated}, nil
+			},
+		}
+
+		publisherCalled := false
+		publisher := &mockUserUpdatedPublisher{
+			PublishUserUpdatedEventFunc: func(ctx context.Context, input user.UserUpdatedEventInput) error {
+				publisherCalled = true
+				return nil
+			},
+		}
+
+		cmd := command.NewUpdateUserCommand(useCase, publisher, logger)
+
+		_, err := cmd.Execute(context.Background(), commandInput)
+
+		require.NoError(t, err)
+		assert.False(t, publisherCalled, "No event should be published for a no-op update")
+	})
+}
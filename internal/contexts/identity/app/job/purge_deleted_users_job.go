@@ -0,0 +1,79 @@
+package job
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/config"
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+// PurgeDeletedUsersJob periodically hard-deletes users whose soft delete is
+// older than the configured retention.
+type PurgeDeletedUsersJob struct {
+	command   user.PurgeDeletedUsersCommand
+	retention time.Duration
+	interval  time.Duration
+	batchSize int
+	tenantID  string
+	logger    *slog.Logger
+}
+
+func NewPurgeDeletedUsersJob(cmd user.PurgeDeletedUsersCommand, cfg config.IdentityConfig, logger *slog.Logger) *PurgeDeletedUsersJob {
+	return &PurgeDeletedUsersJob{
+		command:   cmd,
+		retention: time.Duration(cfg.PurgeRetentionDays) * 24 * time.Hour,
+		interval:  time.Duration(cfg.PurgeIntervalMinutes) * time.Minute,
+		batchSize: cfg.PurgeBatchSize,
+		tenantID:  cfg.TenantID,
+		logger:    logger,
+	}
+}
+
+func (j *PurgeDeletedUsersJob) Enabled() bool {
+	return j.retention > 0 && j.interval > 0
+}
+
+// Run purges once right away and then on every interval until ctx is done.
+func (j *PurgeDeletedUsersJob) Run(ctx context.Context) {
+	if !j.Enabled() {
+		j.logger.Info("purge of deleted users disabled")
+		return
+	}
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *PurgeDeletedUsersJob) RunOnce(ctx context.Context) {
+	correlationID, err := types.NewUUID()
+	if err != nil {
+		j.logger.Error("failed to generate purge correlation id", "error", err)
+		return
+	}
+
+	// Events of the purge are published by the system, not by a user.
+	ctx = event.WithScope(ctx, event.Scope{TenantID: j.tenantID, ActorType: event.ActorSystem})
+	_, err = j.command.Execute(ctx, user.PurgeDeletedUsersCommandInput{
+		CorrelationID: correlationID,
+		TraceID:       correlationID,
+		DeletedBefore: time.Now().Add(-j.retention),
+		BatchSize:     j.batchSize,
+	})
+	if err != nil && ctx.Err() == nil {
+		j.logger.Error("purge of deleted users failed", "error", err)
+	}
+}
@@ -0,0 +1,101 @@
+package job_test
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/job"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/config"
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+)
+
+type mockPurgeDeletedUsersCommand struct {
+	ExecuteFunc func(ctx context.Context, input user.PurgeDeletedUsersCommandInput) (user.PurgeDeletedUsersOutput, error)
+}
+
+func (m *mockPurgeDeletedUsersCommand) Execute(ctx context.Context, input user.PurgeDeletedUsersCommandInput) (user.PurgeDeletedUsersOutput, error) {
+	if m.ExecuteFunc != nil {
+		return m.ExecuteFunc(ctx, input)
+	}
+	return user.PurgeDeletedUsersOutput{}, nil
+}
+
+func TestPurgeDeletedUsersJob(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	t.Run("Success: should purge users deleted before the retention period", func(t *testing.T) {
+		var input user.PurgeDeletedUsersCommandInput
+		var scope event.Scope
+		cmd := &mockPurgeDeletedUsersCommand{
+			ExecuteFunc: func(ctx context.Context, in user.PurgeDeletedUsersCommandInput) (user.PurgeDeletedUsersOutput, error) {
+				input = in
+				scope = event.ScopeFromContext(ctx)
+				return user.PurgeDeletedUsersOutput{}, nil
+			},
+		}
+		j := job.NewPurgeDeletedUsersJob(cmd, config.IdentityConfig{TenantID: "tenant-a", PurgeRetentionDays: 30, PurgeIntervalMinutes: 60, PurgeBatchSize: 50}, logger)
+
+		j.RunOnce(context.Background())
+
+		expected := time.Now().Add(-30 * 24 * time.Hour)
+		assert.WithinDuration(t, expected, input.DeletedBefore, time.Minute)
+		assert.Equal(t, 50, input.BatchSize)
+		assert.False(t, input.CorrelationID.IsNil(), "Each run should get its own correlation id")
+		assert.Equal(t, event.ActorSystem, scope.ActorType, "The purge should publish as the system")
+		assert.Equal(t, "tenant-a", scope.TenantID)
+	})
+
+	t.Run("Success: should run until the context is cancelled", func(t *testing.T) {
+		ran := make(chan struct{}, 1)
+		cmd := &mockPurgeDeletedUsersCommand{
+			ExecuteFunc: func(ctx context.Context, in user.PurgeDeletedUsersCommandInput) (user.PurgeDeletedUsersOutput, error) {
+				select {
+				case ran <- struct{}{}:
+				default:
+				}
+				return user.PurgeDeletedUsersOutput{}, nil
+			},
+		}
+		j := job.NewPurgeDeletedUsersJob(cmd, config.IdentityConfig{PurgeRetentionDays: 30, PurgeIntervalMinutes: 60}, logger)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			j.Run(ctx)
+			close(done)
+		}()
+
+		select {
+		case <-ran:
+		case <-time.After(2 * time.Second):
+			t.Fatal("Job should purge right away")
+		}
+		cancel()
+
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Fatal("Job should stop when the context is cancelled")
+		}
+	})
+
+	t.Run("Success: should do nothing when the retention is zero", func(t *testing.T) {
+		cmd := &mockPurgeDeletedUsersCommand{
+			ExecuteFunc: func(ctx context.Context, in user.PurgeDeletedUsersCommandInput) (user.PurgeDeletedUsersOutput, error) {
+				t.Fatal("Disabled job should not purge")
+				return user.PurgeDeletedUsersOutput{}, nil
+			},
+		}
+		j := job.NewPurgeDeletedUsersJob(cmd, config.IdentityConfig{PurgeIntervalMinutes: 60}, logger)
+
+		require.False(t, j.Enabled())
+		j.Run(context.Background())
+	})
+}
@@ -0,0 +1,36 @@
+package publisher
+
+import (
+	"context"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/role"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+)
+
+// RolePublisher publishes role events as is: their payloads carry no
+// personal data.
+type RolePublisher struct {
+	bus bus.EventBusPublisher
+}
+
+func NewRolePublisher(bus bus.EventBusPublisher) *RolePublisher {
+	return &RolePublisher{
+		bus: bus,
+	}
+}
+
+func (p *RolePublisher) PublishRoleAssignedEvent(ctx context.Context, input role.RoleAssignmentEventInput) error {
+	evt, err := role.NewRoleAssignedEvent(input)
+	if err != nil {
+		return err
+	}
+	return p.bus.Publish(ctx, evt)
+}
+
+func (p *RolePublisher) PublishRoleRevokedEvent(ctx context.Context, input role.RoleAssignmentEventInput) error {
+	evt, err := role.NewRoleRevokedEvent(input)
+	if err != nil {
+		return err
+	}
+	return p.bus.Publish(ctx, evt)
+}
@@ -4,26 +4,156 @@ import (
 	"context"
 
 	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
 	"github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/pii"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
 )
 
 type UserPublisher struct {
-	bus bus.EventBusPublisher
+	bus       bus.EventBusPublisher
+	encrypter pii.PayloadEncrypter
 }
 
-func NewUserPublisher(bus bus.EventBusPublisher) *UserPublisher {
+func NewUserPublisher(bus bus.EventBusPublisher, encrypter pii.PayloadEncrypter) *UserPublisher {
 	return &UserPublisher{
-		bus: bus,
+		bus:       bus,
+		encrypter: encrypter,
 	}
 }
 
 func (u *UserPublisher) PublishUserCreatedEvent(ctx context.Context, input user.USerCreatedEventInput) error {
-	event, err := user.NewUserCreatedEvent(input)
+	evt, err := user.NewUserCreatedEvent(input)
 	if err != nil {
 		return err
 	}
+	return u.publish(ctx, evt, input.Payload.UserID, input.Payload)
+}
+
+func (u *UserPublisher) PublishUserUpdatedEvent(ctx context.Context, input user.UserUpdatedEventInput) error {
+	evt, err := user.NewUserUpdatedEvent(input)
+	if err != nil {
+		return err
+	}
+	return u.publish(ctx, evt, input.Payload.UserID, input.Payload)
+}
+
+func (u *UserPublisher) PublishUserArchivedEvent(ctx context.Context, input user.UserStatusEventInput) error {
+	evt, err := user.NewUserArchivedEvent(input)
+	if err != nil {
+		return err
+	}
+	return u.bus.Publish(ctx, evt)
+}
+
+func (u *UserPublisher) PublishUserUnarchivedEvent(ctx context.Context, input user.UserStatusEventInput) error {
+	evt, err := user.NewUserUnarchivedEvent(input)
+	if err != nil {
+		return err
+	}
+	return u.bus.Publish(ctx, evt)
+}
+
+func (u *UserPublisher) PublishUserDeletedEvent(ctx context.Context, input user.UserStatusEventInput) error {
+	evt, err := user.NewUserDeletedEvent(input)
+	if err != nil {
+		return err
+	}
+	return u.bus.Publish(ctx, evt)
+}
+
+func (u *UserPublisher) PublishUserRestoredEvent(ctx context.Context, input user.UserStatusEventInput) error {
+	evt, err := user.NewUserRestoredEvent(input)
+	if err != nil {
+		return err
+	}
+	return u.bus.Publish(ctx, evt)
+}
+
+func (u *UserPublisher) PublishUserPurgedEvent(ctx context.Context, input user.UserPurgedEventInput) error {
+	evt, err := user.NewUserPurgedEvent(input)
+	if err != nil {
+		return err
+	}
+	return u.bus.Publish(ctx, evt)
+}
+
+func (u *UserPublisher) PublishUserLoggedInEvent(ctx context.Context, input user.UserLoggedInEventInput) error {
+	evt, err := user.NewUserLoggedInEvent(input)
+	if err != nil {
+		return err
+	}
+	return u.bus.Publish(ctx, evt)
+}
+
+func (u *UserPublisher) PublishUserLoginFailedEvent(ctx context.Context, input user.UserLoginFailedEventInput) error {
+	evt, err := user.NewUserLoginFailedEvent(input)
+	if err != nil {
+		return err
+	}
+	return u.bus.Publish(ctx, evt)
+}
 
-	return u.bus.Publish(ctx, event)
+func (u *UserPublisher) PublishUserPasswordChangedEvent(ctx context.Context, input user.UserPasswordChangedEventInput) error {
+	evt, err := user.NewUserPasswordChangedEvent(input)
+	if err != nil {
+		return err
+	}
+	return u.bus.Publish(ctx, evt)
 }
 
-// TODO: Add other user events
+func (u *UserPublisher) PublishUserPasswordResetRequestedEvent(ctx context.Context, input user.UserPasswordResetRequestedEventInput) error {
+	evt, err := user.NewUserPasswordResetRequestedEvent(input)
+	if err != nil {
+		return err
+	}
+	return u.publish(ctx, evt, input.Payload.UserID, input.Payload)
+}
+
+func (u *UserPublisher) PublishUserEmailVerificationRequestedEvent(ctx context.Context, input user.UserEmailVerificationRequestedEventInput) error {
+	evt, err := user.NewUserEmailVerificationRequestedEvent(input)
+	if err != nil {
+		return err
+	}
+	return u.publish(ctx, evt, input.Payload.UserID, input.Payload)
+}
+
+func (u *UserPublisher) PublishUserEmailVerifiedEvent(ctx context.Context, input user.UserEmailVerifiedEventInput) error {
+	evt, err := user.NewUserEmailVerifiedEvent(input)
+	if err != nil {
+		return err
+	}
+	return u.publish(ctx, evt, input.Payload.UserID, input.Payload)
+}
+
+func (u *UserPublisher) PublishUserPhoneVerificationRequestedEvent(ctx context.Context, input user.UserPhoneVerificationRequestedEventInput) error {
+	evt, err := user.NewUserPhoneVerificationRequestedEvent(input)
+	if err != nil {
+		return err
+	}
+	return u.publish(ctx, evt, input.Payload.UserID, input.Payload)
+}
+
+func (u *UserPublisher) PublishUserPhoneVerifiedEvent(ctx context.Context, input user.UserPhoneVerifiedEventInput) error {
+	evt, err := user.NewUserPhoneVerifiedEvent(input)
+	if err != nil {
+		return err
+	}
+	return u.publish(ctx, evt, input.Payload.UserID, input.Payload)
+}
+
+// publish encrypts the pii fields of the payload with the key of the user
+// before handing the event to the bus.
+func (u *UserPublisher) publish(ctx context.Context, evt *event.Event, subjectID types.UUID, payload any) error {
+	encrypted, err := u.encrypter.EncryptPayload(ctx, pii.EncryptPayloadInput{
+		SubjectID: subjectID,
+		Payload:   evt.Payload,
+		Fields:    pii.SensitiveFields(payload),
+	})
+	if err != nil {
+		return err
+	}
+	evt.Payload = encrypted
+
+	return u.bus.Publish(ctx, evt)
+}
@@ -5,13 +5,16 @@ import (
 	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/publisher"
 	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/bus/natstest"
 	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/pii"
 	"github.com/marcelofabianov/redtogreen/internal/platform/types"
 )
 
@@ -27,6 +30,18 @@ func (m *mockEventBusPublisher) Publish(ctx context.Context, event *event.Event)
 	return nil
 }
 
+// Mock for PayloadEncrypter
+type mockPayloadEncrypter struct {
+	EncryptPayloadFunc func(ctx context.Context, input pii.EncryptPayloadInput) (json.RawMessage, error)
+}
+
+func (m *mockPayloadEncrypter) EncryptPayload(ctx context.Context, input pii.EncryptPayloadInput) (json.RawMessage, error) {
+	if m.EncryptPayloadFunc != nil {
+		return m.EncryptPayloadFunc(ctx, input)
+	}
+	return input.Payload, nil
+}
+
 func TestUserPublisher_PublishUserCreatedEvent(t *testing.T) {
 	// Common test data setup for all sub-tests
 	correlationID := types.MustNewUUID()
@@ -82,7 +97,7 @@ func TestUserPublisher_PublishUserCreatedEvent(t *testing.T) {
 				return nil
 			},
 		}
-		userPublisher := publisher.NewUserPublisher(mockBus)
+		userPublisher := publisher.NewUserPublisher(mockBus, &mockPayloadEncrypter{})
 
 		err := userPublisher.PublishUserCreatedEvent(context.Background(), publisherInput) // Pass the input struct
 
@@ -97,11 +112,319 @@ func TestUserPublisher_PublishUserCreatedEvent(t *testing.T) {
 				return busError
 			},
 		}
-		userPublisher := publisher.NewUserPublisher(mockBus)
+		userPublisher := publisher.NewUserPublisher(mockBus, &mockPayloadEncrypter{})
 
 		err := userPublisher.PublishUserCreatedEvent(context.Background(), publisherInput) // Pass the input struct
 
 		require.Error(t, err, "PublishUserCreatedEvent should return an error when EventBusPublisher.Publish fails")
 		assert.Equal(t, busError, err, "Error returned by PublishUserCreatedEvent should match the EventBusPublisher.Publish error")
 	})
+	t.Run("Success: should encrypt sensitive payload fields before publishing", func(t *testing.T) {
+		var encryptInput pii.EncryptPayloadInput
+		mockEncrypter := &mockPayloadEncrypter{
+			EncryptPayloadFunc: func(ctx context.Context, input pii.EncryptPayloadInput) (json.RawMessage, error) {
+				encryptInput = input
+				return json.RawMessage(`{"userId":"` + input.SubjectID.String() + `","email":"encrypted"}`), nil
+			},
+		}
+
+		var publishedPayload json.RawMessage
+		mockBus := &mockEventBusPublisher{
+			PublishFunc: func(ctx context.Context, event *event.Event) error {
+				publishedPayload = event.Payload
+				return nil
+			},
+		}
+		userPublisher := publisher.NewUserPublisher(mockBus, mockEncrypter)
+
+		err := userPublisher.PublishUserCreatedEvent(context.Background(), publisherInput)
+
+		require.NoError(t, err, "PublishUserCreatedEvent should not return an error when encryption succeeds")
+		assert.Equal(t, inputPayload.UserID, encryptInput.SubjectID, "The created user should be the encryption subject")
+		assert.ElementsMatch(t, []string{"email", "phone"}, encryptInput.Fields, "Email and phone should be marked as sensitive fields")
+		assert.Contains(t, string(publishedPayload), `"email":"encrypted"`, "The published payload should be the encrypted one")
+	})
+
+	t.Run("Failure: should not publish if payload encryption fails", func(t *testing.T) {
+		encryptError := errors.New("key store unavailable")
+		mockEncrypter := &mockPayloadEncrypter{
+			EncryptPayloadFunc: func(ctx context.Context, input pii.EncryptPayloadInput) (json.RawMessage, error) {
+				return nil, encryptError
+			},
+		}
+
+		publishCalled := false
+		mockBus := &mockEventBusPublisher{
+			PublishFunc: func(ctx context.Context, event *event.Event) error {
+				publishCalled = true
+				return nil
+			},
+		}
+		userPublisher := publisher.NewUserPublisher(mockBus, mockEncrypter)
+
+		err := userPublisher.PublishUserCreatedEvent(context.Background(), publisherInput)
+
+		require.Error(t, err, "PublishUserCreatedEvent should return an error when encryption fails")
+		assert.Equal(t, encryptError, err, "Error returned should match the encryption error")
+		assert.False(t, publishCalled, "EventBusPublisher.Publish should not be called when encryption fails")
+	})
+}
+
+func TestUserPublisher_PublishUserUpdatedEvent(t *testing.T) {
+	name := "Updated User"
+	email := "updated@example.com"
+	publisherInput := user.UserUpdatedEventInput{
+		CorrelationID: types.MustNewUUID(),
+		TraceID:       types.MustNewUUID(),
+		Payload: user.UserUpdatedPayload{
+			UserID:        types.MustNewUUID(),
+			Version:       3,
+			ChangedFields: []string{user.FieldName, user.FieldEmail},
+			Name:          &name,
+			Email:         &email,
+		},
+	}
+
+	t.Run("Success: should publish user updated event with encrypted sensitive fields", func(t *testing.T) {
+		var encryptInput pii.EncryptPayloadInput
+		mockEncrypter := &mockPayloadEncrypter{
+			EncryptPayloadFunc: func(ctx context.Context, input pii.EncryptPayloadInput) (json.RawMessage, error) {
+				encryptInput = input
+				return input.Payload, nil
+			},
+		}
+
+		var published *event.Event
+		mockBus := &mockEventBusPublisher{
+			PublishFunc: func(ctx context.Context, evt *event.Event) error {
+				published = evt
+				return nil
+			},
+		}
+		userPublisher := publisher.NewUserPublisher(mockBus, mockEncrypter)
+
+		err := userPublisher.PublishUserUpdatedEvent(context.Background(), publisherInput)
+		require.NoError(t, err)
+
+		require.NotNil(t, published, "EventBusPublisher.Publish should be called")
+		assert.Equal(t, user.UserUpdatedEventType, published.Header.EventType)
+		assert.Equal(t, publisherInput.Payload.UserID.String(), published.Header.PartitionKey, "Events of one user should share a partition key")
+		assert.Equal(t, publisherInput.Payload.UserID, encryptInput.SubjectID)
+		assert.ElementsMatch(t, []string{"email", "phone"}, encryptInput.Fields)
+
+		var actualPayload user.UserUpdatedPayload
+		require.NoError(t, json.Unmarshal(published.Payload, &actualPayload))
+		assert.Equal(t, publisherInput.Payload, actualPayload)
+		assert.NotContains(t, string(published.Payload), `"phone"`, "Unchanged fields should be omitted")
+	})
+}
+
+func TestUserPublisher_PublishUserCreatedEvent_NATS(t *testing.T) {
+	t.Run("Success: subscriber should receive the published user created event", func(t *testing.T) {
+		h := natstest.Start(t)
+		recorder := natstest.NewRecorder(nil)
+		require.NoError(t, h.Bus.Subscribe(user.UserCreatedEventType, recorder.Handler()))
+
+		userPublisher := publisher.NewUserPublisher(h.Bus, &mockPayloadEncrypter{})
+		input := user.USerCreatedEventInput{
+			CorrelationID: types.MustNewUUID(),
+			TraceID:       types.MustNewUUID(),
+			Payload: user.UserCreatedPayload{
+				UserID: types.MustNewUUID(),
+				Name:   "Test User",
+				Email:  "test@example.com",
+				Phone:  "+15551234567",
+			},
+		}
+		require.NoError(t, userPublisher.PublishUserCreatedEvent(context.Background(), input))
+
+		received := recorder.Await(t, 5*time.Second)
+		assert.Equal(t, user.UserCreatedEventType, received.Header.EventType)
+		assert.Equal(t, input.CorrelationID, received.Context.CorrelationID)
+
+		var payload user.UserCreatedPayload
+		require.NoError(t, json.Unmarshal(received.Payload, &payload))
+		assert.Equal(t, input.Payload, payload)
+
+		h.AwaitAcked(t, user.UserCreatedEventType, 1)
+	})
+}
+
+func TestUserPublisher_PublishUserPasswordResetRequestedEvent(t *testing.T) {
+	publisherInput := user.UserPasswordResetRequestedEventInput{
+		CorrelationID: types.MustNewUUID(),
+		TraceID:       types.MustNewUUID(),
+		Payload: user.UserPasswordResetRequestedPayload{
+			UserID:     types.MustNewUUID(),
+			Name:       "Reset User",
+			Email:      "reset@example.com",
+			ResetToken: "token-id.secret",
+			ExpiresAt:  time.Now().Add(30 * time.Minute),
+		},
+	}
+
+	t.Run("Success: should encrypt the email and the reset token", func(t *testing.T) {
+		var encryptInput pii.EncryptPayloadInput
+		mockEncrypter := &mockPayloadEncrypter{
+			EncryptPayloadFunc: func(ctx context.Context, input pii.EncryptPayloadInput) (json.RawMessage, error) {
+				encryptInput = input
+				return input.Payload, nil
+			},
+		}
+
+		var published *event.Event
+		mockBus := &mockEventBusPublisher{
+			PublishFunc: func(ctx context.Context, evt *event.Event) error {
+				published = evt
+				return nil
+			},
+		}
+		userPublisher := publisher.NewUserPublisher(mockBus, mockEncrypter)
+
+		err := userPublisher.PublishUserPasswordResetRequestedEvent(context.Background(), publisherInput)
+
+		require.NoError(t, err)
+		require.NotNil(t, published)
+		assert.Equal(t, user.UserPasswordResetRequestedEventType, published.Header.EventType)
+		assert.Equal(t, publisherInput.Payload.UserID, encryptInput.SubjectID)
+		assert.ElementsMatch(t, []string{"email", "resetToken"}, encryptInput.Fields)
+		assert.False(t, published.Context.UserID.IsValid(), "Anyone may request a reset, so there is no author")
+	})
+}
+
+func TestUserPublisher_PublishUserEmailVerificationEvents(t *testing.T) {
+	userID := types.MustNewUUID()
+
+	newPublisher := func(encryptInput *pii.EncryptPayloadInput, published **event.Event) *publisher.UserPublisher {
+		mockEncrypter := &mockPayloadEncrypter{
+			EncryptPayloadFunc: func(ctx context.Context, input pii.EncryptPayloadInput) (json.RawMessage, error) {
+				*encryptInput = input
+				return input.Payload, nil
+			},
+		}
+		mockBus := &mockEventBusPublisher{
+			PublishFunc: func(ctx context.Context, evt *event.Event) error {
+				*published = evt
+				return nil
+			},
+		}
+		return publisher.NewUserPublisher(mockBus, mockEncrypter)
+	}
+
+	t.Run("Success: should encrypt the email and the verification token", func(t *testing.T) {
+		var (
+			encryptInput pii.EncryptPayloadInput
+			published    *event.Event
+		)
+		userPublisher := newPublisher(&encryptInput, &published)
+
+		err := userPublisher.PublishUserEmailVerificationRequestedEvent(context.Background(), user.UserEmailVerificationRequestedEventInput{
+			CorrelationID: types.MustNewUUID(),
+			TraceID:       types.MustNewUUID(),
+			Payload: user.UserEmailVerificationRequestedPayload{
+				UserID:            userID,
+				Name:              "Verify User",
+				Email:             "verify@example.com",
+				VerificationToken: "token-id.secret",
+				ExpiresAt:         time.Now().Add(48 * time.Hour),
+			},
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, published)
+		assert.Equal(t, user.UserEmailVerificationRequestedEventType, published.Header.EventType)
+		assert.Equal(t, userID, encryptInput.SubjectID)
+		assert.ElementsMatch(t, []string{"email", "verificationToken"}, encryptInput.Fields)
+		assert.False(t, published.Context.UserID.IsValid())
+	})
+
+	t.Run("Success: should publish user.email_verified with the user as author", func(t *testing.T) {
+		var (
+			encryptInput pii.EncryptPayloadInput
+			published    *event.Event
+		)
+		userPublisher := newPublisher(&encryptInput, &published)
+
+		err := userPublisher.PublishUserEmailVerifiedEvent(context.Background(), user.UserEmailVerifiedEventInput{
+			CorrelationID: types.MustNewUUID(),
+			TraceID:       types.MustNewUUID(),
+			Payload:       user.UserEmailVerifiedPayload{UserID: userID, Email: "verify@example.com", Version: 2},
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, published)
+		assert.Equal(t, user.UserEmailVerifiedEventType, published.Header.EventType)
+		assert.ElementsMatch(t, []string{"email"}, encryptInput.Fields)
+		authorID, ok := published.Context.UserID.GetUUID()
+		require.True(t, ok)
+		assert.Equal(t, userID, authorID)
+	})
+}
+
+func TestUserPublisher_PublishUserPhoneVerificationEvents(t *testing.T) {
+	userID := types.MustNewUUID()
+
+	newPublisher := func(encryptInput *pii.EncryptPayloadInput, published **event.Event) *publisher.UserPublisher {
+		mockEncrypter := &mockPayloadEncrypter{
+			EncryptPayloadFunc: func(ctx context.Context, input pii.EncryptPayloadInput) (json.RawMessage, error) {
+				*encryptInput = input
+				return input.Payload, nil
+			},
+		}
+		mockBus := &mockEventBusPublisher{
+			PublishFunc: func(ctx context.Context, evt *event.Event) error {
+				*published = evt
+				return nil
+			},
+		}
+		return publisher.NewUserPublisher(mockBus, mockEncrypter)
+	}
+
+	t.Run("Success: should encrypt the phone and the code of the SMS delivery request", func(t *testing.T) {
+		var (
+			encryptInput pii.EncryptPayloadInput
+			published    *event.Event
+		)
+		userPublisher := newPublisher(&encryptInput, &published)
+
+		err := userPublisher.PublishUserPhoneVerificationRequestedEvent(context.Background(), user.UserPhoneVerificationRequestedEventInput{
+			CorrelationID: types.MustNewUUID(),
+			TraceID:       types.MustNewUUID(),
+			Payload: user.UserPhoneVerificationRequestedPayload{
+				UserID:    userID,
+				Channel:   user.PhoneVerificationChannelSMS,
+				Phone:     "5511987654321",
+				Code:      "042917",
+				ExpiresAt: time.Now().Add(10 * time.Minute),
+			},
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, published)
+		assert.Equal(t, user.UserPhoneVerificationRequestedEventType, published.Header.EventType)
+		assert.Equal(t, userID, encryptInput.SubjectID)
+		assert.ElementsMatch(t, []string{"phone", "code"}, encryptInput.Fields)
+		authorID, ok := published.Context.UserID.GetUUID()
+		require.True(t, ok)
+		assert.Equal(t, userID, authorID)
+	})
+
+	t.Run("Success: should publish user.phone_verified with the encrypted phone", func(t *testing.T) {
+		var (
+			encryptInput pii.EncryptPayloadInput
+			published    *event.Event
+		)
+		userPublisher := newPublisher(&encryptInput, &published)
+
+		err := userPublisher.PublishUserPhoneVerifiedEvent(context.Background(), user.UserPhoneVerifiedEventInput{
+			CorrelationID: types.MustNewUUID(),
+			TraceID:       types.MustNewUUID(),
+			Payload:       user.UserPhoneVerifiedPayload{UserID: userID, Phone: "5511987654321", Version: 3},
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, published)
+		assert.Equal(t, user.UserPhoneVerifiedEventType, published.Header.EventType)
+		assert.ElementsMatch(t, []string{"phone"}, encryptInput.Fields)
+	})
 }
@@ -0,0 +1,71 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/logger"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+)
+
+type getUserByIDQuery struct {
+	repo   user.FindUserByIDRepository
+	logger *slog.Logger
+	tracer trace.Tracer
+}
+
+func NewGetUserByIDQuery(repo user.FindUserByIDRepository, logger *slog.Logger) user.GetUserByIDQuery {
+	return &getUserByIDQuery{
+		repo:   repo,
+		logger: logger,
+		tracer: otel.Tracer("identity-query"),
+	}
+}
+
+func (q *getUserByIDQuery) Execute(ctx context.Context, input user.GetUserByIDQueryInput) (*user.User, error) {
+	ctx, span := q.tracer.Start(ctx, "GetUserByIDQuery.Execute",
+		trace.WithAttributes(
+			attribute.String("user.id", input.UserID.String()),
+			attribute.Bool("query.include_deleted", input.IncludeDeleted),
+		),
+	)
+	defer span.End()
+
+	loggerWithTrace := q.logger.With(logger.TraceID(input.TraceID.String()))
+
+	if input.UserID.IsNil() {
+		err := msg.NewValidationError(nil, map[string]any{"field": "userId"}, user.ErrUserIDRequired)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Invalid query input")
+		return nil, err
+	}
+
+	u, err := q.repo.FindUserByID(ctx, user.FindUserByIDRepoInput{
+		UserID:         input.UserID,
+		IncludeDeleted: input.IncludeDeleted,
+	})
+	if err != nil {
+		span.RecordError(err)
+
+		var msgErr *msg.MessageError
+		if errors.As(err, &msgErr) && msgErr.Code == msg.CodeNotFound {
+			span.SetStatus(codes.Error, "User not found")
+			loggerWithTrace.Info("user not found", "user_id", input.UserID.String())
+			return nil, msgErr
+		}
+
+		span.SetStatus(codes.Error, "Failed to find user")
+		loggerWithTrace.Error("failed to find user", "user_id", input.UserID.String(), "error", err)
+		return nil, msg.NewInternalError(err, map[string]any{"user_id": input.UserID.String()})
+	}
+
+	span.SetStatus(codes.Ok, "Query answered")
+	return u, nil
+}
@@ -0,0 +1,105 @@
+package query_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/query"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+// --- Mocks for Dependencies ---
+
+type mockFindUserByIDRepository struct {
+	FindUserByIDFunc func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error)
+}
+
+func (m *mockFindUserByIDRepository) FindUserByID(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+	if m.FindUserByIDFunc != nil {
+		return m.FindUserByIDFunc(ctx, input)
+	}
+	return nil, nil
+}
+
+// --- Test Suite ---
+
+func TestGetUserByIDQuery_Execute(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := types.MustNewUUID()
+
+	t.Run("Success: should return the user found by the repository", func(t *testing.T) {
+		expected := &user.User{ID: userID, Name: "Ana"}
+		repo := &mockFindUserByIDRepository{
+			FindUserByIDFunc: func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+				assert.Equal(t, userID, input.UserID, "Repository should receive the requested user ID")
+				assert.False(t, input.IncludeDeleted, "Deleted users should be hidden by default")
+				return expected, nil
+			},
+		}
+		q := query.NewGetUserByIDQuery(repo, logger)
+
+		u, err := q.Execute(context.Background(), user.GetUserByIDQueryInput{TraceID: types.MustNewUUID(), UserID: userID})
+		require.NoError(t, err)
+		assert.Same(t, expected, u)
+	})
+
+	t.Run("Success: should forward include deleted to the repository", func(t *testing.T) {
+		repo := &mockFindUserByIDRepository{
+			FindUserByIDFunc: func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+				assert.True(t, input.IncludeDeleted)
+				return &user.User{ID: userID}, nil
+			},
+		}
+		q := query.NewGetUserByIDQuery(repo, logger)
+
+		_, err := q.Execute(context.Background(), user.GetUserByIDQueryInput{UserID: userID, IncludeDeleted: true})
+		require.NoError(t, err)
+	})
+
+	t.Run("Failure: should reject an empty user ID", func(t *testing.T) {
+		q := query.NewGetUserByIDQuery(&mockFindUserByIDRepository{}, logger)
+
+		_, err := q.Execute(context.Background(), user.GetUserByIDQueryInput{})
+		var msgErr *msg.MessageError
+		require.ErrorAs(t, err, &msgErr)
+		assert.Equal(t, msg.CodeInvalid, msgErr.Code)
+		assert.Equal(t, user.ErrUserIDRequired, msgErr.Message)
+	})
+
+	t.Run("Failure: should keep the not found error of the repository", func(t *testing.T) {
+		repo := &mockFindUserByIDRepository{
+			FindUserByIDFunc: func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+				return nil, msg.NewMessageError(nil, user.ErrUserNotFound, msg.CodeNotFound, nil)
+			},
+		}
+		q := query.NewGetUserByIDQuery(repo, logger)
+
+		_, err := q.Execute(context.Background(), user.GetUserByIDQueryInput{UserID: userID})
+		var msgErr *msg.MessageError
+		require.ErrorAs(t, err, &msgErr)
+		assert.Equal(t, msg.CodeNotFound, msgErr.Code)
+		assert.Equal(t, user.ErrUserNotFound, msgErr.Message)
+	})
+
+	t.Run("Failure: should return an internal error when the repository fails", func(t *testing.T) {
+		repo := &mockFindUserByIDRepository{
+			FindUserByIDFunc: func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		q := query.NewGetUserByIDQuery(repo, logger)
+
+		_, err := q.Execute(context.Background(), user.GetUserByIDQueryInput{UserID: userID})
+		var msgErr *msg.MessageError
+		require.ErrorAs(t, err, &msgErr)
+		assert.Equal(t, msg.CodeInternal, msgErr.Code)
+	})
+}
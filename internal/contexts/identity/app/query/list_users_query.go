@@ -0,0 +1,157 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/logger"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+)
+
+type listUsersQuery struct {
+	repo   user.ListUsersRepository
+	logger *slog.Logger
+	tracer trace.Tracer
+}
+
+func NewListUsersQuery(repo user.ListUsersRepository, logger *slog.Logger) user.ListUsersQuery {
+	return &listUsersQuery{
+		repo:   repo,
+		logger: logger,
+		tracer: otel.Tracer("identity-query"),
+	}
+}
+
+func (q *listUsersQuery) Execute(ctx context.Context, input user.ListUsersQueryInput) (user.ListUsersQueryOutput, error) {
+	ctx, span := q.tracer.Start(ctx, "ListUsersQuery.Execute",
+		trace.WithAttributes(
+			attribute.String("query.sort", string(input.Sort)),
+			attribute.String("query.direction", string(input.Direction)),
+			attribute.Int("query.limit", input.Limit),
+			attribute.Bool("query.has_cursor", input.Cursor != ""),
+		),
+	)
+	defer span.End()
+
+	loggerWithTrace := q.logger.With(logger.TraceID(input.TraceID.String()))
+
+	repoInput, err := normalizeListUsersInput(input)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Invalid query input")
+		return user.ListUsersQueryOutput{}, err
+	}
+
+	// One extra row tells whether another page exists without a COUNT.
+	limit := repoInput.Limit
+	repoInput.Limit = limit + 1
+
+	users, err := q.repo.ListUsers(ctx, repoInput)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to list users")
+		loggerWithTrace.Error("failed to list users", "error", err)
+		return user.ListUsersQueryOutput{}, msg.NewInternalError(err, nil)
+	}
+
+	output := user.ListUsersQueryOutput{Users: users}
+	if len(users) > limit {
+		output.Users = users[:limit]
+		last := output.Users[limit-1]
+		output.NextCursor = user.NewUserCursor(last, repoInput.Sort, repoInput.Direction).Encode()
+	}
+
+	span.SetAttributes(attribute.Int("query.results", len(output.Users)))
+	span.SetStatus(codes.Ok, "Query answered")
+	return output, nil
+}
+
+// normalizeListUsersInput applies defaults, validates the options and resolves
+// the cursor. Archived and deleted users are hidden unless asked for.
+func normalizeListUsersInput(input user.ListUsersQueryInput) (user.ListUsersRepoInput, error) {
+	repoInput := user.ListUsersRepoInput{
+		Filter:    input.Filter,
+		Sort:      input.Sort,
+		Direction: input.Direction,
+		Limit:     input.Limit,
+	}
+
+	if repoInput.Limit == 0 {
+		repoInput.Limit = user.ListUsersDefaultLimit
+	}
+	if repoInput.Limit < 1 || repoInput.Limit > user.ListUsersMaxLimit {
+		return repoInput, msg.NewValidationError(nil, map[string]any{"field": "limit", "limit": input.Limit}, user.ErrListUsersInvalidLimit)
+	}
+
+	switch repoInput.Sort {
+	case "":
+		repoInput.Sort = user.ListSortCreatedAt
+	case user.ListSortCreatedAt, user.ListSortName, user.ListSortEmail:
+	default:
+		return repoInput, msg.NewValidationError(nil, map[string]any{"field": "sort", "sort": string(input.Sort)}, user.ErrListUsersInvalidSort)
+	}
+
+	switch repoInput.Direction {
+	case "":
+		repoInput.Direction = user.ListDirectionDesc
+		if repoInput.Sort != user.ListSortCreatedAt {
+			repoInput.Direction = user.ListDirectionAsc
+		}
+	case user.ListDirectionAsc, user.ListDirectionDesc:
+	default:
+		return repoInput, msg.NewValidationError(nil, map[string]any{"field": "direction", "direction": string(input.Direction)}, user.ErrListUsersInvalidDirection)
+	}
+
+	var err error
+	if repoInput.Filter.Archived, err = normalizeStatusFilter("archived", input.Filter.Archived, user.StatusExclude); err != nil {
+		return repoInput, err
+	}
+	if repoInput.Filter.Deleted, err = normalizeStatusFilter("deleted", input.Filter.Deleted, user.StatusExclude); err != nil {
+		return repoInput, err
+	}
+
+	repoInput.Filter.NamePrefix = strings.TrimSpace(input.Filter.NamePrefix)
+	repoInput.Filter.EmailPrefix = strings.ToLower(strings.TrimSpace(input.Filter.EmailPrefix))
+	repoInput.Filter.PhonePrefix = strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, input.Filter.PhonePrefix)
+
+	from, to := input.Filter.CreatedFrom, input.Filter.CreatedTo
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return repoInput, msg.NewValidationError(nil, map[string]any{"field": "created_from"}, user.ErrListUsersInvalidRange)
+	}
+
+	if input.Cursor != "" {
+		cursor, err := user.DecodeUserCursor(input.Cursor)
+		if err != nil {
+			return repoInput, err
+		}
+		if cursor.Sort != repoInput.Sort || cursor.Direction != repoInput.Direction {
+			return repoInput, msg.NewValidationError(nil, map[string]any{"field": "cursor"}, user.ErrListUsersCursorMismatch)
+		}
+		repoInput.After = &cursor
+	}
+
+	return repoInput, nil
+}
+
+func normalizeStatusFilter(field string, filter, fallback user.StatusFilter) (user.StatusFilter, error) {
+	switch filter {
+	case "":
+		return fallback, nil
+	case user.StatusExclude, user.StatusInclude, user.StatusOnly:
+		return filter, nil
+	default:
+		return "", msg.NewValidationError(nil, map[string]any{"field": field, field: string(filter)}, user.ErrListUsersInvalidStatus)
+	}
+}
@@ -0,0 +1,160 @@
+package query_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/query"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+// --- Mocks for Dependencies ---
+
+type mockListUsersRepository struct {
+	ListUsersFunc func(ctx context.Context, input user.ListUsersRepoInput) ([]*user.User, error)
+}
+
+func (m *mockListUsersRepository) ListUsers(ctx context.Context, input user.ListUsersRepoInput) ([]*user.User, error) {
+	if m.ListUsersFunc != nil {
+		return m.ListUsersFunc(ctx, input)
+	}
+	return nil, nil
+}
+
+func makeUsers(names ...string) []*user.User {
+	users := make([]*user.User, 0, len(names))
+	for _, name := range names {
+		users = append(users, &user.User{ID: types.MustNewUUID(), Name: name})
+	}
+	return users
+}
+
+// --- Test Suite ---
+
+func TestListUsersQuery_Execute(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	t.Run("Success: should apply defaults when no option is given", func(t *testing.T) {
+		repo := &mockListUsersRepository{
+			ListUsersFunc: func(ctx context.Context, input user.ListUsersRepoInput) ([]*user.User, error) {
+				assert.Equal(t, user.ListSortCreatedAt, input.Sort)
+				assert.Equal(t, user.ListDirectionDesc, input.Direction)
+				assert.Equal(t, user.StatusExclude, input.Filter.Archived, "Archived users should be hidden by default")
+				assert.Equal(t, user.StatusExclude, input.Filter.Deleted)
+				assert.Equal(t, user.ListUsersDefaultLimit+1, input.Limit, "Query should fetch one extra row to detect the next page")
+				assert.Nil(t, input.After)
+				return makeUsers("Ana"), nil
+			},
+		}
+		q := query.NewListUsersQuery(repo, logger)
+
+		output, err := q.Execute(context.Background(), user.ListUsersQueryInput{})
+		require.NoError(t, err)
+		assert.Len(t, output.Users, 1)
+		assert.Empty(t, output.NextCursor, "Last page should not have a next cursor")
+	})
+
+	t.Run("Success: should normalize filters", func(t *testing.T) {
+		repo := &mockListUsersRepository{
+			ListUsersFunc: func(ctx context.Context, input user.ListUsersRepoInput) ([]*user.User, error) {
+				assert.Equal(t, "Ana", input.Filter.NamePrefix)
+				assert.Equal(t, "ana@", input.Filter.EmailPrefix)
+				assert.Equal(t, "5562", input.Filter.PhonePrefix)
+				return nil, nil
+			},
+		}
+		q := query.NewListUsersQuery(repo, logger)
+
+		_, err := q.Execute(context.Background(), user.ListUsersQueryInput{
+			Filter: user.ListUsersFilter{NamePrefix: " Ana ", EmailPrefix: "ANA@", PhonePrefix: "+55 (62"},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("Success: should return a cursor that resumes after the last user", func(t *testing.T) {
+		page := makeUsers("Ana", "Bia", "Caio")
+		calls := 0
+		repo := &mockListUsersRepository{
+			ListUsersFunc: func(ctx context.Context, input user.ListUsersRepoInput) ([]*user.User, error) {
+				calls++
+				if calls == 1 {
+					return page, nil
+				}
+				require.NotNil(t, input.After)
+				assert.Equal(t, page[1].ID, input.After.ID)
+				assert.Equal(t, "Bia", input.After.Value)
+				return page[2:], nil
+			},
+		}
+		q := query.NewListUsersQuery(repo, logger)
+
+		first, err := q.Execute(context.Background(), user.ListUsersQueryInput{Sort: user.ListSortName, Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, page[:2], first.Users)
+		require.NotEmpty(t, first.NextCursor)
+
+		second, err := q.Execute(context.Background(), user.ListUsersQueryInput{Sort: user.ListSortName, Limit: 2, Cursor: first.NextCursor})
+		require.NoError(t, err)
+		assert.Equal(t, page[2:], second.Users)
+		assert.Empty(t, second.NextCursor)
+	})
+
+	t.Run("Failure: should reject invalid options", func(t *testing.T) {
+		now := time.Now()
+		otherSort := user.NewUserCursor(&user.User{ID: types.MustNewUUID()}, user.ListSortEmail, user.ListDirectionAsc).Encode()
+
+		cases := map[string]struct {
+			input   user.ListUsersQueryInput
+			message string
+		}{
+			"limit":      {user.ListUsersQueryInput{Limit: user.ListUsersMaxLimit + 1}, user.ErrListUsersInvalidLimit},
+			"sort":       {user.ListUsersQueryInput{Sort: "password"}, user.ErrListUsersInvalidSort},
+			"direction":  {user.ListUsersQueryInput{Direction: "up"}, user.ErrListUsersInvalidDirection},
+			"archived":   {user.ListUsersQueryInput{Filter: user.ListUsersFilter{Archived: "maybe"}}, user.ErrListUsersInvalidStatus},
+			"range":      {user.ListUsersQueryInput{Filter: user.ListUsersFilter{CreatedFrom: now, CreatedTo: now.Add(-time.Hour)}}, user.ErrListUsersInvalidRange},
+			"cursor":     {user.ListUsersQueryInput{Cursor: "not a cursor"}, user.ErrListUsersInvalidCursor},
+			"cursorSort": {user.ListUsersQueryInput{Sort: user.ListSortName, Cursor: otherSort}, user.ErrListUsersCursorMismatch},
+		}
+
+		for name, tc := range cases {
+			t.Run(name, func(t *testing.T) {
+				repo := &mockListUsersRepository{
+					ListUsersFunc: func(ctx context.Context, input user.ListUsersRepoInput) ([]*user.User, error) {
+						t.Fatal("Repository should not be called with invalid input")
+						return nil, nil
+					},
+				}
+				q := query.NewListUsersQuery(repo, logger)
+
+				_, err := q.Execute(context.Background(), tc.input)
+				var msgErr *msg.MessageError
+				require.ErrorAs(t, err, &msgErr)
+				assert.Equal(t, msg.CodeInvalid, msgErr.Code)
+				assert.Equal(t, tc.message, msgErr.Message)
+			})
+		}
+	})
+
+	t.Run("Failure: should return an internal error when the repository fails", func(t *testing.T) {
+		repo := &mockListUsersRepository{
+			ListUsersFunc: func(ctx context.Context, input user.ListUsersRepoInput) ([]*user.User, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		q := query.NewListUsersQuery(repo, logger)
+
+		_, err := q.Execute(context.Background(), user.ListUsersQueryInput{})
+		var msgErr *msg.MessageError
+		require.ErrorAs(t, err, &msgErr)
+		assert.Equal(t, msg.CodeInternal, msgErr.Code)
+	})
+}
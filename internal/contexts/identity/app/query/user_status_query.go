@@ -0,0 +1,56 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+)
+
+type userStatusQueryHandler struct {
+	repo   user.UserStatusRepository
+	logger *slog.Logger
+	tracer trace.Tracer
+}
+
+func NewUserStatusQueryHandler(repo user.UserStatusRepository, logger *slog.Logger) user.UserStatusQueryHandler {
+	return &userStatusQueryHandler{
+		repo:   repo,
+		logger: logger,
+		tracer: otel.Tracer("identity-query"),
+	}
+}
+
+func (h *userStatusQueryHandler) Handle(ctx context.Context, input user.UserStatusRequest) (user.UserStatus, error) {
+	ctx, span := h.tracer.Start(ctx, "UserStatusQuery.Handle",
+		trace.WithAttributes(
+			attribute.String("user.id", input.UserID.String()),
+			attribute.String("query.type", string(user.UserStatusQueryType)),
+		),
+	)
+	defer span.End()
+
+	if input.UserID.IsNil() {
+		err := msg.NewValidationError(nil, map[string]any{"field": "userId"}, user.ErrUserIDRequired)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Invalid query input")
+		return user.UserStatus{}, err
+	}
+
+	status, err := h.repo.UserStatus(ctx, input.UserID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to read user status")
+		h.logger.Error("failed to read user status", "user_id", input.UserID.String(), "error", err)
+		return user.UserStatus{}, msg.NewInternalError(err, map[string]any{"user_id": input.UserID.String()})
+	}
+
+	span.SetStatus(codes.Ok, "Query answered")
+	return status, nil
+}
@@ -0,0 +1,75 @@
+package query_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/query"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+// --- Mocks for Dependencies ---
+
+type mockUserStatusRepository struct {
+	UserStatusFunc func(ctx context.Context, userID types.UUID) (user.UserStatus, error)
+}
+
+func (m *mockUserStatusRepository) UserStatus(ctx context.Context, userID types.UUID) (user.UserStatus, error) {
+	if m.UserStatusFunc != nil {
+		return m.UserStatusFunc(ctx, userID)
+	}
+	return user.UserStatus{}, nil
+}
+
+// --- Test Suite ---
+
+func TestUserStatusQueryHandler_Handle(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := types.MustNewUUID()
+
+	t.Run("Success: should return the status read from the repository", func(t *testing.T) {
+		repo := &mockUserStatusRepository{
+			UserStatusFunc: func(ctx context.Context, id types.UUID) (user.UserStatus, error) {
+				assert.Equal(t, userID, id, "Repository should receive the requested user ID")
+				return user.UserStatus{Exists: true, Active: true}, nil
+			},
+		}
+		handler := query.NewUserStatusQueryHandler(repo, logger)
+
+		status, err := handler.Handle(context.Background(), user.UserStatusRequest{UserID: userID})
+		require.NoError(t, err)
+		assert.Equal(t, user.UserStatus{Exists: true, Active: true}, status)
+	})
+
+	t.Run("Failure: should reject an empty user ID", func(t *testing.T) {
+		handler := query.NewUserStatusQueryHandler(&mockUserStatusRepository{}, logger)
+
+		_, err := handler.Handle(context.Background(), user.UserStatusRequest{})
+		var msgErr *msg.MessageError
+		require.ErrorAs(t, err, &msgErr)
+		assert.Equal(t, msg.CodeInvalid, msgErr.Code)
+		assert.Equal(t, user.ErrUserIDRequired, msgErr.Message)
+	})
+
+	t.Run("Failure: should return an internal error when the repository fails", func(t *testing.T) {
+		repo := &mockUserStatusRepository{
+			UserStatusFunc: func(ctx context.Context, id types.UUID) (user.UserStatus, error) {
+				return user.UserStatus{}, errors.New("db down")
+			},
+		}
+		handler := query.NewUserStatusQueryHandler(repo, logger)
+
+		_, err := handler.Handle(context.Background(), user.UserStatusRequest{UserID: userID})
+		var msgErr *msg.MessageError
+		require.ErrorAs(t, err, &msgErr)
+		assert.Equal(t, msg.CodeInternal, msgErr.Code)
+	})
+}
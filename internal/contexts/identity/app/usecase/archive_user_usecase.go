@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type archiveUserUseCase struct {
+	repo user.UpdateUserStatusRepository
+}
+
+func NewArchiveUserUseCase(repo user.UpdateUserStatusRepository) user.ArchiveUserUseCase {
+	return &archiveUserUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *archiveUserUseCase) Execute(ctx context.Context, input user.UserStatusUseCaseInput) (user.UserStatusOutput, error) {
+	return changeUserStatus(ctx, uc.repo, input, false, (*user.User).IsArchived, (*user.User).Archive, nil)
+}
+
+type unarchiveUserUseCase struct {
+	repo user.UpdateUserStatusRepository
+}
+
+func NewUnarchiveUserUseCase(repo user.UpdateUserStatusRepository) user.UnarchiveUserUseCase {
+	return &unarchiveUserUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *unarchiveUserUseCase) Execute(ctx context.Context, input user.UserStatusUseCaseInput) (user.UserStatusOutput, error) {
+	isUnarchived := func(u *user.User) bool { return !u.IsArchived() }
+	return changeUserStatus(ctx, uc.repo, input, false, isUnarchived, (*user.User).Unarchive, nil)
+}
+
+// changeUserStatus loads the user, checks the If-Match version and applies
+// the transition unless the user already is in the target state. Soft-deleted
+// users are only found with includeDeleted. A non-nil guard may refuse the
+// transition for the loaded user.
+func changeUserStatus(
+	ctx context.Context,
+	repo user.UpdateUserStatusRepository,
+	input user.UserStatusUseCaseInput,
+	includeDeleted bool,
+	inTargetState func(*user.User) bool,
+	apply func(*user.User),
+	guard func(*user.User) error,
+) (user.UserStatusOutput, error) {
+	u, err := repo.FindUserByID(ctx, user.FindUserByIDRepoInput{UserID: input.UserID, IncludeDeleted: includeDeleted})
+	if err != nil {
+		return user.UserStatusOutput{}, keepMessageError(err)
+	}
+
+	if err := checkExpectedVersion(u, input.ExpectedVersion); err != nil {
+		return user.UserStatusOutput{}, err
+	}
+
+	if guard != nil {
+		if err := guard(u); err != nil {
+			return user.UserStatusOutput{}, err
+		}
+	}
+
+	if inTargetState(u) {
+		return user.UserStatusOutput{User: u}, nil
+	}
+
+	loadedVersion := u.Version
+	apply(u)
+
+	if err := repo.UpdateUserStatus(ctx, user.UpdateUserRepoInput{User: u, ExpectedVersion: loadedVersion}); err != nil {
+		return user.UserStatusOutput{}, keepMessageError(err)
+	}
+
+	return user.UserStatusOutput{User: u, Changed: true}, nil
+}
+
+func checkExpectedVersion(u *user.User, expected types.Version) error {
+	if expected == 0 || u.Version == expected {
+		return nil
+	}
+	return msg.NewMessageError(
+		nil,
+		user.ErrUserVersionMismatch,
+		msg.CodePreconditionFailed,
+		map[string]any{"expected_version": expected.Int(), "current_version": u.Version.Int()},
+	)
+}
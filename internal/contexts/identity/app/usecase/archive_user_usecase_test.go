@@ -0,0 +1,143 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/usecase"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type mockUpdateUserStatusRepo struct {
+	FindUserByIDFunc     func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error)
+	UpdateUserStatusFunc func(ctx context.Context, input user.UpdateUserRepoInput) error
+}
+
+func (m *mockUpdateUserStatusRepo) FindUserByID(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+	if m.FindUserByIDFunc != nil {
+		return m.FindUserByIDFunc(ctx, input)
+	}
+	return nil, nil
+}
+
+func (m *mockUpdateUserStatusRepo) UpdateUserStatus(ctx context.Context, input user.UpdateUserRepoInput) error {
+	if m.UpdateUserStatusFunc != nil {
+		return m.UpdateUserStatusFunc(ctx, input)
+	}
+	return nil
+}
+
+func newActiveUser() *user.User {
+	return user.FromUser(user.FromUserInput{
+		ID:         types.MustNewUUID(),
+		Name:       "Test User",
+		Version:    types.NewVersion(),
+		ArchivedAt: types.NewNilArchivedAt(),
+		DeletedAt:  types.NewNilDeletedAt(),
+	})
+}
+
+func TestArchiveUserUseCase_Execute(t *testing.T) {
+	t.Run("Success: should archive an active user guarded by its version", func(t *testing.T) {
+		stored := newActiveUser()
+		var repoInput user.UpdateUserRepoInput
+		mockRepo := &mockUpdateUserStatusRepo{
+			FindUserByIDFunc: func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+				return stored, nil
+			},
+			UpdateUserStatusFunc: func(ctx context.Context, input user.UpdateUserRepoInput) error {
+				repoInput = input
+				return nil
+			},
+		}
+		uc := usecase.NewArchiveUserUseCase(mockRepo)
+
+		output, err := uc.Execute(context.Background(), user.UserStatusUseCaseInput{UserID: stored.ID, ExpectedVersion: types.Version(1)})
+
+		require.NoError(t, err)
+		assert.True(t, output.Changed)
+		assert.True(t, output.User.IsArchived(), "User should be archived")
+		assert.Equal(t, types.Version(2), output.User.Version)
+		assert.Equal(t, types.Version(1), repoInput.ExpectedVersion, "Write should be guarded by the loaded version")
+	})
+
+	t.Run("Success: should not persist when the user is already archived", func(t *testing.T) {
+		stored := newActiveUser()
+		stored.Archive()
+		mockRepo := &mockUpdateUserStatusRepo{
+			FindUserByIDFunc: func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+				return stored, nil
+			},
+			UpdateUserStatusFunc: func(ctx context.Context, input user.UpdateUserRepoInput) error {
+				t.Fatal("UpdateUserStatus should not be called for an archived user")
+				return nil
+			},
+		}
+		uc := usecase.NewArchiveUserUseCase(mockRepo)
+
+		output, err := uc.Execute(context.Background(), user.UserStatusUseCaseInput{UserID: stored.ID})
+
+		require.NoError(t, err)
+		assert.False(t, output.Changed)
+	})
+
+	t.Run("Failure: should return precondition failed when the version does not match", func(t *testing.T) {
+		stored := newActiveUser()
+		mockRepo := &mockUpdateUserStatusRepo{
+			FindUserByIDFunc: func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+				return stored, nil
+			},
+		}
+		uc := usecase.NewArchiveUserUseCase(mockRepo)
+
+		_, err := uc.Execute(context.Background(), user.UserStatusUseCaseInput{UserID: stored.ID, ExpectedVersion: types.Version(2)})
+
+		var msgErr *msg.MessageError
+		require.ErrorAs(t, err, &msgErr)
+		assert.Equal(t, msg.CodePreconditionFailed, msgErr.Code)
+	})
+}
+
+func TestUnarchiveUserUseCase_Execute(t *testing.T) {
+	t.Run("Success: should unarchive an archived user", func(t *testing.T) {
+		stored := newActiveUser()
+		stored.Archive()
+		mockRepo := &mockUpdateUserStatusRepo{
+			FindUserByIDFunc: func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+				return stored, nil
+			},
+		}
+		uc := usecase.NewUnarchiveUserUseCase(mockRepo)
+
+		output, err := uc.Execute(context.Background(), user.UserStatusUseCaseInput{UserID: stored.ID})
+
+		require.NoError(t, err)
+		assert.True(t, output.Changed)
+		assert.False(t, output.User.IsArchived(), "User should no longer be archived")
+	})
+
+	t.Run("Failure: should keep the conflict error of a concurrent update", func(t *testing.T) {
+		stored := newActiveUser()
+		stored.Archive()
+		mockRepo := &mockUpdateUserStatusRepo{
+			FindUserByIDFunc: func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+				return stored, nil
+			},
+			UpdateUserStatusFunc: func(ctx context.Context, input user.UpdateUserRepoInput) error {
+				return msg.NewMessageError(nil, user.ErrUserConcurrentUpdate, msg.CodeConflict, nil)
+			},
+		}
+		uc := usecase.NewUnarchiveUserUseCase(mockRepo)
+
+		_, err := uc.Execute(context.Background(), user.UserStatusUseCaseInput{UserID: stored.ID})
+
+		var msgErr *msg.MessageError
+		require.ErrorAs(t, err, &msgErr)
+		assert.Equal(t, msg.CodeConflict, msgErr.Code)
+	})
+}
@@ -2,22 +2,33 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/config"
 	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
 	"github.com/marcelofabianov/redtogreen/internal/platform/port/hasher"
 	"github.com/marcelofabianov/redtogreen/internal/platform/types"
 )
 
 type createUserUseCase struct {
-	repo   user.CreateUserRepository
-	hasher hasher.Hasher
+	repo          user.CreateUserRepository
+	verifications user.EmailVerificationTokenRepository
+	hasher        hasher.Hasher
+	ttl           time.Duration
 }
 
-func NewCreateUserUseCase(repo user.CreateUserRepository, h hasher.Hasher) user.CreateUserUseCase {
+func NewCreateUserUseCase(
+	repo user.CreateUserRepository,
+	verifications user.EmailVerificationTokenRepository,
+	h hasher.Hasher,
+	cfg config.EmailVerificationConfig,
+) user.CreateUserUseCase {
 	return &createUserUseCase{
-		repo:   repo,
-		hasher: h,
+		repo:          repo,
+		verifications: verifications,
+		hasher:        h,
+		ttl:           time.Duration(cfg.ExpiryHours) * time.Hour,
 	}
 }
 
@@ -59,8 +70,14 @@ func (uc *createUserUseCase) Execute(ctx context.Context, input user.NewUserInpu
 		return user.CreateUserOutput{}, msg.NewInternalError(err, nil)
 	}
 
+	issued, err := issueEmailVerification(ctx, uc.verifications, uc.hasher, uc.ttl, newUser)
+	if err != nil {
+		return user.CreateUserOutput{}, err
+	}
+
 	output := user.CreateUserOutput{
-		User: newUser,
+		User:              newUser,
+		EmailVerification: issued,
 	}
 
 	return output, nil
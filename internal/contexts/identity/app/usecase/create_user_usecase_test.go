@@ -51,13 +51,15 @@ func TestCreateUserUseCase_Execute(t *testing.T) {
 				return nil
 			},
 		}
-		uc := usecase.NewCreateUserUseCase(mockRepo, h)
+		uc := usecase.NewCreateUserUseCase(mockRepo, newMockEmailVerificationTokenRepo(), h, verificationConfig)
 
 		output, err := uc.Execute(context.Background(), validInput)
 
 		require.NoError(t, err, "Execute should not return an error on success")
 		assert.NotNil(t, output.User, "Returned user should not be nil")
 		assert.Equal(t, validInput.Name, output.User.Name, "User name should match input")
+		assert.False(t, output.User.IsEmailVerified(), "A new email starts unverified")
+		assert.NotEmpty(t, output.EmailVerification.Value, "A verification token should be issued")
 	})
 
 	t.Run("Failure: should return conflict error if user already exists", func(t *testing.T) {
@@ -66,7 +68,7 @@ func TestCreateUserUseCase_Execute(t *testing.T) {
 				return true, nil
 			},
 		}
-		uc := usecase.NewCreateUserUseCase(mockRepo, h)
+		uc := usecase.NewCreateUserUseCase(mockRepo, newMockEmailVerificationTokenRepo(), h, verificationConfig)
 
 		_, err := uc.Execute(context.Background(), validInput)
 
@@ -83,7 +85,7 @@ func TestCreateUserUseCase_Execute(t *testing.T) {
 				return false, dbError
 			},
 		}
-		uc := usecase.NewCreateUserUseCase(mockRepo, h)
+		uc := usecase.NewCreateUserUseCase(mockRepo, newMockEmailVerificationTokenRepo(), h, verificationConfig)
 
 		_, err := uc.Execute(context.Background(), validInput)
 
@@ -97,7 +99,7 @@ func TestCreateUserUseCase_Execute(t *testing.T) {
 		invalidInput := validInput
 		invalidInput.Email = "not-an-email"
 		mockRepo := &mockCreateUserRepo{}
-		uc := usecase.NewCreateUserUseCase(mockRepo, h)
+		uc := usecase.NewCreateUserUseCase(mockRepo, newMockEmailVerificationTokenRepo(), h, verificationConfig)
 
 		_, err := uc.Execute(context.Background(), invalidInput)
 
@@ -117,7 +119,7 @@ func TestCreateUserUseCase_Execute(t *testing.T) {
 				return dbError
 			},
 		}
-		uc := usecase.NewCreateUserUseCase(mockRepo, h)
+		uc := usecase.NewCreateUserUseCase(mockRepo, newMockEmailVerificationTokenRepo(), h, verificationConfig)
 
 		_, err := uc.Execute(context.Background(), validInput)
 
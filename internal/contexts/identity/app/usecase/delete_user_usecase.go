@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+)
+
+type deleteUserUseCase struct {
+	repo user.UpdateUserStatusRepository
+}
+
+func NewDeleteUserUseCase(repo user.UpdateUserStatusRepository) user.DeleteUserUseCase {
+	return &deleteUserUseCase{
+		repo: repo,
+	}
+}
+
+// Execute treats deleting an already deleted user as a no-op, so retries of
+// DELETE keep answering 204.
+func (uc *deleteUserUseCase) Execute(ctx context.Context, input user.UserStatusUseCaseInput) (user.UserStatusOutput, error) {
+	return changeUserStatus(ctx, uc.repo, input, true, (*user.User).IsDeleted, (*user.User).Delete, nil)
+}
+
+type restoreUserUseCase struct {
+	repo user.UpdateUserStatusRepository
+}
+
+func NewRestoreUserUseCase(repo user.UpdateUserStatusRepository) user.RestoreUserUseCase {
+	return &restoreUserUseCase{
+		repo: repo,
+	}
+}
+
+// Execute refuses forgotten users: their data key is gone, so none of their
+// events could be published again.
+func (uc *restoreUserUseCase) Execute(ctx context.Context, input user.UserStatusUseCaseInput) (user.UserStatusOutput, error) {
+	isActive := func(u *user.User) bool { return !u.IsDeleted() }
+	return changeUserStatus(ctx, uc.repo, input, true, isActive, (*user.User).Restore, refuseForgotten)
+}
+
+func refuseForgotten(u *user.User) error {
+	if !u.IsForgotten() {
+		return nil
+	}
+	return msg.NewMessageError(nil, user.ErrUserRestoreForgotten, msg.CodeConflict, map[string]any{"user_id": u.ID.String()})
+}
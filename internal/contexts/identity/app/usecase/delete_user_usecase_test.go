@@ -0,0 +1,103 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/usecase"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+func TestDeleteUserUseCase_Execute(t *testing.T) {
+	t.Run("Success: should soft delete an active user", func(t *testing.T) {
+		stored := newActiveUser()
+		updated := false
+		mockRepo := &mockUpdateUserStatusRepo{
+			FindUserByIDFunc: func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+				return stored, nil
+			},
+			UpdateUserStatusFunc: func(ctx context.Context, input user.UpdateUserRepoInput) error {
+				updated = true
+				assert.Equal(t, types.Version(1), input.ExpectedVersion)
+				return nil
+			},
+		}
+		uc := usecase.NewDeleteUserUseCase(mockRepo)
+
+		output, err := uc.Execute(context.Background(), user.UserStatusUseCaseInput{UserID: stored.ID})
+
+		require.NoError(t, err)
+		assert.True(t, updated)
+		assert.True(t, output.Changed)
+		assert.True(t, output.User.IsDeleted(), "User should be soft-deleted")
+	})
+
+	t.Run("Success: should treat deleting a deleted user as a no-op", func(t *testing.T) {
+		stored := newActiveUser()
+		stored.Delete()
+		mockRepo := &mockUpdateUserStatusRepo{
+			FindUserByIDFunc: func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+				assert.True(t, input.IncludeDeleted, "Deleted users should be loaded to make the delete idempotent")
+				return stored, nil
+			},
+			UpdateUserStatusFunc: func(ctx context.Context, input user.UpdateUserRepoInput) error {
+				t.Fatal("UpdateUserStatus should not be called for a deleted user")
+				return nil
+			},
+		}
+		uc := usecase.NewDeleteUserUseCase(mockRepo)
+
+		output, err := uc.Execute(context.Background(), user.UserStatusUseCaseInput{UserID: stored.ID})
+
+		require.NoError(t, err)
+		assert.False(t, output.Changed)
+	})
+}
+
+func TestRestoreUserUseCase_Execute(t *testing.T) {
+	t.Run("Success: should restore a soft-deleted user", func(t *testing.T) {
+		stored := newActiveUser()
+		stored.Delete()
+		mockRepo := &mockUpdateUserStatusRepo{
+			FindUserByIDFunc: func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+				assert.True(t, input.IncludeDeleted, "Restore should find soft-deleted users")
+				return stored, nil
+			},
+		}
+		uc := usecase.NewRestoreUserUseCase(mockRepo)
+
+		output, err := uc.Execute(context.Background(), user.UserStatusUseCaseInput{UserID: stored.ID})
+
+		require.NoError(t, err)
+		assert.True(t, output.Changed)
+		assert.False(t, output.User.IsDeleted(), "User should no longer be deleted")
+		assert.Equal(t, types.Version(3), output.User.Version)
+	})
+	t.Run("Failure: should refuse to restore a forgotten user", func(t *testing.T) {
+		stored := newActiveUser()
+		stored.Delete()
+		stored.Forget()
+		mockRepo := &mockUpdateUserStatusRepo{
+			FindUserByIDFunc: func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+				return stored, nil
+			},
+			UpdateUserStatusFunc: func(ctx context.Context, input user.UpdateUserRepoInput) error {
+				t.Fatal("UpdateUserStatus should not be called for a forgotten user")
+				return nil
+			},
+		}
+		uc := usecase.NewRestoreUserUseCase(mockRepo)
+
+		_, err := uc.Execute(context.Background(), user.UserStatusUseCaseInput{UserID: stored.ID})
+
+		var msgErr *msg.MessageError
+		require.True(t, errors.As(err, &msgErr))
+		assert.Equal(t, msg.CodeConflict, msgErr.Code)
+	})
+}
@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/config"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/hasher"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type requestEmailVerificationUseCase struct {
+	repo           user.FindUserByLoginRepository
+	verifications  user.EmailVerificationTokenRepository
+	hasher         hasher.Hasher
+	ttl            time.Duration
+	resendInterval time.Duration
+}
+
+func NewRequestEmailVerificationUseCase(
+	repo user.FindUserByLoginRepository,
+	verifications user.EmailVerificationTokenRepository,
+	h hasher.Hasher,
+	cfg config.EmailVerificationConfig,
+) user.RequestEmailVerificationUseCase {
+	return &requestEmailVerificationUseCase{
+		repo:           repo,
+		verifications:  verifications,
+		hasher:         h,
+		ttl:            time.Duration(cfg.ExpiryHours) * time.Hour,
+		resendInterval: time.Duration(cfg.ResendIntervalSeconds) * time.Second,
+	}
+}
+
+func (uc *requestEmailVerificationUseCase) Execute(
+	ctx context.Context,
+	input user.RequestEmailVerificationInput,
+) (user.RequestEmailVerificationOutput, error) {
+	email, err := types.NewEmail(input.Email)
+	if err != nil {
+		return user.RequestEmailVerificationOutput{}, nil
+	}
+
+	u, err := uc.repo.FindUserByLogin(ctx, user.FindUserByLoginRepoInput{Email: email})
+	if err != nil {
+		if isNotFound(err) {
+			return user.RequestEmailVerificationOutput{}, nil
+		}
+		return user.RequestEmailVerificationOutput{}, keepMessageError(err)
+	}
+	if u.IsDeleted() || u.IsArchived() || u.IsEmailVerified() {
+		return user.RequestEmailVerificationOutput{}, nil
+	}
+
+	// Resends are paced per user, so the endpoint cannot be used to flood an
+	// inbox. The answer stays the same when a resend is skipped.
+	latest, err := uc.verifications.FindLatestEmailVerificationToken(ctx, u.ID)
+	if err != nil && !isNotFound(err) {
+		return user.RequestEmailVerificationOutput{}, keepMessageError(err)
+	}
+	if err == nil && time.Since(latest.CreatedAt) < uc.resendInterval {
+		return user.RequestEmailVerificationOutput{}, nil
+	}
+
+	issued, err := issueEmailVerification(ctx, uc.verifications, uc.hasher, uc.ttl, u)
+	if err != nil {
+		return user.RequestEmailVerificationOutput{}, err
+	}
+
+	return user.RequestEmailVerificationOutput{User: u, Token: issued}, nil
+}
+
+type verifyEmailUseCase struct {
+	repo          user.VerifyUserEmailRepository
+	verifications user.EmailVerificationTokenRepository
+	hasher        hasher.Hasher
+}
+
+func NewVerifyEmailUseCase(
+	repo user.VerifyUserEmailRepository,
+	verifications user.EmailVerificationTokenRepository,
+	h hasher.Hasher,
+) user.VerifyEmailUseCase {
+	return &verifyEmailUseCase{
+		repo:          repo,
+		verifications: verifications,
+		hasher:        h,
+	}
+}
+
+func (uc *verifyEmailUseCase) Execute(ctx context.Context, input user.VerifyEmailInput) (user.VerifyEmailOutput, error) {
+	t, err := uc.findVerificationToken(ctx, input.Token)
+	if err != nil {
+		return user.VerifyEmailOutput{}, err
+	}
+
+	u, err := uc.repo.FindUserByID(ctx, user.FindUserByIDRepoInput{UserID: t.UserID})
+	if err != nil {
+		if isNotFound(err) {
+			return user.VerifyEmailOutput{}, invalidEmailVerificationToken()
+		}
+		return user.VerifyEmailOutput{}, keepMessageError(err)
+	}
+	// The token was sent to an address the user no longer has.
+	if u.Email != t.Email {
+		return user.VerifyEmailOutput{}, invalidEmailVerificationToken()
+	}
+
+	err = uc.verifications.UseEmailVerificationToken(ctx, user.UseEmailVerificationTokenRepoInput{
+		ID:     t.ID,
+		UserID: t.UserID,
+		UsedAt: time.Now(),
+	})
+	if err != nil {
+		var msgErr *msg.MessageError
+		if errors.As(err, &msgErr) && msgErr.Code == msg.CodeConflict {
+			return user.VerifyEmailOutput{}, invalidEmailVerificationToken()
+		}
+		return user.VerifyEmailOutput{}, keepMessageError(err)
+	}
+
+	loadedVersion := u.Version
+	u.VerifyEmail()
+	if err := uc.repo.VerifyUserEmail(ctx, user.UpdateUserRepoInput{User: u, ExpectedVersion: loadedVersion}); err != nil {
+		return user.VerifyEmailOutput{}, keepMessageError(err)
+	}
+
+	return user.VerifyEmailOutput{User: u}, nil
+}
+
+// findVerificationToken loads the token of an opaque value and checks its
+// secret. Unknown, wrong, used and expired tokens get the same answer.
+func (uc *verifyEmailUseCase) findVerificationToken(ctx context.Context, value string) (*user.EmailVerificationToken, error) {
+	id, secret, err := user.ParseEmailVerificationToken(value)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := uc.verifications.FindEmailVerificationTokenByID(ctx, id)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, invalidEmailVerificationToken()
+		}
+		return nil, keepMessageError(err)
+	}
+
+	match, err := t.VerifySecret(secret, uc.hasher)
+	if err != nil {
+		return nil, msg.NewInternalError(err, map[string]any{"email_verification_token_id": id.String()})
+	}
+	if !match || t.IsUsed() || t.IsExpired(time.Now()) {
+		return nil, invalidEmailVerificationToken()
+	}
+
+	return t, nil
+}
+
+// issueEmailVerification stores a token for the current email of u and
+// returns the value to deliver.
+func issueEmailVerification(
+	ctx context.Context,
+	verifications user.EmailVerificationTokenRepository,
+	h hasher.Hasher,
+	ttl time.Duration,
+	u *user.User,
+) (user.IssuedEmailVerificationToken, error) {
+	t, issued, err := user.NewEmailVerificationToken(u, ttl, h)
+	if err != nil {
+		return user.IssuedEmailVerificationToken{}, err
+	}
+	if err := verifications.CreateEmailVerificationToken(ctx, t); err != nil {
+		return user.IssuedEmailVerificationToken{}, keepMessageError(err)
+	}
+	return issued, nil
+}
+
+func invalidEmailVerificationToken() error {
+	return msg.NewMessageError(nil, user.ErrEmailVerificationTokenInvalid, msg.CodeUnauthorized, nil)
+}
@@ -0,0 +1,203 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/usecase"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/hasher"
+	"github.com/marcelofabianov/redtogreen/internal/platform/config"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+var verificationConfig = config.EmailVerificationConfig{ExpiryHours: 48, ResendIntervalSeconds: 60}
+
+// mockEmailVerificationTokenRepo keeps verification tokens in memory.
+type mockEmailVerificationTokenRepo struct {
+	tokens map[types.UUID]*user.EmailVerificationToken
+}
+
+func newMockEmailVerificationTokenRepo() *mockEmailVerificationTokenRepo {
+	return &mockEmailVerificationTokenRepo{tokens: map[types.UUID]*user.EmailVerificationToken{}}
+}
+
+func (m *mockEmailVerificationTokenRepo) CreateEmailVerificationToken(ctx context.Context, t *user.EmailVerificationToken) error {
+	m.tokens[t.ID] = t
+	return nil
+}
+
+func (m *mockEmailVerificationTokenRepo) FindEmailVerificationTokenByID(ctx context.Context, id types.UUID) (*user.EmailVerificationToken, error) {
+	t, ok := m.tokens[id]
+	if !ok {
+		return nil, msg.NewMessageError(nil, user.ErrEmailVerificationTokenInvalid, msg.CodeNotFound, nil)
+	}
+	return t, nil
+}
+
+func (m *mockEmailVerificationTokenRepo) FindLatestEmailVerificationToken(ctx context.Context, userID types.UUID) (*user.EmailVerificationToken, error) {
+	var latest *user.EmailVerificationToken
+	for _, t := range m.tokens {
+		if t.UserID == userID && (latest == nil || t.CreatedAt.After(latest.CreatedAt)) {
+			latest = t
+		}
+	}
+	if latest == nil {
+		return nil, msg.NewMessageError(nil, user.ErrEmailVerificationTokenInvalid, msg.CodeNotFound, nil)
+	}
+	return latest, nil
+}
+
+func (m *mockEmailVerificationTokenRepo) UseEmailVerificationToken(ctx context.Context, input user.UseEmailVerificationTokenRepoInput) error {
+	t := m.tokens[input.ID]
+	if t.IsUsed() {
+		return msg.NewMessageError(nil, user.ErrEmailVerificationTokenInvalid, msg.CodeConflict, nil)
+	}
+	for _, other := range m.tokens {
+		if other.UserID == input.UserID && !other.IsUsed() {
+			other.UsedAt.Set(input.UsedAt)
+		}
+	}
+	return nil
+}
+
+type mockVerifyUserEmailRepo struct {
+	mockFindUserByIDRepo
+	verified []user.UpdateUserRepoInput
+}
+
+func (m *mockVerifyUserEmailRepo) VerifyUserEmail(ctx context.Context, input user.UpdateUserRepoInput) error {
+	m.verified = append(m.verified, input)
+	return nil
+}
+
+func verifyRepoReturning(u *user.User) *mockVerifyUserEmailRepo {
+	return &mockVerifyUserEmailRepo{mockFindUserByIDRepo: passwordRepoReturning(u).mockFindUserByIDRepo}
+}
+
+func TestRequestEmailVerificationUseCase_Execute(t *testing.T) {
+	h := hasher.NewHasher()
+
+	t.Run("Success: should issue a token bound to the current email", func(t *testing.T) {
+		u := newUserWithPassword(t, h)
+		verifications := newMockEmailVerificationTokenRepo()
+		uc := usecase.NewRequestEmailVerificationUseCase(repoReturningLogin(u), verifications, h, verificationConfig)
+
+		output, err := uc.Execute(context.Background(), user.RequestEmailVerificationInput{Email: "password@example.com"})
+		require.NoError(t, err)
+
+		require.NotNil(t, output.User)
+		assert.WithinDuration(t, time.Now().Add(48*time.Hour), output.Token.ExpiresAt, time.Minute)
+		require.Len(t, verifications.tokens, 1)
+		for _, stored := range verifications.tokens {
+			assert.Equal(t, u.Email, stored.Email)
+			assert.NotContains(t, output.Token.Value, stored.SecretHash)
+		}
+	})
+
+	t.Run("Success: should not resend before the interval", func(t *testing.T) {
+		u := newUserWithPassword(t, h)
+		verifications := newMockEmailVerificationTokenRepo()
+		uc := usecase.NewRequestEmailVerificationUseCase(repoReturningLogin(u), verifications, h, verificationConfig)
+
+		first, err := uc.Execute(context.Background(), user.RequestEmailVerificationInput{Email: "password@example.com"})
+		require.NoError(t, err)
+		require.NotNil(t, first.User)
+
+		second, err := uc.Execute(context.Background(), user.RequestEmailVerificationInput{Email: "password@example.com"})
+		require.NoError(t, err)
+		assert.Nil(t, second.User)
+		assert.Len(t, verifications.tokens, 1)
+
+		for _, stored := range verifications.tokens {
+			stored.CreatedAt = stored.CreatedAt.Add(-2 * time.Minute)
+		}
+		third, err := uc.Execute(context.Background(), user.RequestEmailVerificationInput{Email: "password@example.com"})
+		require.NoError(t, err)
+		assert.NotNil(t, third.User)
+		assert.Len(t, verifications.tokens, 2)
+	})
+
+	t.Run("Success: should issue nothing for unknown, verified or archived users", func(t *testing.T) {
+		verified := newUserWithPassword(t, h)
+		verified.VerifyEmail()
+		archived := newUserWithPassword(t, h)
+		archived.Archive()
+
+		for _, repo := range []*mockFindUserByLoginRepo{{}, repoReturningLogin(verified), repoReturningLogin(archived)} {
+			verifications := newMockEmailVerificationTokenRepo()
+			uc := usecase.NewRequestEmailVerificationUseCase(repo, verifications, h, verificationConfig)
+
+			output, err := uc.Execute(context.Background(), user.RequestEmailVerificationInput{Email: "password@example.com"})
+
+			require.NoError(t, err)
+			assert.Nil(t, output.User)
+			assert.Empty(t, verifications.tokens)
+		}
+	})
+}
+
+func TestVerifyEmailUseCase_Execute(t *testing.T) {
+	h := hasher.NewHasher()
+
+	issue := func(t *testing.T, verifications *mockEmailVerificationTokenRepo, u *user.User, ttl time.Duration) string {
+		t.Helper()
+		token, issued, err := user.NewEmailVerificationToken(u, ttl, h)
+		require.NoError(t, err)
+		verifications.tokens[token.ID] = token
+		return issued.Value
+	}
+
+	t.Run("Success: should verify the email and spend every pending token", func(t *testing.T) {
+		u := newUserWithPassword(t, h)
+		loadedVersion := u.Version
+		repo := verifyRepoReturning(u)
+		verifications := newMockEmailVerificationTokenRepo()
+		older := issue(t, verifications, u, time.Hour)
+		value := issue(t, verifications, u, time.Hour)
+		uc := usecase.NewVerifyEmailUseCase(repo, verifications, h)
+
+		output, err := uc.Execute(context.Background(), user.VerifyEmailInput{Token: value})
+		require.NoError(t, err)
+
+		assert.True(t, output.User.IsEmailVerified())
+		require.Len(t, repo.verified, 1)
+		assert.Equal(t, loadedVersion, repo.verified[0].ExpectedVersion)
+
+		_, err = uc.Execute(context.Background(), user.VerifyEmailInput{Token: value})
+		assertErrorCode(t, err, msg.CodeUnauthorized)
+		_, err = uc.Execute(context.Background(), user.VerifyEmailInput{Token: older})
+		assertErrorCode(t, err, msg.CodeUnauthorized)
+	})
+
+	t.Run("Failure: should reject a token sent to a previous email", func(t *testing.T) {
+		u := newUserWithPassword(t, h)
+		verifications := newMockEmailVerificationTokenRepo()
+		value := issue(t, verifications, u, time.Hour)
+		require.NoError(t, u.Update(user.UpdateUserInput{Email: "changed@example.com"}))
+		repo := verifyRepoReturning(u)
+		uc := usecase.NewVerifyEmailUseCase(repo, verifications, h)
+
+		_, err := uc.Execute(context.Background(), user.VerifyEmailInput{Token: value})
+
+		assertErrorCode(t, err, msg.CodeUnauthorized)
+		assert.Empty(t, repo.verified)
+	})
+
+	t.Run("Failure: should reject malformed, unknown, wrong and expired tokens alike", func(t *testing.T) {
+		u := newUserWithPassword(t, h)
+		verifications := newMockEmailVerificationTokenRepo()
+		expired := issue(t, verifications, u, -time.Minute)
+		uc := usecase.NewVerifyEmailUseCase(verifyRepoReturning(u), verifications, h)
+
+		for _, value := range []string{"not-a-token", types.MustNewUUID().String() + ".secret", expired[:37] + "wrong-secret", expired} {
+			_, err := uc.Execute(context.Background(), user.VerifyEmailInput{Token: value})
+			assertErrorCode(t, err, msg.CodeUnauthorized)
+		}
+	})
+}
@@ -0,0 +1,219 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/role"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/config"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/hasher"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/oidc"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/token"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type startExternalLoginUseCase struct {
+	identities user.ExternalIdentityRepository
+	provider   oidc.Provider
+}
+
+func NewStartExternalLoginUseCase(identities user.ExternalIdentityRepository, provider oidc.Provider) user.StartExternalLoginUseCase {
+	return &startExternalLoginUseCase{
+		identities: identities,
+		provider:   provider,
+	}
+}
+
+func (uc *startExternalLoginUseCase) Execute(ctx context.Context) (user.StartExternalLoginOutput, error) {
+	state, err := user.NewExternalLoginState(uc.provider.Name())
+	if err != nil {
+		return user.StartExternalLoginOutput{}, err
+	}
+
+	authURL, err := uc.provider.AuthURL(ctx, oidc.AuthURLInput{
+		State:        state.State,
+		Nonce:        state.Nonce,
+		CodeVerifier: state.CodeVerifier,
+	})
+	if err != nil {
+		return user.StartExternalLoginOutput{}, providerError(uc.provider, err)
+	}
+
+	if err := uc.identities.SaveExternalLoginState(ctx, state); err != nil {
+		return user.StartExternalLoginOutput{}, keepMessageError(err)
+	}
+
+	return user.StartExternalLoginOutput{
+		AuthURL:   authURL,
+		State:     state.State,
+		ExpiresAt: state.ExpiresAt,
+	}, nil
+}
+
+type externalLoginUseCase struct {
+	identities user.ExternalIdentityRepository
+	users      user.FindUserByIDRepository
+	logins     user.FindUserByLoginRepository
+	sessions   sessionIssuer
+	provider   oidc.Provider
+}
+
+func NewExternalLoginUseCase(
+	identities user.ExternalIdentityRepository,
+	users user.FindUserByIDRepository,
+	logins user.FindUserByLoginRepository,
+	tokens user.CreateRefreshTokenRepository,
+	permissions role.UserPermissionsRepository,
+	h hasher.Hasher,
+	issuer token.Issuer,
+	cfg config.RefreshTokenConfig,
+	identity config.IdentityConfig,
+	provider oidc.Provider,
+) user.ExternalLoginUseCase {
+	return &externalLoginUseCase{
+		identities: identities,
+		users:      users,
+		logins:     logins,
+		sessions:   newSessionIssuer(tokens, permissions, h, issuer, cfg, identity),
+		provider:   provider,
+	}
+}
+
+func (uc *externalLoginUseCase) Execute(ctx context.Context, input user.ExternalLoginInput) (user.ExternalLoginOutput, error) {
+	state, err := uc.identities.ConsumeExternalLoginState(ctx, input.State)
+	if err != nil {
+		if isNotFound(err) {
+			return user.ExternalLoginOutput{}, invalidExternalLoginState()
+		}
+		return user.ExternalLoginOutput{}, keepMessageError(err)
+	}
+	if state.Provider != uc.provider.Name() || state.IsExpired(time.Now()) {
+		return user.ExternalLoginOutput{}, invalidExternalLoginState()
+	}
+
+	identity, err := uc.provider.Exchange(ctx, oidc.ExchangeInput{
+		Code:         input.Code,
+		CodeVerifier: state.CodeVerifier,
+		Nonce:        state.Nonce,
+	})
+	if err != nil {
+		if errors.Is(err, oidc.ErrExchange) {
+			return user.ExternalLoginOutput{FailureReason: user.LoginFailureProviderRejected},
+				msg.NewMessageError(err, user.ErrExternalLoginRejected, msg.CodeUnauthorized, map[string]any{"provider": uc.provider.Name()})
+		}
+		return user.ExternalLoginOutput{}, providerError(uc.provider, err)
+	}
+
+	output, link, err := uc.resolveUser(ctx, identity)
+	if err != nil {
+		return output, err
+	}
+
+	u := output.User
+	if u.IsDeleted() {
+		output.FailureReason = user.LoginFailureUserDeleted
+		return output, invalidCredentials()
+	}
+	if u.IsArchived() {
+		output.FailureReason = user.LoginFailureUserArchived
+		return output, msg.NewMessageError(nil, user.ErrLoginUserArchived, msg.CodeForbidden, nil)
+	}
+
+	// Anyone may register a local account with an email they do not own, so
+	// linking it unverified would let its password into the real owner's
+	// account.
+	if link != nil && link.NewUser == nil && !u.IsEmailVerified() {
+		output.FailureReason = user.LoginFailureLocalUnverified
+		return output, msg.NewMessageError(nil, user.ErrExternalLoginLocalUnverified, msg.CodeConflict, map[string]any{"provider": uc.provider.Name()})
+	}
+
+	if link != nil {
+		if err := uc.identities.LinkExternalIdentity(ctx, *link); err != nil {
+			return user.ExternalLoginOutput{}, keepMessageError(err)
+		}
+	}
+
+	session, err := uc.sessions.start(ctx, u.ID)
+	if err != nil {
+		return user.ExternalLoginOutput{}, err
+	}
+	output.Session = session
+
+	return output, nil
+}
+
+// resolveUser finds the user behind the identity. When the identity is not
+// linked yet it also returns the link to store once the user is allowed in:
+// to the user with the same email, once both sides verified it, or to a new
+// user.
+func (uc *externalLoginUseCase) resolveUser(
+	ctx context.Context,
+	identity oidc.Identity,
+) (user.ExternalLoginOutput, *user.LinkExternalIdentityRepoInput, error) {
+	linked, err := uc.identities.FindExternalIdentity(ctx, user.FindExternalIdentityRepoInput{
+		Provider: uc.provider.Name(),
+		Subject:  identity.Subject,
+	})
+	if err == nil {
+		u, err := uc.users.FindUserByID(ctx, user.FindUserByIDRepoInput{UserID: linked.UserID, IncludeDeleted: true})
+		if err != nil {
+			return user.ExternalLoginOutput{}, nil, keepMessageError(err)
+		}
+		return user.ExternalLoginOutput{User: u}, nil, nil
+	}
+	if !isNotFound(err) {
+		return user.ExternalLoginOutput{}, nil, keepMessageError(err)
+	}
+
+	if !identity.EmailVerified {
+		return user.ExternalLoginOutput{FailureReason: user.LoginFailureEmailUnverified},
+			nil, msg.NewMessageError(nil, user.ErrExternalLoginEmailUnverified, msg.CodeUnauthorized, map[string]any{"provider": uc.provider.Name()})
+	}
+
+	email, err := types.NewEmail(identity.Email)
+	if err != nil {
+		return user.ExternalLoginOutput{FailureReason: user.LoginFailureProviderRejected},
+			nil, msg.NewMessageError(err, user.ErrExternalLoginRejected, msg.CodeUnauthorized, map[string]any{"provider": uc.provider.Name()})
+	}
+
+	output := user.ExternalLoginOutput{}
+	link := &user.LinkExternalIdentityRepoInput{}
+
+	u, err := uc.logins.FindUserByLogin(ctx, user.FindUserByLoginRepoInput{Email: email})
+	switch {
+	case err == nil:
+		output.User = u
+	case isNotFound(err):
+		u, err = user.NewExternalUser(user.NewExternalUserInput{Name: identity.Name, Email: email.String()})
+		if err != nil {
+			return user.ExternalLoginOutput{}, nil, err
+		}
+		output.User, output.Created = u, true
+		link.NewUser = u
+	default:
+		return user.ExternalLoginOutput{}, nil, keepMessageError(err)
+	}
+
+	link.Identity, err = user.NewExternalIdentity(u.ID, uc.provider.Name(), identity.Subject, email)
+	if err != nil {
+		return user.ExternalLoginOutput{}, nil, err
+	}
+
+	return output, link, nil
+}
+
+// providerError reports a disabled provider as not found; anything else is
+// an internal failure.
+func providerError(provider oidc.Provider, err error) error {
+	if errors.Is(err, oidc.ErrNotConfigured) {
+		return msg.NewMessageError(err, user.ErrExternalLoginDisabled, msg.CodeNotFound, map[string]any{"provider": provider.Name()})
+	}
+	return msg.NewInternalError(err, map[string]any{"provider": provider.Name()})
+}
+
+func invalidExternalLoginState() error {
+	return msg.NewMessageError(nil, user.ErrExternalLoginStateInvalid, msg.CodeUnauthorized, nil)
+}
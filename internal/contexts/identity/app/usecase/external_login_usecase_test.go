@@ -0,0 +1,328 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/usecase"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/hasher"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/oidc"
+)
+
+type mockOIDCProvider struct {
+	AuthURLFunc  func(ctx context.Context, input oidc.AuthURLInput) (string, error)
+	ExchangeFunc func(ctx context.Context, input oidc.ExchangeInput) (oidc.Identity, error)
+}
+
+func (m *mockOIDCProvider) Name() string { return user.ProviderGoogle }
+
+func (m *mockOIDCProvider) AuthURL(ctx context.Context, input oidc.AuthURLInput) (string, error) {
+	if m.AuthURLFunc != nil {
+		return m.AuthURLFunc(ctx, input)
+	}
+	return "https://accounts.example.com/authorize?state=" + input.State, nil
+}
+
+func (m *mockOIDCProvider) Exchange(ctx context.Context, input oidc.ExchangeInput) (oidc.Identity, error) {
+	if m.ExchangeFunc != nil {
+		return m.ExchangeFunc(ctx, input)
+	}
+	return oidc.Identity{}, oidc.ErrExchange
+}
+
+// mockExternalIdentityRepo keeps login states in memory and records links.
+type mockExternalIdentityRepo struct {
+	states     map[string]*user.ExternalLoginState
+	identities []*user.ExternalIdentity
+	links      []user.LinkExternalIdentityRepoInput
+}
+
+func newMockExternalIdentityRepo() *mockExternalIdentityRepo {
+	return &mockExternalIdentityRepo{states: map[string]*user.ExternalLoginState{}}
+}
+
+func (m *mockExternalIdentityRepo) FindExternalIdentity(ctx context.Context, input user.FindExternalIdentityRepoInput) (*user.ExternalIdentity, error) {
+	for _, identity := range m.identities {
+		if identity.Provider == input.Provider && identity.Subject == input.Subject {
+			return identity, nil
+		}
+	}
+	return nil, msg.NewMessageError(nil, user.ErrUserNotFound, msg.CodeNotFound, nil)
+}
+
+func (m *mockExternalIdentityRepo) LinkExternalIdentity(ctx context.Context, input user.LinkExternalIdentityRepoInput) error {
+	m.links = append(m.links, input)
+	m.identities = append(m.identities, input.Identity)
+	return nil
+}
+
+func (m *mockExternalIdentityRepo) SaveExternalLoginState(ctx context.Context, state *user.ExternalLoginState) error {
+	m.states[state.State] = state
+	return nil
+}
+
+func (m *mockExternalIdentityRepo) ConsumeExternalLoginState(ctx context.Context, state string) (*user.ExternalLoginState, error) {
+	s, ok := m.states[state]
+	if !ok {
+		return nil, msg.NewMessageError(nil, user.ErrExternalLoginStateInvalid, msg.CodeNotFound, nil)
+	}
+	delete(m.states, state)
+	return s, nil
+}
+
+func TestStartExternalLoginUseCase_Execute(t *testing.T) {
+	t.Run("Success: should store the state and return the provider URL bound to it", func(t *testing.T) {
+		repo := newMockExternalIdentityRepo()
+		var requested oidc.AuthURLInput
+		provider := &mockOIDCProvider{
+			AuthURLFunc: func(ctx context.Context, input oidc.AuthURLInput) (string, error) {
+				requested = input
+				return "https://accounts.example.com/authorize", nil
+			},
+		}
+		uc := usecase.NewStartExternalLoginUseCase(repo, provider)
+
+		output, err := uc.Execute(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, "https://accounts.example.com/authorize", output.AuthURL)
+		require.Contains(t, repo.states, output.State)
+		saved := repo.states[output.State]
+		assert.Equal(t, user.ProviderGoogle, saved.Provider)
+		assert.Equal(t, saved.State, requested.State)
+		assert.Equal(t, saved.Nonce, requested.Nonce)
+		assert.Equal(t, saved.CodeVerifier, requested.CodeVerifier)
+		assert.NotEqual(t, saved.State, saved.Nonce)
+		assert.WithinDuration(t, time.Now().Add(user.ExternalLoginStateTTL), output.ExpiresAt, time.Minute)
+	})
+
+	t.Run("Failure: should report a provider without credentials as not found", func(t *testing.T) {
+		repo := newMockExternalIdentityRepo()
+		provider := &mockOIDCProvider{
+			AuthURLFunc: func(ctx context.Context, input oidc.AuthURLInput) (string, error) {
+				return "", oidc.ErrNotConfigured
+			},
+		}
+		uc := usecase.NewStartExternalLoginUseCase(repo, provider)
+
+		_, err := uc.Execute(context.Background())
+
+		assertErrorCode(t, err, msg.CodeNotFound)
+		assert.Empty(t, repo.states)
+	})
+}
+
+func TestExternalLoginUseCase_Execute(t *testing.T) {
+	h := hasher.NewHasher()
+
+	googleIdentity := oidc.Identity{
+		Subject:       "google-sub-1",
+		Email:         "jane@example.com",
+		EmailVerified: true,
+		Name:          "Jane Doe",
+	}
+
+	type fixture struct {
+		repo     *mockExternalIdentityRepo
+		users    *mockFindUserByIDRepo
+		logins   *mockFindUserByLoginRepo
+		provider *mockOIDCProvider
+		state    *user.ExternalLoginState
+	}
+
+	// setup stores a pending state and a provider that answers with identity
+	// once it is given the verifier and nonce of that state.
+	setup := func(t *testing.T, identity oidc.Identity) *fixture {
+		t.Helper()
+		state, err := user.NewExternalLoginState(user.ProviderGoogle)
+		require.NoError(t, err)
+
+		f := &fixture{
+			repo:   newMockExternalIdentityRepo(),
+			users:  &mockFindUserByIDRepo{},
+			logins: &mockFindUserByLoginRepo{},
+			state:  state,
+		}
+		f.repo.states[state.State] = state
+		f.provider = &mockOIDCProvider{
+			ExchangeFunc: func(ctx context.Context, input oidc.ExchangeInput) (oidc.Identity, error) {
+				if input.CodeVerifier != state.CodeVerifier || input.Nonce != state.Nonce {
+					return oidc.Identity{}, oidc.ErrExchange
+				}
+				return identity, nil
+			},
+		}
+		return f
+	}
+
+	execute := func(f *fixture) (user.ExternalLoginOutput, error) {
+		uc := usecase.NewExternalLoginUseCase(f.repo, f.users, f.logins, &mockRefreshTokenRepo{}, &mockUserPermissionsRepo{}, h, &mockTokenIssuer{}, refreshConfig, identityConfig, f.provider)
+		return uc.Execute(context.Background(), user.ExternalLoginInput{State: f.state.State, Code: "code"})
+	}
+
+	t.Run("Success: should create and link a user on first sign-in", func(t *testing.T) {
+		f := setup(t, googleIdentity)
+
+		output, err := execute(f)
+		require.NoError(t, err)
+
+		assert.True(t, output.Created)
+		assert.Equal(t, "Jane Doe", output.User.Name)
+		assert.Equal(t, "jane@example.com", output.User.Email.String())
+		assert.False(t, output.User.HasPassword())
+		assert.True(t, output.User.Phone.IsEmpty())
+		assert.Equal(t, "signed-token", output.Session.AccessToken.Value)
+
+		require.Len(t, f.repo.links, 1)
+		assert.Same(t, output.User, f.repo.links[0].NewUser)
+		assert.Equal(t, output.User.ID, f.repo.links[0].Identity.UserID)
+		assert.Equal(t, "google-sub-1", f.repo.links[0].Identity.Subject)
+		assert.Empty(t, f.repo.states, "the state should be consumed")
+	})
+
+	t.Run("Success: should link an existing user with the same verified email", func(t *testing.T) {
+		f := setup(t, googleIdentity)
+		existing := newActiveUser()
+		existing.VerifyEmail()
+		f.logins.FindUserByLoginFunc = func(ctx context.Context, input user.FindUserByLoginRepoInput) (*user.User, error) {
+			assert.Equal(t, "jane@example.com", input.Email.String())
+			return existing, nil
+		}
+
+		output, err := execute(f)
+		require.NoError(t, err)
+
+		assert.False(t, output.Created)
+		assert.Equal(t, existing.ID, output.User.ID)
+		require.Len(t, f.repo.links, 1)
+		assert.Nil(t, f.repo.links[0].NewUser)
+		assert.Equal(t, existing.ID, f.repo.links[0].Identity.UserID)
+	})
+
+	t.Run("Success: should sign in the user of an already linked identity", func(t *testing.T) {
+		f := setup(t, googleIdentity)
+		existing := newActiveUser()
+		identity, err := user.NewExternalIdentity(existing.ID, user.ProviderGoogle, "google-sub-1", existing.Email)
+		require.NoError(t, err)
+		f.repo.identities = append(f.repo.identities, identity)
+		f.users.FindUserByIDFunc = func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+			return existing, nil
+		}
+
+		output, err := execute(f)
+		require.NoError(t, err)
+
+		assert.Equal(t, existing.ID, output.User.ID)
+		assert.False(t, output.Created)
+		assert.Empty(t, f.repo.links)
+	})
+
+	t.Run("Failure: should reject a state that is unknown or already used", func(t *testing.T) {
+		f := setup(t, googleIdentity)
+		delete(f.repo.states, f.state.State)
+		f.provider.ExchangeFunc = func(ctx context.Context, input oidc.ExchangeInput) (oidc.Identity, error) {
+			t.Fatal("The code should not be redeemed without a valid state")
+			return oidc.Identity{}, nil
+		}
+
+		output, err := execute(f)
+
+		assertErrorCode(t, err, msg.CodeUnauthorized)
+		assert.Empty(t, output.FailureReason)
+	})
+
+	t.Run("Failure: should reject an expired state", func(t *testing.T) {
+		f := setup(t, googleIdentity)
+		f.state.ExpiresAt = time.Now().Add(-time.Second)
+
+		_, err := execute(f)
+
+		assertErrorCode(t, err, msg.CodeUnauthorized)
+		assert.Empty(t, f.repo.links)
+	})
+
+	t.Run("Failure: should reject a code the provider does not confirm", func(t *testing.T) {
+		f := setup(t, googleIdentity)
+		f.provider.ExchangeFunc = func(ctx context.Context, input oidc.ExchangeInput) (oidc.Identity, error) {
+			return oidc.Identity{}, fmt.Errorf("%w: nonce mismatch", oidc.ErrExchange)
+		}
+
+		output, err := execute(f)
+
+		assertErrorCode(t, err, msg.CodeUnauthorized)
+		assert.Equal(t, user.LoginFailureProviderRejected, output.FailureReason)
+	})
+
+	t.Run("Failure: should not link an unverified email", func(t *testing.T) {
+		unverified := googleIdentity
+		unverified.EmailVerified = false
+		f := setup(t, unverified)
+		f.logins.FindUserByLoginFunc = func(ctx context.Context, input user.FindUserByLoginRepoInput) (*user.User, error) {
+			t.Fatal("An unverified email should not be looked up")
+			return nil, nil
+		}
+
+		output, err := execute(f)
+
+		assertErrorCode(t, err, msg.CodeUnauthorized)
+		assert.Equal(t, user.LoginFailureEmailUnverified, output.FailureReason)
+		assert.Empty(t, f.repo.links)
+	})
+
+	t.Run("Failure: should not link a local user whose email is unverified", func(t *testing.T) {
+		f := setup(t, googleIdentity)
+		existing := newActiveUser()
+		f.logins.FindUserByLoginFunc = func(ctx context.Context, input user.FindUserByLoginRepoInput) (*user.User, error) {
+			return existing, nil
+		}
+
+		output, err := execute(f)
+
+		assertErrorCode(t, err, msg.CodeConflict)
+		assert.Equal(t, user.LoginFailureLocalUnverified, output.FailureReason)
+		assert.Empty(t, output.Session.AccessToken.Value)
+		assert.Empty(t, f.repo.links)
+	})
+
+	t.Run("Failure: should reject a deleted user without linking it", func(t *testing.T) {
+		f := setup(t, googleIdentity)
+		deleted := newActiveUser()
+		deleted.Delete()
+		f.logins.FindUserByLoginFunc = func(ctx context.Context, input user.FindUserByLoginRepoInput) (*user.User, error) {
+			return deleted, nil
+		}
+
+		output, err := execute(f)
+
+		assertErrorCode(t, err, msg.CodeUnauthorized)
+		assert.Equal(t, user.LoginFailureUserDeleted, output.FailureReason)
+		assert.Equal(t, deleted.ID, output.User.ID)
+		assert.Empty(t, f.repo.links)
+	})
+
+	t.Run("Failure: should forbid an archived user", func(t *testing.T) {
+		f := setup(t, googleIdentity)
+		archived := newActiveUser()
+		archived.Archive()
+		identity, err := user.NewExternalIdentity(archived.ID, user.ProviderGoogle, "google-sub-1", archived.Email)
+		require.NoError(t, err)
+		f.repo.identities = append(f.repo.identities, identity)
+		f.users.FindUserByIDFunc = func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+			assert.True(t, input.IncludeDeleted)
+			return archived, nil
+		}
+
+		output, err := execute(f)
+
+		assertErrorCode(t, err, msg.CodeForbidden)
+		assert.Equal(t, user.LoginFailureUserArchived, output.FailureReason)
+		assert.Empty(t, output.Session.AccessToken.Value)
+	})
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/pii"
+)
+
+type forgetUserUseCase struct {
+	repo     user.UpdateUserStatusRepository
+	shredder pii.Shredder
+}
+
+func NewForgetUserUseCase(repo user.UpdateUserStatusRepository, shredder pii.Shredder) user.ForgetUserUseCase {
+	return &forgetUserUseCase{
+		repo:     repo,
+		shredder: shredder,
+	}
+}
+
+// Execute only forgets soft-deleted users, since an active user still
+// publishes events that need its key. The user is marked before the key is
+// destroyed, so a retry after a failed shred finishes the job.
+func (uc *forgetUserUseCase) Execute(ctx context.Context, input user.ForgetUserUseCaseInput) error {
+	u, err := uc.repo.FindUserByID(ctx, user.FindUserByIDRepoInput{UserID: input.UserID, IncludeDeleted: true})
+	if err != nil {
+		return keepMessageError(err)
+	}
+
+	if !u.IsDeleted() {
+		return msg.NewMessageError(nil, user.ErrUserForgetNotDeleted, msg.CodeConflict, map[string]any{"user_id": input.UserID.String()})
+	}
+
+	if !u.IsForgotten() {
+		loadedVersion := u.Version
+		u.Forget()
+
+		if err := uc.repo.UpdateUserStatus(ctx, user.UpdateUserRepoInput{User: u, ExpectedVersion: loadedVersion}); err != nil {
+			return keepMessageError(err)
+		}
+	}
+
+	if err := uc.shredder.ForgetSubject(ctx, input.UserID); err != nil {
+		return msg.NewInternalError(err, map[string]any{"user_id": input.UserID.String()})
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/usecase"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type mockShredder struct {
+	ForgetSubjectFunc func(ctx context.Context, subjectID types.UUID) error
+	forgotten         []types.UUID
+}
+
+func (m *mockShredder) ForgetSubject(ctx context.Context, subjectID types.UUID) error {
+	if m.ForgetSubjectFunc != nil {
+		if err := m.ForgetSubjectFunc(ctx, subjectID); err != nil {
+			return err
+		}
+	}
+	m.forgotten = append(m.forgotten, subjectID)
+	return nil
+}
+
+func TestForgetUserUseCase_Execute(t *testing.T) {
+	t.Run("Success: should mark a deleted user as forgotten and destroy its key", func(t *testing.T) {
+		stored := newActiveUser()
+		stored.Delete()
+		var saved *user.User
+		mockRepo := &mockUpdateUserStatusRepo{
+			FindUserByIDFunc: func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+				assert.True(t, input.IncludeDeleted, "Forget should find soft-deleted users")
+				return stored, nil
+			},
+			UpdateUserStatusFunc: func(ctx context.Context, input user.UpdateUserRepoInput) error {
+				saved = input.User
+				assert.Equal(t, types.Version(2), input.ExpectedVersion)
+				return nil
+			},
+		}
+		shredder := &mockShredder{}
+		uc := usecase.NewForgetUserUseCase(mockRepo, shredder)
+
+		err := uc.Execute(context.Background(), user.ForgetUserUseCaseInput{UserID: stored.ID})
+
+		require.NoError(t, err)
+		require.NotNil(t, saved)
+		assert.True(t, saved.IsForgotten())
+		assert.Equal(t, []types.UUID{stored.ID}, shredder.forgotten)
+	})
+
+	t.Run("Success: should destroy the key again for an already forgotten user", func(t *testing.T) {
+		stored := newActiveUser()
+		stored.Delete()
+		stored.Forget()
+		mockRepo := &mockUpdateUserStatusRepo{
+			FindUserByIDFunc: func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+				return stored, nil
+			},
+			UpdateUserStatusFunc: func(ctx context.Context, input user.UpdateUserRepoInput) error {
+				t.Fatal("UpdateUserStatus should not be called for a forgotten user")
+				return nil
+			},
+		}
+		shredder := &mockShredder{}
+		uc := usecase.NewForgetUserUseCase(mockRepo, shredder)
+
+		err := uc.Execute(context.Background(), user.ForgetUserUseCaseInput{UserID: stored.ID})
+
+		require.NoError(t, err)
+		assert.Len(t, shredder.forgotten, 1)
+	})
+
+	t.Run("Failure: should refuse to forget an active user", func(t *testing.T) {
+		stored := newActiveUser()
+		mockRepo := &mockUpdateUserStatusRepo{
+			FindUserByIDFunc: func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+				return stored, nil
+			},
+		}
+		shredder := &mockShredder{}
+		uc := usecase.NewForgetUserUseCase(mockRepo, shredder)
+
+		err := uc.Execute(context.Background(), user.ForgetUserUseCaseInput{UserID: stored.ID})
+
+		var msgErr *msg.MessageError
+		require.True(t, errors.As(err, &msgErr))
+		assert.Equal(t, msg.CodeConflict, msgErr.Code)
+		assert.Equal(t, user.ErrUserForgetNotDeleted, msgErr.Message)
+		assert.Empty(t, shredder.forgotten, "The key of an active user must be kept")
+	})
+}
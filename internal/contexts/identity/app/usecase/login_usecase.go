@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/role"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/config"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/hasher"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/token"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+// dummyPassword is hashed once and compared against when the identifier
+// matches nobody, so unknown users take as long to reject as wrong passwords.
+const dummyPassword = "Dummy-Password-0"
+
+type loginUseCase struct {
+	repo     user.FindUserByLoginRepository
+	sessions sessionIssuer
+	hasher   hasher.Hasher
+
+	requireVerifiedEmail bool
+
+	dummyOnce sync.Once
+	dummyHash types.HashedPassword
+}
+
+func NewLoginUseCase(
+	repo user.FindUserByLoginRepository,
+	tokens user.CreateRefreshTokenRepository,
+	permissions role.UserPermissionsRepository,
+	h hasher.Hasher,
+	issuer token.Issuer,
+	cfg config.RefreshTokenConfig,
+	identity config.IdentityConfig,
+	verification config.EmailVerificationConfig,
+) user.LoginUseCase {
+	return &loginUseCase{
+		repo:                 repo,
+		sessions:             newSessionIssuer(tokens, permissions, h, issuer, cfg, identity),
+		hasher:               h,
+		requireVerifiedEmail: verification.Required,
+	}
+}
+
+func (uc *loginUseCase) Execute(ctx context.Context, input user.LoginInput) (user.LoginOutput, error) {
+	password := types.Password(input.Password)
+
+	repoInput, ok := loginRepoInput(input)
+	if !ok {
+		uc.compareDummy(password)
+		return user.LoginOutput{FailureReason: user.LoginFailureUnknownUser}, invalidCredentials()
+	}
+
+	u, err := uc.repo.FindUserByLogin(ctx, repoInput)
+	if err != nil {
+		var msgErr *msg.MessageError
+		if errors.As(err, &msgErr) && msgErr.Code == msg.CodeNotFound {
+			uc.compareDummy(password)
+			return user.LoginOutput{FailureReason: user.LoginFailureUnknownUser}, invalidCredentials()
+		}
+		return user.LoginOutput{}, keepMessageError(err)
+	}
+
+	if !u.HasPassword() {
+		uc.compareDummy(password)
+		return user.LoginOutput{User: u, FailureReason: user.LoginFailurePasswordNotSet}, invalidCredentials()
+	}
+
+	match, err := u.ComparePassword(password, uc.hasher)
+	if err != nil {
+		return user.LoginOutput{}, msg.NewInternalError(err, map[string]any{"user_id": u.ID.String()})
+	}
+	if !match {
+		return user.LoginOutput{User: u, FailureReason: user.LoginFailureInvalidPassword}, invalidCredentials()
+	}
+
+	if u.IsDeleted() {
+		return user.LoginOutput{User: u, FailureReason: user.LoginFailureUserDeleted}, invalidCredentials()
+	}
+	if u.IsArchived() {
+		return user.LoginOutput{User: u, FailureReason: user.LoginFailureUserArchived},
+			msg.NewMessageError(nil, user.ErrLoginUserArchived, msg.CodeForbidden, nil)
+	}
+	if uc.requireVerifiedEmail && !u.IsEmailVerified() {
+		return user.LoginOutput{User: u, FailureReason: user.LoginFailureEmailUnverified},
+			msg.NewMessageError(nil, user.ErrLoginEmailUnverified, msg.CodeForbidden, nil)
+	}
+
+	session, err := uc.sessions.start(ctx, u.ID)
+	if err != nil {
+		return user.LoginOutput{}, err
+	}
+
+	return user.LoginOutput{User: u, Session: session}, nil
+}
+
+// loginRepoInput reports false when the identifier is malformed and so cannot
+// belong to any user.
+func loginRepoInput(input user.LoginInput) (user.FindUserByLoginRepoInput, bool) {
+	if input.Email != "" {
+		email, err := types.NewEmail(input.Email)
+		return user.FindUserByLoginRepoInput{Email: email}, err == nil
+	}
+	phone, err := types.NewPhone(input.Phone)
+	return user.FindUserByLoginRepoInput{Phone: phone}, err == nil
+}
+
+func (uc *loginUseCase) compareDummy(password types.Password) {
+	uc.dummyOnce.Do(func() {
+		hash, err := uc.hasher.Hash(dummyPassword)
+		if err == nil {
+			uc.dummyHash = types.NewHashedPassword(hash)
+		}
+	})
+	if !uc.dummyHash.IsEmpty() {
+		_, _ = uc.dummyHash.Compare(password, uc.hasher)
+	}
+}
+
+func invalidCredentials() error {
+	return msg.NewMessageError(nil, user.ErrLoginInvalidCredentials, msg.CodeUnauthorized, nil)
+}
@@ -0,0 +1,293 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/usecase"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/hasher"
+	"github.com/marcelofabianov/redtogreen/internal/platform/config"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/token"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type mockFindUserByLoginRepo struct {
+	FindUserByLoginFunc func(ctx context.Context, input user.FindUserByLoginRepoInput) (*user.User, error)
+}
+
+func (m *mockFindUserByLoginRepo) FindUserByLogin(ctx context.Context, input user.FindUserByLoginRepoInput) (*user.User, error) {
+	if m.FindUserByLoginFunc != nil {
+		return m.FindUserByLoginFunc(ctx, input)
+	}
+	return nil, msg.NewMessageError(nil, user.ErrUserNotFound, msg.CodeNotFound, nil)
+}
+
+type mockTokenIssuer struct {
+	IssueFunc func(ctx context.Context, input token.IssueInput) (token.AccessToken, error)
+}
+
+func (m *mockTokenIssuer) Issue(ctx context.Context, input token.IssueInput) (token.AccessToken, error) {
+	if m.IssueFunc != nil {
+		return m.IssueFunc(ctx, input)
+	}
+	return token.AccessToken{
+		Value:     "signed-token",
+		Type:      token.TypeBearer,
+		ExpiresAt: time.Now().Add(time.Hour),
+		Claims:    token.Claims{ID: "jti", Subject: input.Subject, TenantID: input.TenantID, Permissions: input.Permissions},
+	}, nil
+}
+
+type mockRefreshTokenRepo struct {
+	CreateRefreshTokenFunc   func(ctx context.Context, input user.CreateRefreshTokenRepoInput) error
+	FindRefreshTokenByIDFunc func(ctx context.Context, id types.UUID) (*user.RefreshToken, error)
+	RotateRefreshTokenFunc   func(ctx context.Context, input user.RotateRefreshTokenRepoInput) error
+	RevokeRefreshTokensFunc  func(ctx context.Context, input user.RevokeRefreshTokensRepoInput) (int, error)
+}
+
+func (m *mockRefreshTokenRepo) CreateRefreshToken(ctx context.Context, input user.CreateRefreshTokenRepoInput) error {
+	if m.CreateRefreshTokenFunc != nil {
+		return m.CreateRefreshTokenFunc(ctx, input)
+	}
+	return nil
+}
+
+func (m *mockRefreshTokenRepo) FindRefreshTokenByID(ctx context.Context, id types.UUID) (*user.RefreshToken, error) {
+	if m.FindRefreshTokenByIDFunc != nil {
+		return m.FindRefreshTokenByIDFunc(ctx, id)
+	}
+	return nil, msg.NewMessageError(nil, user.ErrRefreshTokenInvalid, msg.CodeNotFound, nil)
+}
+
+func (m *mockRefreshTokenRepo) RotateRefreshToken(ctx context.Context, input user.RotateRefreshTokenRepoInput) error {
+	if m.RotateRefreshTokenFunc != nil {
+		return m.RotateRefreshTokenFunc(ctx, input)
+	}
+	return nil
+}
+
+func (m *mockRefreshTokenRepo) RevokeRefreshTokens(ctx context.Context, input user.RevokeRefreshTokensRepoInput) (int, error) {
+	if m.RevokeRefreshTokensFunc != nil {
+		return m.RevokeRefreshTokensFunc(ctx, input)
+	}
+	return 0, nil
+}
+
+type mockUserPermissionsRepo struct {
+	FindUserPermissionsFunc func(ctx context.Context, userID types.UUID) ([]string, error)
+}
+
+func (m *mockUserPermissionsRepo) FindUserPermissions(ctx context.Context, userID types.UUID) ([]string, error) {
+	if m.FindUserPermissionsFunc != nil {
+		return m.FindUserPermissionsFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+var refreshConfig = config.RefreshTokenConfig{ExpiryHours: 24}
+
+var identityConfig = config.IdentityConfig{TenantID: "tenant-a"}
+
+func assertErrorCode(t *testing.T, err error, code msg.ErrorCode) {
+	t.Helper()
+	var msgErr *msg.MessageError
+	require.True(t, errors.As(err, &msgErr), "Error should be a MessageError")
+	assert.Equal(t, code, msgErr.Code)
+}
+
+func TestLoginUseCase_Execute(t *testing.T) {
+	h := hasher.NewHasher()
+	const password = "ValidPassword123!"
+
+	stored, err := user.NewUser(user.NewUserInput{
+		Name:     "Test User",
+		Email:    "test@example.com",
+		Phone:    "5562999998888",
+		Password: password,
+	}, h)
+	require.NoError(t, err)
+
+	repoReturning := func(u *user.User) *mockFindUserByLoginRepo {
+		return &mockFindUserByLoginRepo{
+			FindUserByLoginFunc: func(ctx context.Context, input user.FindUserByLoginRepoInput) (*user.User, error) {
+				return u, nil
+			},
+		}
+	}
+
+	t.Run("Success: should issue a token for the right email and password", func(t *testing.T) {
+		var lookedUp user.FindUserByLoginRepoInput
+		repo := &mockFindUserByLoginRepo{
+			FindUserByLoginFunc: func(ctx context.Context, input user.FindUserByLoginRepoInput) (*user.User, error) {
+				lookedUp = input
+				return stored, nil
+			},
+		}
+		var created *user.RefreshToken
+		tokens := &mockRefreshTokenRepo{
+			CreateRefreshTokenFunc: func(ctx context.Context, input user.CreateRefreshTokenRepoInput) error {
+				created = input.Token
+				return nil
+			},
+		}
+		uc := usecase.NewLoginUseCase(repo, tokens, &mockUserPermissionsRepo{}, h, &mockTokenIssuer{}, refreshConfig, identityConfig, verificationConfig)
+
+		output, err := uc.Execute(context.Background(), user.LoginInput{Email: "Test@Example.com", Password: password})
+
+		require.NoError(t, err)
+		assert.Equal(t, "test@example.com", lookedUp.Email.String(), "Email should be normalized before the lookup")
+		assert.Equal(t, stored.ID, output.Session.AccessToken.Claims.Subject)
+		assert.Equal(t, "tenant-a", output.Session.AccessToken.Claims.TenantID, "The token should be scoped to the deployment tenant")
+		require.NotNil(t, created, "Login should store a refresh token")
+		assert.Equal(t, stored.ID, created.UserID)
+		assert.Equal(t, created.ID, created.FamilyID, "Login should start a new session")
+		assert.Equal(t, created.FamilyID, output.Session.RefreshToken.FamilyID)
+		assert.NotEmpty(t, output.Session.RefreshToken.Value)
+		assert.Empty(t, output.FailureReason)
+	})
+
+	t.Run("Success: should grant the permissions of the user roles", func(t *testing.T) {
+		permissions := &mockUserPermissionsRepo{
+			FindUserPermissionsFunc: func(ctx context.Context, userID types.UUID) ([]string, error) {
+				assert.Equal(t, stored.ID, userID)
+				return []string{"users:read"}, nil
+			},
+		}
+		uc := usecase.NewLoginUseCase(repoReturning(stored), &mockRefreshTokenRepo{}, permissions, h, &mockTokenIssuer{}, refreshConfig, identityConfig, verificationConfig)
+
+		output, err := uc.Execute(context.Background(), user.LoginInput{Email: "test@example.com", Password: password})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"users:read"}, output.Session.AccessToken.Claims.Permissions)
+	})
+
+	t.Run("Success: should log in by phone", func(t *testing.T) {
+		var lookedUp user.FindUserByLoginRepoInput
+		repo := &mockFindUserByLoginRepo{
+			FindUserByLoginFunc: func(ctx context.Context, input user.FindUserByLoginRepoInput) (*user.User, error) {
+				lookedUp = input
+				return stored, nil
+			},
+		}
+		uc := usecase.NewLoginUseCase(repo, &mockRefreshTokenRepo{}, &mockUserPermissionsRepo{}, h, &mockTokenIssuer{}, refreshConfig, identityConfig, verificationConfig)
+
+		_, err := uc.Execute(context.Background(), user.LoginInput{Phone: "5562999998888", Password: password})
+
+		require.NoError(t, err)
+		assert.True(t, lookedUp.Email.IsEmpty())
+		assert.Equal(t, stored.Phone, lookedUp.Phone)
+	})
+
+	t.Run("Failure: should reject an unknown user as invalid credentials", func(t *testing.T) {
+		uc := usecase.NewLoginUseCase(&mockFindUserByLoginRepo{}, &mockRefreshTokenRepo{}, &mockUserPermissionsRepo{}, h, &mockTokenIssuer{}, refreshConfig, identityConfig, verificationConfig)
+
+		output, err := uc.Execute(context.Background(), user.LoginInput{Email: "nobody@example.com", Password: password})
+
+		assertErrorCode(t, err, msg.CodeUnauthorized)
+		assert.Equal(t, user.LoginFailureUnknownUser, output.FailureReason)
+		assert.Nil(t, output.User)
+	})
+
+	t.Run("Failure: should reject a wrong password as invalid credentials", func(t *testing.T) {
+		uc := usecase.NewLoginUseCase(repoReturning(stored), &mockRefreshTokenRepo{}, &mockUserPermissionsRepo{}, h, &mockTokenIssuer{}, refreshConfig, identityConfig, verificationConfig)
+
+		output, err := uc.Execute(context.Background(), user.LoginInput{Email: "test@example.com", Password: "WrongPassword123!"})
+
+		assertErrorCode(t, err, msg.CodeUnauthorized)
+		assert.Equal(t, user.LoginFailureInvalidPassword, output.FailureReason)
+		assert.Equal(t, stored.ID, output.User.ID)
+	})
+
+	t.Run("Failure: should reject a user without password as invalid credentials", func(t *testing.T) {
+		external, err := user.NewExternalUser(user.NewExternalUserInput{Name: "Google User", Email: "test@example.com"})
+		require.NoError(t, err)
+		uc := usecase.NewLoginUseCase(repoReturning(external), &mockRefreshTokenRepo{}, &mockUserPermissionsRepo{}, h, &mockTokenIssuer{}, refreshConfig, identityConfig, verificationConfig)
+
+		output, err := uc.Execute(context.Background(), user.LoginInput{Email: "test@example.com", Password: password})
+
+		assertErrorCode(t, err, msg.CodeUnauthorized)
+		assert.Equal(t, user.LoginFailurePasswordNotSet, output.FailureReason)
+		assert.Equal(t, external.ID, output.User.ID)
+	})
+
+	t.Run("Failure: should reject a deleted user as invalid credentials", func(t *testing.T) {
+		deleted := *stored
+		deleted.Delete()
+		uc := usecase.NewLoginUseCase(repoReturning(&deleted), &mockRefreshTokenRepo{}, &mockUserPermissionsRepo{}, h, &mockTokenIssuer{}, refreshConfig, identityConfig, verificationConfig)
+
+		output, err := uc.Execute(context.Background(), user.LoginInput{Email: "test@example.com", Password: password})
+
+		assertErrorCode(t, err, msg.CodeUnauthorized)
+		assert.Equal(t, user.LoginFailureUserDeleted, output.FailureReason)
+	})
+
+	t.Run("Failure: should forbid an archived user", func(t *testing.T) {
+		archived := *stored
+		archived.Archive()
+		issuer := &mockTokenIssuer{
+			IssueFunc: func(ctx context.Context, input token.IssueInput) (token.AccessToken, error) {
+				t.Fatal("Archived users should not get a token")
+				return token.AccessToken{}, nil
+			},
+		}
+		uc := usecase.NewLoginUseCase(repoReturning(&archived), &mockRefreshTokenRepo{}, &mockUserPermissionsRepo{}, h, issuer, refreshConfig, identityConfig, verificationConfig)
+
+		output, err := uc.Execute(context.Background(), user.LoginInput{Email: "test@example.com", Password: password})
+
+		assertErrorCode(t, err, msg.CodeForbidden)
+		assert.Equal(t, user.LoginFailureUserArchived, output.FailureReason)
+	})
+
+	t.Run("Failure: should forbid an unverified email when verification is required", func(t *testing.T) {
+		required := verificationConfig
+		required.Required = true
+		uc := usecase.NewLoginUseCase(repoReturning(stored), &mockRefreshTokenRepo{}, &mockUserPermissionsRepo{}, h, &mockTokenIssuer{}, refreshConfig, identityConfig, required)
+
+		output, err := uc.Execute(context.Background(), user.LoginInput{Email: "test@example.com", Password: password})
+
+		assertErrorCode(t, err, msg.CodeForbidden)
+		assert.Equal(t, user.LoginFailureEmailUnverified, output.FailureReason)
+
+		verified := *stored
+		verified.VerifyEmail()
+		uc = usecase.NewLoginUseCase(repoReturning(&verified), &mockRefreshTokenRepo{}, &mockUserPermissionsRepo{}, h, &mockTokenIssuer{}, refreshConfig, identityConfig, required)
+
+		_, err = uc.Execute(context.Background(), user.LoginInput{Email: "test@example.com", Password: password})
+		require.NoError(t, err)
+	})
+
+	t.Run("Failure: should return internal error when the repository fails", func(t *testing.T) {
+		repo := &mockFindUserByLoginRepo{
+			FindUserByLoginFunc: func(ctx context.Context, input user.FindUserByLoginRepoInput) (*user.User, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		uc := usecase.NewLoginUseCase(repo, &mockRefreshTokenRepo{}, &mockUserPermissionsRepo{}, h, &mockTokenIssuer{}, refreshConfig, identityConfig, verificationConfig)
+
+		output, err := uc.Execute(context.Background(), user.LoginInput{Email: "test@example.com", Password: password})
+
+		assertErrorCode(t, err, msg.CodeInternal)
+		assert.Empty(t, output.FailureReason, "Infrastructure errors are not login failures")
+	})
+
+	t.Run("Failure: should return internal error when the token cannot be issued", func(t *testing.T) {
+		issuer := &mockTokenIssuer{
+			IssueFunc: func(ctx context.Context, input token.IssueInput) (token.AccessToken, error) {
+				return token.AccessToken{}, errors.New("signing failed")
+			},
+		}
+		uc := usecase.NewLoginUseCase(repoReturning(stored), &mockRefreshTokenRepo{}, &mockUserPermissionsRepo{}, h, issuer, refreshConfig, identityConfig, verificationConfig)
+
+		_, err := uc.Execute(context.Background(), user.LoginInput{Email: "test@example.com", Password: password})
+
+		assertErrorCode(t, err, msg.CodeInternal)
+	})
+
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/hasher"
+)
+
+type logoutUseCase struct {
+	tokens user.RefreshTokenRepository
+	hasher hasher.Hasher
+}
+
+func NewLogoutUseCase(tokens user.RefreshTokenRepository, h hasher.Hasher) user.LogoutUseCase {
+	return &logoutUseCase{
+		tokens: tokens,
+		hasher: h,
+	}
+}
+
+func (uc *logoutUseCase) Execute(ctx context.Context, input user.LogoutInput) error {
+	t, err := findRefreshToken(ctx, uc.tokens, uc.hasher, input.RefreshToken)
+	if err != nil {
+		var msgErr *msg.MessageError
+		if errors.As(err, &msgErr) && msgErr.Code == msg.CodeUnauthorized {
+			return nil
+		}
+		return err
+	}
+
+	if _, err := uc.tokens.RevokeRefreshTokens(ctx, user.RevokeRefreshTokensRepoInput{FamilyID: t.FamilyID}); err != nil {
+		return keepMessageError(err)
+	}
+	return nil
+}
+
+type logoutAllUseCase struct {
+	tokens user.RefreshTokenRepository
+}
+
+func NewLogoutAllUseCase(tokens user.RefreshTokenRepository) user.LogoutAllUseCase {
+	return &logoutAllUseCase{
+		tokens: tokens,
+	}
+}
+
+func (uc *logoutAllUseCase) Execute(ctx context.Context, input user.LogoutAllInput) (user.LogoutAllOutput, error) {
+	revoked, err := uc.tokens.RevokeRefreshTokens(ctx, user.RevokeRefreshTokensRepoInput{UserID: input.UserID})
+	if err != nil {
+		return user.LogoutAllOutput{}, keepMessageError(err)
+	}
+	return user.LogoutAllOutput{Revoked: revoked}, nil
+}
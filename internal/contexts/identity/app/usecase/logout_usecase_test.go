@@ -0,0 +1,74 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/usecase"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/hasher"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+func TestLogoutUseCase_Execute(t *testing.T) {
+	h := hasher.NewHasher()
+
+	t.Run("Success: should revoke the family of the token", func(t *testing.T) {
+		stored := newStoredRefreshToken(t, h, types.MustNewUUID())
+		uc := usecase.NewLogoutUseCase(stored.repo(), h)
+
+		err := uc.Execute(context.Background(), user.LogoutInput{RefreshToken: stored.value})
+
+		require.NoError(t, err)
+		require.Len(t, stored.revoked, 1)
+		assert.Equal(t, stored.token.FamilyID, stored.revoked[0].FamilyID)
+	})
+
+	t.Run("Success: should ignore unknown and malformed tokens", func(t *testing.T) {
+		stored := newStoredRefreshToken(t, h, types.MustNewUUID())
+		uc := usecase.NewLogoutUseCase(stored.repo(), h)
+
+		require.NoError(t, uc.Execute(context.Background(), user.LogoutInput{RefreshToken: "not-a-token"}))
+		require.NoError(t, uc.Execute(context.Background(), user.LogoutInput{RefreshToken: stored.token.ID.String() + ".forged"}))
+		assert.Empty(t, stored.revoked)
+	})
+
+	t.Run("Failure: should return internal error when the lookup fails", func(t *testing.T) {
+		stored := newStoredRefreshToken(t, h, types.MustNewUUID())
+		repo := stored.repo()
+		repo.FindRefreshTokenByIDFunc = func(ctx context.Context, id types.UUID) (*user.RefreshToken, error) {
+			return nil, errors.New("connection refused")
+		}
+		uc := usecase.NewLogoutUseCase(repo, h)
+
+		err := uc.Execute(context.Background(), user.LogoutInput{RefreshToken: stored.value})
+
+		assertErrorCode(t, err, msg.CodeInternal)
+	})
+}
+
+func TestLogoutAllUseCase_Execute(t *testing.T) {
+	t.Run("Success: should revoke every session of the user", func(t *testing.T) {
+		userID := types.MustNewUUID()
+		var revoked user.RevokeRefreshTokensRepoInput
+		repo := &mockRefreshTokenRepo{
+			RevokeRefreshTokensFunc: func(ctx context.Context, input user.RevokeRefreshTokensRepoInput) (int, error) {
+				revoked = input
+				return 3, nil
+			},
+		}
+		uc := usecase.NewLogoutAllUseCase(repo)
+
+		output, err := uc.Execute(context.Background(), user.LogoutAllInput{UserID: userID})
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, output.Revoked)
+		assert.Equal(t, userID, revoked.UserID)
+		assert.True(t, revoked.FamilyID.IsNil())
+	})
+}
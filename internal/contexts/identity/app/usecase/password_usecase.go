@@ -0,0 +1,240 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/config"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/database"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/hasher"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type changePasswordUseCase struct {
+	repo   user.UpdateUserPasswordRepository
+	tokens user.RefreshTokenRepository
+	hasher hasher.Hasher
+}
+
+func NewChangePasswordUseCase(
+	repo user.UpdateUserPasswordRepository,
+	tokens user.RefreshTokenRepository,
+	h hasher.Hasher,
+) user.ChangePasswordUseCase {
+	return &changePasswordUseCase{
+		repo:   repo,
+		tokens: tokens,
+		hasher: h,
+	}
+}
+
+func (uc *changePasswordUseCase) Execute(ctx context.Context, input user.ChangePasswordInput) (user.PasswordChangedOutput, error) {
+	u, err := uc.repo.FindUserByID(ctx, user.FindUserByIDRepoInput{UserID: input.UserID})
+	if err != nil {
+		return user.PasswordChangedOutput{}, keepMessageError(err)
+	}
+	if u.IsArchived() {
+		return user.PasswordChangedOutput{}, msg.NewMessageError(nil, user.ErrLoginUserArchived, msg.CodeForbidden, nil)
+	}
+	if !u.HasPassword() {
+		return user.PasswordChangedOutput{}, msg.NewMessageError(nil, user.ErrPasswordNotSet, msg.CodeConflict, map[string]any{"user_id": u.ID.String()})
+	}
+
+	match, err := u.ComparePassword(types.Password(input.CurrentPassword), uc.hasher)
+	if err != nil {
+		return user.PasswordChangedOutput{}, msg.NewInternalError(err, map[string]any{"user_id": u.ID.String()})
+	}
+	if !match {
+		return user.PasswordChangedOutput{}, msg.NewValidationError(nil, map[string]any{"field": "current_password"}, user.ErrPasswordCurrentInvalid)
+	}
+
+	return storePassword(ctx, uc.repo, uc.tokens, uc.hasher, u, input.NewPassword)
+}
+
+type requestPasswordResetUseCase struct {
+	repo   user.FindUserByLoginRepository
+	resets user.PasswordResetTokenRepository
+	hasher hasher.Hasher
+	ttl    time.Duration
+}
+
+func NewRequestPasswordResetUseCase(
+	repo user.FindUserByLoginRepository,
+	resets user.PasswordResetTokenRepository,
+	h hasher.Hasher,
+	cfg config.PasswordResetConfig,
+) user.RequestPasswordResetUseCase {
+	return &requestPasswordResetUseCase{
+		repo:   repo,
+		resets: resets,
+		hasher: h,
+		ttl:    time.Duration(cfg.ExpiryMinutes) * time.Minute,
+	}
+}
+
+func (uc *requestPasswordResetUseCase) Execute(ctx context.Context, input user.RequestPasswordResetInput) (user.RequestPasswordResetOutput, error) {
+	email, err := types.NewEmail(input.Email)
+	if err != nil {
+		return user.RequestPasswordResetOutput{}, nil
+	}
+
+	u, err := uc.repo.FindUserByLogin(ctx, user.FindUserByLoginRepoInput{Email: email})
+	if err != nil {
+		if isNotFound(err) {
+			return user.RequestPasswordResetOutput{}, nil
+		}
+		return user.RequestPasswordResetOutput{}, keepMessageError(err)
+	}
+	if u.IsDeleted() || u.IsArchived() {
+		return user.RequestPasswordResetOutput{}, nil
+	}
+
+	t, issued, err := user.NewPasswordResetToken(u.ID, uc.ttl, uc.hasher)
+	if err != nil {
+		return user.RequestPasswordResetOutput{}, err
+	}
+	if err := uc.resets.CreatePasswordResetToken(ctx, t); err != nil {
+		return user.RequestPasswordResetOutput{}, keepMessageError(err)
+	}
+
+	return user.RequestPasswordResetOutput{User: u, Token: issued}, nil
+}
+
+type resetPasswordUseCase struct {
+	db     database.DB
+	repo   user.UpdateUserPasswordRepository
+	resets user.PasswordResetTokenRepository
+	tokens user.RefreshTokenRepository
+	hasher hasher.Hasher
+}
+
+func NewResetPasswordUseCase(
+	db database.DB,
+	repo user.UpdateUserPasswordRepository,
+	resets user.PasswordResetTokenRepository,
+	tokens user.RefreshTokenRepository,
+	h hasher.Hasher,
+) user.ResetPasswordUseCase {
+	return &resetPasswordUseCase{
+		db:     db,
+		repo:   repo,
+		resets: resets,
+		tokens: tokens,
+		hasher: h,
+	}
+}
+
+func (uc *resetPasswordUseCase) Execute(ctx context.Context, input user.ResetPasswordInput) (user.PasswordChangedOutput, error) {
+	t, err := uc.findResetToken(ctx, input.Token)
+	if err != nil {
+		return user.PasswordChangedOutput{}, err
+	}
+
+	u, err := uc.repo.FindUserByID(ctx, user.FindUserByIDRepoInput{UserID: t.UserID})
+	if err != nil {
+		if isNotFound(err) {
+			return user.PasswordChangedOutput{}, invalidPasswordResetToken()
+		}
+		return user.PasswordChangedOutput{}, keepMessageError(err)
+	}
+	if u.IsArchived() {
+		return user.PasswordChangedOutput{}, msg.NewMessageError(nil, user.ErrLoginUserArchived, msg.CodeForbidden, nil)
+	}
+
+	// Checked before the token is spent, so a rejected password can be retried.
+	if _, err := types.NewPassword(input.NewPassword); err != nil {
+		return user.PasswordChangedOutput{}, err
+	}
+
+	// The token is only spent together with the new password and the revoked
+	// sessions; joins the transaction of the command bus when there is one.
+	var output user.PasswordChangedOutput
+	err = database.InTransaction(ctx, uc.db, func(ctx context.Context) error {
+		var err error
+		output, err = uc.reset(ctx, t, u, input.NewPassword)
+		return err
+	})
+	if err != nil {
+		return user.PasswordChangedOutput{}, err
+	}
+
+	return output, nil
+}
+
+func (uc *resetPasswordUseCase) reset(ctx context.Context, t *user.PasswordResetToken, u *user.User, newPassword string) (user.PasswordChangedOutput, error) {
+	err := uc.resets.UsePasswordResetToken(ctx, user.UsePasswordResetTokenRepoInput{
+		ID:     t.ID,
+		UserID: t.UserID,
+		UsedAt: time.Now(),
+	})
+	if err != nil {
+		var msgErr *msg.MessageError
+		if errors.As(err, &msgErr) && msgErr.Code == msg.CodeConflict {
+			return user.PasswordChangedOutput{}, invalidPasswordResetToken()
+		}
+		return user.PasswordChangedOutput{}, keepMessageError(err)
+	}
+
+	return storePassword(ctx, uc.repo, uc.tokens, uc.hasher, u, newPassword)
+}
+
+// findResetToken loads the token of an opaque value and checks its secret.
+// Unknown, wrong, used and expired tokens get the same answer.
+func (uc *resetPasswordUseCase) findResetToken(ctx context.Context, value string) (*user.PasswordResetToken, error) {
+	id, secret, err := user.ParsePasswordResetToken(value)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := uc.resets.FindPasswordResetTokenByID(ctx, id)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, invalidPasswordResetToken()
+		}
+		return nil, keepMessageError(err)
+	}
+
+	match, err := t.VerifySecret(secret, uc.hasher)
+	if err != nil {
+		return nil, msg.NewInternalError(err, map[string]any{"password_reset_token_id": id.String()})
+	}
+	if !match || t.IsUsed() || t.IsExpired(time.Now()) {
+		return nil, invalidPasswordResetToken()
+	}
+
+	return t, nil
+}
+
+// storePassword sets the new password guarded by the loaded version and
+// revokes every session, so a stolen refresh token stops working too.
+func storePassword(
+	ctx context.Context,
+	repo user.UpdateUserPasswordRepository,
+	tokens user.RefreshTokenRepository,
+	h hasher.Hasher,
+	u *user.User,
+	newPassword string,
+) (user.PasswordChangedOutput, error) {
+	loadedVersion := u.Version
+	if err := u.ChangePassword(newPassword, h); err != nil {
+		return user.PasswordChangedOutput{}, err
+	}
+
+	if err := repo.UpdateUserPassword(ctx, user.UpdateUserRepoInput{User: u, ExpectedVersion: loadedVersion}); err != nil {
+		return user.PasswordChangedOutput{}, keepMessageError(err)
+	}
+
+	revoked, err := tokens.RevokeRefreshTokens(ctx, user.RevokeRefreshTokensRepoInput{UserID: u.ID})
+	if err != nil {
+		return user.PasswordChangedOutput{}, keepMessageError(err)
+	}
+
+	return user.PasswordChangedOutput{User: u, SessionsRevoked: revoked}, nil
+}
+
+func invalidPasswordResetToken() error {
+	return msg.NewMessageError(nil, user.ErrPasswordResetTokenInvalid, msg.CodeUnauthorized, nil)
+}
@@ -0,0 +1,346 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/usecase"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/hasher"
+	"github.com/marcelofabianov/redtogreen/internal/platform/config"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/database"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type mockUpdateUserPasswordRepo struct {
+	mockFindUserByIDRepo
+	updated []user.UpdateUserRepoInput
+}
+
+func (m *mockUpdateUserPasswordRepo) UpdateUserPassword(ctx context.Context, input user.UpdateUserRepoInput) error {
+	m.updated = append(m.updated, input)
+	return nil
+}
+
+// fakeDB runs transactions without a database, so repositories only see a
+// transaction in ctx.
+type fakeDB struct {
+	transactions int
+}
+
+func (d *fakeDB) Conn() *sql.DB { return nil }
+
+func (d *fakeDB) WithTransaction(ctx context.Context, opts *sql.TxOptions, fn func(tx *sql.Tx) error) error {
+	d.transactions++
+	return fn(&sql.Tx{})
+}
+
+func (d *fakeDB) Close() error { return nil }
+
+// mockPasswordResetTokenRepo keeps reset tokens in memory.
+type mockPasswordResetTokenRepo struct {
+	tokens map[types.UUID]*user.PasswordResetToken
+}
+
+func newMockPasswordResetTokenRepo() *mockPasswordResetTokenRepo {
+	return &mockPasswordResetTokenRepo{tokens: map[types.UUID]*user.PasswordResetToken{}}
+}
+
+func (m *mockPasswordResetTokenRepo) CreatePasswordResetToken(ctx context.Context, t *user.PasswordResetToken) error {
+	m.tokens[t.ID] = t
+	return nil
+}
+
+func (m *mockPasswordResetTokenRepo) FindPasswordResetTokenByID(ctx context.Context, id types.UUID) (*user.PasswordResetToken, error) {
+	t, ok := m.tokens[id]
+	if !ok {
+		return nil, msg.NewMessageError(nil, user.ErrPasswordResetTokenInvalid, msg.CodeNotFound, nil)
+	}
+	return t, nil
+}
+
+func (m *mockPasswordResetTokenRepo) UsePasswordResetToken(ctx context.Context, input user.UsePasswordResetTokenRepoInput) error {
+	t := m.tokens[input.ID]
+	if t.IsUsed() {
+		return msg.NewMessageError(nil, user.ErrPasswordResetTokenInvalid, msg.CodeConflict, nil)
+	}
+	for _, other := range m.tokens {
+		if other.UserID == input.UserID && !other.IsUsed() {
+			other.UsedAt.Set(input.UsedAt)
+		}
+	}
+	return nil
+}
+
+const (
+	currentPassword = "ValidPassword123!"
+	newPassword     = "NewPassword456!"
+)
+
+func newUserWithPassword(t *testing.T, h *hasher.Hasher) *user.User {
+	t.Helper()
+	u, err := user.NewUser(user.NewUserInput{
+		Name:     "Password User",
+		Email:    "password@example.com",
+		Phone:    "5562999998888",
+		Password: currentPassword,
+	}, h)
+	require.NoError(t, err)
+	return u
+}
+
+func passwordRepoReturning(u *user.User) *mockUpdateUserPasswordRepo {
+	return &mockUpdateUserPasswordRepo{
+		mockFindUserByIDRepo: mockFindUserByIDRepo{
+			FindUserByIDFunc: func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+				if input.UserID != u.ID {
+					return nil, msg.NewMessageError(nil, user.ErrUserNotFound, msg.CodeNotFound, nil)
+				}
+				return u, nil
+			},
+		},
+	}
+}
+
+func TestChangePasswordUseCase_Execute(t *testing.T) {
+	h := hasher.NewHasher()
+
+	t.Run("Success: should store the new password and revoke every session", func(t *testing.T) {
+		u := newUserWithPassword(t, h)
+		loadedVersion := u.Version
+		repo := passwordRepoReturning(u)
+		var revoked user.RevokeRefreshTokensRepoInput
+		tokens := &mockRefreshTokenRepo{
+			RevokeRefreshTokensFunc: func(ctx context.Context, input user.RevokeRefreshTokensRepoInput) (int, error) {
+				revoked = input
+				return 2, nil
+			},
+		}
+		uc := usecase.NewChangePasswordUseCase(repo, tokens, h)
+
+		output, err := uc.Execute(context.Background(), user.ChangePasswordInput{
+			UserID:          u.ID,
+			CurrentPassword: currentPassword,
+			NewPassword:     newPassword,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, output.SessionsRevoked)
+		assert.Equal(t, u.ID, revoked.UserID)
+		require.Len(t, repo.updated, 1)
+		assert.Equal(t, loadedVersion, repo.updated[0].ExpectedVersion)
+		match, err := u.ComparePassword(newPassword, h)
+		require.NoError(t, err)
+		assert.True(t, match)
+	})
+
+	t.Run("Failure: should reject a wrong current password", func(t *testing.T) {
+		u := newUserWithPassword(t, h)
+		repo := passwordRepoReturning(u)
+		uc := usecase.NewChangePasswordUseCase(repo, &mockRefreshTokenRepo{}, h)
+
+		_, err := uc.Execute(context.Background(), user.ChangePasswordInput{
+			UserID:          u.ID,
+			CurrentPassword: "WrongPassword123!",
+			NewPassword:     newPassword,
+		})
+
+		assertErrorCode(t, err, msg.CodeInvalid)
+		assert.Empty(t, repo.updated)
+	})
+
+	t.Run("Failure: should enforce the password rules", func(t *testing.T) {
+		u := newUserWithPassword(t, h)
+		repo := passwordRepoReturning(u)
+		uc := usecase.NewChangePasswordUseCase(repo, &mockRefreshTokenRepo{}, h)
+
+		_, err := uc.Execute(context.Background(), user.ChangePasswordInput{
+			UserID:          u.ID,
+			CurrentPassword: currentPassword,
+			NewPassword:     "short",
+		})
+
+		assertErrorCode(t, err, msg.CodeInvalid)
+		assert.Empty(t, repo.updated)
+	})
+
+	t.Run("Failure: should point users without a password to the reset flow", func(t *testing.T) {
+		external, err := user.NewExternalUser(user.NewExternalUserInput{Name: "Google User", Email: "google@example.com"})
+		require.NoError(t, err)
+		uc := usecase.NewChangePasswordUseCase(passwordRepoReturning(external), &mockRefreshTokenRepo{}, h)
+
+		_, err = uc.Execute(context.Background(), user.ChangePasswordInput{
+			UserID:          external.ID,
+			CurrentPassword: currentPassword,
+			NewPassword:     newPassword,
+		})
+
+		assertErrorCode(t, err, msg.CodeConflict)
+	})
+
+	t.Run("Failure: should forbid an archived user", func(t *testing.T) {
+		u := newUserWithPassword(t, h)
+		u.Archive()
+		uc := usecase.NewChangePasswordUseCase(passwordRepoReturning(u), &mockRefreshTokenRepo{}, h)
+
+		_, err := uc.Execute(context.Background(), user.ChangePasswordInput{
+			UserID:          u.ID,
+			CurrentPassword: currentPassword,
+			NewPassword:     newPassword,
+		})
+
+		assertErrorCode(t, err, msg.CodeForbidden)
+	})
+}
+
+func TestRequestPasswordResetUseCase_Execute(t *testing.T) {
+	h := hasher.NewHasher()
+	cfg := config.PasswordResetConfig{ExpiryMinutes: 30}
+
+	t.Run("Success: should store a hashed token and return its value", func(t *testing.T) {
+		u := newUserWithPassword(t, h)
+		resets := newMockPasswordResetTokenRepo()
+		uc := usecase.NewRequestPasswordResetUseCase(repoReturningLogin(u), resets, h, cfg)
+
+		output, err := uc.Execute(context.Background(), user.RequestPasswordResetInput{Email: "Password@Example.com"})
+		require.NoError(t, err)
+
+		require.NotNil(t, output.User)
+		assert.Equal(t, u.ID, output.User.ID)
+		assert.WithinDuration(t, time.Now().Add(30*time.Minute), output.Token.ExpiresAt, time.Minute)
+		require.Len(t, resets.tokens, 1)
+		for _, stored := range resets.tokens {
+			assert.Equal(t, u.ID, stored.UserID)
+			assert.False(t, strings.Contains(output.Token.Value, stored.SecretHash))
+			assert.True(t, strings.HasPrefix(output.Token.Value, stored.ID.String()+"."))
+		}
+	})
+
+	t.Run("Success: should answer the same for an unknown email without issuing a token", func(t *testing.T) {
+		resets := newMockPasswordResetTokenRepo()
+		uc := usecase.NewRequestPasswordResetUseCase(&mockFindUserByLoginRepo{}, resets, h, cfg)
+
+		output, err := uc.Execute(context.Background(), user.RequestPasswordResetInput{Email: "nobody@example.com"})
+
+		require.NoError(t, err)
+		assert.Nil(t, output.User)
+		assert.Empty(t, resets.tokens)
+	})
+
+	t.Run("Success: should not issue a token for a deleted user", func(t *testing.T) {
+		u := newUserWithPassword(t, h)
+		u.Delete()
+		resets := newMockPasswordResetTokenRepo()
+		uc := usecase.NewRequestPasswordResetUseCase(repoReturningLogin(u), resets, h, cfg)
+
+		output, err := uc.Execute(context.Background(), user.RequestPasswordResetInput{Email: "password@example.com"})
+
+		require.NoError(t, err)
+		assert.Nil(t, output.User)
+		assert.Empty(t, resets.tokens)
+	})
+}
+
+func TestResetPasswordUseCase_Execute(t *testing.T) {
+	h := hasher.NewHasher()
+
+	// issue stores a reset token for u and returns the value sent by email.
+	issue := func(t *testing.T, resets *mockPasswordResetTokenRepo, u *user.User, ttl time.Duration) string {
+		t.Helper()
+		token, issued, err := user.NewPasswordResetToken(u.ID, ttl, h)
+		require.NoError(t, err)
+		resets.tokens[token.ID] = token
+		return issued.Value
+	}
+
+	t.Run("Success: should set the password, spend the token and revoke every session", func(t *testing.T) {
+		u := newUserWithPassword(t, h)
+		repo := passwordRepoReturning(u)
+		resets := newMockPasswordResetTokenRepo()
+		value := issue(t, resets, u, time.Hour)
+		older := issue(t, resets, u, time.Hour)
+		tokens := &mockRefreshTokenRepo{
+			RevokeRefreshTokensFunc: func(ctx context.Context, input user.RevokeRefreshTokensRepoInput) (int, error) {
+				_, inTx := database.TxFromContext(ctx)
+				assert.True(t, inTx, "Sessions should be revoked in the transaction that spends the token")
+				return 1, nil
+			},
+		}
+		db := &fakeDB{}
+		uc := usecase.NewResetPasswordUseCase(db, repo, resets, tokens, h)
+
+		output, err := uc.Execute(context.Background(), user.ResetPasswordInput{Token: value, NewPassword: newPassword})
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, output.SessionsRevoked)
+		assert.Equal(t, 1, db.transactions)
+		require.Len(t, repo.updated, 1)
+		match, err := u.ComparePassword(newPassword, h)
+		require.NoError(t, err)
+		assert.True(t, match)
+
+		_, err = uc.Execute(context.Background(), user.ResetPasswordInput{Token: value, NewPassword: "AnotherPassword789!"})
+		assertErrorCode(t, err, msg.CodeUnauthorized)
+		_, err = uc.Execute(context.Background(), user.ResetPasswordInput{Token: older, NewPassword: "AnotherPassword789!"})
+		assertErrorCode(t, err, msg.CodeUnauthorized)
+	})
+
+	t.Run("Success: should let a user without password set one", func(t *testing.T) {
+		external, err := user.NewExternalUser(user.NewExternalUserInput{Name: "Google User", Email: "google@example.com"})
+		require.NoError(t, err)
+		resets := newMockPasswordResetTokenRepo()
+		value := issue(t, resets, external, time.Hour)
+		uc := usecase.NewResetPasswordUseCase(&fakeDB{}, passwordRepoReturning(external), resets, &mockRefreshTokenRepo{}, h)
+
+		_, err = uc.Execute(context.Background(), user.ResetPasswordInput{Token: value, NewPassword: newPassword})
+
+		require.NoError(t, err)
+		assert.True(t, external.HasPassword())
+	})
+
+	t.Run("Failure: should reject malformed, unknown, wrong and expired tokens alike", func(t *testing.T) {
+		u := newUserWithPassword(t, h)
+		resets := newMockPasswordResetTokenRepo()
+		valid := issue(t, resets, u, time.Hour)
+		id, _, found := strings.Cut(valid, ".")
+		require.True(t, found)
+		expired := issue(t, resets, u, -time.Minute)
+		uc := usecase.NewResetPasswordUseCase(&fakeDB{}, passwordRepoReturning(u), resets, &mockRefreshTokenRepo{}, h)
+
+		for _, value := range []string{"not-a-token", types.MustNewUUID().String() + ".secret", id + ".wrong-secret", expired} {
+			_, err := uc.Execute(context.Background(), user.ResetPasswordInput{Token: value, NewPassword: newPassword})
+			assertErrorCode(t, err, msg.CodeUnauthorized)
+		}
+	})
+
+	t.Run("Failure: should keep the token when the new password breaks the rules", func(t *testing.T) {
+		u := newUserWithPassword(t, h)
+		resets := newMockPasswordResetTokenRepo()
+		value := issue(t, resets, u, time.Hour)
+		uc := usecase.NewResetPasswordUseCase(&fakeDB{}, passwordRepoReturning(u), resets, &mockRefreshTokenRepo{}, h)
+
+		_, err := uc.Execute(context.Background(), user.ResetPasswordInput{Token: value, NewPassword: "short"})
+		assertErrorCode(t, err, msg.CodeInvalid)
+
+		_, err = uc.Execute(context.Background(), user.ResetPasswordInput{Token: value, NewPassword: newPassword})
+		require.NoError(t, err)
+	})
+}
+
+func repoReturningLogin(u *user.User) *mockFindUserByLoginRepo {
+	return &mockFindUserByLoginRepo{
+		FindUserByLoginFunc: func(ctx context.Context, input user.FindUserByLoginRepoInput) (*user.User, error) {
+			if input.Email != u.Email {
+				return nil, msg.NewMessageError(nil, user.ErrUserNotFound, msg.CodeNotFound, nil)
+			}
+			return u, nil
+		},
+	}
+}
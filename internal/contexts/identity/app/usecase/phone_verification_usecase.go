@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/config"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/hasher"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type requestPhoneVerificationUseCase struct {
+	repo           user.FindUserByIDRepository
+	codes          user.PhoneVerificationCodeRepository
+	hasher         hasher.Hasher
+	ttl            time.Duration
+	resendInterval time.Duration
+}
+
+func NewRequestPhoneVerificationUseCase(
+	repo user.FindUserByIDRepository,
+	codes user.PhoneVerificationCodeRepository,
+	h hasher.Hasher,
+	cfg config.PhoneVerificationConfig,
+) user.RequestPhoneVerificationUseCase {
+	return &requestPhoneVerificationUseCase{
+		repo:           repo,
+		codes:          codes,
+		hasher:         h,
+		ttl:            time.Duration(cfg.ExpiryMinutes) * time.Minute,
+		resendInterval: time.Duration(cfg.ResendIntervalSeconds) * time.Second,
+	}
+}
+
+func (uc *requestPhoneVerificationUseCase) Execute(
+	ctx context.Context,
+	input user.RequestPhoneVerificationInput,
+) (user.RequestPhoneVerificationOutput, error) {
+	u, err := findPhoneToVerify(ctx, uc.repo, input.UserID)
+	if err != nil {
+		return user.RequestPhoneVerificationOutput{}, err
+	}
+
+	// Every SMS costs money and lands on someone's phone, so codes are paced
+	// per user.
+	latest, err := uc.codes.FindLatestPhoneVerificationCode(ctx, u.ID)
+	if err != nil && !isNotFound(err) {
+		return user.RequestPhoneVerificationOutput{}, keepMessageError(err)
+	}
+	if err == nil && time.Since(latest.CreatedAt) < uc.resendInterval {
+		return user.RequestPhoneVerificationOutput{}, msg.NewMessageError(nil, user.ErrPhoneVerificationResendTooSoon, msg.CodeConflict, map[string]any{
+			"user_id": u.ID.String(),
+		})
+	}
+
+	c, issued, err := user.NewPhoneVerificationCode(u, uc.ttl, uc.hasher)
+	if err != nil {
+		return user.RequestPhoneVerificationOutput{}, err
+	}
+	if err := uc.codes.CreatePhoneVerificationCode(ctx, c); err != nil {
+		return user.RequestPhoneVerificationOutput{}, keepMessageError(err)
+	}
+
+	return user.RequestPhoneVerificationOutput{User: u, Code: issued}, nil
+}
+
+type verifyPhoneUseCase struct {
+	repo        user.VerifyUserPhoneRepository
+	codes       user.PhoneVerificationCodeRepository
+	hasher      hasher.Hasher
+	maxAttempts int
+}
+
+func NewVerifyPhoneUseCase(
+	repo user.VerifyUserPhoneRepository,
+	codes user.PhoneVerificationCodeRepository,
+	h hasher.Hasher,
+	cfg config.PhoneVerificationConfig,
+) user.VerifyPhoneUseCase {
+	return &verifyPhoneUseCase{
+		repo:        repo,
+		codes:       codes,
+		hasher:      h,
+		maxAttempts: cfg.MaxAttempts,
+	}
+}
+
+func (uc *verifyPhoneUseCase) Execute(ctx context.Context, input user.VerifyPhoneInput) (user.VerifyPhoneOutput, error) {
+	u, err := findPhoneToVerify(ctx, uc.repo, input.UserID)
+	if err != nil {
+		return user.VerifyPhoneOutput{}, err
+	}
+
+	c, err := uc.codes.FindLatestPhoneVerificationCode(ctx, u.ID)
+	if err != nil {
+		if isNotFound(err) {
+			return user.VerifyPhoneOutput{}, invalidPhoneVerificationCode()
+		}
+		return user.VerifyPhoneOutput{}, keepMessageError(err)
+	}
+	// The code was sent to a number the user no longer has.
+	if c.IsUsed() || c.IsExpired(time.Now()) || c.Phone != u.Phone {
+		return user.VerifyPhoneOutput{}, invalidPhoneVerificationCode()
+	}
+	if !c.HasAttemptsLeft(uc.maxAttempts) {
+		return user.VerifyPhoneOutput{}, phoneVerificationAttemptsExceeded()
+	}
+
+	// The attempt is counted before the code is compared, so concurrent
+	// guesses cannot go past the limit.
+	err = uc.codes.RecordPhoneVerificationAttempt(ctx, user.RecordPhoneVerificationAttemptRepoInput{
+		ID:          c.ID,
+		MaxAttempts: uc.maxAttempts,
+	})
+	if err != nil {
+		var msgErr *msg.MessageError
+		if errors.As(err, &msgErr) && msgErr.Code == msg.CodeConflict {
+			return user.VerifyPhoneOutput{}, phoneVerificationAttemptsExceeded()
+		}
+		return user.VerifyPhoneOutput{}, keepMessageError(err)
+	}
+
+	match, err := c.VerifyCode(input.Code, uc.hasher)
+	if err != nil {
+		return user.VerifyPhoneOutput{}, msg.NewInternalError(err, map[string]any{"phone_verification_code_id": c.ID.String()})
+	}
+	if !match {
+		return user.VerifyPhoneOutput{}, msg.NewValidationError(nil, map[string]any{
+			"field":         "code",
+			"attempts_left": uc.maxAttempts - c.Attempts - 1,
+		}, user.ErrPhoneVerificationCodeInvalid)
+	}
+
+	err = uc.codes.UsePhoneVerificationCode(ctx, user.UsePhoneVerificationCodeRepoInput{
+		ID:     c.ID,
+		UserID: u.ID,
+		UsedAt: time.Now(),
+	})
+	if err != nil {
+		var msgErr *msg.MessageError
+		if errors.As(err, &msgErr) && msgErr.Code == msg.CodeConflict {
+			return user.VerifyPhoneOutput{}, invalidPhoneVerificationCode()
+		}
+		return user.VerifyPhoneOutput{}, keepMessageError(err)
+	}
+
+	loadedVersion := u.Version
+	u.VerifyPhone()
+	if err := uc.repo.VerifyUserPhone(ctx, user.UpdateUserRepoInput{User: u, ExpectedVersion: loadedVersion}); err != nil {
+		return user.VerifyPhoneOutput{}, keepMessageError(err)
+	}
+
+	return user.VerifyPhoneOutput{User: u}, nil
+}
+
+// findPhoneToVerify loads the authenticated user and checks it has a phone
+// still waiting for verification.
+func findPhoneToVerify(ctx context.Context, repo user.FindUserByIDRepository, userID types.UUID) (*user.User, error) {
+	u, err := repo.FindUserByID(ctx, user.FindUserByIDRepoInput{UserID: userID})
+	if err != nil {
+		return nil, keepMessageError(err)
+	}
+	if u.IsArchived() {
+		return nil, msg.NewMessageError(nil, user.ErrLoginUserArchived, msg.CodeForbidden, nil)
+	}
+	if u.Phone.IsEmpty() {
+		return nil, msg.NewMessageError(nil, user.ErrUserPhoneNotSet, msg.CodeConflict, map[string]any{"user_id": u.ID.String()})
+	}
+	if u.IsPhoneVerified() {
+		return nil, msg.NewMessageError(nil, user.ErrUserPhoneAlreadyVerified, msg.CodeConflict, map[string]any{"user_id": u.ID.String()})
+	}
+	return u, nil
+}
+
+func invalidPhoneVerificationCode() error {
+	return msg.NewValidationError(nil, map[string]any{"field": "code"}, user.ErrPhoneVerificationCodeInvalid)
+}
+
+func phoneVerificationAttemptsExceeded() error {
+	return msg.NewValidationError(nil, map[string]any{"field": "code"}, user.ErrPhoneVerificationAttemptsExceeded)
+}
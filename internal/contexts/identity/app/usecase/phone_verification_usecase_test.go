@@ -0,0 +1,231 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/usecase"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/hasher"
+	"github.com/marcelofabianov/redtogreen/internal/platform/config"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+var phoneVerificationConfig = config.PhoneVerificationConfig{ExpiryMinutes: 10, ResendIntervalSeconds: 60, MaxAttempts: 3}
+
+// mockPhoneVerificationCodeRepo keeps verification codes in memory.
+type mockPhoneVerificationCodeRepo struct {
+	codes map[types.UUID]*user.PhoneVerificationCode
+}
+
+func newMockPhoneVerificationCodeRepo() *mockPhoneVerificationCodeRepo {
+	return &mockPhoneVerificationCodeRepo{codes: map[types.UUID]*user.PhoneVerificationCode{}}
+}
+
+func (m *mockPhoneVerificationCodeRepo) CreatePhoneVerificationCode(ctx context.Context, c *user.PhoneVerificationCode) error {
+	m.codes[c.ID] = c
+	return nil
+}
+
+func (m *mockPhoneVerificationCodeRepo) FindLatestPhoneVerificationCode(ctx context.Context, userID types.UUID) (*user.PhoneVerificationCode, error) {
+	var latest *user.PhoneVerificationCode
+	for _, c := range m.codes {
+		if c.UserID == userID && (latest == nil || c.CreatedAt.After(latest.CreatedAt)) {
+			latest = c
+		}
+	}
+	if latest == nil {
+		return nil, msg.NewMessageError(nil, user.ErrPhoneVerificationCodeInvalid, msg.CodeNotFound, nil)
+	}
+	// Return a copy, as a database would, so attempts recorded later do not
+	// show up on a code already loaded.
+	loaded := *latest
+	return &loaded, nil
+}
+
+func (m *mockPhoneVerificationCodeRepo) RecordPhoneVerificationAttempt(ctx context.Context, input user.RecordPhoneVerificationAttemptRepoInput) error {
+	c := m.codes[input.ID]
+	if c.IsUsed() || c.Attempts >= input.MaxAttempts {
+		return msg.NewMessageError(nil, user.ErrPhoneVerificationAttemptsExceeded, msg.CodeConflict, nil)
+	}
+	c.Attempts++
+	return nil
+}
+
+func (m *mockPhoneVerificationCodeRepo) UsePhoneVerificationCode(ctx context.Context, input user.UsePhoneVerificationCodeRepoInput) error {
+	c := m.codes[input.ID]
+	if c.IsUsed() {
+		return msg.NewMessageError(nil, user.ErrPhoneVerificationCodeInvalid, msg.CodeConflict, nil)
+	}
+	for _, other := range m.codes {
+		if other.UserID == input.UserID && !other.IsUsed() {
+			other.UsedAt.Set(input.UsedAt)
+		}
+	}
+	return nil
+}
+
+type mockVerifyUserPhoneRepo struct {
+	mockFindUserByIDRepo
+	verified []user.UpdateUserRepoInput
+}
+
+func (m *mockVerifyUserPhoneRepo) VerifyUserPhone(ctx context.Context, input user.UpdateUserRepoInput) error {
+	m.verified = append(m.verified, input)
+	return nil
+}
+
+func phoneRepoReturning(u *user.User) *mockVerifyUserPhoneRepo {
+	return &mockVerifyUserPhoneRepo{mockFindUserByIDRepo: passwordRepoReturning(u).mockFindUserByIDRepo}
+}
+
+func TestRequestPhoneVerificationUseCase_Execute(t *testing.T) {
+	h := hasher.NewHasher()
+
+	t.Run("Success: should issue a numeric code bound to the current phone", func(t *testing.T) {
+		u := newUserWithPassword(t, h)
+		codes := newMockPhoneVerificationCodeRepo()
+		uc := usecase.NewRequestPhoneVerificationUseCase(phoneRepoReturning(u), codes, h, phoneVerificationConfig)
+
+		output, err := uc.Execute(context.Background(), user.RequestPhoneVerificationInput{UserID: u.ID})
+		require.NoError(t, err)
+
+		assert.Regexp(t, `^[0-9]{6}$`, output.Code.Code)
+		assert.WithinDuration(t, time.Now().Add(10*time.Minute), output.Code.ExpiresAt, time.Minute)
+		require.Len(t, codes.codes, 1)
+		for _, stored := range codes.codes {
+			assert.Equal(t, u.Phone, stored.Phone)
+			assert.NotEqual(t, output.Code.Code, stored.CodeHash)
+			assert.Zero(t, stored.Attempts)
+		}
+	})
+
+	t.Run("Failure: should not resend before the interval", func(t *testing.T) {
+		u := newUserWithPassword(t, h)
+		codes := newMockPhoneVerificationCodeRepo()
+		uc := usecase.NewRequestPhoneVerificationUseCase(phoneRepoReturning(u), codes, h, phoneVerificationConfig)
+
+		_, err := uc.Execute(context.Background(), user.RequestPhoneVerificationInput{UserID: u.ID})
+		require.NoError(t, err)
+
+		_, err = uc.Execute(context.Background(), user.RequestPhoneVerificationInput{UserID: u.ID})
+		assertErrorCode(t, err, msg.CodeConflict)
+		assert.Len(t, codes.codes, 1)
+
+		for _, stored := range codes.codes {
+			stored.CreatedAt = stored.CreatedAt.Add(-2 * time.Minute)
+		}
+		_, err = uc.Execute(context.Background(), user.RequestPhoneVerificationInput{UserID: u.ID})
+		require.NoError(t, err)
+		assert.Len(t, codes.codes, 2)
+	})
+
+	t.Run("Failure: should reject verified phones and users without a phone", func(t *testing.T) {
+		verified := newUserWithPassword(t, h)
+		verified.VerifyPhone()
+		external, err := user.NewExternalUser(user.NewExternalUserInput{Email: "google@example.com", Name: "Google User"})
+		require.NoError(t, err)
+
+		for _, u := range []*user.User{verified, external} {
+			codes := newMockPhoneVerificationCodeRepo()
+			uc := usecase.NewRequestPhoneVerificationUseCase(phoneRepoReturning(u), codes, h, phoneVerificationConfig)
+
+			_, err := uc.Execute(context.Background(), user.RequestPhoneVerificationInput{UserID: u.ID})
+
+			assertErrorCode(t, err, msg.CodeConflict)
+			assert.Empty(t, codes.codes)
+		}
+	})
+}
+
+func TestVerifyPhoneUseCase_Execute(t *testing.T) {
+	h := hasher.NewHasher()
+
+	issue := func(t *testing.T, codes *mockPhoneVerificationCodeRepo, u *user.User, ttl time.Duration) string {
+		t.Helper()
+		c, issued, err := user.NewPhoneVerificationCode(u, ttl, h)
+		require.NoError(t, err)
+		codes.codes[c.ID] = c
+		return issued.Code
+	}
+
+	// wrongCode differs from code in every digit.
+	wrongCode := func(code string) string {
+		wrong := []byte(code)
+		for i := range wrong {
+			wrong[i] = '0' + (wrong[i]-'0'+1)%10
+		}
+		return string(wrong)
+	}
+
+	t.Run("Success: should verify the phone and spend the code", func(t *testing.T) {
+		u := newUserWithPassword(t, h)
+		loadedVersion := u.Version
+		repo := phoneRepoReturning(u)
+		codes := newMockPhoneVerificationCodeRepo()
+		code := issue(t, codes, u, time.Minute)
+		uc := usecase.NewVerifyPhoneUseCase(repo, codes, h, phoneVerificationConfig)
+
+		output, err := uc.Execute(context.Background(), user.VerifyPhoneInput{UserID: u.ID, Code: code})
+		require.NoError(t, err)
+
+		assert.True(t, output.User.IsPhoneVerified())
+		require.Len(t, repo.verified, 1)
+		assert.Equal(t, loadedVersion, repo.verified[0].ExpectedVersion)
+		for _, stored := range codes.codes {
+			assert.True(t, stored.IsUsed())
+		}
+	})
+
+	t.Run("Failure: should lock the code once the attempts run out", func(t *testing.T) {
+		u := newUserWithPassword(t, h)
+		repo := phoneRepoReturning(u)
+		codes := newMockPhoneVerificationCodeRepo()
+		code := issue(t, codes, u, time.Minute)
+		uc := usecase.NewVerifyPhoneUseCase(repo, codes, h, phoneVerificationConfig)
+
+		for i := 0; i < phoneVerificationConfig.MaxAttempts; i++ {
+			_, err := uc.Execute(context.Background(), user.VerifyPhoneInput{UserID: u.ID, Code: wrongCode(code)})
+			assertErrorCode(t, err, msg.CodeInvalid)
+		}
+
+		_, err := uc.Execute(context.Background(), user.VerifyPhoneInput{UserID: u.ID, Code: code})
+
+		assertErrorCode(t, err, msg.CodeInvalid)
+		assert.Contains(t, err.Error(), user.ErrPhoneVerificationAttemptsExceeded)
+		assert.Empty(t, repo.verified)
+		assert.False(t, u.IsPhoneVerified())
+	})
+
+	t.Run("Failure: should reject a code sent to a previous phone", func(t *testing.T) {
+		u := newUserWithPassword(t, h)
+		codes := newMockPhoneVerificationCodeRepo()
+		code := issue(t, codes, u, time.Minute)
+		require.NoError(t, u.Update(user.UpdateUserInput{Phone: "5562988887777"}))
+		repo := phoneRepoReturning(u)
+		uc := usecase.NewVerifyPhoneUseCase(repo, codes, h, phoneVerificationConfig)
+
+		_, err := uc.Execute(context.Background(), user.VerifyPhoneInput{UserID: u.ID, Code: code})
+
+		assertErrorCode(t, err, msg.CodeInvalid)
+		assert.Empty(t, repo.verified)
+	})
+
+	t.Run("Failure: should reject expired codes and users without a code", func(t *testing.T) {
+		u := newUserWithPassword(t, h)
+		uc := usecase.NewVerifyPhoneUseCase(phoneRepoReturning(u), newMockPhoneVerificationCodeRepo(), h, phoneVerificationConfig)
+		_, err := uc.Execute(context.Background(), user.VerifyPhoneInput{UserID: u.ID, Code: "123456"})
+		assertErrorCode(t, err, msg.CodeInvalid)
+
+		codes := newMockPhoneVerificationCodeRepo()
+		code := issue(t, codes, u, -time.Minute)
+		uc = usecase.NewVerifyPhoneUseCase(phoneRepoReturning(u), codes, h, phoneVerificationConfig)
+		_, err = uc.Execute(context.Background(), user.VerifyPhoneInput{UserID: u.ID, Code: code})
+		assertErrorCode(t, err, msg.CodeInvalid)
+	})
+}
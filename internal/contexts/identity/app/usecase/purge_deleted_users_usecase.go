@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/database"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/pii"
+)
+
+type purgeDeletedUsersUseCase struct {
+	db       database.DB
+	repo     user.PurgeDeletedUsersRepository
+	shredder pii.Shredder
+}
+
+func NewPurgeDeletedUsersUseCase(db database.DB, repo user.PurgeDeletedUsersRepository, shredder pii.Shredder) user.PurgeDeletedUsersUseCase {
+	return &purgeDeletedUsersUseCase{
+		db:       db,
+		repo:     repo,
+		shredder: shredder,
+	}
+}
+
+// Execute deletes a batch and destroys the data keys of its users in one
+// transaction. Once a row is gone no later run would find its key, so a failed
+// shred rolls the batch back and the next run retries it.
+func (uc *purgeDeletedUsersUseCase) Execute(ctx context.Context, input user.PurgeDeletedUsersUseCaseInput) ([]user.PurgedUser, error) {
+	var purged []user.PurgedUser
+	err := database.InTransaction(ctx, uc.db, func(ctx context.Context) error {
+		var err error
+		purged, err = uc.repo.PurgeDeletedUsers(ctx, user.PurgeDeletedUsersRepoInput{
+			DeletedBefore: input.DeletedBefore,
+			Limit:         input.Limit,
+		})
+		if err != nil {
+			return msg.NewInternalError(err, map[string]any{"deleted_before": input.DeletedBefore})
+		}
+
+		for _, p := range purged {
+			if err := uc.shredder.ForgetSubject(ctx, p.ID); err != nil {
+				return msg.NewInternalError(err, map[string]any{"user_id": p.ID.String()})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return purged, nil
+}
@@ -0,0 +1,80 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/usecase"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/database"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type mockPurgeDeletedUsersRepo struct {
+	PurgeDeletedUsersFunc func(ctx context.Context, input user.PurgeDeletedUsersRepoInput) ([]user.PurgedUser, error)
+}
+
+func (m *mockPurgeDeletedUsersRepo) PurgeDeletedUsers(ctx context.Context, input user.PurgeDeletedUsersRepoInput) ([]user.PurgedUser, error) {
+	return m.PurgeDeletedUsersFunc(ctx, input)
+}
+
+func TestPurgeDeletedUsersUseCase_Execute(t *testing.T) {
+	cutoff := time.Now().Add(-30 * 24 * time.Hour)
+	purged := []user.PurgedUser{
+		{ID: types.MustNewUUID(), DeletedAt: cutoff.Add(-time.Hour)},
+		{ID: types.MustNewUUID(), DeletedAt: cutoff.Add(-time.Minute)},
+	}
+
+	t.Run("Success: should destroy the data keys in the transaction that deletes the users", func(t *testing.T) {
+		repo := &mockPurgeDeletedUsersRepo{
+			PurgeDeletedUsersFunc: func(ctx context.Context, input user.PurgeDeletedUsersRepoInput) ([]user.PurgedUser, error) {
+				_, inTx := database.TxFromContext(ctx)
+				assert.True(t, inTx, "Users should be deleted in a transaction")
+				assert.Equal(t, cutoff, input.DeletedBefore)
+				assert.Equal(t, 2, input.Limit)
+				return purged, nil
+			},
+		}
+		shredder := &mockShredder{
+			ForgetSubjectFunc: func(ctx context.Context, subjectID types.UUID) error {
+				_, inTx := database.TxFromContext(ctx)
+				assert.True(t, inTx, "Keys should be destroyed in the transaction that deletes the users")
+				return nil
+			},
+		}
+		db := &fakeDB{}
+		uc := usecase.NewPurgeDeletedUsersUseCase(db, repo, shredder)
+
+		output, err := uc.Execute(context.Background(), user.PurgeDeletedUsersUseCaseInput{DeletedBefore: cutoff, Limit: 2})
+
+		require.NoError(t, err)
+		assert.Equal(t, purged, output)
+		assert.Equal(t, []types.UUID{purged[0].ID, purged[1].ID}, shredder.forgotten)
+		assert.Equal(t, 1, db.transactions)
+	})
+
+	t.Run("Failure: should fail the batch when a data key cannot be destroyed", func(t *testing.T) {
+		repo := &mockPurgeDeletedUsersRepo{
+			PurgeDeletedUsersFunc: func(ctx context.Context, input user.PurgeDeletedUsersRepoInput) ([]user.PurgedUser, error) {
+				return purged, nil
+			},
+		}
+		shredder := &mockShredder{
+			ForgetSubjectFunc: func(ctx context.Context, subjectID types.UUID) error {
+				return errors.New("key store down")
+			},
+		}
+		uc := usecase.NewPurgeDeletedUsersUseCase(&fakeDB{}, repo, shredder)
+
+		output, err := uc.Execute(context.Background(), user.PurgeDeletedUsersUseCaseInput{DeletedBefore: cutoff, Limit: 2})
+
+		assertErrorCode(t, err, msg.CodeInternal)
+		assert.Empty(t, output, "A failed batch is rolled back, so no user counts as purged")
+	})
+}
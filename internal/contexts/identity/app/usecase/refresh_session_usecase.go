@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/role"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/config"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/hasher"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/token"
+)
+
+type refreshSessionUseCase struct {
+	tokens   user.RefreshTokenRepository
+	users    user.FindUserByIDRepository
+	sessions sessionIssuer
+	hasher   hasher.Hasher
+}
+
+func NewRefreshSessionUseCase(
+	tokens user.RefreshTokenRepository,
+	users user.FindUserByIDRepository,
+	permissions role.UserPermissionsRepository,
+	h hasher.Hasher,
+	issuer token.Issuer,
+	cfg config.RefreshTokenConfig,
+	identity config.IdentityConfig,
+) user.RefreshSessionUseCase {
+	return &refreshSessionUseCase{
+		tokens:   tokens,
+		users:    users,
+		sessions: newSessionIssuer(tokens, permissions, h, issuer, cfg, identity),
+		hasher:   h,
+	}
+}
+
+func (uc *refreshSessionUseCase) Execute(ctx context.Context, input user.RefreshSessionInput) (user.RefreshSessionOutput, error) {
+	current, err := findRefreshToken(ctx, uc.tokens, uc.hasher, input.RefreshToken)
+	if err != nil {
+		return user.RefreshSessionOutput{}, err
+	}
+
+	if current.IsRevoked() || current.IsExpired(time.Now()) {
+		return user.RefreshSessionOutput{}, invalidRefreshToken()
+	}
+	if current.IsRotated() {
+		return user.RefreshSessionOutput{}, uc.revokeReused(ctx, current)
+	}
+
+	u, err := uc.users.FindUserByID(ctx, user.FindUserByIDRepoInput{UserID: current.UserID})
+	if err != nil {
+		if isNotFound(err) {
+			return user.RefreshSessionOutput{}, uc.revoke(ctx, current, invalidRefreshToken())
+		}
+		return user.RefreshSessionOutput{}, keepMessageError(err)
+	}
+	if u.IsArchived() {
+		return user.RefreshSessionOutput{}, uc.revoke(ctx, current,
+			msg.NewMessageError(nil, user.ErrLoginUserArchived, msg.CodeForbidden, nil))
+	}
+
+	next, issued, err := uc.sessions.newRefreshToken(u.ID, current.FamilyID)
+	if err != nil {
+		return user.RefreshSessionOutput{}, err
+	}
+
+	err = uc.tokens.RotateRefreshToken(ctx, user.RotateRefreshTokenRepoInput{CurrentID: current.ID, Next: next})
+	if err != nil {
+		var msgErr *msg.MessageError
+		if errors.As(err, &msgErr) && msgErr.Code == msg.CodeConflict {
+			// Another request rotated the token after it was loaded.
+			return user.RefreshSessionOutput{}, uc.revokeReused(ctx, current)
+		}
+		return user.RefreshSessionOutput{}, keepMessageError(err)
+	}
+
+	session, err := uc.sessions.withAccessToken(ctx, u.ID, issued)
+	if err != nil {
+		return user.RefreshSessionOutput{}, err
+	}
+
+	return user.RefreshSessionOutput{User: u, Session: session}, nil
+}
+
+// revokeReused ends the session of a token presented after its rotation: either
+// the client or an attacker holds a stolen copy, and there is no telling which.
+func (uc *refreshSessionUseCase) revokeReused(ctx context.Context, current *user.RefreshToken) error {
+	return uc.revoke(ctx, current, msg.NewMessageError(nil, user.ErrRefreshTokenReused, msg.CodeUnauthorized, map[string]any{
+		"family_id": current.FamilyID.String(),
+	}))
+}
+
+// revoke revokes the family of current and returns reason, unless the
+// revocation itself fails.
+func (uc *refreshSessionUseCase) revoke(ctx context.Context, current *user.RefreshToken, reason error) error {
+	if _, err := uc.tokens.RevokeRefreshTokens(ctx, user.RevokeRefreshTokensRepoInput{FamilyID: current.FamilyID}); err != nil {
+		return keepMessageError(err)
+	}
+	return reason
+}
+
+// findRefreshToken loads the token of an opaque value and checks its secret.
+// Anything that does not match a stored token is reported as invalid.
+func findRefreshToken(ctx context.Context, tokens user.RefreshTokenRepository, h hasher.Hasher, value string) (*user.RefreshToken, error) {
+	id, secret, err := user.ParseRefreshToken(value)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := tokens.FindRefreshTokenByID(ctx, id)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, invalidRefreshToken()
+		}
+		return nil, keepMessageError(err)
+	}
+
+	match, err := t.VerifySecret(secret, h)
+	if err != nil {
+		return nil, msg.NewInternalError(err, map[string]any{"refresh_token_id": id.String()})
+	}
+	if !match {
+		return nil, invalidRefreshToken()
+	}
+
+	return t, nil
+}
+
+func invalidRefreshToken() error {
+	return msg.NewMessageError(nil, user.ErrRefreshTokenInvalid, msg.CodeUnauthorized, nil)
+}
+
+func isNotFound(err error) bool {
+	var msgErr *msg.MessageError
+	return errors.As(err, &msgErr) && msgErr.Code == msg.CodeNotFound
+}
@@ -0,0 +1,183 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/usecase"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/hasher"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type mockFindUserByIDRepo struct {
+	FindUserByIDFunc func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error)
+}
+
+func (m *mockFindUserByIDRepo) FindUserByID(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+	if m.FindUserByIDFunc != nil {
+		return m.FindUserByIDFunc(ctx, input)
+	}
+	return nil, msg.NewMessageError(nil, user.ErrUserNotFound, msg.CodeNotFound, nil)
+}
+
+// storedRefreshToken is a stored token together with the value the client
+// holds. The repository built by repo serves it and records writes.
+type storedRefreshToken struct {
+	token   *user.RefreshToken
+	value   string
+	revoked []user.RevokeRefreshTokensRepoInput
+	rotated []user.RotateRefreshTokenRepoInput
+}
+
+func newStoredRefreshToken(t *testing.T, h *hasher.Hasher, userID types.UUID) *storedRefreshToken {
+	t.Helper()
+	rt, issued, err := user.NewRefreshToken(user.NewRefreshTokenInput{UserID: userID, TTL: time.Hour}, h)
+	require.NoError(t, err)
+	return &storedRefreshToken{token: rt, value: issued.Value}
+}
+
+func (s *storedRefreshToken) repo() *mockRefreshTokenRepo {
+	return &mockRefreshTokenRepo{
+		FindRefreshTokenByIDFunc: func(ctx context.Context, id types.UUID) (*user.RefreshToken, error) {
+			if id != s.token.ID {
+				return nil, msg.NewMessageError(nil, user.ErrRefreshTokenInvalid, msg.CodeNotFound, nil)
+			}
+			return s.token, nil
+		},
+		RotateRefreshTokenFunc: func(ctx context.Context, input user.RotateRefreshTokenRepoInput) error {
+			s.rotated = append(s.rotated, input)
+			return nil
+		},
+		RevokeRefreshTokensFunc: func(ctx context.Context, input user.RevokeRefreshTokensRepoInput) (int, error) {
+			s.revoked = append(s.revoked, input)
+			return 1, nil
+		},
+	}
+}
+
+func TestRefreshSessionUseCase_Execute(t *testing.T) {
+	h := hasher.NewHasher()
+
+	usersReturning := func(u *user.User) *mockFindUserByIDRepo {
+		return &mockFindUserByIDRepo{
+			FindUserByIDFunc: func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+				return u, nil
+			},
+		}
+	}
+
+	t.Run("Success: should rotate the token within the same family", func(t *testing.T) {
+		u := newActiveUser()
+		stored := newStoredRefreshToken(t, h, u.ID)
+		uc := usecase.NewRefreshSessionUseCase(stored.repo(), usersReturning(u), &mockUserPermissionsRepo{}, h, &mockTokenIssuer{}, refreshConfig, identityConfig)
+
+		output, err := uc.Execute(context.Background(), user.RefreshSessionInput{RefreshToken: stored.value})
+
+		require.NoError(t, err)
+		require.Len(t, stored.rotated, 1)
+		next := stored.rotated[0].Next
+		assert.Equal(t, stored.token.ID, stored.rotated[0].CurrentID)
+		assert.Equal(t, stored.token.FamilyID, next.FamilyID)
+		assert.NotEqual(t, stored.token.ID, next.ID)
+		assert.Equal(t, u.ID, output.Session.AccessToken.Claims.Subject)
+		assert.NotEqual(t, stored.value, output.Session.RefreshToken.Value)
+		assert.Empty(t, stored.revoked)
+	})
+
+	t.Run("Failure: should revoke the family when a rotated token is reused", func(t *testing.T) {
+		u := newActiveUser()
+		stored := newStoredRefreshToken(t, h, u.ID)
+		stored.token.RotatedAt = types.NullableTime{NullTime: sql.NullTime{Time: time.Now(), Valid: true}}
+		uc := usecase.NewRefreshSessionUseCase(stored.repo(), usersReturning(u), &mockUserPermissionsRepo{}, h, &mockTokenIssuer{}, refreshConfig, identityConfig)
+
+		_, err := uc.Execute(context.Background(), user.RefreshSessionInput{RefreshToken: stored.value})
+
+		assertErrorCode(t, err, msg.CodeUnauthorized)
+		assert.Contains(t, err.Error(), user.ErrRefreshTokenReused)
+		require.Len(t, stored.revoked, 1)
+		assert.Equal(t, stored.token.FamilyID, stored.revoked[0].FamilyID)
+		assert.Empty(t, stored.rotated)
+	})
+
+	t.Run("Failure: should treat a concurrent rotation as reuse", func(t *testing.T) {
+		u := newActiveUser()
+		stored := newStoredRefreshToken(t, h, u.ID)
+		repo := stored.repo()
+		repo.RotateRefreshTokenFunc = func(ctx context.Context, input user.RotateRefreshTokenRepoInput) error {
+			return msg.NewMessageError(nil, "already rotated", msg.CodeConflict, nil)
+		}
+		uc := usecase.NewRefreshSessionUseCase(repo, usersReturning(u), &mockUserPermissionsRepo{}, h, &mockTokenIssuer{}, refreshConfig, identityConfig)
+
+		_, err := uc.Execute(context.Background(), user.RefreshSessionInput{RefreshToken: stored.value})
+
+		assertErrorCode(t, err, msg.CodeUnauthorized)
+		require.Len(t, stored.revoked, 1)
+	})
+
+	t.Run("Failure: should reject a wrong secret without revoking", func(t *testing.T) {
+		u := newActiveUser()
+		stored := newStoredRefreshToken(t, h, u.ID)
+		uc := usecase.NewRefreshSessionUseCase(stored.repo(), usersReturning(u), &mockUserPermissionsRepo{}, h, &mockTokenIssuer{}, refreshConfig, identityConfig)
+
+		_, err := uc.Execute(context.Background(), user.RefreshSessionInput{RefreshToken: stored.token.ID.String() + ".forged"})
+
+		assertErrorCode(t, err, msg.CodeUnauthorized)
+		assert.Empty(t, stored.revoked)
+	})
+
+	t.Run("Failure: should reject unknown, revoked and expired tokens", func(t *testing.T) {
+		u := newActiveUser()
+
+		unknown := newStoredRefreshToken(t, h, u.ID)
+		other := newStoredRefreshToken(t, h, u.ID)
+		uc := usecase.NewRefreshSessionUseCase(unknown.repo(), usersReturning(u), &mockUserPermissionsRepo{}, h, &mockTokenIssuer{}, refreshConfig, identityConfig)
+		_, err := uc.Execute(context.Background(), user.RefreshSessionInput{RefreshToken: other.value})
+		assertErrorCode(t, err, msg.CodeUnauthorized)
+
+		revoked := newStoredRefreshToken(t, h, u.ID)
+		revoked.token.RevokedAt = types.NullableTime{NullTime: sql.NullTime{Time: time.Now(), Valid: true}}
+		uc = usecase.NewRefreshSessionUseCase(revoked.repo(), usersReturning(u), &mockUserPermissionsRepo{}, h, &mockTokenIssuer{}, refreshConfig, identityConfig)
+		_, err = uc.Execute(context.Background(), user.RefreshSessionInput{RefreshToken: revoked.value})
+		assertErrorCode(t, err, msg.CodeUnauthorized)
+
+		expired := newStoredRefreshToken(t, h, u.ID)
+		expired.token.ExpiresAt = time.Now().Add(-time.Minute)
+		uc = usecase.NewRefreshSessionUseCase(expired.repo(), usersReturning(u), &mockUserPermissionsRepo{}, h, &mockTokenIssuer{}, refreshConfig, identityConfig)
+		_, err = uc.Execute(context.Background(), user.RefreshSessionInput{RefreshToken: expired.value})
+		assertErrorCode(t, err, msg.CodeUnauthorized)
+
+		_, err = uc.Execute(context.Background(), user.RefreshSessionInput{RefreshToken: "not-a-token"})
+		assertErrorCode(t, err, msg.CodeUnauthorized)
+	})
+
+	t.Run("Failure: should revoke the session of a deleted user", func(t *testing.T) {
+		u := newActiveUser()
+		stored := newStoredRefreshToken(t, h, u.ID)
+		uc := usecase.NewRefreshSessionUseCase(stored.repo(), &mockFindUserByIDRepo{}, &mockUserPermissionsRepo{}, h, &mockTokenIssuer{}, refreshConfig, identityConfig)
+
+		_, err := uc.Execute(context.Background(), user.RefreshSessionInput{RefreshToken: stored.value})
+
+		assertErrorCode(t, err, msg.CodeUnauthorized)
+		require.Len(t, stored.revoked, 1)
+	})
+
+	t.Run("Failure: should revoke the session of an archived user", func(t *testing.T) {
+		u := newActiveUser()
+		u.Archive()
+		stored := newStoredRefreshToken(t, h, u.ID)
+		uc := usecase.NewRefreshSessionUseCase(stored.repo(), usersReturning(u), &mockUserPermissionsRepo{}, h, &mockTokenIssuer{}, refreshConfig, identityConfig)
+
+		_, err := uc.Execute(context.Background(), user.RefreshSessionInput{RefreshToken: stored.value})
+
+		assertErrorCode(t, err, msg.CodeForbidden)
+		require.Len(t, stored.revoked, 1)
+		assert.Empty(t, stored.rotated)
+	})
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/role"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type assignRoleUseCase struct {
+	roles role.RoleAssignmentRepository
+	users user.FindUserByIDRepository
+}
+
+func NewAssignRoleUseCase(roles role.RoleAssignmentRepository, users user.FindUserByIDRepository) role.AssignRoleUseCase {
+	return &assignRoleUseCase{
+		roles: roles,
+		users: users,
+	}
+}
+
+func (uc *assignRoleUseCase) Execute(ctx context.Context, input role.RoleAssignmentUseCaseInput) (role.RoleAssignmentOutput, error) {
+	assignment, err := loadAssignment(ctx, uc.roles, uc.users, input)
+	if err != nil {
+		return role.RoleAssignmentOutput{}, err
+	}
+
+	changed, err := uc.roles.AssignRole(ctx, assignment)
+	if err != nil {
+		return role.RoleAssignmentOutput{}, keepMessageError(err)
+	}
+
+	return role.RoleAssignmentOutput{Assignment: assignment, Changed: changed}, nil
+}
+
+type revokeRoleUseCase struct {
+	roles role.RoleAssignmentRepository
+	users user.FindUserByIDRepository
+}
+
+func NewRevokeRoleUseCase(roles role.RoleAssignmentRepository, users user.FindUserByIDRepository) role.RevokeRoleUseCase {
+	return &revokeRoleUseCase{
+		roles: roles,
+		users: users,
+	}
+}
+
+func (uc *revokeRoleUseCase) Execute(ctx context.Context, input role.RoleAssignmentUseCaseInput) (role.RoleAssignmentOutput, error) {
+	assignment, err := loadAssignment(ctx, uc.roles, uc.users, input)
+	if err != nil {
+		return role.RoleAssignmentOutput{}, err
+	}
+
+	// Keeps the last administrator from locking everyone out by accident.
+	if isAuthor(input.AssignedBy, input.UserID) && slices.Contains(assignment.Role.Permissions, role.PermissionRolesAssign) {
+		return role.RoleAssignmentOutput{}, msg.NewMessageError(nil, role.ErrRoleRevokeOwnAssign, msg.CodeForbidden, map[string]any{
+			"role": assignment.Role.Name,
+		})
+	}
+
+	changed, err := uc.roles.RevokeRole(ctx, role.RevokeRoleRepoInput{UserID: input.UserID, RoleID: assignment.Role.ID})
+	if err != nil {
+		return role.RoleAssignmentOutput{}, keepMessageError(err)
+	}
+
+	return role.RoleAssignmentOutput{Assignment: assignment, Changed: changed}, nil
+}
+
+// loadAssignment checks that the user and the role exist. Soft-deleted users
+// are not found.
+func loadAssignment(
+	ctx context.Context,
+	roles role.FindRoleByNameRepository,
+	users user.FindUserByIDRepository,
+	input role.RoleAssignmentUseCaseInput,
+) (*role.Assignment, error) {
+	u, err := users.FindUserByID(ctx, user.FindUserByIDRepoInput{UserID: input.UserID})
+	if err != nil {
+		return nil, keepMessageError(err)
+	}
+
+	r, err := roles.FindRoleByName(ctx, input.Role)
+	if err != nil {
+		return nil, keepMessageError(err)
+	}
+
+	return role.NewAssignment(u.ID, r, input.AssignedBy), nil
+}
+
+func isAuthor(author types.NullableUUID, userID types.UUID) bool {
+	id, ok := author.GetUUID()
+	return ok && id == userID
+}
@@ -0,0 +1,176 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/usecase"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/role"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type mockRoleAssignmentRepo struct {
+	FindRoleByNameFunc func(ctx context.Context, name string) (*role.Role, error)
+	AssignRoleFunc     func(ctx context.Context, assignment *role.Assignment) (bool, error)
+	RevokeRoleFunc     func(ctx context.Context, input role.RevokeRoleRepoInput) (bool, error)
+}
+
+func (m *mockRoleAssignmentRepo) FindRoleByName(ctx context.Context, name string) (*role.Role, error) {
+	if m.FindRoleByNameFunc != nil {
+		return m.FindRoleByNameFunc(ctx, name)
+	}
+	return nil, msg.NewMessageError(nil, role.ErrRoleNotFound, msg.CodeNotFound, nil)
+}
+
+func (m *mockRoleAssignmentRepo) AssignRole(ctx context.Context, assignment *role.Assignment) (bool, error) {
+	if m.AssignRoleFunc != nil {
+		return m.AssignRoleFunc(ctx, assignment)
+	}
+	return true, nil
+}
+
+func (m *mockRoleAssignmentRepo) RevokeRole(ctx context.Context, input role.RevokeRoleRepoInput) (bool, error) {
+	if m.RevokeRoleFunc != nil {
+		return m.RevokeRoleFunc(ctx, input)
+	}
+	return true, nil
+}
+
+func TestAssignRoleUseCase_Execute(t *testing.T) {
+	admin := &role.Role{ID: types.MustNewUUID(), Name: "admin", Permissions: []string{role.PermissionUsersRead, role.PermissionRolesAssign}}
+	author := types.NewValidNullableUUID(types.MustNewUUID())
+
+	rolesWith := func(r *role.Role) *mockRoleAssignmentRepo {
+		return &mockRoleAssignmentRepo{
+			FindRoleByNameFunc: func(ctx context.Context, name string) (*role.Role, error) {
+				return r, nil
+			},
+		}
+	}
+	usersWith := func(u *user.User) *mockFindUserByIDRepo {
+		return &mockFindUserByIDRepo{
+			FindUserByIDFunc: func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+				return u, nil
+			},
+		}
+	}
+
+	t.Run("Success: should assign the role to the user", func(t *testing.T) {
+		u := newActiveUser()
+		var stored *role.Assignment
+		roles := rolesWith(admin)
+		roles.AssignRoleFunc = func(ctx context.Context, assignment *role.Assignment) (bool, error) {
+			stored = assignment
+			return true, nil
+		}
+		uc := usecase.NewAssignRoleUseCase(roles, usersWith(u))
+
+		output, err := uc.Execute(context.Background(), role.RoleAssignmentUseCaseInput{UserID: u.ID, Role: "admin", AssignedBy: author})
+
+		require.NoError(t, err)
+		assert.True(t, output.Changed)
+		require.NotNil(t, stored)
+		assert.Equal(t, u.ID, stored.UserID)
+		assert.Equal(t, admin, stored.Role)
+		assert.Equal(t, author, stored.AssignedBy)
+	})
+
+	t.Run("Success: should report no change when the user already has the role", func(t *testing.T) {
+		u := newActiveUser()
+		roles := rolesWith(admin)
+		roles.AssignRoleFunc = func(ctx context.Context, assignment *role.Assignment) (bool, error) {
+			return false, nil
+		}
+		uc := usecase.NewAssignRoleUseCase(roles, usersWith(u))
+
+		output, err := uc.Execute(context.Background(), role.RoleAssignmentUseCaseInput{UserID: u.ID, Role: "admin"})
+
+		require.NoError(t, err)
+		assert.False(t, output.Changed)
+	})
+
+	t.Run("Failure: should return not found for an unknown user or role", func(t *testing.T) {
+		u := newActiveUser()
+
+		uc := usecase.NewAssignRoleUseCase(rolesWith(admin), &mockFindUserByIDRepo{})
+		_, err := uc.Execute(context.Background(), role.RoleAssignmentUseCaseInput{UserID: u.ID, Role: "admin"})
+		assertErrorCode(t, err, msg.CodeNotFound)
+
+		uc = usecase.NewAssignRoleUseCase(&mockRoleAssignmentRepo{}, usersWith(u))
+		_, err = uc.Execute(context.Background(), role.RoleAssignmentUseCaseInput{UserID: u.ID, Role: "owner"})
+		assertErrorCode(t, err, msg.CodeNotFound)
+	})
+}
+
+func TestRevokeRoleUseCase_Execute(t *testing.T) {
+	admin := &role.Role{ID: types.MustNewUUID(), Name: "admin", Permissions: []string{role.PermissionUsersRead, role.PermissionRolesAssign}}
+	viewer := &role.Role{ID: types.MustNewUUID(), Name: "viewer", Permissions: []string{role.PermissionUsersRead}}
+
+	setup := func(r *role.Role, u *user.User) (*mockRoleAssignmentRepo, *[]role.RevokeRoleRepoInput, role.RevokeRoleUseCase) {
+		var revoked []role.RevokeRoleRepoInput
+		roles := &mockRoleAssignmentRepo{
+			FindRoleByNameFunc: func(ctx context.Context, name string) (*role.Role, error) {
+				return r, nil
+			},
+			RevokeRoleFunc: func(ctx context.Context, input role.RevokeRoleRepoInput) (bool, error) {
+				revoked = append(revoked, input)
+				return true, nil
+			},
+		}
+		users := &mockFindUserByIDRepo{
+			FindUserByIDFunc: func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+				return u, nil
+			},
+		}
+		return roles, &revoked, usecase.NewRevokeRoleUseCase(roles, users)
+	}
+
+	t.Run("Success: should revoke the role of another user", func(t *testing.T) {
+		u := newActiveUser()
+		_, revoked, uc := setup(admin, u)
+
+		output, err := uc.Execute(context.Background(), role.RoleAssignmentUseCaseInput{
+			UserID:     u.ID,
+			Role:       "admin",
+			AssignedBy: types.NewValidNullableUUID(types.MustNewUUID()),
+		})
+
+		require.NoError(t, err)
+		assert.True(t, output.Changed)
+		require.Len(t, *revoked, 1)
+		assert.Equal(t, role.RevokeRoleRepoInput{UserID: u.ID, RoleID: admin.ID}, (*revoked)[0])
+	})
+
+	t.Run("Success: should let users drop their own roles that do not manage roles", func(t *testing.T) {
+		u := newActiveUser()
+		_, revoked, uc := setup(viewer, u)
+
+		_, err := uc.Execute(context.Background(), role.RoleAssignmentUseCaseInput{
+			UserID:     u.ID,
+			Role:       "viewer",
+			AssignedBy: types.NewValidNullableUUID(u.ID),
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, *revoked, 1)
+	})
+
+	t.Run("Failure: should forbid revoking your own permission to manage roles", func(t *testing.T) {
+		u := newActiveUser()
+		_, revoked, uc := setup(admin, u)
+
+		_, err := uc.Execute(context.Background(), role.RoleAssignmentUseCaseInput{
+			UserID:     u.ID,
+			Role:       "admin",
+			AssignedBy: types.NewValidNullableUUID(u.ID),
+		})
+
+		assertErrorCode(t, err, msg.CodeForbidden)
+		assert.Empty(t, *revoked)
+	})
+}
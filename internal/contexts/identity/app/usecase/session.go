@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/role"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/config"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/hasher"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/token"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+// sessionIssuer hands out the access and refresh tokens of a session, shared
+// by every way of logging in.
+type sessionIssuer struct {
+	tokens      user.CreateRefreshTokenRepository
+	permissions role.UserPermissionsRepository
+	hasher      hasher.Hasher
+	issuer      token.Issuer
+	refreshTTL  time.Duration
+	tenantID    string
+}
+
+func newSessionIssuer(
+	tokens user.CreateRefreshTokenRepository,
+	permissions role.UserPermissionsRepository,
+	h hasher.Hasher,
+	issuer token.Issuer,
+	cfg config.RefreshTokenConfig,
+	identity config.IdentityConfig,
+) sessionIssuer {
+	return sessionIssuer{
+		tokens:      tokens,
+		permissions: permissions,
+		hasher:      h,
+		issuer:      issuer,
+		refreshTTL:  time.Duration(cfg.ExpiryHours) * time.Hour,
+		tenantID:    identity.TenantID,
+	}
+}
+
+// start opens a new session, i.e. a new refresh token family, for the user.
+func (s sessionIssuer) start(ctx context.Context, userID types.UUID) (user.Session, error) {
+	refresh, issued, err := s.newRefreshToken(userID, types.Nil)
+	if err != nil {
+		return user.Session{}, err
+	}
+	if err := s.tokens.CreateRefreshToken(ctx, user.CreateRefreshTokenRepoInput{Token: refresh}); err != nil {
+		return user.Session{}, keepMessageError(err)
+	}
+	return s.withAccessToken(ctx, userID, issued)
+}
+
+func (s sessionIssuer) newRefreshToken(userID, familyID types.UUID) (*user.RefreshToken, user.IssuedRefreshToken, error) {
+	return user.NewRefreshToken(user.NewRefreshTokenInput{
+		UserID:   userID,
+		FamilyID: familyID,
+		TTL:      s.refreshTTL,
+	}, s.hasher)
+}
+
+// withAccessToken issues the access token with the permissions the user has
+// now, so role changes take effect on the next refresh, scoped to the tenant
+// of the deployment.
+func (s sessionIssuer) withAccessToken(ctx context.Context, userID types.UUID, refresh user.IssuedRefreshToken) (user.Session, error) {
+	permissions, err := s.permissions.FindUserPermissions(ctx, userID)
+	if err != nil {
+		return user.Session{}, keepMessageError(err)
+	}
+
+	accessToken, err := s.issuer.Issue(ctx, token.IssueInput{Subject: userID, TenantID: s.tenantID, Permissions: permissions})
+	if err != nil {
+		return user.Session{}, msg.NewInternalError(err, map[string]any{"operation": user.ErrLoginOperationIssueToken})
+	}
+	return user.Session{AccessToken: accessToken, RefreshToken: refresh}, nil
+}
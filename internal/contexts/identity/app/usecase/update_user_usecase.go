@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+)
+
+type updateUserUseCase struct {
+	repo user.UpdateUserRepository
+}
+
+func NewUpdateUserUseCase(repo user.UpdateUserRepository) user.UpdateUserUseCase {
+	return &updateUserUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *updateUserUseCase) Execute(ctx context.Context, input user.UpdateUserUseCaseInput) (user.UpdateUserOutput, error) {
+	u, err := uc.repo.FindUserByID(ctx, user.FindUserByIDRepoInput{UserID: input.UserID})
+	if err != nil {
+		return user.UpdateUserOutput{}, keepMessageError(err)
+	}
+
+	if err := checkExpectedVersion(u, input.ExpectedVersion); err != nil {
+		return user.UpdateUserOutput{}, err
+	}
+
+	before := *u
+	if err := u.Update(input.Changes); err != nil {
+		return user.UpdateUserOutput{}, err
+	}
+
+	changed := u.ChangedFields(before)
+	if len(changed) == 0 {
+		return user.UpdateUserOutput{User: &before}, nil
+	}
+
+	repoInput := user.UpdateUserRepoInput{
+		User:            u,
+		ExpectedVersion: before.Version,
+	}
+
+	if err := uc.repo.UpdateUser(ctx, repoInput); err != nil {
+		return user.UpdateUserOutput{}, keepMessageError(err)
+	}
+
+	return user.UpdateUserOutput{User: u, ChangedFields: changed}, nil
+}
+
+// keepMessageError passes on errors the repository already classified, such
+// as not_found or conflict, and hides anything else as internal.
+func keepMessageError(err error) error {
+	var msgErr *msg.MessageError
+	if errors.As(err, &msgErr) {
+		return msgErr
+	}
+	return msg.NewInternalError(err, nil)
+}
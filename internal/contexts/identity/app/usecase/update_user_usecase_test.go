@@ -0,0 +1,179 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/usecase"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/hasher"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type mockUpdateUserRepo struct {
+	FindUserByIDFunc func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error)
+	UpdateUserFunc   func(ctx context.Context, input user.UpdateUserRepoInput) error
+}
+
+func (m *mockUpdateUserRepo) FindUserByID(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+	if m.FindUserByIDFunc != nil {
+		return m.FindUserByIDFunc(ctx, input)
+	}
+	return nil, nil
+}
+
+func (m *mockUpdateUserRepo) UpdateUser(ctx context.Context, input user.UpdateUserRepoInput) error {
+	if m.UpdateUserFunc != nil {
+		return m.UpdateUserFunc(ctx, input)
+	}
+	return nil
+}
+
+func TestUpdateUserUseCase_Execute(t *testing.T) {
+	newStoredUser := func(t *testing.T) *user.User {
+		u, err := user.NewUser(user.NewUserInput{
+			Name:     "Test User",
+			Email:    "test@example.com",
+			Phone:    "5562999998888",
+			Password: "ValidPassword123!",
+		}, hasher.NewHasher())
+		require.NoError(t, err, "Setup: Failed to create user")
+		return u
+	}
+
+	t.Run("Success: should persist the changes guarded by the loaded version", func(t *testing.T) {
+		stored := newStoredUser(t)
+		var repoInput user.UpdateUserRepoInput
+		mockRepo := &mockUpdateUserRepo{
+			FindUserByIDFunc: func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+				assert.False(t, input.IncludeDeleted, "Deleted users should not be updatable")
+				return stored, nil
+			},
+			UpdateUserFunc: func(ctx context.Context, input user.UpdateUserRepoInput) error {
+				repoInput = input
+				return nil
+			},
+		}
+		uc := usecase.NewUpdateUserUseCase(mockRepo)
+
+		output, err := uc.Execute(context.Background(), user.UpdateUserUseCaseInput{
+			UserID:          stored.ID,
+			ExpectedVersion: types.Version(1),
+			Changes:         user.UpdateUserInput{Name: "New Name", Email: "test@example.com"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "New Name", output.User.Name)
+		assert.Equal(t, types.Version(2), output.User.Version, "Version should be incremented")
+		assert.Equal(t, []string{user.FieldName}, output.ChangedFields, "Fields set to their current value should not be reported")
+		assert.Equal(t, types.Version(1), repoInput.ExpectedVersion, "Write should be guarded by the loaded version")
+	})
+
+	t.Run("Success: should not persist when nothing changes", func(t *testing.T) {
+		stored := newStoredUser(t)
+		mockRepo := &mockUpdateUserRepo{
+			FindUserByIDFunc: func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+				return stored, nil
+			},
+			UpdateUserFunc: func(ctx context.Context, input user.UpdateUserRepoInput) error {
+				t.Fatal("UpdateUser should not be called without changes")
+				return nil
+			},
+		}
+		uc := usecase.NewUpdateUserUseCase(mockRepo)
+
+		output, err := uc.Execute(context.Background(), user.UpdateUserUseCaseInput{
+			UserID:  stored.ID,
+			Changes: user.UpdateUserInput{Name: "Test User"},
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, output.ChangedFields)
+		assert.Equal(t, types.Version(1), output.User.Version, "Version should be kept when nothing changes")
+	})
+
+	t.Run("Failure: should return precondition failed when the version does not match", func(t *testing.T) {
+		stored := newStoredUser(t)
+		mockRepo := &mockUpdateUserRepo{
+			FindUserByIDFunc: func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+				return stored, nil
+			},
+		}
+		uc := usecase.NewUpdateUserUseCase(mockRepo)
+
+		_, err := uc.Execute(context.Background(), user.UpdateUserUseCaseInput{
+			UserID:          stored.ID,
+			ExpectedVersion: types.Version(4),
+			Changes:         user.UpdateUserInput{Name: "New Name"},
+		})
+
+		var msgErr *msg.MessageError
+		require.ErrorAs(t, err, &msgErr)
+		assert.Equal(t, msg.CodePreconditionFailed, msgErr.Code)
+		assert.Equal(t, user.ErrUserVersionMismatch, msgErr.Message)
+	})
+
+	t.Run("Failure: should keep the conflict error of a concurrent update", func(t *testing.T) {
+		stored := newStoredUser(t)
+		mockRepo := &mockUpdateUserRepo{
+			FindUserByIDFunc: func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+				return stored, nil
+			},
+			UpdateUserFunc: func(ctx context.Context, input user.UpdateUserRepoInput) error {
+				return msg.NewMessageError(nil, user.ErrUserConcurrentUpdate, msg.CodeConflict, nil)
+			},
+		}
+		uc := usecase.NewUpdateUserUseCase(mockRepo)
+
+		_, err := uc.Execute(context.Background(), user.UpdateUserUseCaseInput{
+			UserID:  stored.ID,
+			Changes: user.UpdateUserInput{Name: "New Name"},
+		})
+
+		var msgErr *msg.MessageError
+		require.ErrorAs(t, err, &msgErr)
+		assert.Equal(t, msg.CodeConflict, msgErr.Code)
+	})
+
+	t.Run("Failure: should keep the not found error of the repository", func(t *testing.T) {
+		mockRepo := &mockUpdateUserRepo{
+			FindUserByIDFunc: func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+				return nil, msg.NewMessageError(nil, user.ErrUserNotFound, msg.CodeNotFound, nil)
+			},
+		}
+		uc := usecase.NewUpdateUserUseCase(mockRepo)
+
+		_, err := uc.Execute(context.Background(), user.UpdateUserUseCaseInput{UserID: types.MustNewUUID()})
+
+		var msgErr *msg.MessageError
+		require.ErrorAs(t, err, &msgErr)
+		assert.Equal(t, msg.CodeNotFound, msgErr.Code)
+	})
+
+	t.Run("Failure: should return internal error if the update fails", func(t *testing.T) {
+		stored := newStoredUser(t)
+		mockRepo := &mockUpdateUserRepo{
+			FindUserByIDFunc: func(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+				return stored, nil
+			},
+			UpdateUserFunc: func(ctx context.Context, input user.UpdateUserRepoInput) error {
+				return errors.New("database connection error")
+			},
+		}
+		uc := usecase.NewUpdateUserUseCase(mockRepo)
+
+		_, err := uc.Execute(context.Background(), user.UpdateUserUseCaseInput{
+			UserID:  stored.ID,
+			Changes: user.UpdateUserInput{Phone: "5562988887777"},
+		})
+
+		var msgErr *msg.MessageError
+		require.ErrorAs(t, err, &msgErr)
+		assert.Equal(t, msg.CodeInternal, msgErr.Code)
+	})
+}
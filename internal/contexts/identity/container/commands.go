@@ -0,0 +1,120 @@
+package container
+
+import (
+	"context"
+
+	"go.uber.org/dig"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/role"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+)
+
+// Commands groups the identity command handlers registered on the command bus.
+type Commands struct {
+	dig.In
+
+	CreateUser     user.CreateUserCommand
+	UpdateUser     user.UpdateUserCommand
+	ArchiveUser    user.ArchiveUserCommand
+	UnarchiveUser  user.UnarchiveUserCommand
+	DeleteUser     user.DeleteUserCommand
+	RestoreUser    user.RestoreUserCommand
+	ForgetUser     user.ForgetUserCommand
+	Login          user.LoginCommand
+	RefreshSession user.RefreshSessionCommand
+	Logout         user.LogoutCommand
+	LogoutAll      user.LogoutAllCommand
+	AssignRole     role.AssignRoleCommand
+	RevokeRole     role.RevokeRoleCommand
+
+	StartExternalLogin user.StartExternalLoginCommand
+	ExternalLogin      user.ExternalLoginCommand
+
+	ChangePassword       user.ChangePasswordCommand
+	RequestPasswordReset user.RequestPasswordResetCommand
+	ResetPassword        user.ResetPasswordCommand
+
+	RequestEmailVerification user.RequestEmailVerificationCommand
+	VerifyEmail              user.VerifyEmailCommand
+
+	RequestPhoneVerification user.RequestPhoneVerificationCommand
+	VerifyPhone              user.VerifyPhoneCommand
+}
+
+func RegisterCommands(registry bus.CommandRegistry, commands Commands) error {
+	if err := bus.HandleCommand(registry, commands.CreateUser.Execute); err != nil {
+		return err
+	}
+	if err := bus.HandleCommand(registry, commands.UpdateUser.Execute); err != nil {
+		return err
+	}
+	if err := bus.HandleCommand(registry, commands.ArchiveUser.Execute); err != nil {
+		return err
+	}
+	if err := bus.HandleCommand(registry, commands.UnarchiveUser.Execute); err != nil {
+		return err
+	}
+	if err := bus.HandleCommand(registry, commands.DeleteUser.Execute); err != nil {
+		return err
+	}
+	if err := bus.HandleCommand(registry, commands.RestoreUser.Execute); err != nil {
+		return err
+	}
+	if err := bus.HandleCommand(registry, commands.Login.Execute); err != nil {
+		return err
+	}
+	if err := bus.HandleCommand(registry, commands.RefreshSession.Execute); err != nil {
+		return err
+	}
+	if err := bus.HandleCommand(registry, commands.LogoutAll.Execute); err != nil {
+		return err
+	}
+	if err := bus.HandleCommand(registry, commands.StartExternalLogin.Execute); err != nil {
+		return err
+	}
+	if err := bus.HandleCommand(registry, commands.ExternalLogin.Execute); err != nil {
+		return err
+	}
+	if err := bus.HandleCommand(registry, commands.ChangePassword.Execute); err != nil {
+		return err
+	}
+	if err := bus.HandleCommand(registry, commands.ResetPassword.Execute); err != nil {
+		return err
+	}
+	if err := bus.HandleCommand(registry, commands.VerifyEmail.Execute); err != nil {
+		return err
+	}
+	if err := bus.HandleCommand(registry, commands.VerifyPhone.Execute); err != nil {
+		return err
+	}
+	if err := bus.HandleCommand(registry, commands.AssignRole.Execute); err != nil {
+		return err
+	}
+	if err := bus.HandleCommand(registry, commands.RevokeRole.Execute); err != nil {
+		return err
+	}
+	if err := bus.HandleCommand(registry, func(ctx context.Context, input user.LogoutCommandInput) (struct{}, error) {
+		return struct{}{}, commands.Logout.Execute(ctx, input)
+	}); err != nil {
+		return err
+	}
+	if err := bus.HandleCommand(registry, func(ctx context.Context, input user.RequestPasswordResetCommandInput) (struct{}, error) {
+		return struct{}{}, commands.RequestPasswordReset.Execute(ctx, input)
+	}); err != nil {
+		return err
+	}
+	if err := bus.HandleCommand(registry, func(ctx context.Context, input user.RequestEmailVerificationCommandInput) (struct{}, error) {
+		return struct{}{}, commands.RequestEmailVerification.Execute(ctx, input)
+	}); err != nil {
+		return err
+	}
+	if err := bus.HandleCommand(registry, func(ctx context.Context, input user.RequestPhoneVerificationCommandInput) (struct{}, error) {
+		return struct{}{}, commands.RequestPhoneVerification.Execute(ctx, input)
+	}); err != nil {
+		return err
+	}
+	return bus.HandleCommand(registry, func(ctx context.Context, input user.ForgetUserCommandInput) (struct{}, error) {
+		return struct{}{}, commands.ForgetUser.Execute(ctx, input)
+	})
+}
@@ -0,0 +1,57 @@
+package container
+
+import (
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/role"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+)
+
+const (
+	contextName    = "identity"
+	identityStream = "identity-stream"
+)
+
+func RegisterEvents(catalog *event.Catalog) error {
+	definitions := []event.Definition{
+		user.UserCreatedEvent.Describe(identityStream, contextName,
+			"A new user registered in the identity context."),
+		user.UserUpdatedEvent.Describe(identityStream, contextName,
+			"A user profile changed; carries the changed fields and their new values."),
+		user.UserArchivedEvent.Describe(identityStream, contextName,
+			"A user was archived and can no longer sign in."),
+		user.UserUnarchivedEvent.Describe(identityStream, contextName,
+			"An archived user was reactivated."),
+		user.UserDeletedEvent.Describe(identityStream, contextName,
+			"A user was soft-deleted and will be purged after the retention period."),
+		user.UserRestoredEvent.Describe(identityStream, contextName,
+			"A soft-deleted user was restored before being purged."),
+		user.UserPurgedEvent.Describe(identityStream, contextName,
+			"A soft-deleted user was permanently removed; consumers should drop or redact its data."),
+		user.UserLoggedInEvent.Describe(identityStream, contextName,
+			"A user logged in with a password or an identity provider and received an access token."),
+		user.UserLoginFailedEvent.Describe(identityStream, contextName,
+			"A login was rejected; carries the method, the reason and the user when known."),
+		user.UserPasswordChangedEvent.Describe(identityStream, contextName,
+			"A user changed its password or reset it with a token; every session was revoked."),
+		user.UserPasswordResetRequestedEvent.Describe(identityStream, contextName,
+			"A user asked for a password reset; carries the encrypted single-use token to email."),
+		user.UserEmailVerificationRequestedEvent.Describe(identityStream, contextName,
+			"A user registered or asked to resend the verification; carries the encrypted single-use token to email."),
+		user.UserEmailVerifiedEvent.Describe(identityStream, contextName,
+			"A user proved it owns its email address."),
+		user.UserPhoneVerificationRequestedEvent.Describe(identityStream, contextName,
+			"A user asked to verify its phone; an SMS delivery request carrying the encrypted one-time code."),
+		user.UserPhoneVerifiedEvent.Describe(identityStream, contextName,
+			"A user proved it owns its phone number."),
+		role.RoleAssignedEvent.Describe(identityStream, contextName,
+			"A role was assigned to a user; its permissions apply from the next login or refresh."),
+		role.RoleRevokedEvent.Describe(identityStream, contextName,
+			"A role was revoked from a user; its permissions are dropped from the next login or refresh."),
+	}
+	for _, def := range definitions {
+		if err := catalog.Register(def); err != nil {
+			return err
+		}
+	}
+	return nil
+}
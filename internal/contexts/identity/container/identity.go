@@ -4,12 +4,17 @@ import (
 	"go.uber.org/dig"
 
 	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/command"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/job"
 	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/publisher"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/query"
 	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/app/usecase"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/role"
 	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
 	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/infra/http"
 	storage "github.com/marcelofabianov/redtogreen/internal/contexts/identity/infra/storage"
 	pDB "github.com/marcelofabianov/redtogreen/internal/platform/port/database"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/hasher"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/pii"
 )
 
 func Register(container *dig.Container) error {
@@ -32,12 +37,210 @@ func registerApp(container *dig.Container) error {
 	if err := container.Provide(func(p user.UserPublisher) user.UserCreatedEventPublisher { return p }); err != nil {
 		return err
 	}
+	if err := container.Provide(func(p user.UserPublisher) user.UserEmailVerificationEventPublisher { return p }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(p user.UserPublisher) user.CreateUserEventPublisher { return p }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(p user.UserPublisher) user.UserPhoneVerificationEventPublisher { return p }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(p user.UserPublisher) user.UserUpdatedEventPublisher { return p }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(p user.UserPublisher) user.UserArchivedEventPublisher { return p }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(p user.UserPublisher) user.UserDeletedEventPublisher { return p }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(p user.UserPublisher) user.UserPurgedEventPublisher { return p }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(p user.UserPublisher) user.UserLoginEventPublisher { return p }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(p user.UserPublisher) user.ExternalLoginEventPublisher { return p }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(p user.UserPublisher) user.UserPasswordEventPublisher { return p }); err != nil {
+		return err
+	}
+	if err := container.Provide(publisher.NewRolePublisher); err != nil {
+		return err
+	}
+	if err := container.Provide(func(p *publisher.RolePublisher) role.RoleAssignmentEventPublisher { return p }); err != nil {
+		return err
+	}
 	if err := container.Provide(usecase.NewCreateUserUseCase); err != nil {
 		return err
 	}
 	if err := container.Provide(command.NewCreateUserCommand); err != nil {
 		return err
 	}
+	if err := container.Provide(usecase.NewForgetUserUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(command.NewForgetUserCommand); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewUpdateUserUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(command.NewUpdateUserCommand); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewArchiveUserUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewUnarchiveUserUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(command.NewArchiveUserCommand); err != nil {
+		return err
+	}
+	if err := container.Provide(command.NewUnarchiveUserCommand); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewDeleteUserUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewRestoreUserUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(command.NewDeleteUserCommand); err != nil {
+		return err
+	}
+	if err := container.Provide(command.NewRestoreUserCommand); err != nil {
+		return err
+	}
+	type purgeDeletedUsersParams struct {
+		dig.In
+		DB       pDB.DB `name:"mainDB"`
+		Repo     user.PurgeDeletedUsersRepository
+		Shredder pii.Shredder
+	}
+	if err := container.Provide(func(p purgeDeletedUsersParams) user.PurgeDeletedUsersUseCase {
+		return usecase.NewPurgeDeletedUsersUseCase(p.DB, p.Repo, p.Shredder)
+	}); err != nil {
+		return err
+	}
+	if err := container.Provide(command.NewPurgeDeletedUsersCommand); err != nil {
+		return err
+	}
+	if err := container.Provide(job.NewPurgeDeletedUsersJob); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewLoginUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(command.NewLoginCommand); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewRefreshSessionUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(command.NewRefreshSessionCommand); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewLogoutUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(command.NewLogoutCommand); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewLogoutAllUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(command.NewLogoutAllCommand); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewStartExternalLoginUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(command.NewStartExternalLoginCommand); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewExternalLoginUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(command.NewExternalLoginCommand); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewChangePasswordUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(command.NewChangePasswordCommand); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewRequestPasswordResetUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(command.NewRequestPasswordResetCommand); err != nil {
+		return err
+	}
+	type resetPasswordParams struct {
+		dig.In
+		DB     pDB.DB `name:"mainDB"`
+		Repo   user.UpdateUserPasswordRepository
+		Resets user.PasswordResetTokenRepository
+		Tokens user.RefreshTokenRepository
+		Hasher hasher.Hasher
+	}
+	if err := container.Provide(func(p resetPasswordParams) user.ResetPasswordUseCase {
+		return usecase.NewResetPasswordUseCase(p.DB, p.Repo, p.Resets, p.Tokens, p.Hasher)
+	}); err != nil {
+		return err
+	}
+	if err := container.Provide(command.NewResetPasswordCommand); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewRequestEmailVerificationUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(command.NewRequestEmailVerificationCommand); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewVerifyEmailUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(command.NewVerifyEmailCommand); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewRequestPhoneVerificationUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(command.NewRequestPhoneVerificationCommand); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewVerifyPhoneUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(command.NewVerifyPhoneCommand); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewAssignRoleUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(command.NewAssignRoleCommand); err != nil {
+		return err
+	}
+	if err := container.Provide(usecase.NewRevokeRoleUseCase); err != nil {
+		return err
+	}
+	if err := container.Provide(command.NewRevokeRoleCommand); err != nil {
+		return err
+	}
+	if err := container.Provide(query.NewUserStatusQueryHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(query.NewGetUserByIDQuery); err != nil {
+		return err
+	}
+	if err := container.Provide(query.NewListUsersQuery); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -55,10 +258,154 @@ func registerInfra(container *dig.Container) error {
 	if err := container.Provide(func(repo user.UserRepository) user.CreateUserRepository { return repo }); err != nil {
 		return err
 	}
+	if err := container.Provide(func(repo user.UserRepository) user.UserStatusRepository { return repo }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(repo user.UserRepository) user.FindUserByIDRepository { return repo }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(repo user.UserRepository) user.ListUsersRepository { return repo }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(repo user.UserRepository) user.UpdateUserRepository { return repo }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(repo user.UserRepository) user.UpdateUserStatusRepository { return repo }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(repo user.UserRepository) user.PurgeDeletedUsersRepository { return repo }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(repo user.UserRepository) user.FindUserByLoginRepository { return repo }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(repo user.UserRepository) user.UpdateUserPasswordRepository { return repo }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(repo user.UserRepository) user.VerifyUserEmailRepository { return repo }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(repo user.UserRepository) user.VerifyUserPhoneRepository { return repo }); err != nil {
+		return err
+	}
+
+	if err := container.Provide(func(p userRepoParams) user.RefreshTokenRepository {
+		return storage.NewRefreshTokenRepository(p.DB)
+	}); err != nil {
+		return err
+	}
+	if err := container.Provide(func(repo user.RefreshTokenRepository) user.CreateRefreshTokenRepository { return repo }); err != nil {
+		return err
+	}
+
+	if err := container.Provide(func(p userRepoParams) user.ExternalIdentityRepository {
+		return storage.NewExternalIdentityRepository(p.DB)
+	}); err != nil {
+		return err
+	}
+
+	if err := container.Provide(func(p userRepoParams) user.PasswordResetTokenRepository {
+		return storage.NewPasswordResetTokenRepository(p.DB)
+	}); err != nil {
+		return err
+	}
+
+	if err := container.Provide(func(p userRepoParams) user.EmailVerificationTokenRepository {
+		return storage.NewEmailVerificationTokenRepository(p.DB)
+	}); err != nil {
+		return err
+	}
+
+	if err := container.Provide(func(p userRepoParams) user.PhoneVerificationCodeRepository {
+		return storage.NewPhoneVerificationCodeRepository(p.DB)
+	}); err != nil {
+		return err
+	}
+
+	if err := container.Provide(func(p userRepoParams) role.RoleRepository {
+		return storage.NewRoleRepository(p.DB)
+	}); err != nil {
+		return err
+	}
+	if err := container.Provide(func(repo role.RoleRepository) role.RoleAssignmentRepository { return repo }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(repo role.RoleRepository) role.UserPermissionsRepository { return repo }); err != nil {
+		return err
+	}
 
 	if err := container.Provide(http.NewCreateUserHandler); err != nil {
 		return err
 	}
+	if err := container.Provide(http.NewForgetUserHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(http.NewGetUserByIDHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(http.NewGetUsersHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(http.NewUpdateUserHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(http.NewArchiveUserHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(http.NewUnarchiveUserHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(http.NewDeleteUserHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(http.NewRestoreUserHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(http.NewLoginHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(http.NewRefreshSessionHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(http.NewLogoutHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(http.NewLogoutAllHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(http.NewAssignRoleHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(http.NewRevokeRoleHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(http.NewStartExternalLoginHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(http.NewExternalLoginCallbackHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(http.NewChangePasswordHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(http.NewForgotPasswordHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(http.NewResetPasswordHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(http.NewVerifyEmailHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(http.NewResendEmailVerificationHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(http.NewRequestPhoneVerificationHandler); err != nil {
+		return err
+	}
+	if err := container.Provide(http.NewVerifyPhoneHandler); err != nil {
+		return err
+	}
 	if err := container.Provide(http.NewIdentityRouter); err != nil {
 		return err
 	}
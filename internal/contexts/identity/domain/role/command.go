@@ -0,0 +1,62 @@
+package role
+
+import (
+	"context"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+const (
+	AssignRoleCommandType bus.CommandType = "identity.role.assign"
+	RevokeRoleCommandType bus.CommandType = "identity.role.revoke"
+)
+
+const ErrRoleUserIDRequired = "User ID is required."
+
+// --- Assign/RevokeRoleCommand ---
+
+type RoleAssignmentCommandInput struct {
+	CorrelationID types.UUID
+	TraceID       types.UUID
+	UserAuthorID  types.NullableUUID
+	UserID        types.UUID
+	Role          string
+}
+
+func (RoleAssignmentCommandInput) Transactional() bool { return true }
+
+func (i RoleAssignmentCommandInput) Validate() error {
+	if i.UserID.IsNil() {
+		return msg.NewValidationError(nil, map[string]any{"field": "userId"}, ErrRoleUserIDRequired)
+	}
+	name := NormalizeName(i.Role)
+	if name == "" {
+		return msg.NewValidationError(nil, map[string]any{"field": "role"}, ErrRoleNameRequired)
+	}
+	if len(name) > RoleNameMaxLength {
+		return msg.NewValidationError(nil, map[string]any{"field": "role"}, ErrRoleNameTooLong)
+	}
+	return nil
+}
+
+type AssignRoleCommandInput struct {
+	RoleAssignmentCommandInput
+}
+
+func (AssignRoleCommandInput) CommandType() bus.CommandType { return AssignRoleCommandType }
+
+type AssignRoleCommand interface {
+	Execute(ctx context.Context, input AssignRoleCommandInput) (RoleAssignmentOutput, error)
+}
+
+type RevokeRoleCommandInput struct {
+	RoleAssignmentCommandInput
+}
+
+func (RevokeRoleCommandInput) CommandType() bus.CommandType { return RevokeRoleCommandType }
+
+type RevokeRoleCommand interface {
+	Execute(ctx context.Context, input RevokeRoleCommandInput) (RoleAssignmentOutput, error)
+}
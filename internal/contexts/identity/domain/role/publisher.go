@@ -0,0 +1,10 @@
+package role
+
+import (
+	"context"
+)
+
+type RoleAssignmentEventPublisher interface {
+	PublishRoleAssignedEvent(ctx context.Context, input RoleAssignmentEventInput) error
+	PublishRoleRevokedEvent(ctx context.Context, input RoleAssignmentEventInput) error
+}
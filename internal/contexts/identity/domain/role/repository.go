@@ -0,0 +1,44 @@
+package role
+
+import (
+	"context"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+// --- FindRoleByNameRepository ---
+
+// FindRoleByNameRepository returns a not_found MessageError when no role has
+// the name. The role comes with its permissions.
+type FindRoleByNameRepository interface {
+	FindRoleByName(ctx context.Context, name string) (*Role, error)
+}
+
+// --- UserPermissionsRepository ---
+
+// UserPermissionsRepository returns the distinct permissions granted by every
+// role of the user, sorted; a user without roles has none.
+type UserPermissionsRepository interface {
+	FindUserPermissions(ctx context.Context, userID types.UUID) ([]string, error)
+}
+
+// --- RoleAssignmentRepository ---
+
+type RevokeRoleRepoInput struct {
+	UserID types.UUID
+	RoleID types.UUID
+}
+
+// RoleAssignmentRepository reports false when the user already had the role
+// (AssignRole) or did not have it (RevokeRole); nothing is written then.
+type RoleAssignmentRepository interface {
+	FindRoleByNameRepository
+	AssignRole(ctx context.Context, assignment *Assignment) (bool, error)
+	RevokeRole(ctx context.Context, input RevokeRoleRepoInput) (bool, error)
+}
+
+// --- RoleRepository ---
+type RoleRepository interface {
+	RoleAssignmentRepository
+	UserPermissionsRepository
+}
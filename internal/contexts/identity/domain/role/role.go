@@ -0,0 +1,61 @@
+package role
+
+import (
+	"strings"
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+// Permissions of the identity routes, formatted as "<resource>:<action>".
+// The roles granting them are seeded by the roles migration.
+const (
+	PermissionUsersRead    = "users:read"
+	PermissionUsersUpdate  = "users:update"
+	PermissionUsersArchive = "users:archive"
+	PermissionUsersDelete  = "users:delete"
+	PermissionUsersRestore = "users:restore"
+	PermissionUsersForget  = "users:forget"
+	PermissionRolesAssign  = "roles:assign"
+)
+
+const RoleNameMaxLength = 50
+
+const (
+	ErrRoleNameRequired = "Role name is required."
+	ErrRoleNameTooLong  = "Role name is too long."
+	ErrRoleNotFound     = "Role not found."
+
+	ErrRoleRevokeOwnAssign = "You cannot revoke your own permission to manage roles."
+)
+
+type Role struct {
+	ID          types.UUID
+	Name        string
+	Description string
+	Permissions []string
+	CreatedAt   time.Time
+}
+
+// Assignment grants the permissions of a role to a user. AssignedBy is empty
+// for assignments made outside the API, such as the first admin.
+type Assignment struct {
+	UserID     types.UUID
+	Role       *Role
+	AssignedAt time.Time
+	AssignedBy types.NullableUUID
+}
+
+func NewAssignment(userID types.UUID, r *Role, author types.NullableUUID) *Assignment {
+	return &Assignment{
+		UserID:     userID,
+		Role:       r,
+		AssignedAt: time.Now(),
+		AssignedBy: author,
+	}
+}
+
+// NormalizeName returns the stored form of a role name.
+func NormalizeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
@@ -0,0 +1,62 @@
+package role
+
+import (
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+const (
+	RoleEventSource          string             = "IdentityService"
+	RoleAssignedEventType    event.EventType    = "role.assigned"
+	RoleAssignedEventVersion event.EventVersion = "v1.0.0"
+	RoleRevokedEventType     event.EventType    = "role.revoked"
+	RoleRevokedEventVersion  event.EventVersion = "v1.0.0"
+)
+
+// RoleAssignmentPayload is shared by role.assigned and role.revoked. The
+// permissions are those of the role when the event was published.
+type RoleAssignmentPayload struct {
+	UserID      types.UUID `json:"userId"`
+	RoleID      types.UUID `json:"roleId"`
+	Role        string     `json:"role"`
+	Permissions []string   `json:"permissions"`
+}
+
+func (p RoleAssignmentPayload) PartitionKey() string {
+	return p.UserID.String()
+}
+
+type RoleAssignmentEventInput struct {
+	CorrelationID types.UUID
+	UserID        types.NullableUUID // Author
+	TraceID       types.UUID         // OTEL
+	Assignment    *Assignment
+}
+
+var (
+	RoleAssignedEvent = event.NewTypedDefinition[RoleAssignmentPayload](RoleAssignedEventType, RoleAssignedEventVersion, RoleEventSource)
+	RoleRevokedEvent  = event.NewTypedDefinition[RoleAssignmentPayload](RoleRevokedEventType, RoleRevokedEventVersion, RoleEventSource)
+)
+
+func NewRoleAssignedEvent(input RoleAssignmentEventInput) (*event.Event, error) {
+	return newRoleAssignmentEvent(RoleAssignedEvent, input)
+}
+
+func NewRoleRevokedEvent(input RoleAssignmentEventInput) (*event.Event, error) {
+	return newRoleAssignmentEvent(RoleRevokedEvent, input)
+}
+
+func newRoleAssignmentEvent(def event.TypedDefinition[RoleAssignmentPayload], input RoleAssignmentEventInput) (*event.Event, error) {
+	a := input.Assignment
+	return def.New(event.TypedInput[RoleAssignmentPayload]{
+		CorrelationID: input.CorrelationID,
+		UserID:        input.UserID,
+		TraceID:       input.TraceID,
+		Payload: RoleAssignmentPayload{
+			UserID:      a.UserID,
+			RoleID:      a.Role.ID,
+			Role:        a.Role.Name,
+			Permissions: a.Role.Permissions,
+		},
+	})
+}
@@ -0,0 +1,29 @@
+package role
+
+import (
+	"context"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+// --- Assign/RevokeRoleUseCase ---
+type RoleAssignmentUseCaseInput struct {
+	UserID     types.UUID
+	Role       string
+	AssignedBy types.NullableUUID
+}
+
+// RoleAssignmentOutput reports Changed false when the user already was in the
+// requested state; nothing is persisted in that case.
+type RoleAssignmentOutput struct {
+	Assignment *Assignment
+	Changed    bool
+}
+
+type AssignRoleUseCase interface {
+	Execute(ctx context.Context, input RoleAssignmentUseCaseInput) (RoleAssignmentOutput, error)
+}
+
+type RevokeRoleUseCase interface {
+	Execute(ctx context.Context, input RoleAssignmentUseCaseInput) (RoleAssignmentOutput, error)
+}
@@ -2,10 +2,40 @@ package user
 
 import (
 	"context"
+	"time"
 
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
 	"github.com/marcelofabianov/redtogreen/internal/platform/types"
 )
 
+const (
+	CreateUserCommandType     bus.CommandType = "identity.user.create"
+	ForgetUserCommandType     bus.CommandType = "identity.user.forget"
+	UpdateUserCommandType     bus.CommandType = "identity.user.update"
+	ArchiveUserCommandType    bus.CommandType = "identity.user.archive"
+	UnarchiveUserCommandType  bus.CommandType = "identity.user.unarchive"
+	DeleteUserCommandType     bus.CommandType = "identity.user.delete"
+	RestoreUserCommandType    bus.CommandType = "identity.user.restore"
+	LoginCommandType          bus.CommandType = "identity.auth.login"
+	RefreshSessionCommandType bus.CommandType = "identity.auth.refresh"
+	LogoutCommandType         bus.CommandType = "identity.auth.logout"
+	LogoutAllCommandType      bus.CommandType = "identity.auth.logout_all"
+
+	ChangePasswordCommandType       bus.CommandType = "identity.auth.password_change"
+	RequestPasswordResetCommandType bus.CommandType = "identity.auth.password_reset_request"
+	ResetPasswordCommandType        bus.CommandType = "identity.auth.password_reset"
+
+	RequestEmailVerificationCommandType bus.CommandType = "identity.user.email_verification_request"
+	VerifyEmailCommandType              bus.CommandType = "identity.user.email_verify"
+
+	RequestPhoneVerificationCommandType bus.CommandType = "identity.user.phone_verification_request"
+	VerifyPhoneCommandType              bus.CommandType = "identity.user.phone_verify"
+
+	StartExternalLoginCommandType bus.CommandType = "identity.auth.external_start"
+	ExternalLoginCommandType      bus.CommandType = "identity.auth.external_login"
+)
+
 // --- CreateUserCommand ---
 
 type CreateUserCommandInput struct {
@@ -17,6 +47,460 @@ type CreateUserCommandInput struct {
 	NewUserInput    NewUserInput
 }
 
+func (CreateUserCommandInput) CommandType() bus.CommandType { return CreateUserCommandType }
+
+// Transactional keeps the user and its email verification token together.
+func (CreateUserCommandInput) Transactional() bool { return true }
+
 type CreateUserCommand interface {
 	Execute(ctx context.Context, input CreateUserCommandInput) (CreateUserOutput, error)
 }
+
+// --- ForgetUserCommand ---
+
+type ForgetUserCommandInput struct {
+	CorrelationID types.UUID
+	TraceID       types.UUID
+	UserAuthorID  types.NullableUUID
+	UserID        types.UUID
+}
+
+func (ForgetUserCommandInput) CommandType() bus.CommandType { return ForgetUserCommandType }
+
+func (i ForgetUserCommandInput) Validate() error {
+	if i.UserID.IsNil() {
+		return msg.NewValidationError(nil, map[string]any{"field": "userId"}, ErrUserIDRequired)
+	}
+	return nil
+}
+
+type ForgetUserCommand interface {
+	Execute(ctx context.Context, input ForgetUserCommandInput) error
+}
+
+// --- UpdateUserCommand ---
+
+// UpdateUserCommandInput applies Changes to the user. A zero ExpectedVersion
+// skips the If-Match precondition; the write is still guarded by the version
+// that was loaded.
+type UpdateUserCommandInput struct {
+	CorrelationID   types.UUID
+	TraceID         types.UUID
+	UserAuthorID    types.NullableUUID
+	UserID          types.UUID
+	ExpectedVersion types.Version
+	Changes         UpdateUserInput
+}
+
+func (UpdateUserCommandInput) CommandType() bus.CommandType { return UpdateUserCommandType }
+
+func (UpdateUserCommandInput) Transactional() bool { return true }
+
+func (i UpdateUserCommandInput) Validate() error {
+	if i.UserID.IsNil() {
+		return msg.NewValidationError(nil, map[string]any{"field": "userId"}, ErrUserIDRequired)
+	}
+	return nil
+}
+
+type UpdateUserCommand interface {
+	Execute(ctx context.Context, input UpdateUserCommandInput) (UpdateUserOutput, error)
+}
+
+// --- Archive/UnarchiveUserCommand ---
+
+// UserStatusCommandInput is shared by the commands that only move a user
+// through its lifecycle. A zero ExpectedVersion skips the If-Match check.
+type UserStatusCommandInput struct {
+	CorrelationID   types.UUID
+	TraceID         types.UUID
+	UserAuthorID    types.NullableUUID
+	UserID          types.UUID
+	ExpectedVersion types.Version
+}
+
+func (UserStatusCommandInput) Transactional() bool { return true }
+
+func (i UserStatusCommandInput) Validate() error {
+	if i.UserID.IsNil() {
+		return msg.NewValidationError(nil, map[string]any{"field": "userId"}, ErrUserIDRequired)
+	}
+	return nil
+}
+
+type ArchiveUserCommandInput struct {
+	UserStatusCommandInput
+}
+
+func (ArchiveUserCommandInput) CommandType() bus.CommandType { return ArchiveUserCommandType }
+
+type ArchiveUserCommand interface {
+	Execute(ctx context.Context, input ArchiveUserCommandInput) (UserStatusOutput, error)
+}
+
+type UnarchiveUserCommandInput struct {
+	UserStatusCommandInput
+}
+
+func (UnarchiveUserCommandInput) CommandType() bus.CommandType { return UnarchiveUserCommandType }
+
+type UnarchiveUserCommand interface {
+	Execute(ctx context.Context, input UnarchiveUserCommandInput) (UserStatusOutput, error)
+}
+
+// --- Delete/RestoreUserCommand ---
+
+type DeleteUserCommandInput struct {
+	UserStatusCommandInput
+}
+
+func (DeleteUserCommandInput) CommandType() bus.CommandType { return DeleteUserCommandType }
+
+type DeleteUserCommand interface {
+	Execute(ctx context.Context, input DeleteUserCommandInput) (UserStatusOutput, error)
+}
+
+type RestoreUserCommandInput struct {
+	UserStatusCommandInput
+}
+
+func (RestoreUserCommandInput) CommandType() bus.CommandType { return RestoreUserCommandType }
+
+type RestoreUserCommand interface {
+	Execute(ctx context.Context, input RestoreUserCommandInput) (UserStatusOutput, error)
+}
+
+// --- PurgeDeletedUsersCommand ---
+
+const PurgeDefaultBatchSize = 100
+
+// PurgeDeletedUsersCommandInput hard-deletes users soft-deleted before
+// DeletedBefore, BatchSize rows per statement. It is run by the purge job
+// rather than dispatched through the command bus.
+type PurgeDeletedUsersCommandInput struct {
+	CorrelationID types.UUID
+	TraceID       types.UUID
+	DeletedBefore time.Time
+	BatchSize     int
+}
+
+type PurgeDeletedUsersOutput struct {
+	Purged []PurgedUser
+}
+
+type PurgeDeletedUsersCommand interface {
+	Execute(ctx context.Context, input PurgeDeletedUsersCommandInput) (PurgeDeletedUsersOutput, error)
+}
+
+// --- LoginCommand ---
+
+type LoginCommandInput struct {
+	CorrelationID types.UUID
+	TraceID       types.UUID
+	Credentials   LoginInput
+}
+
+func (LoginCommandInput) CommandType() bus.CommandType { return LoginCommandType }
+
+func (i LoginCommandInput) Validate() error {
+	email, phone := i.Credentials.Email != "", i.Credentials.Phone != ""
+	if !email && !phone {
+		return msg.NewValidationError(nil, map[string]any{"fields": []string{"email", "phone"}}, ErrLoginIdentifierRequired)
+	}
+	if email && phone {
+		return msg.NewValidationError(nil, map[string]any{"fields": []string{"email", "phone"}}, ErrLoginIdentifierAmbiguous)
+	}
+	if i.Credentials.Password == "" {
+		return msg.NewValidationError(nil, map[string]any{"field": "password"}, ErrUserPasswordRequired)
+	}
+	return nil
+}
+
+// LoginCommand publishes user.logged_in or user.login_failed for every
+// attempt that reaches the use case.
+type LoginCommand interface {
+	Execute(ctx context.Context, input LoginCommandInput) (LoginOutput, error)
+}
+
+// --- RefreshSessionCommand ---
+
+type RefreshSessionCommandInput struct {
+	CorrelationID types.UUID
+	TraceID       types.UUID
+	RefreshToken  string
+}
+
+func (RefreshSessionCommandInput) CommandType() bus.CommandType { return RefreshSessionCommandType }
+
+func (i RefreshSessionCommandInput) Validate() error {
+	if i.RefreshToken == "" {
+		return msg.NewValidationError(nil, map[string]any{"field": "refresh_token"}, ErrRefreshTokenRequired)
+	}
+	return nil
+}
+
+type RefreshSessionCommand interface {
+	Execute(ctx context.Context, input RefreshSessionCommandInput) (RefreshSessionOutput, error)
+}
+
+// --- Logout/LogoutAllCommand ---
+
+type LogoutCommandInput struct {
+	CorrelationID types.UUID
+	TraceID       types.UUID
+	RefreshToken  string
+}
+
+func (LogoutCommandInput) CommandType() bus.CommandType { return LogoutCommandType }
+
+func (i LogoutCommandInput) Validate() error {
+	if i.RefreshToken == "" {
+		return msg.NewValidationError(nil, map[string]any{"field": "refresh_token"}, ErrRefreshTokenRequired)
+	}
+	return nil
+}
+
+type LogoutCommand interface {
+	Execute(ctx context.Context, input LogoutCommandInput) error
+}
+
+// LogoutAllCommandInput revokes every session of the authenticated author.
+type LogoutAllCommandInput struct {
+	CorrelationID types.UUID
+	TraceID       types.UUID
+	UserAuthorID  types.NullableUUID
+}
+
+func (LogoutAllCommandInput) CommandType() bus.CommandType { return LogoutAllCommandType }
+
+func (i LogoutAllCommandInput) Validate() error {
+	if !i.UserAuthorID.IsValid() {
+		return msg.NewValidationError(nil, map[string]any{"field": "userId"}, ErrUserIDRequired)
+	}
+	return nil
+}
+
+type LogoutAllCommand interface {
+	Execute(ctx context.Context, input LogoutAllCommandInput) (LogoutAllOutput, error)
+}
+
+// --- Start/ExternalLoginCommand ---
+
+type StartExternalLoginCommandInput struct {
+	CorrelationID types.UUID
+	TraceID       types.UUID
+}
+
+func (StartExternalLoginCommandInput) CommandType() bus.CommandType {
+	return StartExternalLoginCommandType
+}
+
+type StartExternalLoginCommand interface {
+	Execute(ctx context.Context, input StartExternalLoginCommandInput) (StartExternalLoginOutput, error)
+}
+
+type ExternalLoginCommandInput struct {
+	CorrelationID types.UUID
+	TraceID       types.UUID
+	Callback      ExternalLoginInput
+}
+
+func (ExternalLoginCommandInput) CommandType() bus.CommandType { return ExternalLoginCommandType }
+
+func (i ExternalLoginCommandInput) Validate() error {
+	if i.Callback.State == "" || i.Callback.Code == "" {
+		return msg.NewValidationError(nil, map[string]any{"fields": []string{"state", "code"}}, ErrExternalLoginStateRequired)
+	}
+	return nil
+}
+
+// ExternalLoginCommand publishes user.created when the sign-in registered
+// the user, then user.logged_in or user.login_failed like LoginCommand.
+type ExternalLoginCommand interface {
+	Execute(ctx context.Context, input ExternalLoginCommandInput) (ExternalLoginOutput, error)
+}
+
+// --- ChangePassword/RequestPasswordReset/ResetPasswordCommand ---
+
+// ChangePasswordCommandInput changes the password of the authenticated
+// author.
+type ChangePasswordCommandInput struct {
+	CorrelationID   types.UUID
+	TraceID         types.UUID
+	UserAuthorID    types.NullableUUID
+	CurrentPassword string
+	NewPassword     string
+}
+
+func (ChangePasswordCommandInput) CommandType() bus.CommandType { return ChangePasswordCommandType }
+
+func (ChangePasswordCommandInput) Transactional() bool { return true }
+
+func (i ChangePasswordCommandInput) Validate() error {
+	if !i.UserAuthorID.IsValid() {
+		return msg.NewValidationError(nil, map[string]any{"field": "userId"}, ErrUserIDRequired)
+	}
+	if i.CurrentPassword == "" {
+		return msg.NewValidationError(nil, map[string]any{"field": "current_password"}, ErrPasswordCurrentRequired)
+	}
+	if i.NewPassword == "" {
+		return msg.NewValidationError(nil, map[string]any{"field": "new_password"}, ErrPasswordNewRequired)
+	}
+	return nil
+}
+
+type ChangePasswordCommand interface {
+	Execute(ctx context.Context, input ChangePasswordCommandInput) (PasswordChangedOutput, error)
+}
+
+type RequestPasswordResetCommandInput struct {
+	CorrelationID types.UUID
+	TraceID       types.UUID
+	Email         string
+}
+
+func (RequestPasswordResetCommandInput) CommandType() bus.CommandType {
+	return RequestPasswordResetCommandType
+}
+
+func (RequestPasswordResetCommandInput) Transactional() bool { return true }
+
+func (i RequestPasswordResetCommandInput) Validate() error {
+	if i.Email == "" {
+		return msg.NewValidationError(nil, map[string]any{"field": "email"}, ErrUserEmailRequired)
+	}
+	return nil
+}
+
+// RequestPasswordResetCommand publishes user.password_reset_requested only
+// when a token was issued.
+type RequestPasswordResetCommand interface {
+	Execute(ctx context.Context, input RequestPasswordResetCommandInput) error
+}
+
+type ResetPasswordCommandInput struct {
+	CorrelationID types.UUID
+	TraceID       types.UUID
+	Token         string
+	NewPassword   string
+}
+
+func (ResetPasswordCommandInput) CommandType() bus.CommandType { return ResetPasswordCommandType }
+
+func (ResetPasswordCommandInput) Transactional() bool { return true }
+
+func (i ResetPasswordCommandInput) Validate() error {
+	if i.Token == "" {
+		return msg.NewValidationError(nil, map[string]any{"field": "token"}, ErrPasswordResetTokenRequired)
+	}
+	if i.NewPassword == "" {
+		return msg.NewValidationError(nil, map[string]any{"field": "new_password"}, ErrPasswordNewRequired)
+	}
+	return nil
+}
+
+type ResetPasswordCommand interface {
+	Execute(ctx context.Context, input ResetPasswordCommandInput) (PasswordChangedOutput, error)
+}
+
+// --- RequestEmailVerification/VerifyEmailCommand ---
+
+type RequestEmailVerificationCommandInput struct {
+	CorrelationID types.UUID
+	TraceID       types.UUID
+	Email         string
+}
+
+func (RequestEmailVerificationCommandInput) CommandType() bus.CommandType {
+	return RequestEmailVerificationCommandType
+}
+
+func (RequestEmailVerificationCommandInput) Transactional() bool { return true }
+
+func (i RequestEmailVerificationCommandInput) Validate() error {
+	if i.Email == "" {
+		return msg.NewValidationError(nil, map[string]any{"field": "email"}, ErrUserEmailRequired)
+	}
+	return nil
+}
+
+// RequestEmailVerificationCommand publishes user.email_verification_requested
+// only when a token was issued.
+type RequestEmailVerificationCommand interface {
+	Execute(ctx context.Context, input RequestEmailVerificationCommandInput) error
+}
+
+type VerifyEmailCommandInput struct {
+	CorrelationID types.UUID
+	TraceID       types.UUID
+	Token         string
+}
+
+func (VerifyEmailCommandInput) CommandType() bus.CommandType { return VerifyEmailCommandType }
+
+func (VerifyEmailCommandInput) Transactional() bool { return true }
+
+func (i VerifyEmailCommandInput) Validate() error {
+	if i.Token == "" {
+		return msg.NewValidationError(nil, map[string]any{"field": "token"}, ErrEmailVerificationTokenRequired)
+	}
+	return nil
+}
+
+type VerifyEmailCommand interface {
+	Execute(ctx context.Context, input VerifyEmailCommandInput) (VerifyEmailOutput, error)
+}
+
+// --- RequestPhoneVerification/VerifyPhoneCommand ---
+
+// RequestPhoneVerificationCommandInput sends a code to the phone of the
+// authenticated author.
+type RequestPhoneVerificationCommandInput struct {
+	CorrelationID types.UUID
+	TraceID       types.UUID
+	UserAuthorID  types.NullableUUID
+}
+
+func (RequestPhoneVerificationCommandInput) CommandType() bus.CommandType {
+	return RequestPhoneVerificationCommandType
+}
+
+func (RequestPhoneVerificationCommandInput) Transactional() bool { return true }
+
+func (i RequestPhoneVerificationCommandInput) Validate() error {
+	if !i.UserAuthorID.IsValid() {
+		return msg.NewValidationError(nil, map[string]any{"field": "userId"}, ErrUserIDRequired)
+	}
+	return nil
+}
+
+// RequestPhoneVerificationCommand publishes user.phone_verification_requested
+// with the code; the caller never sees it.
+type RequestPhoneVerificationCommand interface {
+	Execute(ctx context.Context, input RequestPhoneVerificationCommandInput) error
+}
+
+// VerifyPhoneCommandInput is not transactional: a wrong guess fails the
+// command, and the attempt it used must not be rolled back with it.
+type VerifyPhoneCommandInput struct {
+	CorrelationID types.UUID
+	TraceID       types.UUID
+	UserAuthorID  types.NullableUUID
+	Code          string
+}
+
+func (VerifyPhoneCommandInput) CommandType() bus.CommandType { return VerifyPhoneCommandType }
+
+func (i VerifyPhoneCommandInput) Validate() error {
+	if !i.UserAuthorID.IsValid() {
+		return msg.NewValidationError(nil, map[string]any{"field": "userId"}, ErrUserIDRequired)
+	}
+	if i.Code == "" {
+		return msg.NewValidationError(nil, map[string]any{"field": "code"}, ErrPhoneVerificationCodeRequired)
+	}
+	return nil
+}
+
+type VerifyPhoneCommand interface {
+	Execute(ctx context.Context, input VerifyPhoneCommandInput) (VerifyPhoneOutput, error)
+}
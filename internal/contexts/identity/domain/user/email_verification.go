@@ -0,0 +1,126 @@
+package user
+
+import (
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/hasher"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+const EmailVerificationTokenSecretBytes = 32
+
+const (
+	ErrEmailVerificationTokenRequired     = "Verification token is required."
+	ErrEmailVerificationTokenInvalid      = "The verification token is invalid or has expired."
+	ErrEmailVerificationOperationGenerate = "Failed to generate email verification token."
+)
+
+func (u *User) IsEmailVerified() bool {
+	return !u.EmailVerifiedAt.IsNullable()
+}
+
+// VerifyEmail records that the user proved it owns its current email.
+func (u *User) VerifyEmail() {
+	u.EmailVerifiedAt.Set(time.Now())
+	u.UpdatedAt = types.NewUpdatedAt()
+	u.Version.Increment()
+}
+
+// EmailVerificationToken proves the user can read the mail sent to Email.
+// Like PasswordResetToken it is handed out as "<token id>.<secret>", only the
+// hash of the secret is stored and it works once before it expires. It only
+// verifies the address it was sent to, so changing the email voids it.
+type EmailVerificationToken struct {
+	ID         types.UUID
+	UserID     types.UUID
+	Email      types.Email
+	SecretHash string
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	UsedAt     types.NullableTime
+}
+
+type IssuedEmailVerificationToken struct {
+	Value     string
+	ExpiresAt time.Time
+}
+
+func NewEmailVerificationToken(u *User, ttl time.Duration, h hasher.Hasher) (*EmailVerificationToken, IssuedEmailVerificationToken, error) {
+	id, err := types.NewUUID()
+	if err != nil {
+		return nil, IssuedEmailVerificationToken{}, msg.NewInternalError(err, map[string]any{"operation": ErrEmailVerificationOperationGenerate})
+	}
+
+	secret, err := newTokenSecret(EmailVerificationTokenSecretBytes)
+	if err != nil {
+		return nil, IssuedEmailVerificationToken{}, msg.NewInternalError(err, map[string]any{"operation": ErrEmailVerificationOperationGenerate})
+	}
+
+	hash, err := h.Hash(secret)
+	if err != nil {
+		return nil, IssuedEmailVerificationToken{}, msg.NewInternalError(err, map[string]any{"operation": ErrEmailVerificationOperationGenerate})
+	}
+
+	now := time.Now()
+	t := &EmailVerificationToken{
+		ID:         id,
+		UserID:     u.ID,
+		Email:      u.Email,
+		SecretHash: hash,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(ttl),
+		UsedAt:     types.NewNullTime(),
+	}
+
+	return t, IssuedEmailVerificationToken{
+		Value:     id.String() + "." + secret,
+		ExpiresAt: t.ExpiresAt,
+	}, nil
+}
+
+// ParseEmailVerificationToken splits an opaque value into the token ID and
+// its secret.
+func ParseEmailVerificationToken(value string) (types.UUID, string, error) {
+	id, secret, ok := splitOpaqueToken(value)
+	if !ok {
+		return types.Nil, "", invalidEmailVerificationToken()
+	}
+	return id, secret, nil
+}
+
+func (t *EmailVerificationToken) VerifySecret(secret string, h hasher.Hasher) (bool, error) {
+	return h.Compare(secret, t.SecretHash)
+}
+
+func (t *EmailVerificationToken) IsUsed() bool {
+	return !t.UsedAt.IsNullable()
+}
+
+func (t *EmailVerificationToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+func invalidEmailVerificationToken() *msg.MessageError {
+	return msg.NewMessageError(nil, ErrEmailVerificationTokenInvalid, msg.CodeUnauthorized, nil)
+}
+
+type RequestEmailVerificationInput struct {
+	Email string
+}
+
+// RequestEmailVerificationOutput has no User when nothing was issued: the
+// email matched no active unverified user, or the last token is too recent
+// to send another. The caller answers the same either way.
+type RequestEmailVerificationOutput struct {
+	User  *User
+	Token IssuedEmailVerificationToken
+}
+
+type VerifyEmailInput struct {
+	Token string
+}
+
+type VerifyEmailOutput struct {
+	User *User
+}
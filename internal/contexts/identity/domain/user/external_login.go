@@ -0,0 +1,166 @@
+package user
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+const (
+	ProviderGoogle    = "google"
+	LoginMethodGoogle = "google"
+
+	// ExternalLoginStateTTL bounds how long the user may take at the provider.
+	ExternalLoginStateTTL    = 10 * time.Minute
+	externalLoginRandomBytes = 32
+)
+
+const (
+	ErrExternalLoginDisabled          = "Sign-in with this provider is not enabled."
+	ErrExternalLoginStateRequired     = "State and code are required."
+	ErrExternalLoginStateInvalid      = "The sign-in request is invalid or has expired."
+	ErrExternalLoginRejected          = "The identity provider did not confirm the sign-in."
+	ErrExternalLoginEmailUnverified   = "The identity provider has not verified this email address."
+	ErrExternalLoginLocalUnverified   = "An account with this email exists but its email is not verified. Sign in with your password and verify your email first."
+	ErrExternalLoginOperationGenerate = "Failed to start external sign-in."
+)
+
+// ExternalIdentity links a user to the subject an identity provider knows
+// them by. The email is the one asserted when the link was made.
+type ExternalIdentity struct {
+	ID        types.UUID
+	UserID    types.UUID
+	Provider  string
+	Subject   string
+	Email     types.Email
+	CreatedAt time.Time
+}
+
+func NewExternalIdentity(userID types.UUID, provider, subject string, email types.Email) (*ExternalIdentity, error) {
+	id, err := types.NewUUID()
+	if err != nil {
+		return nil, msg.NewInternalError(err, map[string]any{"operation": ErrUserOperationGenerateUUID})
+	}
+
+	return &ExternalIdentity{
+		ID:        id,
+		UserID:    userID,
+		Provider:  provider,
+		Subject:   subject,
+		Email:     email,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// ExternalLoginState is kept between redirecting the user to the provider
+// and the callback. It is consumed on first use, so a callback cannot be
+// replayed, and holds the PKCE verifier that never leaves the server.
+type ExternalLoginState struct {
+	State        string
+	Provider     string
+	Nonce        string
+	CodeVerifier string
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
+
+func NewExternalLoginState(provider string) (*ExternalLoginState, error) {
+	values := make([]string, 3)
+	for i := range values {
+		value, err := newTokenSecret(externalLoginRandomBytes)
+		if err != nil {
+			return nil, msg.NewInternalError(err, map[string]any{"operation": ErrExternalLoginOperationGenerate})
+		}
+		values[i] = value
+	}
+
+	now := time.Now()
+	return &ExternalLoginState{
+		State:        values[0],
+		Provider:     provider,
+		Nonce:        values[1],
+		CodeVerifier: values[2],
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(ExternalLoginStateTTL),
+	}, nil
+}
+
+func (s *ExternalLoginState) IsExpired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
+
+type NewExternalUserInput struct {
+	Name  string
+	Email string
+}
+
+// NewExternalUser creates a user that signs in through an identity provider
+// only: it has no password and no phone until it sets them. Without a name
+// the local part of the email is used. The email starts verified, since only
+// addresses the provider verified create a user.
+func NewExternalUser(input NewExternalUserInput) (*User, error) {
+	id, err := types.NewUUID()
+	if err != nil {
+		return nil, msg.NewInternalError(err, map[string]any{"operation": ErrUserOperationGenerateUUID})
+	}
+
+	email, err := types.NewEmail(input.Email)
+	if err != nil {
+		var msgErr *msg.MessageError
+		if errors.As(err, &msgErr) {
+			return nil, msgErr.WithContext("field", "Email")
+		}
+		return nil, msg.NewValidationError(err, map[string]any{"field": "Email", "input_email": input.Email}, ErrUserEmailInvalid)
+	}
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		name, _, _ = strings.Cut(email.String(), "@")
+	}
+	if runes := []rune(name); len(runes) > UserNameMaxLength {
+		name = string(runes[:UserNameMaxLength])
+	}
+
+	currentTime := time.Now()
+
+	user := &User{
+		ID:              id,
+		Name:            name,
+		Email:           email,
+		EmailVerifiedAt: types.NewValidNullableTime(currentTime),
+		CreatedAt:       types.CreatedAt(currentTime),
+		UpdatedAt:       types.UpdatedAt(currentTime),
+		Version:         types.NewVersion(),
+		ArchivedAt:      types.NewNilArchivedAt(),
+		DeletedAt:       types.NewNilDeletedAt(),
+	}
+
+	if err := user.validate(); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+type StartExternalLoginOutput struct {
+	AuthURL   string
+	State     string
+	ExpiresAt time.Time
+}
+
+type ExternalLoginInput struct {
+	State string
+	Code  string
+}
+
+// ExternalLoginOutput mirrors LoginOutput. Created is set when the sign-in
+// registered the user.
+type ExternalLoginOutput struct {
+	User          *User
+	Session       Session
+	Created       bool
+	FailureReason LoginFailureReason
+}
@@ -0,0 +1,66 @@
+package user_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+)
+
+func TestNewExternalUser(t *testing.T) {
+	t.Run("Success: should create an active user without password or phone", func(t *testing.T) {
+		u, err := user.NewExternalUser(user.NewExternalUserInput{Name: " Jane Doe ", Email: "Jane@Example.com"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "Jane Doe", u.Name)
+		assert.Equal(t, "jane@example.com", u.Email.String())
+		assert.True(t, u.Phone.IsEmpty())
+		assert.False(t, u.HasPassword())
+		assert.False(t, u.IsArchived())
+		assert.False(t, u.IsDeleted())
+	})
+
+	t.Run("Success: should name the user after the email without a name", func(t *testing.T) {
+		u, err := user.NewExternalUser(user.NewExternalUserInput{Email: "jane.doe@example.com"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "jane.doe", u.Name)
+	})
+
+	t.Run("Success: should cut a name longer than the maximum", func(t *testing.T) {
+		u, err := user.NewExternalUser(user.NewExternalUserInput{Name: strings.Repeat("á", 150), Email: "jane@example.com"})
+		require.NoError(t, err)
+
+		assert.Equal(t, strings.Repeat("á", user.UserNameMaxLength), u.Name)
+	})
+
+	t.Run("Failure: should reject an invalid email", func(t *testing.T) {
+		_, err := user.NewExternalUser(user.NewExternalUserInput{Name: "Jane", Email: "not-an-email"})
+
+		var msgErr *msg.MessageError
+		require.ErrorAs(t, err, &msgErr)
+		assert.Equal(t, msg.CodeInvalid, msgErr.Code)
+	})
+}
+
+func TestNewExternalLoginState(t *testing.T) {
+	t.Run("Success: should generate distinct random values valid for the TTL", func(t *testing.T) {
+		state, err := user.NewExternalLoginState(user.ProviderGoogle)
+		require.NoError(t, err)
+		other, err := user.NewExternalLoginState(user.ProviderGoogle)
+		require.NoError(t, err)
+
+		assert.Equal(t, user.ProviderGoogle, state.Provider)
+		assert.Len(t, state.CodeVerifier, 43, "PKCE verifiers take 43 to 128 characters")
+		assert.NotEqual(t, state.State, state.Nonce)
+		assert.NotEqual(t, state.State, other.State)
+		assert.Equal(t, user.ExternalLoginStateTTL, state.ExpiresAt.Sub(state.CreatedAt))
+		assert.False(t, state.IsExpired(time.Now()))
+		assert.True(t, state.IsExpired(state.ExpiresAt))
+	})
+}
@@ -0,0 +1,49 @@
+package user
+
+const LoginMethodPassword = "password"
+
+const (
+	ErrLoginIdentifierRequired  = "Provide either email or phone to log in."
+	ErrLoginIdentifierAmbiguous = "Provide only one of email or phone to log in."
+	ErrLoginInvalidCredentials  = "Invalid credentials."
+	ErrLoginUserArchived        = "User is archived and cannot log in."
+	ErrLoginEmailUnverified     = "Verify your email address before logging in."
+	ErrLoginOperationIssueToken = "Failed to issue access token."
+)
+
+// LoginFailureReason tells audit consumers why a login was rejected. It is
+// published in user.login_failed and never returned to the client.
+type LoginFailureReason string
+
+const (
+	LoginFailureUnknownUser     LoginFailureReason = "unknown_user"
+	LoginFailureInvalidPassword LoginFailureReason = "invalid_password"
+	LoginFailureUserArchived    LoginFailureReason = "user_archived"
+	LoginFailureUserDeleted     LoginFailureReason = "user_deleted"
+	// LoginFailurePasswordNotSet is a password login for a user that only
+	// signs in through an identity provider.
+	LoginFailurePasswordNotSet LoginFailureReason = "password_not_set"
+	// LoginFailureProviderRejected covers codes the provider would not
+	// redeem and ID tokens that failed verification.
+	LoginFailureProviderRejected LoginFailureReason = "provider_rejected"
+	LoginFailureEmailUnverified  LoginFailureReason = "email_unverified"
+	// LoginFailureLocalUnverified is an external sign-in matching a local user
+	// whose own email is unverified, which is never linked automatically.
+	LoginFailureLocalUnverified LoginFailureReason = "local_email_unverified"
+)
+
+// LoginInput identifies the user by exactly one of Email or Phone.
+type LoginInput struct {
+	Email    string
+	Phone    string
+	Password string
+}
+
+// LoginOutput carries the new session on success. On failure it still
+// reports the reason and, when known, the user, so the attempt can be
+// published.
+type LoginOutput struct {
+	User          *User
+	Session       Session
+	FailureReason LoginFailureReason
+}
@@ -0,0 +1,149 @@
+package user
+
+import (
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/hasher"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+const (
+	PasswordChangeMethodChange = "change"
+	PasswordChangeMethodReset  = "reset"
+
+	PasswordResetTokenSecretBytes = 32
+)
+
+const (
+	ErrPasswordCurrentRequired        = "Current password is required."
+	ErrPasswordNewRequired            = "New password is required."
+	ErrPasswordCurrentInvalid         = "Current password is incorrect."
+	ErrPasswordNotSet                 = "User has no password; request a password reset to set one."
+	ErrPasswordResetTokenRequired     = "Reset token is required."
+	ErrPasswordResetTokenInvalid      = "The reset token is invalid or has expired."
+	ErrPasswordResetOperationGenerate = "Failed to generate password reset token."
+)
+
+// ChangePassword replaces the password with the hash of plaintext, which
+// must satisfy the password rules.
+func (u *User) ChangePassword(plaintext string, h hasher.Hasher) error {
+	password, err := types.NewPassword(plaintext)
+	if err != nil {
+		return err
+	}
+
+	hashed, err := h.Hash(password.String())
+	if err != nil {
+		return msg.NewInternalError(err, map[string]any{"operation": ErrUserOperationHashPassword})
+	}
+
+	u.Password = types.NewHashedPassword(hashed)
+	u.UpdatedAt = types.NewUpdatedAt()
+	u.Version.Increment()
+	return nil
+}
+
+// PasswordResetToken lets a user who lost the password set a new one. It is
+// handed out as "<token id>.<secret>", only the hash of the secret is stored,
+// and it works once before it expires.
+type PasswordResetToken struct {
+	ID         types.UUID
+	UserID     types.UUID
+	SecretHash string
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	UsedAt     types.NullableTime
+}
+
+type IssuedPasswordResetToken struct {
+	Value     string
+	ExpiresAt time.Time
+}
+
+func NewPasswordResetToken(userID types.UUID, ttl time.Duration, h hasher.Hasher) (*PasswordResetToken, IssuedPasswordResetToken, error) {
+	id, err := types.NewUUID()
+	if err != nil {
+		return nil, IssuedPasswordResetToken{}, msg.NewInternalError(err, map[string]any{"operation": ErrPasswordResetOperationGenerate})
+	}
+
+	secret, err := newTokenSecret(PasswordResetTokenSecretBytes)
+	if err != nil {
+		return nil, IssuedPasswordResetToken{}, msg.NewInternalError(err, map[string]any{"operation": ErrPasswordResetOperationGenerate})
+	}
+
+	hash, err := h.Hash(secret)
+	if err != nil {
+		return nil, IssuedPasswordResetToken{}, msg.NewInternalError(err, map[string]any{"operation": ErrPasswordResetOperationGenerate})
+	}
+
+	now := time.Now()
+	t := &PasswordResetToken{
+		ID:         id,
+		UserID:     userID,
+		SecretHash: hash,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(ttl),
+		UsedAt:     types.NewNullTime(),
+	}
+
+	return t, IssuedPasswordResetToken{
+		Value:     id.String() + "." + secret,
+		ExpiresAt: t.ExpiresAt,
+	}, nil
+}
+
+// ParsePasswordResetToken splits an opaque value into the token ID and its
+// secret.
+func ParsePasswordResetToken(value string) (types.UUID, string, error) {
+	id, secret, ok := splitOpaqueToken(value)
+	if !ok {
+		return types.Nil, "", invalidPasswordResetToken()
+	}
+	return id, secret, nil
+}
+
+func (t *PasswordResetToken) VerifySecret(secret string, h hasher.Hasher) (bool, error) {
+	return h.Compare(secret, t.SecretHash)
+}
+
+func (t *PasswordResetToken) IsUsed() bool {
+	return !t.UsedAt.IsNullable()
+}
+
+func (t *PasswordResetToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+func invalidPasswordResetToken() *msg.MessageError {
+	return msg.NewMessageError(nil, ErrPasswordResetTokenInvalid, msg.CodeUnauthorized, nil)
+}
+
+type ChangePasswordInput struct {
+	UserID          types.UUID
+	CurrentPassword string
+	NewPassword     string
+}
+
+type RequestPasswordResetInput struct {
+	Email string
+}
+
+// RequestPasswordResetOutput has no User when the email matched no active
+// user; the caller answers the same either way.
+type RequestPasswordResetOutput struct {
+	User  *User
+	Token IssuedPasswordResetToken
+}
+
+type ResetPasswordInput struct {
+	Token       string
+	NewPassword string
+}
+
+// PasswordChangedOutput reports how many sessions were revoked along with
+// the old password.
+type PasswordChangedOutput struct {
+	User            *User
+	SessionsRevoked int
+}
@@ -0,0 +1,133 @@
+package user
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/hasher"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+const PhoneVerificationCodeDigits = 6
+
+const (
+	ErrPhoneVerificationCodeRequired      = "Verification code is required."
+	ErrPhoneVerificationCodeInvalid       = "The verification code is invalid or has expired."
+	ErrPhoneVerificationAttemptsExceeded  = "Too many attempts with this verification code; request a new one."
+	ErrPhoneVerificationResendTooSoon     = "A verification code was sent recently; wait before requesting another."
+	ErrPhoneVerificationOperationGenerate = "Failed to generate phone verification code."
+	ErrUserPhoneNotSet                    = "The user has no phone number to verify."
+	ErrUserPhoneAlreadyVerified           = "The phone number is already verified."
+)
+
+func (u *User) IsPhoneVerified() bool {
+	return !u.PhoneVerifiedAt.IsNullable()
+}
+
+// VerifyPhone records that the user proved it owns its current phone.
+func (u *User) VerifyPhone() {
+	u.PhoneVerifiedAt.Set(time.Now())
+	u.UpdatedAt = types.NewUpdatedAt()
+	u.Version.Increment()
+}
+
+// PhoneVerificationCode is a short numeric code sent by SMS to Phone. Only
+// its hash is stored. A code this short can be guessed, so it is always
+// checked against the latest code of the authenticated user and allows a
+// limited number of attempts before it expires.
+type PhoneVerificationCode struct {
+	ID        types.UUID
+	UserID    types.UUID
+	Phone     types.Phone
+	CodeHash  string
+	Attempts  int
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	UsedAt    types.NullableTime
+}
+
+type IssuedPhoneVerificationCode struct {
+	Code      string
+	ExpiresAt time.Time
+}
+
+func NewPhoneVerificationCode(u *User, ttl time.Duration, h hasher.Hasher) (*PhoneVerificationCode, IssuedPhoneVerificationCode, error) {
+	id, err := types.NewUUID()
+	if err != nil {
+		return nil, IssuedPhoneVerificationCode{}, msg.NewInternalError(err, map[string]any{"operation": ErrPhoneVerificationOperationGenerate})
+	}
+
+	code, err := newNumericCode(PhoneVerificationCodeDigits)
+	if err != nil {
+		return nil, IssuedPhoneVerificationCode{}, msg.NewInternalError(err, map[string]any{"operation": ErrPhoneVerificationOperationGenerate})
+	}
+
+	hash, err := h.Hash(code)
+	if err != nil {
+		return nil, IssuedPhoneVerificationCode{}, msg.NewInternalError(err, map[string]any{"operation": ErrPhoneVerificationOperationGenerate})
+	}
+
+	now := time.Now()
+	c := &PhoneVerificationCode{
+		ID:        id,
+		UserID:    u.ID,
+		Phone:     u.Phone,
+		CodeHash:  hash,
+		Attempts:  0,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+		UsedAt:    types.NewNullTime(),
+	}
+
+	return c, IssuedPhoneVerificationCode{
+		Code:      code,
+		ExpiresAt: c.ExpiresAt,
+	}, nil
+}
+
+// newNumericCode returns n random decimal digits, keeping leading zeros.
+func newNumericCode(n int) (string, error) {
+	limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+	v, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", n, v), nil
+}
+
+func (c *PhoneVerificationCode) VerifyCode(code string, h hasher.Hasher) (bool, error) {
+	return h.Compare(code, c.CodeHash)
+}
+
+func (c *PhoneVerificationCode) IsUsed() bool {
+	return !c.UsedAt.IsNullable()
+}
+
+func (c *PhoneVerificationCode) IsExpired(now time.Time) bool {
+	return !now.Before(c.ExpiresAt)
+}
+
+func (c *PhoneVerificationCode) HasAttemptsLeft(maxAttempts int) bool {
+	return c.Attempts < maxAttempts
+}
+
+type RequestPhoneVerificationInput struct {
+	UserID types.UUID
+}
+
+type RequestPhoneVerificationOutput struct {
+	User *User
+	Code IssuedPhoneVerificationCode
+}
+
+type VerifyPhoneInput struct {
+	UserID types.UUID
+	Code   string
+}
+
+type VerifyPhoneOutput struct {
+	User *User
+}
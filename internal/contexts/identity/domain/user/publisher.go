@@ -8,6 +8,64 @@ type UserCreatedEventPublisher interface {
 	PublishUserCreatedEvent(ctx context.Context, input USerCreatedEventInput) error
 }
 
+type UserUpdatedEventPublisher interface {
+	PublishUserUpdatedEvent(ctx context.Context, input UserUpdatedEventInput) error
+}
+
+type UserArchivedEventPublisher interface {
+	PublishUserArchivedEvent(ctx context.Context, input UserStatusEventInput) error
+	PublishUserUnarchivedEvent(ctx context.Context, input UserStatusEventInput) error
+}
+
+type UserDeletedEventPublisher interface {
+	PublishUserDeletedEvent(ctx context.Context, input UserStatusEventInput) error
+	PublishUserRestoredEvent(ctx context.Context, input UserStatusEventInput) error
+}
+
+type UserPurgedEventPublisher interface {
+	PublishUserPurgedEvent(ctx context.Context, input UserPurgedEventInput) error
+}
+
+type UserLoginEventPublisher interface {
+	PublishUserLoggedInEvent(ctx context.Context, input UserLoggedInEventInput) error
+	PublishUserLoginFailedEvent(ctx context.Context, input UserLoginFailedEventInput) error
+}
+
+type UserPasswordEventPublisher interface {
+	PublishUserPasswordChangedEvent(ctx context.Context, input UserPasswordChangedEventInput) error
+	PublishUserPasswordResetRequestedEvent(ctx context.Context, input UserPasswordResetRequestedEventInput) error
+}
+
+type UserEmailVerificationEventPublisher interface {
+	PublishUserEmailVerificationRequestedEvent(ctx context.Context, input UserEmailVerificationRequestedEventInput) error
+	PublishUserEmailVerifiedEvent(ctx context.Context, input UserEmailVerifiedEventInput) error
+}
+
+type UserPhoneVerificationEventPublisher interface {
+	PublishUserPhoneVerificationRequestedEvent(ctx context.Context, input UserPhoneVerificationRequestedEventInput) error
+	PublishUserPhoneVerifiedEvent(ctx context.Context, input UserPhoneVerifiedEventInput) error
+}
+
+// CreateUserEventPublisher announces the new user and sends it the email
+// verification token.
+type CreateUserEventPublisher interface {
+	UserCreatedEventPublisher
+	UserEmailVerificationEventPublisher
+}
+
+type ExternalLoginEventPublisher interface {
+	UserCreatedEventPublisher
+	UserLoginEventPublisher
+}
+
 type UserPublisher interface {
 	UserCreatedEventPublisher
+	UserUpdatedEventPublisher
+	UserArchivedEventPublisher
+	UserDeletedEventPublisher
+	UserPurgedEventPublisher
+	UserLoginEventPublisher
+	UserPasswordEventPublisher
+	UserEmailVerificationEventPublisher
+	UserPhoneVerificationEventPublisher
 }
@@ -0,0 +1,159 @@
+package user
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+const (
+	UserStatusQueryType bus.QueryType = "identity.user.status"
+
+	ErrUserIDRequired = "User ID is required."
+)
+
+// --- UserStatusQuery ---
+
+// UserStatusQuery lets other contexts check whether a user exists and is
+// active without reading the identity database.
+var UserStatusQuery = bus.NewQueryDefinition[UserStatusRequest, UserStatus](UserStatusQueryType)
+
+type UserStatusRequest struct {
+	UserID types.UUID `json:"userId"`
+}
+
+type UserStatus struct {
+	Exists bool `json:"exists"`
+	Active bool `json:"active"`
+}
+
+type UserStatusQueryHandler interface {
+	Handle(ctx context.Context, input UserStatusRequest) (UserStatus, error)
+}
+
+// --- GetUserByIDQuery ---
+
+type GetUserByIDQueryInput struct {
+	TraceID        types.UUID
+	UserID         types.UUID
+	IncludeDeleted bool
+}
+
+type GetUserByIDQuery interface {
+	Execute(ctx context.Context, input GetUserByIDQueryInput) (*User, error)
+}
+
+// --- ListUsersQuery ---
+
+type ListSort string
+
+const (
+	ListSortCreatedAt ListSort = "created_at"
+	ListSortName      ListSort = "name"
+	ListSortEmail     ListSort = "email"
+)
+
+type ListDirection string
+
+const (
+	ListDirectionAsc  ListDirection = "asc"
+	ListDirectionDesc ListDirection = "desc"
+)
+
+// StatusFilter selects users by a soft state such as archived or deleted.
+type StatusFilter string
+
+const (
+	StatusExclude StatusFilter = "exclude"
+	StatusInclude StatusFilter = "include"
+	StatusOnly    StatusFilter = "only"
+)
+
+const (
+	ListUsersDefaultLimit = 20
+	ListUsersMaxLimit     = 100
+)
+
+const (
+	ErrListUsersInvalidLimit     = "Limit must be between 1 and 100."
+	ErrListUsersInvalidSort      = "Sort must be one of created_at, name or email."
+	ErrListUsersInvalidDirection = "Direction must be asc or desc."
+	ErrListUsersInvalidStatus    = "Status filter must be one of exclude, include or only."
+	ErrListUsersInvalidRange     = "created_from must be before created_to."
+	ErrListUsersInvalidCursor    = "Invalid cursor."
+	ErrListUsersCursorMismatch   = "Cursor does not match the requested sort."
+)
+
+type ListUsersFilter struct {
+	NamePrefix  string
+	EmailPrefix string
+	PhonePrefix string
+	Archived    StatusFilter
+	Deleted     StatusFilter
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+}
+
+type ListUsersQueryInput struct {
+	TraceID   types.UUID
+	Filter    ListUsersFilter
+	Sort      ListSort
+	Direction ListDirection
+	Cursor    string
+	Limit     int
+}
+
+type ListUsersQueryOutput struct {
+	Users      []*User `json:"users"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
+
+type ListUsersQuery interface {
+	Execute(ctx context.Context, input ListUsersQueryInput) (ListUsersQueryOutput, error)
+}
+
+// UserCursor marks the last user of a page. Value holds the sort key of that
+// user and is empty when sorting by creation time, since the UUIDv7 ID
+// already follows creation order.
+type UserCursor struct {
+	Sort      ListSort      `json:"s"`
+	Direction ListDirection `json:"d"`
+	Value     string        `json:"v,omitempty"`
+	ID        types.UUID    `json:"id"`
+}
+
+func NewUserCursor(u *User, sort ListSort, direction ListDirection) UserCursor {
+	cursor := UserCursor{Sort: sort, Direction: direction, ID: u.ID}
+	switch sort {
+	case ListSortName:
+		cursor.Value = u.Name
+	case ListSortEmail:
+		cursor.Value = u.Email.String()
+	}
+	return cursor
+}
+
+// Encode returns the opaque form handed to clients as next_cursor.
+func (c UserCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeUserCursor(raw string) (UserCursor, error) {
+	var cursor UserCursor
+
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return cursor, msg.NewValidationError(err, map[string]any{"field": "cursor"}, ErrListUsersInvalidCursor)
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID.IsNil() {
+		return cursor, msg.NewValidationError(err, map[string]any{"field": "cursor"}, ErrListUsersInvalidCursor)
+	}
+
+	return cursor, nil
+}
@@ -0,0 +1,150 @@
+package user
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/hasher"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/token"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+const RefreshTokenSecretBytes = 32
+
+const (
+	ErrRefreshTokenRequired          = "Refresh token is required."
+	ErrRefreshTokenInvalid           = "The refresh token is invalid or has expired."
+	ErrRefreshTokenReused            = "The refresh token was already used."
+	ErrRefreshTokenOperationGenerate = "Failed to generate refresh token."
+)
+
+// RefreshToken is one link of a session. Every refresh rotates it into a new
+// token of the same family; presenting a rotated token again revokes the
+// whole family. Only the hash of the secret is stored.
+type RefreshToken struct {
+	ID           types.UUID
+	FamilyID     types.UUID
+	UserID       types.UUID
+	SecretHash   string
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+	RotatedAt    types.NullableTime
+	ReplacedByID types.NullableUUID
+	RevokedAt    types.NullableTime
+}
+
+type NewRefreshTokenInput struct {
+	UserID types.UUID
+	// FamilyID continues an existing session; a nil ID starts a new one.
+	FamilyID types.UUID
+	TTL      time.Duration
+}
+
+// IssuedRefreshToken is the opaque value handed to the client, formatted as
+// "<token id>.<secret>".
+type IssuedRefreshToken struct {
+	Value     string
+	FamilyID  types.UUID
+	ExpiresAt time.Time
+}
+
+// Session is what a successful login or refresh returns to the client.
+type Session struct {
+	AccessToken  token.AccessToken
+	RefreshToken IssuedRefreshToken
+}
+
+func NewRefreshToken(input NewRefreshTokenInput, h hasher.Hasher) (*RefreshToken, IssuedRefreshToken, error) {
+	id, err := types.NewUUID()
+	if err != nil {
+		return nil, IssuedRefreshToken{}, msg.NewInternalError(err, map[string]any{"operation": ErrRefreshTokenOperationGenerate})
+	}
+
+	familyID := input.FamilyID
+	if familyID.IsNil() {
+		familyID = id
+	}
+
+	secret, err := newTokenSecret(RefreshTokenSecretBytes)
+	if err != nil {
+		return nil, IssuedRefreshToken{}, msg.NewInternalError(err, map[string]any{"operation": ErrRefreshTokenOperationGenerate})
+	}
+
+	hash, err := h.Hash(secret)
+	if err != nil {
+		return nil, IssuedRefreshToken{}, msg.NewInternalError(err, map[string]any{"operation": ErrRefreshTokenOperationGenerate})
+	}
+
+	now := time.Now()
+	t := &RefreshToken{
+		ID:           id,
+		FamilyID:     familyID,
+		UserID:       input.UserID,
+		SecretHash:   hash,
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(input.TTL),
+		RotatedAt:    types.NewNullTime(),
+		ReplacedByID: types.NewNullUUID(),
+		RevokedAt:    types.NewNullTime(),
+	}
+
+	return t, IssuedRefreshToken{
+		Value:     id.String() + "." + secret,
+		FamilyID:  familyID,
+		ExpiresAt: t.ExpiresAt,
+	}, nil
+}
+
+// ParseRefreshToken splits an opaque value into the token ID and its secret.
+func ParseRefreshToken(value string) (types.UUID, string, error) {
+	id, secret, ok := splitOpaqueToken(value)
+	if !ok {
+		return types.Nil, "", invalidRefreshToken()
+	}
+	return id, secret, nil
+}
+
+// newTokenSecret returns n random bytes encoded for use in URLs.
+func newTokenSecret(n int) (string, error) {
+	raw := make([]byte, n)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// splitOpaqueToken splits a "<token id>.<secret>" value handed to a client.
+func splitOpaqueToken(value string) (types.UUID, string, bool) {
+	rawID, secret, found := strings.Cut(value, ".")
+	if !found || secret == "" {
+		return types.Nil, "", false
+	}
+	id, err := types.ParseUUID(rawID)
+	if err != nil {
+		return types.Nil, "", false
+	}
+	return id, secret, true
+}
+
+func (t *RefreshToken) VerifySecret(secret string, h hasher.Hasher) (bool, error) {
+	return h.Compare(secret, t.SecretHash)
+}
+
+func (t *RefreshToken) IsRotated() bool {
+	return !t.RotatedAt.IsNullable()
+}
+
+func (t *RefreshToken) IsRevoked() bool {
+	return !t.RevokedAt.IsNullable()
+}
+
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+func invalidRefreshToken() *msg.MessageError {
+	return msg.NewMessageError(nil, ErrRefreshTokenInvalid, msg.CodeUnauthorized, nil)
+}
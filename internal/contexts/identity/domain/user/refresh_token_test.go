@@ -0,0 +1,62 @@
+package user_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/hasher"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+func TestNewRefreshToken(t *testing.T) {
+	h := hasher.NewHasher()
+	userID := types.MustNewUUID()
+
+	t.Run("Success: should start a new family and hash the secret", func(t *testing.T) {
+		rt, issued, err := user.NewRefreshToken(user.NewRefreshTokenInput{UserID: userID, TTL: time.Hour}, h)
+		require.NoError(t, err)
+
+		assert.Equal(t, rt.ID, rt.FamilyID)
+		assert.Equal(t, userID, rt.UserID)
+		assert.Equal(t, rt.ExpiresAt, issued.ExpiresAt)
+		assert.False(t, rt.IsRotated())
+		assert.False(t, rt.IsRevoked())
+		assert.False(t, rt.IsExpired(time.Now()))
+
+		id, secret, err := user.ParseRefreshToken(issued.Value)
+		require.NoError(t, err)
+		assert.Equal(t, rt.ID, id)
+		assert.NotContains(t, rt.SecretHash, secret, "Only the hash of the secret should be stored")
+
+		match, err := rt.VerifySecret(secret, h)
+		require.NoError(t, err)
+		assert.True(t, match)
+	})
+
+	t.Run("Success: should continue the given family", func(t *testing.T) {
+		familyID := types.MustNewUUID()
+		rt, issued, err := user.NewRefreshToken(user.NewRefreshTokenInput{UserID: userID, FamilyID: familyID, TTL: time.Hour}, h)
+		require.NoError(t, err)
+
+		assert.Equal(t, familyID, rt.FamilyID)
+		assert.Equal(t, familyID, issued.FamilyID)
+		assert.NotEqual(t, familyID, rt.ID)
+	})
+
+	t.Run("Failure: should fail when hashing fails", func(t *testing.T) {
+		_, _, err := user.NewRefreshToken(user.NewRefreshTokenInput{UserID: userID, TTL: time.Hour}, &mockHasher{ShouldFail: true})
+		require.Error(t, err)
+	})
+}
+
+func TestParseRefreshToken(t *testing.T) {
+	for _, value := range []string{"", "no-separator", types.MustNewUUID().String() + ".", "not-a-uuid.secret", strings.Repeat(".", 3)} {
+		_, _, err := user.ParseRefreshToken(value)
+		assert.Error(t, err, "value %q should be rejected", value)
+	}
+}
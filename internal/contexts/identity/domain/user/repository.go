@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"time"
 
 	"github.com/marcelofabianov/redtogreen/internal/platform/types"
 )
@@ -27,7 +28,259 @@ type CreateUserRepository interface {
 	CreateUser(ctx context.Context, input CreateUserRepoInput) error
 }
 
+// --- UserStatusRepository ---
+
+type UserStatusRepository interface {
+	UserStatus(ctx context.Context, userID types.UUID) (UserStatus, error)
+}
+
+// --- FindUserByIDRepository ---
+
+type FindUserByIDRepoInput struct {
+	UserID         types.UUID
+	IncludeDeleted bool
+}
+
+// FindUserByIDRepository returns a not_found MessageError when no user
+// matches. Soft-deleted users only match with IncludeDeleted.
+type FindUserByIDRepository interface {
+	FindUserByID(ctx context.Context, input FindUserByIDRepoInput) (*User, error)
+}
+
+// --- ListUsersRepository ---
+
+type ListUsersRepoInput struct {
+	Filter    ListUsersFilter
+	Sort      ListSort
+	Direction ListDirection
+	After     *UserCursor
+	Limit     int
+}
+
+// ListUsersRepository returns at most Limit users ordered by the sort key and
+// then by ID, starting strictly after the After cursor when one is given.
+type ListUsersRepository interface {
+	ListUsers(ctx context.Context, input ListUsersRepoInput) ([]*User, error)
+}
+
+// --- UpdateUserRepository ---
+
+type UpdateUserRepoInput struct {
+	User            *User
+	ExpectedVersion types.Version
+}
+
+// UpdateUserRepository writes the user only if the stored version still equals
+// ExpectedVersion and returns a conflict MessageError otherwise.
+type UpdateUserRepository interface {
+	FindUserByIDRepository
+	UpdateUser(ctx context.Context, input UpdateUserRepoInput) error
+}
+
+// --- UpdateUserStatusRepository ---
+
+// UpdateUserStatusRepository writes only the lifecycle columns (archived_at,
+// deleted_at, forgotten_at) with the same version guard as UpdateUser.
+type UpdateUserStatusRepository interface {
+	FindUserByIDRepository
+	UpdateUserStatus(ctx context.Context, input UpdateUserRepoInput) error
+}
+
+// --- PurgeDeletedUsersRepository ---
+
+type PurgeDeletedUsersRepoInput struct {
+	DeletedBefore time.Time
+	Limit         int
+}
+
+type PurgedUser struct {
+	ID        types.UUID
+	DeletedAt time.Time
+}
+
+// PurgeDeletedUsersRepository hard-deletes at most Limit users soft-deleted
+// before DeletedBefore. Rows locked by a concurrent purge are skipped.
+type PurgeDeletedUsersRepository interface {
+	PurgeDeletedUsers(ctx context.Context, input PurgeDeletedUsersRepoInput) ([]PurgedUser, error)
+}
+
+// --- FindUserByLoginRepository ---
+
+// FindUserByLoginRepoInput sets exactly one of Email or Phone.
+type FindUserByLoginRepoInput struct {
+	Email types.Email
+	Phone types.Phone
+}
+
+// FindUserByLoginRepository returns a not_found MessageError when no user
+// matches. Archived and soft-deleted users are returned so the caller can
+// tell why the login is rejected.
+type FindUserByLoginRepository interface {
+	FindUserByLogin(ctx context.Context, input FindUserByLoginRepoInput) (*User, error)
+}
+
+// --- RefreshTokenRepository ---
+
+type CreateRefreshTokenRepoInput struct {
+	Token *RefreshToken
+}
+
+type CreateRefreshTokenRepository interface {
+	CreateRefreshToken(ctx context.Context, input CreateRefreshTokenRepoInput) error
+}
+
+type RotateRefreshTokenRepoInput struct {
+	CurrentID types.UUID
+	Next      *RefreshToken
+}
+
+// RevokeRefreshTokensRepoInput sets exactly one of FamilyID or UserID.
+type RevokeRefreshTokensRepoInput struct {
+	FamilyID types.UUID
+	UserID   types.UUID
+}
+
+// RefreshTokenRepository stores refresh tokens. FindRefreshTokenByID returns a
+// not_found MessageError when no token matches. RotateRefreshToken marks
+// CurrentID as replaced by Next and stores Next atomically, returning a
+// conflict MessageError when CurrentID was already rotated or revoked.
+// RevokeRefreshTokens reports how many active tokens it revoked.
+type RefreshTokenRepository interface {
+	CreateRefreshTokenRepository
+	FindRefreshTokenByID(ctx context.Context, id types.UUID) (*RefreshToken, error)
+	RotateRefreshToken(ctx context.Context, input RotateRefreshTokenRepoInput) error
+	RevokeRefreshTokens(ctx context.Context, input RevokeRefreshTokensRepoInput) (int, error)
+}
+
+// --- UpdateUserPasswordRepository ---
+
+// UpdateUserPasswordRepository writes the password hash with the same
+// version guard as UpdateUser.
+type UpdateUserPasswordRepository interface {
+	FindUserByIDRepository
+	UpdateUserPassword(ctx context.Context, input UpdateUserRepoInput) error
+}
+
+// --- PasswordResetTokenRepository ---
+
+type UsePasswordResetTokenRepoInput struct {
+	ID     types.UUID
+	UserID types.UUID
+	UsedAt time.Time
+}
+
+// PasswordResetTokenRepository returns a not_found MessageError from
+// FindPasswordResetTokenByID when no token matches. UsePasswordResetToken
+// marks the token used, returning a conflict MessageError when it already
+// was, and drops every other unused token of the user.
+type PasswordResetTokenRepository interface {
+	CreatePasswordResetToken(ctx context.Context, token *PasswordResetToken) error
+	FindPasswordResetTokenByID(ctx context.Context, id types.UUID) (*PasswordResetToken, error)
+	UsePasswordResetToken(ctx context.Context, input UsePasswordResetTokenRepoInput) error
+}
+
+// --- VerifyUserEmailRepository ---
+
+// VerifyUserEmailRepository writes the verification time with the same
+// version guard as UpdateUser.
+type VerifyUserEmailRepository interface {
+	FindUserByIDRepository
+	VerifyUserEmail(ctx context.Context, input UpdateUserRepoInput) error
+}
+
+// --- EmailVerificationTokenRepository ---
+
+type UseEmailVerificationTokenRepoInput struct {
+	ID     types.UUID
+	UserID types.UUID
+	UsedAt time.Time
+}
+
+// EmailVerificationTokenRepository returns a not_found MessageError from
+// FindEmailVerificationTokenByID and FindLatestEmailVerificationToken when no
+// token matches. UseEmailVerificationToken marks the token used, returning a
+// conflict MessageError when it already was, and drops every other unused
+// token of the user.
+type EmailVerificationTokenRepository interface {
+	CreateEmailVerificationToken(ctx context.Context, token *EmailVerificationToken) error
+	FindEmailVerificationTokenByID(ctx context.Context, id types.UUID) (*EmailVerificationToken, error)
+	FindLatestEmailVerificationToken(ctx context.Context, userID types.UUID) (*EmailVerificationToken, error)
+	UseEmailVerificationToken(ctx context.Context, input UseEmailVerificationTokenRepoInput) error
+}
+
+// --- VerifyUserPhoneRepository ---
+
+// VerifyUserPhoneRepository writes the verification time with the same
+// version guard as UpdateUser.
+type VerifyUserPhoneRepository interface {
+	FindUserByIDRepository
+	VerifyUserPhone(ctx context.Context, input UpdateUserRepoInput) error
+}
+
+// --- PhoneVerificationCodeRepository ---
+
+type RecordPhoneVerificationAttemptRepoInput struct {
+	ID          types.UUID
+	MaxAttempts int
+}
+
+type UsePhoneVerificationCodeRepoInput struct {
+	ID     types.UUID
+	UserID types.UUID
+	UsedAt time.Time
+}
+
+// PhoneVerificationCodeRepository returns a not_found MessageError from
+// FindLatestPhoneVerificationCode when the user has no code.
+// RecordPhoneVerificationAttempt counts a guess before it is checked,
+// returning a conflict MessageError when the code is used or has no attempts
+// left. UsePhoneVerificationCode marks the code used, returning a conflict
+// MessageError when it already was, and drops every other unused code of the
+// user.
+type PhoneVerificationCodeRepository interface {
+	CreatePhoneVerificationCode(ctx context.Context, code *PhoneVerificationCode) error
+	FindLatestPhoneVerificationCode(ctx context.Context, userID types.UUID) (*PhoneVerificationCode, error)
+	RecordPhoneVerificationAttempt(ctx context.Context, input RecordPhoneVerificationAttemptRepoInput) error
+	UsePhoneVerificationCode(ctx context.Context, input UsePhoneVerificationCodeRepoInput) error
+}
+
+// --- ExternalIdentityRepository ---
+
+type FindExternalIdentityRepoInput struct {
+	Provider string
+	Subject  string
+}
+
+// LinkExternalIdentityRepoInput links the identity to an existing user, or
+// when NewUser is set, creates that user together with the link.
+type LinkExternalIdentityRepoInput struct {
+	Identity *ExternalIdentity
+	NewUser  *User
+}
+
+// ExternalIdentityRepository returns a not_found MessageError from
+// FindExternalIdentity and ConsumeExternalLoginState when nothing matches.
+// Consuming deletes the state, so it succeeds once. LinkExternalIdentity
+// returns a conflict MessageError when the identity or the new user already
+// exists.
+type ExternalIdentityRepository interface {
+	FindExternalIdentity(ctx context.Context, input FindExternalIdentityRepoInput) (*ExternalIdentity, error)
+	LinkExternalIdentity(ctx context.Context, input LinkExternalIdentityRepoInput) error
+	SaveExternalLoginState(ctx context.Context, state *ExternalLoginState) error
+	ConsumeExternalLoginState(ctx context.Context, state string) (*ExternalLoginState, error)
+}
+
 // --- UserRepository ---
 type UserRepository interface {
 	CreateUserRepository
+	UserStatusRepository
+	FindUserByIDRepository
+	ListUsersRepository
+	UpdateUserRepository
+	UpdateUserStatusRepository
+	PurgeDeletedUsersRepository
+	FindUserByLoginRepository
+	UpdateUserPassword(ctx context.Context, input UpdateUserRepoInput) error
+	VerifyUserEmail(ctx context.Context, input UpdateUserRepoInput) error
+	VerifyUserPhone(ctx context.Context, input UpdateUserRepoInput) error
 }
@@ -1,12 +1,205 @@
 package user
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
 
 // --- CreateUserUseCase ---
+// CreateUserOutput carries the email verification token issued with the
+// user; it is delivered by event and never returned to the client.
 type CreateUserOutput struct {
-	User *User
+	User              *User
+	EmailVerification IssuedEmailVerificationToken
 }
 
 type CreateUserUseCase interface {
 	Execute(ctx context.Context, input NewUserInput) (CreateUserOutput, error)
 }
+
+// --- UpdateUserUseCase ---
+type UpdateUserUseCaseInput struct {
+	UserID          types.UUID
+	ExpectedVersion types.Version
+	Changes         UpdateUserInput
+}
+
+// UpdateUserOutput reports no ChangedFields when the input left the user as
+// it was; nothing is persisted in that case.
+type UpdateUserOutput struct {
+	User          *User
+	ChangedFields []string
+}
+
+type UpdateUserUseCase interface {
+	Execute(ctx context.Context, input UpdateUserUseCaseInput) (UpdateUserOutput, error)
+}
+
+// --- Archive/Unarchive/Delete/RestoreUserUseCase ---
+type UserStatusUseCaseInput struct {
+	UserID          types.UUID
+	ExpectedVersion types.Version
+}
+
+// UserStatusOutput reports Changed false when the user already was in the
+// requested state; nothing is persisted in that case.
+type UserStatusOutput struct {
+	User    *User
+	Changed bool
+}
+
+type ArchiveUserUseCase interface {
+	Execute(ctx context.Context, input UserStatusUseCaseInput) (UserStatusOutput, error)
+}
+
+type UnarchiveUserUseCase interface {
+	Execute(ctx context.Context, input UserStatusUseCaseInput) (UserStatusOutput, error)
+}
+
+type DeleteUserUseCase interface {
+	Execute(ctx context.Context, input UserStatusUseCaseInput) (UserStatusOutput, error)
+}
+
+type RestoreUserUseCase interface {
+	Execute(ctx context.Context, input UserStatusUseCaseInput) (UserStatusOutput, error)
+}
+
+// ForgetUserUseCase destroys the data key of a deleted user, after which its
+// personal data in past events reads as null. The user cannot be restored
+// afterwards.
+type ForgetUserUseCase interface {
+	Execute(ctx context.Context, input ForgetUserUseCaseInput) error
+}
+
+type ForgetUserUseCaseInput struct {
+	UserID types.UUID
+}
+
+// --- PurgeDeletedUsersUseCase ---
+type PurgeDeletedUsersUseCaseInput struct {
+	DeletedBefore time.Time
+	Limit         int
+}
+
+type PurgeDeletedUsersUseCase interface {
+	Execute(ctx context.Context, input PurgeDeletedUsersUseCaseInput) ([]PurgedUser, error)
+}
+
+// --- LoginUseCase ---
+
+// LoginUseCase rejects unknown identifiers, wrong passwords and deleted users
+// with the same unauthorized error; archived users get forbidden once their
+// password is verified.
+type LoginUseCase interface {
+	Execute(ctx context.Context, input LoginInput) (LoginOutput, error)
+}
+
+// --- RefreshSessionUseCase ---
+type RefreshSessionInput struct {
+	RefreshToken string
+}
+
+type RefreshSessionOutput struct {
+	User    *User
+	Session Session
+}
+
+// RefreshSessionUseCase rotates the refresh token. Reusing a rotated token, or
+// refreshing for a user that was deleted or archived since, revokes the whole
+// session.
+type RefreshSessionUseCase interface {
+	Execute(ctx context.Context, input RefreshSessionInput) (RefreshSessionOutput, error)
+}
+
+// --- Logout/LogoutAllUseCase ---
+type LogoutInput struct {
+	RefreshToken string
+}
+
+// LogoutUseCase revokes the session of the refresh token. Unknown or invalid
+// tokens are ignored, so logging out twice succeeds.
+type LogoutUseCase interface {
+	Execute(ctx context.Context, input LogoutInput) error
+}
+
+type LogoutAllInput struct {
+	UserID types.UUID
+}
+
+type LogoutAllOutput struct {
+	Revoked int
+}
+
+type LogoutAllUseCase interface {
+	Execute(ctx context.Context, input LogoutAllInput) (LogoutAllOutput, error)
+}
+
+// --- Start/ExternalLoginUseCase ---
+
+// StartExternalLoginUseCase stores a new login state and returns the URL of
+// the provider to send the user to.
+type StartExternalLoginUseCase interface {
+	Execute(ctx context.Context) (StartExternalLoginOutput, error)
+}
+
+// ExternalLoginUseCase completes the sign-in on the provider callback. The
+// user is found by the linked identity, else linked by verified email, else
+// created. Deleted users are rejected as unauthorized and archived users as
+// forbidden, before anything is written.
+type ExternalLoginUseCase interface {
+	Execute(ctx context.Context, input ExternalLoginInput) (ExternalLoginOutput, error)
+}
+
+// --- ChangePassword/RequestPasswordReset/ResetPasswordUseCase ---
+
+// ChangePasswordUseCase verifies the current password, stores the new one
+// and revokes every session of the user.
+type ChangePasswordUseCase interface {
+	Execute(ctx context.Context, input ChangePasswordInput) (PasswordChangedOutput, error)
+}
+
+// RequestPasswordResetUseCase issues a reset token for an active user. An
+// unknown, deleted or archived email is not an error, so the answer does not
+// reveal which addresses are registered.
+type RequestPasswordResetUseCase interface {
+	Execute(ctx context.Context, input RequestPasswordResetInput) (RequestPasswordResetOutput, error)
+}
+
+// ResetPasswordUseCase consumes the reset token, stores the new password and
+// revokes every session of the user.
+type ResetPasswordUseCase interface {
+	Execute(ctx context.Context, input ResetPasswordInput) (PasswordChangedOutput, error)
+}
+
+// --- RequestEmailVerification/VerifyEmailUseCase ---
+
+// RequestEmailVerificationUseCase issues a new verification token for an
+// active user whose email is not verified, at most once per resend interval.
+// Anything else is not an error, so the answer does not reveal which
+// addresses are registered.
+type RequestEmailVerificationUseCase interface {
+	Execute(ctx context.Context, input RequestEmailVerificationInput) (RequestEmailVerificationOutput, error)
+}
+
+// VerifyEmailUseCase consumes the verification token and marks the email of
+// its user verified.
+type VerifyEmailUseCase interface {
+	Execute(ctx context.Context, input VerifyEmailInput) (VerifyEmailOutput, error)
+}
+
+// --- RequestPhoneVerification/VerifyPhoneUseCase ---
+
+// RequestPhoneVerificationUseCase issues a new code for the phone of the
+// user, at most once per resend interval.
+type RequestPhoneVerificationUseCase interface {
+	Execute(ctx context.Context, input RequestPhoneVerificationInput) (RequestPhoneVerificationOutput, error)
+}
+
+// VerifyPhoneUseCase checks the code against the latest one sent to the user
+// and marks its phone verified. Every guess counts against the attempt limit
+// of the code.
+type VerifyPhoneUseCase interface {
+	Execute(ctx context.Context, input VerifyPhoneInput) (VerifyPhoneOutput, error)
+}
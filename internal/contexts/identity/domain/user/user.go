@@ -1,6 +1,7 @@
 package user
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"strings"
@@ -16,11 +17,18 @@ const (
 	UserNameMaxLength = 100
 )
 
+// Field names used to report which attributes of a user changed.
+const (
+	FieldName        = "name"
+	FieldEmail       = "email"
+	FieldPhone       = "phone"
+	FieldPreferences = "preferences"
+)
+
 const (
 	ErrUserNameRequired                  = "Name cannot be empty."
 	ErrUserNameTooLong                   = "Name is too long (max 100 characters)."
 	ErrUserEmailRequired                 = "Email cannot be empty."
-	ErrUserPhoneRequired                 = "Phone cannot be empty."
 	ErrUserPreferencesInvalidJSON        = "Preferences field contains invalid JSON."
 	ErrUserEmailInvalid                  = "Invalid email provided."
 	ErrUserPhoneInvalid                  = "Invalid phone number provided."
@@ -32,6 +40,11 @@ const (
 	ErrUserOperationGenerateUUID         = "Failed to generate UUID for user."
 	ErrUserOperationHashPassword         = "Failed to hash user password."
 	ErrUserAlreadyExists                 = "A user with the given email or phone already exists."
+	ErrUserNotFound                      = "User not found."
+	ErrUserVersionMismatch               = "User version does not match the If-Match precondition."
+	ErrUserConcurrentUpdate              = "User was modified by another request, reload it and try again."
+	ErrUserForgetNotDeleted              = "Only a deleted user can have its personal data forgotten."
+	ErrUserRestoreForgotten              = "A user whose personal data was forgotten cannot be restored."
 )
 
 type NewUserInput struct {
@@ -50,31 +63,43 @@ type UpdateUserInput struct {
 }
 
 type FromUserInput struct {
-	ID          types.UUID
-	Name        string
-	Email       types.Email
-	Phone       types.Phone
-	Password    types.HashedPassword
-	Preferences json.RawMessage
-	CreatedAt   types.CreatedAt
-	UpdatedAt   types.UpdatedAt
-	Version     types.Version
-	ArchivedAt  types.ArchivedAt
-	DeletedAt   types.DeletedAt
+	ID              types.UUID
+	Name            string
+	Email           types.Email
+	EmailVerifiedAt types.NullableTime
+	Phone           types.Phone
+	PhoneVerifiedAt types.NullableTime
+	Password        types.HashedPassword
+	Preferences     json.RawMessage
+	CreatedAt       types.CreatedAt
+	UpdatedAt       types.UpdatedAt
+	Version         types.Version
+	ArchivedAt      types.ArchivedAt
+	DeletedAt       types.DeletedAt
+	ForgottenAt     types.NullableTime
 }
 
 type User struct {
-	ID          types.UUID           `json:"id" db:"id"`
-	Name        string               `json:"name" db:"name"`
-	Email       types.Email          `json:"email" db:"email"`
-	Phone       types.Phone          `json:"phone" db:"phone"`
-	Password    types.HashedPassword `json:"-" db:"password"`
-	Preferences json.RawMessage      `json:"preferences,omitempty" db:"preferences"`
-	CreatedAt   types.CreatedAt      `json:"created_at" db:"created_at"`
-	UpdatedAt   types.UpdatedAt      `json:"updated_at" db:"updated_at"`
-	Version     types.Version        `json:"version" db:"version"`
-	ArchivedAt  types.ArchivedAt     `json:"archived_at,omitempty" db:"archived_at"`
-	DeletedAt   types.DeletedAt      `json:"deleted_at,omitempty" db:"deleted_at"`
+	ID              types.UUID           `json:"id" db:"id"`
+	Name            string               `json:"name" db:"name"`
+	Email           types.Email          `json:"email" db:"email"`
+	EmailVerifiedAt types.NullableTime   `json:"email_verified_at" db:"email_verified_at"`
+	Phone           types.Phone          `json:"phone" db:"phone"`
+	PhoneVerifiedAt types.NullableTime   `json:"phone_verified_at" db:"phone_verified_at"`
+	Password        types.HashedPassword `json:"-" db:"password"`
+	Preferences     json.RawMessage      `json:"preferences,omitempty" db:"preferences"`
+	CreatedAt       types.CreatedAt      `json:"created_at" db:"created_at"`
+	UpdatedAt       types.UpdatedAt      `json:"updated_at" db:"updated_at"`
+	Version         types.Version        `json:"version" db:"version"`
+	ArchivedAt      types.ArchivedAt     `json:"archived_at,omitempty" db:"archived_at"`
+	DeletedAt       types.DeletedAt      `json:"deleted_at,omitempty" db:"deleted_at"`
+	ForgottenAt     types.NullableTime   `json:"-" db:"forgotten_at"`
+}
+
+// HasPassword is false for users created from an external identity, who
+// cannot log in with a password.
+func (u *User) HasPassword() bool {
+	return !u.Password.IsEmpty()
 }
 
 func (u *User) ComparePassword(plaintextPassword types.Password, h hasher.Hasher) (bool, error) {
@@ -94,9 +119,6 @@ func (u *User) validate() error {
 	if u.Email.IsEmpty() {
 		return msg.NewValidationError(nil, map[string]any{"email": u.Email.String()}, ErrUserEmailRequired)
 	}
-	if u.Phone.IsEmpty() {
-		return msg.NewValidationError(nil, map[string]any{"phone": u.Phone.String()}, ErrUserPhoneRequired)
-	}
 
 	if u.Preferences != nil && !json.Valid(u.Preferences) {
 		return msg.NewValidationError(nil, map[string]any{"field": "Preferences"}, ErrUserPreferencesInvalidJSON)
@@ -156,17 +178,19 @@ func NewUser(input NewUserInput, h hasher.Hasher) (*User, error) {
 	currentTime := time.Now()
 
 	user := &User{
-		ID:          id,
-		Name:        input.Name,
-		Email:       email,
-		Phone:       phone,
-		Password:    types.NewHashedPassword(hashedPassword),
-		Preferences: preferencesValue,
-		CreatedAt:   types.CreatedAt(currentTime),
-		UpdatedAt:   types.UpdatedAt(currentTime),
-		Version:     types.NewVersion(),
-		ArchivedAt:  types.NewNilArchivedAt(),
-		DeletedAt:   types.NewNilDeletedAt(),
+		ID:              id,
+		Name:            input.Name,
+		Email:           email,
+		EmailVerifiedAt: types.NewNullTime(),
+		Phone:           phone,
+		PhoneVerifiedAt: types.NewNullTime(),
+		Password:        types.NewHashedPassword(hashedPassword),
+		Preferences:     preferencesValue,
+		CreatedAt:       types.CreatedAt(currentTime),
+		UpdatedAt:       types.UpdatedAt(currentTime),
+		Version:         types.NewVersion(),
+		ArchivedAt:      types.NewNilArchivedAt(),
+		DeletedAt:       types.NewNilDeletedAt(),
 	}
 
 	if err := user.validate(); err != nil {
@@ -178,17 +202,20 @@ func NewUser(input NewUserInput, h hasher.Hasher) (*User, error) {
 
 func FromUser(input FromUserInput) *User {
 	return &User{
-		ID:          input.ID,
-		Name:        input.Name,
-		Email:       input.Email,
-		Phone:       input.Phone,
-		Password:    input.Password,
-		Preferences: input.Preferences,
-		CreatedAt:   input.CreatedAt,
-		UpdatedAt:   input.UpdatedAt,
-		Version:     input.Version,
-		ArchivedAt:  input.ArchivedAt,
-		DeletedAt:   input.DeletedAt,
+		ID:              input.ID,
+		Name:            input.Name,
+		Email:           input.Email,
+		EmailVerifiedAt: input.EmailVerifiedAt,
+		Phone:           input.Phone,
+		PhoneVerifiedAt: input.PhoneVerifiedAt,
+		Password:        input.Password,
+		Preferences:     input.Preferences,
+		CreatedAt:       input.CreatedAt,
+		UpdatedAt:       input.UpdatedAt,
+		Version:         input.Version,
+		ArchivedAt:      input.ArchivedAt,
+		DeletedAt:       input.DeletedAt,
+		ForgottenAt:     input.ForgottenAt,
 	}
 }
 
@@ -209,6 +236,10 @@ func (u *User) Update(input UpdateUserInput) error {
 			}
 			return msg.NewValidationError(err, map[string]any{"field": "Email", "input_email": input.Email}, ErrUserEmailInvalidUpdate)
 		}
+		if email != u.Email {
+			// A new address has to be verified again.
+			u.EmailVerifiedAt.SetNull()
+		}
 		u.Email = email
 		changed = true
 	}
@@ -221,6 +252,10 @@ func (u *User) Update(input UpdateUserInput) error {
 			}
 			return msg.NewValidationError(err, map[string]any{"field": "Phone", "input_phone": input.Phone}, ErrUserPhoneInvalidUpdate)
 		}
+		if phone != u.Phone {
+			// A new number has to be verified again.
+			u.PhoneVerifiedAt.SetNull()
+		}
 		u.Phone = phone
 		changed = true
 	}
@@ -250,6 +285,24 @@ func (u *User) Update(input UpdateUserInput) error {
 	return nil
 }
 
+// ChangedFields compares u with a snapshot taken before it was modified.
+func (u *User) ChangedFields(before User) []string {
+	var fields []string
+	if u.Name != before.Name {
+		fields = append(fields, FieldName)
+	}
+	if u.Email != before.Email {
+		fields = append(fields, FieldEmail)
+	}
+	if u.Phone != before.Phone {
+		fields = append(fields, FieldPhone)
+	}
+	if !bytes.Equal(u.Preferences, before.Preferences) {
+		fields = append(fields, FieldPreferences)
+	}
+	return fields
+}
+
 func (u *User) Archive() {
 	if !u.IsArchived() {
 		u.ArchivedAt = types.NewArchivedAtNow()
@@ -289,3 +342,17 @@ func (u *User) IsArchived() bool {
 func (u *User) IsDeleted() bool {
 	return !u.DeletedAt.IsNullable()
 }
+
+// Forget records that the data key of the user was destroyed. Its events can
+// no longer be encrypted, so a forgotten user stays deleted for good.
+func (u *User) Forget() {
+	if !u.IsForgotten() {
+		u.ForgottenAt.Set(time.Now())
+		u.UpdatedAt = types.NewUpdatedAt()
+		u.Version.Increment()
+	}
+}
+
+func (u *User) IsForgotten() bool {
+	return !u.ForgottenAt.IsNullable()
+}
@@ -0,0 +1,76 @@
+package user
+
+import (
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+const (
+	UserArchivedEventType      event.EventType    = "user.archived"
+	UserArchivedEventVersion   event.EventVersion = "v1.0.0"
+	UserUnarchivedEventType    event.EventType    = "user.unarchived"
+	UserUnarchivedEventVersion event.EventVersion = "v1.0.0"
+)
+
+// UserStatusEventInput carries the envelope fields shared by the events that
+// only change the lifecycle of a user.
+type UserStatusEventInput struct {
+	CorrelationID   types.UUID
+	UserID          types.NullableUUID // Author
+	TraceID         types.UUID         // OTEL
+	PreviousEventID types.NullableUUID
+	CausationID     types.NullableUUID
+	User            *User
+}
+
+type UserArchivedPayload struct {
+	UserID     types.UUID `json:"userId"`
+	Version    int        `json:"version"`
+	ArchivedAt time.Time  `json:"archivedAt"`
+}
+
+func (p UserArchivedPayload) PartitionKey() string {
+	return p.UserID.String()
+}
+
+type UserUnarchivedPayload struct {
+	UserID  types.UUID `json:"userId"`
+	Version int        `json:"version"`
+}
+
+func (p UserUnarchivedPayload) PartitionKey() string {
+	return p.UserID.String()
+}
+
+var (
+	UserArchivedEvent   = event.NewTypedDefinition[UserArchivedPayload](UserArchivedEventType, UserArchivedEventVersion, UserEventSource)
+	UserUnarchivedEvent = event.NewTypedDefinition[UserUnarchivedPayload](UserUnarchivedEventType, UserUnarchivedEventVersion, UserEventSource)
+)
+
+func NewUserArchivedEvent(input UserStatusEventInput) (*event.Event, error) {
+	return newUserStatusEvent(UserArchivedEvent, input, UserArchivedPayload{
+		UserID:     input.User.ID,
+		Version:    input.User.Version.Int(),
+		ArchivedAt: input.User.ArchivedAt.TimeOrZero(),
+	})
+}
+
+func NewUserUnarchivedEvent(input UserStatusEventInput) (*event.Event, error) {
+	return newUserStatusEvent(UserUnarchivedEvent, input, UserUnarchivedPayload{
+		UserID:  input.User.ID,
+		Version: input.User.Version.Int(),
+	})
+}
+
+func newUserStatusEvent[P any](def event.TypedDefinition[P], input UserStatusEventInput, payload P) (*event.Event, error) {
+	return def.New(event.TypedInput[P]{
+		CorrelationID:   input.CorrelationID,
+		UserID:          input.UserID,
+		TraceID:         input.TraceID,
+		PreviousEventID: input.PreviousEventID,
+		CausationID:     input.CausationID,
+		Payload:         payload,
+	})
+}
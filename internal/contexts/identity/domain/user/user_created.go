@@ -1,11 +1,7 @@
 package user
 
 import (
-	"encoding/json"
-	"fmt"
-
 	"github.com/marcelofabianov/redtogreen/internal/platform/event"
-	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
 	"github.com/marcelofabianov/redtogreen/internal/platform/types"
 )
 
@@ -27,30 +23,25 @@ type USerCreatedEventInput struct {
 type UserCreatedPayload struct {
 	UserID types.UUID `json:"userId"`
 	Name   string     `json:"name"`
-	Email  string     `json:"email"`
-	Phone  string     `json:"phone"`
+	Email  string     `json:"email" pii:"true"`
+	Phone  string     `json:"phone" pii:"true"`
 }
 
-func NewUserCreatedEvent(input USerCreatedEventInput) (*event.Event, error) {
-	payloadBytes, err := json.Marshal(input.Payload)
-	if err != nil {
-		return nil, msg.NewInternalError(err, map[string]any{"payload": fmt.Sprintf("%+v", input.Payload)})
-	}
+// PartitionKey keeps the events of one user in order for ordered subscribers.
+func (p UserCreatedPayload) PartitionKey() string {
+	return p.UserID.String()
+}
 
-	evt, err := event.NewEvent(event.EventInput{
-		EventType:       UserCreatedEventType,
-		EventVersion:    UserCreatedEventVersion,
-		Source:          UserEventSource,
+// UserCreatedEvent binds user.created to its payload for typed publishing and decoding.
+var UserCreatedEvent = event.NewTypedDefinition[UserCreatedPayload](UserCreatedEventType, UserCreatedEventVersion, UserEventSource)
+
+func NewUserCreatedEvent(input USerCreatedEventInput) (*event.Event, error) {
+	return UserCreatedEvent.New(event.TypedInput[UserCreatedPayload]{
 		CorrelationID:   input.CorrelationID,
 		UserID:          input.UserID,
 		TraceID:         input.TraceID,
 		PreviousEventID: input.PreviousEventID,
 		CausationID:     input.CausationID,
-		Payload:         payloadBytes,
+		Payload:         input.Payload,
 	})
-	if err != nil {
-		return nil, err
-	}
-
-	return &evt, nil
 }
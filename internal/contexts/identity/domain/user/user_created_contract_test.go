@@ -0,0 +1,28 @@
+package user_test
+
+import (
+	"testing"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	"github.com/marcelofabianov/redtogreen/internal/platform/event/contract"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+func TestUserEvents_SatisfyConsumerContracts(t *testing.T) {
+	contract.VerifyProducer(t, map[event.EventType]contract.EventFactory{
+		user.UserCreatedEventType: func() (*event.Event, error) {
+			return user.NewUserCreatedEvent(user.USerCreatedEventInput{
+				CorrelationID: types.MustNewUUID(),
+				UserID:        types.NewValidNullableUUID(types.MustNewUUID()),
+				TraceID:       types.MustNewUUID(),
+				Payload: user.UserCreatedPayload{
+					UserID: types.MustNewUUID(),
+					Name:   "Contract User",
+					Email:  "contract@example.com",
+					Phone:  "+5562999998888",
+				},
+			})
+		},
+	})
+}
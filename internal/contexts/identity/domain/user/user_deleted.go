@@ -0,0 +1,83 @@
+package user
+
+import (
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+const (
+	UserDeletedEventType     event.EventType    = "user.deleted"
+	UserDeletedEventVersion  event.EventVersion = "v1.0.0"
+	UserRestoredEventType    event.EventType    = "user.restored"
+	UserRestoredEventVersion event.EventVersion = "v1.0.0"
+	UserPurgedEventType      event.EventType    = "user.purged"
+	UserPurgedEventVersion   event.EventVersion = "v1.0.0"
+)
+
+type UserDeletedPayload struct {
+	UserID    types.UUID `json:"userId"`
+	Version   int        `json:"version"`
+	DeletedAt time.Time  `json:"deletedAt"`
+}
+
+func (p UserDeletedPayload) PartitionKey() string {
+	return p.UserID.String()
+}
+
+type UserRestoredPayload struct {
+	UserID  types.UUID `json:"userId"`
+	Version int        `json:"version"`
+}
+
+func (p UserRestoredPayload) PartitionKey() string {
+	return p.UserID.String()
+}
+
+// UserPurgedPayload announces that the user row is gone for good. Consumers
+// should drop or redact whatever they still hold about the user.
+type UserPurgedPayload struct {
+	UserID    types.UUID `json:"userId"`
+	DeletedAt time.Time  `json:"deletedAt"`
+	PurgedAt  time.Time  `json:"purgedAt"`
+}
+
+func (p UserPurgedPayload) PartitionKey() string {
+	return p.UserID.String()
+}
+
+var (
+	UserDeletedEvent  = event.NewTypedDefinition[UserDeletedPayload](UserDeletedEventType, UserDeletedEventVersion, UserEventSource)
+	UserRestoredEvent = event.NewTypedDefinition[UserRestoredPayload](UserRestoredEventType, UserRestoredEventVersion, UserEventSource)
+	UserPurgedEvent   = event.NewTypedDefinition[UserPurgedPayload](UserPurgedEventType, UserPurgedEventVersion, UserEventSource)
+)
+
+type UserPurgedEventInput struct {
+	CorrelationID types.UUID
+	TraceID       types.UUID // OTEL
+	Payload       UserPurgedPayload
+}
+
+func NewUserDeletedEvent(input UserStatusEventInput) (*event.Event, error) {
+	return newUserStatusEvent(UserDeletedEvent, input, UserDeletedPayload{
+		UserID:    input.User.ID,
+		Version:   input.User.Version.Int(),
+		DeletedAt: input.User.DeletedAt.TimeOrZero(),
+	})
+}
+
+func NewUserRestoredEvent(input UserStatusEventInput) (*event.Event, error) {
+	return newUserStatusEvent(UserRestoredEvent, input, UserRestoredPayload{
+		UserID:  input.User.ID,
+		Version: input.User.Version.Int(),
+	})
+}
+
+func NewUserPurgedEvent(input UserPurgedEventInput) (*event.Event, error) {
+	return UserPurgedEvent.New(event.TypedInput[UserPurgedPayload]{
+		CorrelationID: input.CorrelationID,
+		TraceID:       input.TraceID,
+		Payload:       input.Payload,
+	})
+}
@@ -0,0 +1,78 @@
+package user
+
+import (
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+const (
+	UserEmailVerificationRequestedEventType    event.EventType    = "user.email_verification_requested"
+	UserEmailVerificationRequestedEventVersion event.EventVersion = "v1.0.0"
+	UserEmailVerifiedEventType                 event.EventType    = "user.email_verified"
+	UserEmailVerifiedEventVersion              event.EventVersion = "v1.0.0"
+)
+
+// UserEmailVerificationRequestedPayload gives a notification subscriber what
+// it needs to email the verification link. The email and the token are
+// encrypted with the user key.
+type UserEmailVerificationRequestedPayload struct {
+	UserID            types.UUID `json:"userId"`
+	Name              string     `json:"name"`
+	Email             string     `json:"email" pii:"true"`
+	VerificationToken string     `json:"verificationToken" pii:"true"`
+	ExpiresAt         time.Time  `json:"expiresAt"`
+}
+
+func (p UserEmailVerificationRequestedPayload) PartitionKey() string {
+	return p.UserID.String()
+}
+
+type UserEmailVerifiedPayload struct {
+	UserID  types.UUID `json:"userId"`
+	Email   string     `json:"email" pii:"true"`
+	Version int        `json:"version"`
+}
+
+func (p UserEmailVerifiedPayload) PartitionKey() string {
+	return p.UserID.String()
+}
+
+type UserEmailVerificationRequestedEventInput struct {
+	CorrelationID types.UUID
+	TraceID       types.UUID // OTEL
+	Payload       UserEmailVerificationRequestedPayload
+}
+
+type UserEmailVerifiedEventInput struct {
+	CorrelationID types.UUID
+	TraceID       types.UUID // OTEL
+	Payload       UserEmailVerifiedPayload
+}
+
+var (
+	UserEmailVerificationRequestedEvent = event.NewTypedDefinition[UserEmailVerificationRequestedPayload](UserEmailVerificationRequestedEventType, UserEmailVerificationRequestedEventVersion, UserEventSource)
+	UserEmailVerifiedEvent              = event.NewTypedDefinition[UserEmailVerifiedPayload](UserEmailVerifiedEventType, UserEmailVerifiedEventVersion, UserEventSource)
+)
+
+// NewUserEmailVerificationRequestedEvent has no author: the token is issued
+// on registration and resent to anyone who asks for an address.
+func NewUserEmailVerificationRequestedEvent(input UserEmailVerificationRequestedEventInput) (*event.Event, error) {
+	return UserEmailVerificationRequestedEvent.New(event.TypedInput[UserEmailVerificationRequestedPayload]{
+		CorrelationID: input.CorrelationID,
+		TraceID:       input.TraceID,
+		Payload:       input.Payload,
+	})
+}
+
+// NewUserEmailVerifiedEvent records the user as the author, since it held
+// the token.
+func NewUserEmailVerifiedEvent(input UserEmailVerifiedEventInput) (*event.Event, error) {
+	return UserEmailVerifiedEvent.New(event.TypedInput[UserEmailVerifiedPayload]{
+		CorrelationID: input.CorrelationID,
+		UserID:        types.NewValidNullableUUID(input.Payload.UserID),
+		TraceID:       input.TraceID,
+		Payload:       input.Payload,
+	})
+}
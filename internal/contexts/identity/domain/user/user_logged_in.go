@@ -0,0 +1,75 @@
+package user
+
+import (
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+const (
+	UserLoggedInEventType       event.EventType    = "user.logged_in"
+	UserLoggedInEventVersion    event.EventVersion = "v1.0.0"
+	UserLoginFailedEventType    event.EventType    = "user.login_failed"
+	UserLoginFailedEventVersion event.EventVersion = "v1.0.0"
+)
+
+type UserLoggedInPayload struct {
+	UserID    types.UUID `json:"userId"`
+	Method    string     `json:"method"`
+	TokenID   string     `json:"tokenId"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+}
+
+func (p UserLoggedInPayload) PartitionKey() string {
+	return p.UserID.String()
+}
+
+// UserLoginFailedPayload has no UserID when the identifier matched nobody.
+type UserLoginFailedPayload struct {
+	UserID types.NullableUUID `json:"userId"`
+	Method string             `json:"method"`
+	Reason LoginFailureReason `json:"reason"`
+}
+
+func (p UserLoginFailedPayload) PartitionKey() string {
+	if id, ok := p.UserID.GetUUID(); ok {
+		return id.String()
+	}
+	return ""
+}
+
+type UserLoggedInEventInput struct {
+	CorrelationID types.UUID
+	TraceID       types.UUID // OTEL
+	Payload       UserLoggedInPayload
+}
+
+type UserLoginFailedEventInput struct {
+	CorrelationID types.UUID
+	TraceID       types.UUID // OTEL
+	Payload       UserLoginFailedPayload
+}
+
+var (
+	UserLoggedInEvent    = event.NewTypedDefinition[UserLoggedInPayload](UserLoggedInEventType, UserLoggedInEventVersion, UserEventSource)
+	UserLoginFailedEvent = event.NewTypedDefinition[UserLoginFailedPayload](UserLoginFailedEventType, UserLoginFailedEventVersion, UserEventSource)
+)
+
+// NewUserLoggedInEvent records the user as the author of its own login.
+func NewUserLoggedInEvent(input UserLoggedInEventInput) (*event.Event, error) {
+	return UserLoggedInEvent.New(event.TypedInput[UserLoggedInPayload]{
+		CorrelationID: input.CorrelationID,
+		UserID:        types.NewValidNullableUUID(input.Payload.UserID),
+		TraceID:       input.TraceID,
+		Payload:       input.Payload,
+	})
+}
+
+func NewUserLoginFailedEvent(input UserLoginFailedEventInput) (*event.Event, error) {
+	return UserLoginFailedEvent.New(event.TypedInput[UserLoginFailedPayload]{
+		CorrelationID: input.CorrelationID,
+		TraceID:       input.TraceID,
+		Payload:       input.Payload,
+	})
+}
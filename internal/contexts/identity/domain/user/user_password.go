@@ -0,0 +1,81 @@
+package user
+
+import (
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+const (
+	UserPasswordChangedEventType           event.EventType    = "user.password_changed"
+	UserPasswordChangedEventVersion        event.EventVersion = "v1.0.0"
+	UserPasswordResetRequestedEventType    event.EventType    = "user.password_reset_requested"
+	UserPasswordResetRequestedEventVersion event.EventVersion = "v1.0.0"
+)
+
+// UserPasswordChangedPayload never carries the password. Method is "change"
+// or "reset".
+type UserPasswordChangedPayload struct {
+	UserID          types.UUID `json:"userId"`
+	Method          string     `json:"method"`
+	Version         int        `json:"version"`
+	SessionsRevoked int        `json:"sessionsRevoked"`
+}
+
+func (p UserPasswordChangedPayload) PartitionKey() string {
+	return p.UserID.String()
+}
+
+// UserPasswordResetRequestedPayload gives a notification subscriber what it
+// needs to email the reset link. The token is encrypted like the email, so
+// only holders of the user key can read it.
+type UserPasswordResetRequestedPayload struct {
+	UserID     types.UUID `json:"userId"`
+	Name       string     `json:"name"`
+	Email      string     `json:"email" pii:"true"`
+	ResetToken string     `json:"resetToken" pii:"true"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+}
+
+func (p UserPasswordResetRequestedPayload) PartitionKey() string {
+	return p.UserID.String()
+}
+
+type UserPasswordChangedEventInput struct {
+	CorrelationID types.UUID
+	TraceID       types.UUID // OTEL
+	Payload       UserPasswordChangedPayload
+}
+
+type UserPasswordResetRequestedEventInput struct {
+	CorrelationID types.UUID
+	TraceID       types.UUID // OTEL
+	Payload       UserPasswordResetRequestedPayload
+}
+
+var (
+	UserPasswordChangedEvent        = event.NewTypedDefinition[UserPasswordChangedPayload](UserPasswordChangedEventType, UserPasswordChangedEventVersion, UserEventSource)
+	UserPasswordResetRequestedEvent = event.NewTypedDefinition[UserPasswordResetRequestedPayload](UserPasswordResetRequestedEventType, UserPasswordResetRequestedEventVersion, UserEventSource)
+)
+
+// NewUserPasswordChangedEvent records the user as the author: it either
+// knew the current password or held the reset token.
+func NewUserPasswordChangedEvent(input UserPasswordChangedEventInput) (*event.Event, error) {
+	return UserPasswordChangedEvent.New(event.TypedInput[UserPasswordChangedPayload]{
+		CorrelationID: input.CorrelationID,
+		UserID:        types.NewValidNullableUUID(input.Payload.UserID),
+		TraceID:       input.TraceID,
+		Payload:       input.Payload,
+	})
+}
+
+// NewUserPasswordResetRequestedEvent has no author; anyone may ask for a
+// reset link to be sent to an address.
+func NewUserPasswordResetRequestedEvent(input UserPasswordResetRequestedEventInput) (*event.Event, error) {
+	return UserPasswordResetRequestedEvent.New(event.TypedInput[UserPasswordResetRequestedPayload]{
+		CorrelationID: input.CorrelationID,
+		TraceID:       input.TraceID,
+		Payload:       input.Payload,
+	})
+}
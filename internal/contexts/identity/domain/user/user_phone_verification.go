@@ -0,0 +1,79 @@
+package user
+
+import (
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+const (
+	UserPhoneVerificationRequestedEventType    event.EventType    = "user.phone_verification_requested"
+	UserPhoneVerificationRequestedEventVersion event.EventVersion = "v1.0.0"
+	UserPhoneVerifiedEventType                 event.EventType    = "user.phone_verified"
+	UserPhoneVerifiedEventVersion              event.EventVersion = "v1.0.0"
+)
+
+// PhoneVerificationChannelSMS is the only delivery channel for now.
+const PhoneVerificationChannelSMS = "sms"
+
+// UserPhoneVerificationRequestedPayload is a delivery request for the SMS
+// channel. The phone and the code are encrypted with the user key.
+type UserPhoneVerificationRequestedPayload struct {
+	UserID    types.UUID `json:"userId"`
+	Channel   string     `json:"channel"`
+	Phone     string     `json:"phone" pii:"true"`
+	Code      string     `json:"code" pii:"true"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+}
+
+func (p UserPhoneVerificationRequestedPayload) PartitionKey() string {
+	return p.UserID.String()
+}
+
+type UserPhoneVerifiedPayload struct {
+	UserID  types.UUID `json:"userId"`
+	Phone   string     `json:"phone" pii:"true"`
+	Version int        `json:"version"`
+}
+
+func (p UserPhoneVerifiedPayload) PartitionKey() string {
+	return p.UserID.String()
+}
+
+type UserPhoneVerificationRequestedEventInput struct {
+	CorrelationID types.UUID
+	TraceID       types.UUID // OTEL
+	Payload       UserPhoneVerificationRequestedPayload
+}
+
+type UserPhoneVerifiedEventInput struct {
+	CorrelationID types.UUID
+	TraceID       types.UUID // OTEL
+	Payload       UserPhoneVerifiedPayload
+}
+
+var (
+	UserPhoneVerificationRequestedEvent = event.NewTypedDefinition[UserPhoneVerificationRequestedPayload](UserPhoneVerificationRequestedEventType, UserPhoneVerificationRequestedEventVersion, UserEventSource)
+	UserPhoneVerifiedEvent              = event.NewTypedDefinition[UserPhoneVerifiedPayload](UserPhoneVerifiedEventType, UserPhoneVerifiedEventVersion, UserEventSource)
+)
+
+// NewUserPhoneVerificationRequestedEvent records the user as the author: only
+// the authenticated user asks for a code to its own phone.
+func NewUserPhoneVerificationRequestedEvent(input UserPhoneVerificationRequestedEventInput) (*event.Event, error) {
+	return UserPhoneVerificationRequestedEvent.New(event.TypedInput[UserPhoneVerificationRequestedPayload]{
+		CorrelationID: input.CorrelationID,
+		UserID:        types.NewValidNullableUUID(input.Payload.UserID),
+		TraceID:       input.TraceID,
+		Payload:       input.Payload,
+	})
+}
+
+func NewUserPhoneVerifiedEvent(input UserPhoneVerifiedEventInput) (*event.Event, error) {
+	return UserPhoneVerifiedEvent.New(event.TypedInput[UserPhoneVerifiedPayload]{
+		CorrelationID: input.CorrelationID,
+		UserID:        types.NewValidNullableUUID(input.Payload.UserID),
+		TraceID:       input.TraceID,
+		Payload:       input.Payload,
+	})
+}
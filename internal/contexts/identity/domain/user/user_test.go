@@ -145,6 +145,29 @@ func TestUser_Update(t *testing.T) {
 		require.Error(t, err, "Updating with an invalid email should produce an error")
 	})
 
+	t.Run("Success: a new email must be verified again", func(t *testing.T) {
+		u.VerifyEmail()
+		require.True(t, u.IsEmailVerified())
+
+		require.NoError(t, u.Update(user.UpdateUserInput{Email: "original@example.com"}))
+		assert.True(t, u.IsEmailVerified(), "Sending the same email keeps it verified")
+
+		require.NoError(t, u.Update(user.UpdateUserInput{Email: "changed@example.com"}))
+		assert.False(t, u.IsEmailVerified(), "A changed email should no longer be verified")
+	})
+
+	t.Run("Success: a new phone must be verified again", func(t *testing.T) {
+		require.NoError(t, u.Update(user.UpdateUserInput{Phone: "5562999998888"}))
+		u.VerifyPhone()
+		require.True(t, u.IsPhoneVerified())
+
+		require.NoError(t, u.Update(user.UpdateUserInput{Phone: "(62) 99999-8888"}))
+		assert.True(t, u.IsPhoneVerified(), "The same number in another format keeps it verified")
+
+		require.NoError(t, u.Update(user.UpdateUserInput{Phone: "5562988887777"}))
+		assert.False(t, u.IsPhoneVerified(), "A changed phone should no longer be verified")
+	})
+
 	t.Run("Success: no changes applied", func(t *testing.T) {
 		u.Name = "Original Name"
 		updateInput := user.UpdateUserInput{}
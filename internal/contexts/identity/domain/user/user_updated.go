@@ -0,0 +1,72 @@
+package user
+
+import (
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+const (
+	UserUpdatedEventType    event.EventType    = "user.updated"
+	UserUpdatedEventVersion event.EventVersion = "v1.0.0"
+)
+
+type UserUpdatedEventInput struct {
+	CorrelationID   types.UUID
+	UserID          types.NullableUUID // Author
+	TraceID         types.UUID         // OTEL
+	PreviousEventID types.NullableUUID
+	CausationID     types.NullableUUID
+	Payload         UserUpdatedPayload
+}
+
+// UserUpdatedPayload lists the changed fields and carries the new value of
+// each changed profile field. Unchanged fields are omitted.
+type UserUpdatedPayload struct {
+	UserID        types.UUID `json:"userId"`
+	Version       int        `json:"version"`
+	ChangedFields []string   `json:"changedFields"`
+	Name          *string    `json:"name,omitempty"`
+	Email         *string    `json:"email,omitempty" pii:"true"`
+	Phone         *string    `json:"phone,omitempty" pii:"true"`
+}
+
+func (p UserUpdatedPayload) PartitionKey() string {
+	return p.UserID.String()
+}
+
+var UserUpdatedEvent = event.NewTypedDefinition[UserUpdatedPayload](UserUpdatedEventType, UserUpdatedEventVersion, UserEventSource)
+
+// NewUserUpdatedPayload builds the payload for the fields reported as changed
+// by UpdateUser.
+func NewUserUpdatedPayload(u *User, changedFields []string) UserUpdatedPayload {
+	payload := UserUpdatedPayload{
+		UserID:        u.ID,
+		Version:       u.Version.Int(),
+		ChangedFields: changedFields,
+	}
+	for _, field := range changedFields {
+		switch field {
+		case FieldName:
+			name := u.Name
+			payload.Name = &name
+		case FieldEmail:
+			email := u.Email.String()
+			payload.Email = &email
+		case FieldPhone:
+			phone := u.Phone.String()
+			payload.Phone = &phone
+		}
+	}
+	return payload
+}
+
+func NewUserUpdatedEvent(input UserUpdatedEventInput) (*event.Event, error) {
+	return UserUpdatedEvent.New(event.TypedInput[UserUpdatedPayload]{
+		CorrelationID:   input.CorrelationID,
+		UserID:          input.UserID,
+		TraceID:         input.TraceID,
+		PreviousEventID: input.PreviousEventID,
+		CausationID:     input.CausationID,
+		Payload:         input.Payload,
+	})
+}
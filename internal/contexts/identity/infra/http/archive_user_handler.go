@@ -1,27 +1,64 @@
 package http
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
 	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/web"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
 )
 
-func ArchiveUserHandler(w http.ResponseWriter, r *http.Request) {
+type ArchiveUserHandler struct {
+	commands bus.CommandDispatcher
+}
+
+func NewArchiveUserHandler(commands bus.CommandDispatcher) *ArchiveUserHandler {
+	return &ArchiveUserHandler{
+		commands: commands,
+	}
+}
+
+func (h *ArchiveUserHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	logger := web.GetLogger(r.Context())
 
-	userID := chi.URLParam(r, "userID")
+	input, err := userStatusCommandInput(r)
+	if err != nil {
+		web.RespondError(w, r, err)
+		return
+	}
 
-	logger.Info("archive user request received", "user_id", userID)
+	output, err := bus.SendCommand[user.UserStatusOutput](r.Context(), h.commands, user.ArchiveUserCommandInput{UserStatusCommandInput: input})
+	if err != nil {
+		logger.Error("failed to execute archive user command", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	w.Header().Set(web.HeaderETag, web.ETag(output.User.Version.Int()))
+	web.Respond(w, r, http.StatusOK, output.User)
+}
+
+// userStatusCommandInput reads the user ID and the If-Match precondition
+// shared by the lifecycle endpoints.
+func userStatusCommandInput(r *http.Request) (user.UserStatusCommandInput, error) {
+	userID, err := types.ParseUUID(chi.URLParam(r, "userID"))
+	if err != nil {
+		return user.UserStatusCommandInput{}, err
+	}
 
-	response := map[string]string{
-		"status":  "user archived successfully",
-		"user_id": userID,
+	expectedVersion, err := web.IfMatchVersion(r)
+	if err != nil {
+		return user.UserStatusCommandInput{}, err
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(response)
+	return user.UserStatusCommandInput{
+		CorrelationID:   web.GetCorrelationID(r.Context()),
+		TraceID:         web.GetTraceID(r.Context()),
+		UserAuthorID:    web.GetUserAuthorID(r.Context()),
+		UserID:          userID,
+		ExpectedVersion: types.Version(expectedVersion),
+	}, nil
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
 	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/validator"
 	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/web"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
 	"github.com/marcelofabianov/redtogreen/internal/platform/types"
 )
 
@@ -20,13 +21,13 @@ type CreateUserRequest struct {
 }
 
 type CreateUserHandler struct {
-	command   user.CreateUserCommand
+	commands  bus.CommandDispatcher
 	validator *validator.Validator
 }
 
-func NewCreateUserHandler(cmd user.CreateUserCommand, v *validator.Validator) *CreateUserHandler {
+func NewCreateUserHandler(commands bus.CommandDispatcher, v *validator.Validator) *CreateUserHandler {
 	return &CreateUserHandler{
-		command:   cmd,
+		commands:  commands,
 		validator: v,
 	}
 }
@@ -71,7 +72,7 @@ func (h *CreateUserHandler) Handle(w http.ResponseWriter, r *http.Request) {
 		NewUserInput:    newUserUseCaseInput,
 	}
 
-	output, err := h.command.Execute(r.Context(), commandInput)
+	output, err := bus.SendCommand[user.CreateUserOutput](r.Context(), h.commands, commandInput)
 	if err != nil {
 		logger.Error("failed to execute create user command", "error", err)
 		web.RespondError(w, r, err)
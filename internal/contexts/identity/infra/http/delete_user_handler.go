@@ -3,17 +3,35 @@ package http
 import (
 	"net/http"
 
-	"github.com/go-chi/chi/v5"
-
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
 	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/web"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
 )
 
-func DeleteUserHandler(w http.ResponseWriter, r *http.Request) {
+type DeleteUserHandler struct {
+	commands bus.CommandDispatcher
+}
+
+func NewDeleteUserHandler(commands bus.CommandDispatcher) *DeleteUserHandler {
+	return &DeleteUserHandler{
+		commands: commands,
+	}
+}
+
+func (h *DeleteUserHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	logger := web.GetLogger(r.Context())
 
-	userID := chi.URLParam(r, "userID")
+	input, err := userStatusCommandInput(r)
+	if err != nil {
+		web.RespondError(w, r, err)
+		return
+	}
 
-	logger.Info("delete user request received", "user_id", userID)
+	if _, err := h.commands.Dispatch(r.Context(), user.DeleteUserCommandInput{UserStatusCommandInput: input}); err != nil {
+		logger.Error("failed to execute delete user command", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
 
-	w.WriteHeader(http.StatusNoContent)
+	web.Respond(w, r, http.StatusNoContent, nil)
 }
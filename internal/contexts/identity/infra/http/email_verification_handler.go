@@ -0,0 +1,108 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/validator"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/web"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+)
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required,max=128"`
+}
+
+type ResendEmailVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// VerifyEmailHandler is public: holding the token sent to the address is
+// what proves ownership.
+type VerifyEmailHandler struct {
+	commands  bus.CommandDispatcher
+	validator *validator.Validator
+}
+
+func NewVerifyEmailHandler(commands bus.CommandDispatcher, v *validator.Validator) *VerifyEmailHandler {
+	return &VerifyEmailHandler{
+		commands:  commands,
+		validator: v,
+	}
+}
+
+func (h *VerifyEmailHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	logger := web.GetLogger(r.Context())
+
+	var req VerifyEmailRequest
+	if err := web.Decode(w, r, &req); err != nil {
+		logger.Error("failed to decode request body", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		logger.Error("request validation failed", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	commandInput := user.VerifyEmailCommandInput{
+		CorrelationID: web.GetCorrelationID(r.Context()),
+		TraceID:       web.GetTraceID(r.Context()),
+		Token:         req.Token,
+	}
+
+	if _, err := h.commands.Dispatch(r.Context(), commandInput); err != nil {
+		logger.Error("failed to execute verify email command", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	web.Respond(w, r, http.StatusNoContent, nil)
+}
+
+// ResendEmailVerificationHandler always answers 202 Accepted, like
+// ForgotPasswordHandler, so it cannot be used to probe for users.
+type ResendEmailVerificationHandler struct {
+	commands  bus.CommandDispatcher
+	validator *validator.Validator
+}
+
+func NewResendEmailVerificationHandler(commands bus.CommandDispatcher, v *validator.Validator) *ResendEmailVerificationHandler {
+	return &ResendEmailVerificationHandler{
+		commands:  commands,
+		validator: v,
+	}
+}
+
+func (h *ResendEmailVerificationHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	logger := web.GetLogger(r.Context())
+
+	var req ResendEmailVerificationRequest
+	if err := web.Decode(w, r, &req); err != nil {
+		logger.Error("failed to decode request body", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		logger.Error("request validation failed", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	commandInput := user.RequestEmailVerificationCommandInput{
+		CorrelationID: web.GetCorrelationID(r.Context()),
+		TraceID:       web.GetTraceID(r.Context()),
+		Email:         req.Email,
+	}
+
+	if _, err := h.commands.Dispatch(r.Context(), commandInput); err != nil {
+		logger.Error("failed to execute request email verification command", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	web.Respond(w, r, http.StatusAccepted, nil)
+}
@@ -0,0 +1,117 @@
+package http
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/web"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+)
+
+// externalLoginStateCookie binds the callback to the browser that started
+// the sign-in, so a callback URL cannot be completed by someone else.
+const externalLoginStateCookie = "identity_login_state"
+
+// StartExternalLoginHandler redirects the user to the identity provider.
+type StartExternalLoginHandler struct {
+	commands bus.CommandDispatcher
+}
+
+func NewStartExternalLoginHandler(commands bus.CommandDispatcher) *StartExternalLoginHandler {
+	return &StartExternalLoginHandler{commands: commands}
+}
+
+func (h *StartExternalLoginHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	logger := web.GetLogger(r.Context())
+
+	commandInput := user.StartExternalLoginCommandInput{
+		CorrelationID: web.GetCorrelationID(r.Context()),
+		TraceID:       web.GetTraceID(r.Context()),
+	}
+
+	output, err := bus.SendCommand[user.StartExternalLoginOutput](r.Context(), h.commands, commandInput)
+	if err != nil {
+		logger.Error("failed to start external login", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	// Scoped to this path, the cookie is only sent back to the callback below it.
+	http.SetCookie(w, &http.Cookie{
+		Name:     externalLoginStateCookie,
+		Value:    output.State,
+		Path:     r.URL.Path,
+		Expires:  output.ExpiresAt,
+		MaxAge:   int(time.Until(output.ExpiresAt).Seconds()),
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, output.AuthURL, http.StatusFound)
+}
+
+// ExternalLoginCallbackHandler completes the sign-in when the provider
+// redirects back, and answers with a session like LoginHandler.
+type ExternalLoginCallbackHandler struct {
+	commands bus.CommandDispatcher
+}
+
+func NewExternalLoginCallbackHandler(commands bus.CommandDispatcher) *ExternalLoginCallbackHandler {
+	return &ExternalLoginCallbackHandler{commands: commands}
+}
+
+func (h *ExternalLoginCallbackHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	logger := web.GetLogger(r.Context())
+	query := r.URL.Query()
+
+	cookie, cookieErr := r.Cookie(externalLoginStateCookie)
+	if cookieErr == nil {
+		// The cookie was set on the start path, the parent of this one.
+		http.SetCookie(w, &http.Cookie{
+			Name:     externalLoginStateCookie,
+			Path:     path.Dir(r.URL.Path),
+			MaxAge:   -1,
+			HttpOnly: true,
+			Secure:   true,
+			SameSite: http.SameSiteLaxMode,
+		})
+	}
+
+	if providerErr := query.Get("error"); providerErr != "" {
+		logger.Warn("identity provider returned an error", "error", providerErr)
+		web.RespondError(w, r, msg.NewMessageError(nil, user.ErrExternalLoginRejected, msg.CodeUnauthorized, map[string]any{
+			"provider_error": providerErr,
+		}))
+		return
+	}
+
+	state := query.Get("state")
+	if cookieErr != nil || state == "" || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(state)) != 1 {
+		logger.Warn("external login state does not match the browser")
+		web.RespondError(w, r, msg.NewMessageError(nil, user.ErrExternalLoginStateInvalid, msg.CodeUnauthorized, nil))
+		return
+	}
+
+	commandInput := user.ExternalLoginCommandInput{
+		CorrelationID: web.GetCorrelationID(r.Context()),
+		TraceID:       web.GetTraceID(r.Context()),
+		Callback: user.ExternalLoginInput{
+			State: state,
+			Code:  query.Get("code"),
+		},
+	}
+
+	output, err := bus.SendCommand[user.ExternalLoginOutput](r.Context(), h.commands, commandInput)
+	if err != nil {
+		logger.Warn("external login failed", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	respondSession(w, r, output.Session)
+}
@@ -0,0 +1,48 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/web"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type ForgetUserHandler struct {
+	commands bus.CommandDispatcher
+}
+
+func NewForgetUserHandler(commands bus.CommandDispatcher) *ForgetUserHandler {
+	return &ForgetUserHandler{
+		commands: commands,
+	}
+}
+
+func (h *ForgetUserHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	logger := web.GetLogger(r.Context())
+
+	userID, err := types.ParseUUID(chi.URLParam(r, "userID"))
+	if err != nil {
+		logger.Error("invalid user id", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	commandInput := user.ForgetUserCommandInput{
+		CorrelationID: web.GetCorrelationID(r.Context()),
+		TraceID:       web.GetTraceID(r.Context()),
+		UserAuthorID:  web.GetUserAuthorID(r.Context()),
+		UserID:        userID,
+	}
+
+	if _, err := h.commands.Dispatch(r.Context(), commandInput); err != nil {
+		logger.Error("failed to execute forget user command", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	web.Respond(w, r, http.StatusNoContent, nil)
+}
@@ -1,26 +1,63 @@
 package http
 
 import (
-	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
 	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/web"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
 )
 
-func GetUserByIDHandler(w http.ResponseWriter, r *http.Request) {
+const (
+	includeDeletedParam = "include_deleted"
+
+	ErrInvalidBooleanParam = "Invalid boolean value."
+)
+
+type GetUserByIDHandler struct {
+	query user.GetUserByIDQuery
+}
+
+func NewGetUserByIDHandler(q user.GetUserByIDQuery) *GetUserByIDHandler {
+	return &GetUserByIDHandler{
+		query: q,
+	}
+}
+
+func (h *GetUserByIDHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	logger := web.GetLogger(r.Context())
 
-	userID := chi.URLParam(r, "userID")
+	userID, err := types.ParseUUID(chi.URLParam(r, "userID"))
+	if err != nil {
+		logger.Error("invalid user id", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
 
-	logger.Info("get user by id request received", "user_id", userID)
+	var includeDeleted bool
+	if raw := r.URL.Query().Get(includeDeletedParam); raw != "" {
+		includeDeleted, err = strconv.ParseBool(raw)
+		if err != nil {
+			web.RespondError(w, r, msg.NewValidationError(err, map[string]any{"field": includeDeletedParam}, ErrInvalidBooleanParam))
+			return
+		}
+	}
 
-	response := map[string]string{
-		"user_id": userID,
+	u, err := h.query.Execute(r.Context(), user.GetUserByIDQueryInput{
+		TraceID:        web.GetTraceID(r.Context()),
+		UserID:         userID,
+		IncludeDeleted: includeDeleted,
+	})
+	if err != nil {
+		logger.Error("failed to get user by id", "error", err)
+		web.RespondError(w, r, err)
+		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(response)
+	w.Header().Set(web.HeaderETag, web.ETag(u.Version.Int()))
+	web.Respond(w, r, http.StatusOK, u)
 }
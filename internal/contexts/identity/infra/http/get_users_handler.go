@@ -1,21 +1,93 @@
 package http
 
 import (
-	"encoding/json"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
 	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/web"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
 )
 
-func GetUsersHandler(w http.ResponseWriter, r *http.Request) {
+const (
+	ErrInvalidIntegerParam   = "Invalid integer value."
+	ErrInvalidTimestampParam = "Invalid timestamp, expected RFC 3339."
+)
+
+type GetUsersHandler struct {
+	query user.ListUsersQuery
+}
+
+func NewGetUsersHandler(q user.ListUsersQuery) *GetUsersHandler {
+	return &GetUsersHandler{
+		query: q,
+	}
+}
+
+func (h *GetUsersHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	logger := web.GetLogger(r.Context())
-	logger.Info("identity context test handler called")
 
-	response := map[string]string{
-		"message": "users retrieved successfully",
+	input, err := parseListUsersParams(r.URL.Query())
+	if err != nil {
+		web.RespondError(w, r, err)
+		return
+	}
+	input.TraceID = web.GetTraceID(r.Context())
+
+	output, err := h.query.Execute(r.Context(), input)
+	if err != nil {
+		logger.Error("failed to list users", "error", err)
+		web.RespondError(w, r, err)
+		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(response)
+	web.RespondPage(w, r, output.Users, output.NextCursor)
+}
+
+func parseListUsersParams(params url.Values) (user.ListUsersQueryInput, error) {
+	input := user.ListUsersQueryInput{
+		Filter: user.ListUsersFilter{
+			NamePrefix:  params.Get("name"),
+			EmailPrefix: params.Get("email"),
+			PhonePrefix: params.Get("phone"),
+			Archived:    user.StatusFilter(params.Get("archived")),
+			Deleted:     user.StatusFilter(params.Get("deleted")),
+		},
+		Sort:      user.ListSort(params.Get("sort")),
+		Direction: user.ListDirection(params.Get("direction")),
+		Cursor:    params.Get("cursor"),
+	}
+
+	if raw := params.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil {
+			return input, msg.NewValidationError(err, map[string]any{"field": "limit"}, ErrInvalidIntegerParam)
+		}
+		input.Limit = limit
+	}
+
+	var err error
+	if input.Filter.CreatedFrom, err = parseTimeParam(params, "created_from"); err != nil {
+		return input, err
+	}
+	if input.Filter.CreatedTo, err = parseTimeParam(params, "created_to"); err != nil {
+		return input, err
+	}
+
+	return input, nil
+}
+
+func parseTimeParam(params url.Values, field string) (time.Time, error) {
+	raw := params.Get(field)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, msg.NewValidationError(err, map[string]any{"field": field}, ErrInvalidTimestampParam)
+	}
+	return t, nil
 }
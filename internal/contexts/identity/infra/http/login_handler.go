@@ -0,0 +1,90 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/validator"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/web"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+)
+
+// LoginRequest identifies the user by either email or phone.
+type LoginRequest struct {
+	Email    string `json:"email" validate:"omitempty,email"`
+	Phone    string `json:"phone" validate:"omitempty,min=10,max=30"`
+	Password string `json:"password" validate:"required,max=72"`
+}
+
+// SessionResponse follows the OAuth 2.0 token response (RFC 6749, section
+// 5.1). It answers both login and refresh.
+type SessionResponse struct {
+	AccessToken      string    `json:"access_token"`
+	TokenType        string    `json:"token_type"`
+	ExpiresIn        int       `json:"expires_in"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+func respondSession(w http.ResponseWriter, r *http.Request, session user.Session) {
+	access := session.AccessToken
+	w.Header().Set("Cache-Control", "no-store")
+	web.Respond(w, r, http.StatusOK, SessionResponse{
+		AccessToken:      access.Value,
+		TokenType:        access.Type,
+		ExpiresIn:        int(access.ExpiresAt.Sub(access.Claims.IssuedAt).Seconds()),
+		ExpiresAt:        access.ExpiresAt,
+		RefreshToken:     session.RefreshToken.Value,
+		RefreshExpiresAt: session.RefreshToken.ExpiresAt,
+	})
+}
+
+type LoginHandler struct {
+	commands  bus.CommandDispatcher
+	validator *validator.Validator
+}
+
+func NewLoginHandler(commands bus.CommandDispatcher, v *validator.Validator) *LoginHandler {
+	return &LoginHandler{
+		commands:  commands,
+		validator: v,
+	}
+}
+
+func (h *LoginHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	logger := web.GetLogger(r.Context())
+
+	var req LoginRequest
+	if err := web.Decode(w, r, &req); err != nil {
+		logger.Error("failed to decode request body", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		logger.Error("request validation failed", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	commandInput := user.LoginCommandInput{
+		CorrelationID: web.GetCorrelationID(r.Context()),
+		TraceID:       web.GetTraceID(r.Context()),
+		Credentials: user.LoginInput{
+			Email:    req.Email,
+			Phone:    req.Phone,
+			Password: req.Password,
+		},
+	}
+
+	output, err := bus.SendCommand[user.LoginOutput](r.Context(), h.commands, commandInput)
+	if err != nil {
+		logger.Warn("login failed", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	respondSession(w, r, output.Session)
+}
@@ -0,0 +1,83 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/validator"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/web"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+)
+
+type LogoutHandler struct {
+	commands  bus.CommandDispatcher
+	validator *validator.Validator
+}
+
+func NewLogoutHandler(commands bus.CommandDispatcher, v *validator.Validator) *LogoutHandler {
+	return &LogoutHandler{
+		commands:  commands,
+		validator: v,
+	}
+}
+
+func (h *LogoutHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	logger := web.GetLogger(r.Context())
+
+	var req RefreshTokenRequest
+	if err := web.Decode(w, r, &req); err != nil {
+		logger.Error("failed to decode request body", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		logger.Error("request validation failed", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	commandInput := user.LogoutCommandInput{
+		CorrelationID: web.GetCorrelationID(r.Context()),
+		TraceID:       web.GetTraceID(r.Context()),
+		RefreshToken:  req.RefreshToken,
+	}
+
+	if _, err := h.commands.Dispatch(r.Context(), commandInput); err != nil {
+		logger.Error("failed to execute logout command", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	web.Respond(w, r, http.StatusNoContent, nil)
+}
+
+// LogoutAllHandler revokes every session of the authenticated user. Access
+// tokens already issued stay valid until they expire.
+type LogoutAllHandler struct {
+	commands bus.CommandDispatcher
+}
+
+func NewLogoutAllHandler(commands bus.CommandDispatcher) *LogoutAllHandler {
+	return &LogoutAllHandler{
+		commands: commands,
+	}
+}
+
+func (h *LogoutAllHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	logger := web.GetLogger(r.Context())
+
+	commandInput := user.LogoutAllCommandInput{
+		CorrelationID: web.GetCorrelationID(r.Context()),
+		TraceID:       web.GetTraceID(r.Context()),
+		UserAuthorID:  web.GetUserAuthorID(r.Context()),
+	}
+
+	if _, err := h.commands.Dispatch(r.Context(), commandInput); err != nil {
+		logger.Error("failed to execute logout all command", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	web.Respond(w, r, http.StatusNoContent, nil)
+}
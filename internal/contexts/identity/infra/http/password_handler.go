@@ -0,0 +1,160 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/validator"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/web"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+)
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required,max=72"`
+	NewPassword     string `json:"new_password" validate:"required,max=72"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required,max=128"`
+	NewPassword string `json:"new_password" validate:"required,max=72"`
+}
+
+// ChangePasswordHandler changes the password of the authenticated user and
+// signs out every session, this one included.
+type ChangePasswordHandler struct {
+	commands  bus.CommandDispatcher
+	validator *validator.Validator
+}
+
+func NewChangePasswordHandler(commands bus.CommandDispatcher, v *validator.Validator) *ChangePasswordHandler {
+	return &ChangePasswordHandler{
+		commands:  commands,
+		validator: v,
+	}
+}
+
+func (h *ChangePasswordHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	logger := web.GetLogger(r.Context())
+
+	var req ChangePasswordRequest
+	if err := web.Decode(w, r, &req); err != nil {
+		logger.Error("failed to decode request body", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		logger.Error("request validation failed", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	commandInput := user.ChangePasswordCommandInput{
+		CorrelationID:   web.GetCorrelationID(r.Context()),
+		TraceID:         web.GetTraceID(r.Context()),
+		UserAuthorID:    web.GetUserAuthorID(r.Context()),
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
+	}
+
+	if _, err := h.commands.Dispatch(r.Context(), commandInput); err != nil {
+		logger.Error("failed to execute change password command", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	web.Respond(w, r, http.StatusNoContent, nil)
+}
+
+// ForgotPasswordHandler always answers 202 Accepted, whether or not the
+// address belongs to an account, so it cannot be used to probe for users.
+type ForgotPasswordHandler struct {
+	commands  bus.CommandDispatcher
+	validator *validator.Validator
+}
+
+func NewForgotPasswordHandler(commands bus.CommandDispatcher, v *validator.Validator) *ForgotPasswordHandler {
+	return &ForgotPasswordHandler{
+		commands:  commands,
+		validator: v,
+	}
+}
+
+func (h *ForgotPasswordHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	logger := web.GetLogger(r.Context())
+
+	var req ForgotPasswordRequest
+	if err := web.Decode(w, r, &req); err != nil {
+		logger.Error("failed to decode request body", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		logger.Error("request validation failed", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	commandInput := user.RequestPasswordResetCommandInput{
+		CorrelationID: web.GetCorrelationID(r.Context()),
+		TraceID:       web.GetTraceID(r.Context()),
+		Email:         req.Email,
+	}
+
+	if _, err := h.commands.Dispatch(r.Context(), commandInput); err != nil {
+		logger.Error("failed to execute request password reset command", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	web.Respond(w, r, http.StatusAccepted, nil)
+}
+
+type ResetPasswordHandler struct {
+	commands  bus.CommandDispatcher
+	validator *validator.Validator
+}
+
+func NewResetPasswordHandler(commands bus.CommandDispatcher, v *validator.Validator) *ResetPasswordHandler {
+	return &ResetPasswordHandler{
+		commands:  commands,
+		validator: v,
+	}
+}
+
+func (h *ResetPasswordHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	logger := web.GetLogger(r.Context())
+
+	var req ResetPasswordRequest
+	if err := web.Decode(w, r, &req); err != nil {
+		logger.Error("failed to decode request body", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		logger.Error("request validation failed", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	commandInput := user.ResetPasswordCommandInput{
+		CorrelationID: web.GetCorrelationID(r.Context()),
+		TraceID:       web.GetTraceID(r.Context()),
+		Token:         req.Token,
+		NewPassword:   req.NewPassword,
+	}
+
+	if _, err := h.commands.Dispatch(r.Context(), commandInput); err != nil {
+		logger.Error("failed to execute reset password command", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	web.Respond(w, r, http.StatusNoContent, nil)
+}
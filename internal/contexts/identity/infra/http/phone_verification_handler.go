@@ -0,0 +1,88 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/validator"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/web"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+)
+
+type VerifyPhoneRequest struct {
+	Code string `json:"code" validate:"required,numeric,len=6"`
+}
+
+// RequestPhoneVerificationHandler sends a code by SMS to the phone of the
+// authenticated user. The code itself only travels in the event.
+type RequestPhoneVerificationHandler struct {
+	commands bus.CommandDispatcher
+}
+
+func NewRequestPhoneVerificationHandler(commands bus.CommandDispatcher) *RequestPhoneVerificationHandler {
+	return &RequestPhoneVerificationHandler{
+		commands: commands,
+	}
+}
+
+func (h *RequestPhoneVerificationHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	logger := web.GetLogger(r.Context())
+
+	commandInput := user.RequestPhoneVerificationCommandInput{
+		CorrelationID: web.GetCorrelationID(r.Context()),
+		TraceID:       web.GetTraceID(r.Context()),
+		UserAuthorID:  web.GetUserAuthorID(r.Context()),
+	}
+
+	if _, err := h.commands.Dispatch(r.Context(), commandInput); err != nil {
+		logger.Error("failed to execute request phone verification command", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	web.Respond(w, r, http.StatusAccepted, nil)
+}
+
+type VerifyPhoneHandler struct {
+	commands  bus.CommandDispatcher
+	validator *validator.Validator
+}
+
+func NewVerifyPhoneHandler(commands bus.CommandDispatcher, v *validator.Validator) *VerifyPhoneHandler {
+	return &VerifyPhoneHandler{
+		commands:  commands,
+		validator: v,
+	}
+}
+
+func (h *VerifyPhoneHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	logger := web.GetLogger(r.Context())
+
+	var req VerifyPhoneRequest
+	if err := web.Decode(w, r, &req); err != nil {
+		logger.Error("failed to decode request body", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		logger.Error("request validation failed", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	commandInput := user.VerifyPhoneCommandInput{
+		CorrelationID: web.GetCorrelationID(r.Context()),
+		TraceID:       web.GetTraceID(r.Context()),
+		UserAuthorID:  web.GetUserAuthorID(r.Context()),
+		Code:          req.Code,
+	}
+
+	if _, err := h.commands.Dispatch(r.Context(), commandInput); err != nil {
+		logger.Error("failed to execute verify phone command", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	web.Respond(w, r, http.StatusNoContent, nil)
+}
@@ -0,0 +1,58 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/validator"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/web"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+)
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required,max=128"`
+}
+
+type RefreshSessionHandler struct {
+	commands  bus.CommandDispatcher
+	validator *validator.Validator
+}
+
+func NewRefreshSessionHandler(commands bus.CommandDispatcher, v *validator.Validator) *RefreshSessionHandler {
+	return &RefreshSessionHandler{
+		commands:  commands,
+		validator: v,
+	}
+}
+
+func (h *RefreshSessionHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	logger := web.GetLogger(r.Context())
+
+	var req RefreshTokenRequest
+	if err := web.Decode(w, r, &req); err != nil {
+		logger.Error("failed to decode request body", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		logger.Error("request validation failed", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	commandInput := user.RefreshSessionCommandInput{
+		CorrelationID: web.GetCorrelationID(r.Context()),
+		TraceID:       web.GetTraceID(r.Context()),
+		RefreshToken:  req.RefreshToken,
+	}
+
+	output, err := bus.SendCommand[user.RefreshSessionOutput](r.Context(), h.commands, commandInput)
+	if err != nil {
+		logger.Warn("refresh failed", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	respondSession(w, r, output.Session)
+}
@@ -0,0 +1,39 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/web"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+)
+
+type RestoreUserHandler struct {
+	commands bus.CommandDispatcher
+}
+
+func NewRestoreUserHandler(commands bus.CommandDispatcher) *RestoreUserHandler {
+	return &RestoreUserHandler{
+		commands: commands,
+	}
+}
+
+func (h *RestoreUserHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	logger := web.GetLogger(r.Context())
+
+	input, err := userStatusCommandInput(r)
+	if err != nil {
+		web.RespondError(w, r, err)
+		return
+	}
+
+	output, err := bus.SendCommand[user.UserStatusOutput](r.Context(), h.commands, user.RestoreUserCommandInput{UserStatusCommandInput: input})
+	if err != nil {
+		logger.Error("failed to execute restore user command", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	w.Header().Set(web.HeaderETag, web.ETag(output.User.Version.Int()))
+	web.Respond(w, r, http.StatusOK, output.User)
+}
@@ -0,0 +1,85 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/role"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/web"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type AssignRoleHandler struct {
+	commands bus.CommandDispatcher
+}
+
+func NewAssignRoleHandler(commands bus.CommandDispatcher) *AssignRoleHandler {
+	return &AssignRoleHandler{
+		commands: commands,
+	}
+}
+
+func (h *AssignRoleHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	logger := web.GetLogger(r.Context())
+
+	input, err := roleAssignmentCommandInput(r)
+	if err != nil {
+		logger.Error("invalid user id", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	if _, err := h.commands.Dispatch(r.Context(), role.AssignRoleCommandInput{RoleAssignmentCommandInput: input}); err != nil {
+		logger.Error("failed to execute assign role command", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	web.Respond(w, r, http.StatusNoContent, nil)
+}
+
+type RevokeRoleHandler struct {
+	commands bus.CommandDispatcher
+}
+
+func NewRevokeRoleHandler(commands bus.CommandDispatcher) *RevokeRoleHandler {
+	return &RevokeRoleHandler{
+		commands: commands,
+	}
+}
+
+func (h *RevokeRoleHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	logger := web.GetLogger(r.Context())
+
+	input, err := roleAssignmentCommandInput(r)
+	if err != nil {
+		logger.Error("invalid user id", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	if _, err := h.commands.Dispatch(r.Context(), role.RevokeRoleCommandInput{RoleAssignmentCommandInput: input}); err != nil {
+		logger.Error("failed to execute revoke role command", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	web.Respond(w, r, http.StatusNoContent, nil)
+}
+
+func roleAssignmentCommandInput(r *http.Request) (role.RoleAssignmentCommandInput, error) {
+	userID, err := types.ParseUUID(chi.URLParam(r, "userID"))
+	if err != nil {
+		return role.RoleAssignmentCommandInput{}, err
+	}
+
+	return role.RoleAssignmentCommandInput{
+		CorrelationID: web.GetCorrelationID(r.Context()),
+		TraceID:       web.GetTraceID(r.Context()),
+		UserAuthorID:  web.GetUserAuthorID(r.Context()),
+		UserID:        userID,
+		Role:          chi.URLParam(r, "role"),
+	}, nil
+}
@@ -2,6 +2,9 @@ package http
 
 import (
 	"github.com/go-chi/chi/v5"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/role"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/web"
 )
 
 type Router struct {
@@ -9,18 +12,69 @@ type Router struct {
 }
 
 func NewIdentityRouter(
+	auth *web.Authenticator,
 	createUserHandler *CreateUserHandler,
+	forgetUserHandler *ForgetUserHandler,
+	getUserByIDHandler *GetUserByIDHandler,
+	getUsersHandler *GetUsersHandler,
+	updateUserHandler *UpdateUserHandler,
+	archiveUserHandler *ArchiveUserHandler,
+	unarchiveUserHandler *UnarchiveUserHandler,
+	deleteUserHandler *DeleteUserHandler,
+	restoreUserHandler *RestoreUserHandler,
+	loginHandler *LoginHandler,
+	refreshSessionHandler *RefreshSessionHandler,
+	logoutHandler *LogoutHandler,
+	logoutAllHandler *LogoutAllHandler,
+	assignRoleHandler *AssignRoleHandler,
+	revokeRoleHandler *RevokeRoleHandler,
+	startGoogleLoginHandler *StartExternalLoginHandler,
+	googleLoginCallbackHandler *ExternalLoginCallbackHandler,
+	changePasswordHandler *ChangePasswordHandler,
+	forgotPasswordHandler *ForgotPasswordHandler,
+	resetPasswordHandler *ResetPasswordHandler,
+	verifyEmailHandler *VerifyEmailHandler,
+	resendEmailVerificationHandler *ResendEmailVerificationHandler,
+	requestPhoneVerificationHandler *RequestPhoneVerificationHandler,
+	verifyPhoneHandler *VerifyPhoneHandler,
 ) *Router {
 	r := chi.NewRouter()
 
 	r.Route("/users", func(r chi.Router) {
-		r.Post("/", createUserHandler.Handle)
-		// r.Get("/", getUsersHandler.Handle)
-		// r.Get("/{userID}", getUserByIDHandler.Handle)
-		// r.Put("/{userID}", updateUserHandler.Handle)
-		// r.Delete("/{userID}", deleteUserHandler.Handle)
-		// r.Patch("/{userID}/archive", archiveUserHandler.Handle)
-		// r.Patch("/{userID}/unarchive", unarchiveUserHandler.Handle)
+		// Registration is public; a token, when sent, makes its user the author.
+		r.With(auth.Optional).Post("/", createUserHandler.Handle)
+		r.Post("/verify-email", verifyEmailHandler.Handle)
+		r.Post("/verify-email/resend", resendEmailVerificationHandler.Handle)
+
+		r.Group(func(r chi.Router) {
+			r.Use(auth.Require)
+			r.With(web.RequirePermission(role.PermissionUsersRead)).Get("/", getUsersHandler.Handle)
+			// Users read and update their own profile without the permission.
+			r.With(web.RequirePermissionOrSelf(role.PermissionUsersRead, "userID")).Get("/{userID}", getUserByIDHandler.Handle)
+			r.With(web.RequirePermissionOrSelf(role.PermissionUsersUpdate, "userID")).Put("/{userID}", updateUserHandler.Handle)
+			r.With(web.RequirePermissionOrSelf(role.PermissionUsersUpdate, "userID")).Patch("/{userID}", updateUserHandler.Handle)
+			r.With(web.RequirePermission(role.PermissionUsersDelete)).Delete("/{userID}", deleteUserHandler.Handle)
+			r.With(web.RequirePermission(role.PermissionUsersArchive)).Patch("/{userID}/archive", archiveUserHandler.Handle)
+			r.With(web.RequirePermission(role.PermissionUsersArchive)).Patch("/{userID}/unarchive", unarchiveUserHandler.Handle)
+			r.With(web.RequirePermission(role.PermissionUsersRestore)).Patch("/{userID}/restore", restoreUserHandler.Handle)
+			r.With(web.RequirePermission(role.PermissionUsersForget)).Delete("/{userID}/personal-data", forgetUserHandler.Handle)
+			r.With(web.RequirePermission(role.PermissionRolesAssign)).Put("/{userID}/roles/{role}", assignRoleHandler.Handle)
+			r.With(web.RequirePermission(role.PermissionRolesAssign)).Delete("/{userID}/roles/{role}", revokeRoleHandler.Handle)
+		})
+	})
+
+	r.Route("/auth", func(r chi.Router) {
+		r.Post("/login", loginHandler.Handle)
+		r.Post("/refresh", refreshSessionHandler.Handle)
+		r.Post("/logout", logoutHandler.Handle)
+		r.With(auth.Require).Post("/logout-all", logoutAllHandler.Handle)
+		r.Get("/google", startGoogleLoginHandler.Handle)
+		r.Get("/google/callback", googleLoginCallbackHandler.Handle)
+		r.With(auth.Require).Put("/password", changePasswordHandler.Handle)
+		r.Post("/password/forgot", forgotPasswordHandler.Handle)
+		r.Post("/password/reset", resetPasswordHandler.Handle)
+		r.With(auth.Require).Post("/phone/verification", requestPhoneVerificationHandler.Handle)
+		r.With(auth.Require).Post("/phone/verify", verifyPhoneHandler.Handle)
 	})
 
 	return &Router{Mux: r}
@@ -1,27 +1,39 @@
 package http
 
 import (
-	"encoding/json"
 	"net/http"
 
-	"github.com/go-chi/chi/v5"
-
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
 	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/web"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
 )
 
-func UnarchiveUserHandler(w http.ResponseWriter, r *http.Request) {
-	logger := web.GetLogger(r.Context())
+type UnarchiveUserHandler struct {
+	commands bus.CommandDispatcher
+}
+
+func NewUnarchiveUserHandler(commands bus.CommandDispatcher) *UnarchiveUserHandler {
+	return &UnarchiveUserHandler{
+		commands: commands,
+	}
+}
 
-	userID := chi.URLParam(r, "userID")
+func (h *UnarchiveUserHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	logger := web.GetLogger(r.Context())
 
-	logger.Info("unarchive user request received", "user_id", userID)
+	input, err := userStatusCommandInput(r)
+	if err != nil {
+		web.RespondError(w, r, err)
+		return
+	}
 
-	response := map[string]string{
-		"status":  "user unarchived successfully",
-		"user_id": userID,
+	output, err := bus.SendCommand[user.UserStatusOutput](r.Context(), h.commands, user.UnarchiveUserCommandInput{UserStatusCommandInput: input})
+	if err != nil {
+		logger.Error("failed to execute unarchive user command", "error", err)
+		web.RespondError(w, r, err)
+		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(response)
+	w.Header().Set(web.HeaderETag, web.ETag(output.User.Version.Int()))
+	web.Respond(w, r, http.StatusOK, output.User)
 }
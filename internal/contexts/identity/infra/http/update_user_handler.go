@@ -6,35 +6,83 @@ import (
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/validator"
 	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/web"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
 )
 
+// UpdateUserRequest serves both PUT and PATCH: omitted fields stay unchanged.
 type UpdateUserRequest struct {
-	Name  string `json:"name"`
-	Phone string `json:"phone"`
+	Name        string          `json:"name" validate:"omitempty,min=2,max=100"`
+	Email       string          `json:"email" validate:"omitempty,email"`
+	Phone       string          `json:"phone" validate:"omitempty,min=10,max=30"`
+	Preferences json.RawMessage `json:"preferences,omitempty"`
 }
 
-func UpdateUserHandler(w http.ResponseWriter, r *http.Request) {
+type UpdateUserHandler struct {
+	commands  bus.CommandDispatcher
+	validator *validator.Validator
+}
+
+func NewUpdateUserHandler(commands bus.CommandDispatcher, v *validator.Validator) *UpdateUserHandler {
+	return &UpdateUserHandler{
+		commands:  commands,
+		validator: v,
+	}
+}
+
+func (h *UpdateUserHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	logger := web.GetLogger(r.Context())
 
-	userID := chi.URLParam(r, "userID")
+	userID, err := types.ParseUUID(chi.URLParam(r, "userID"))
+	if err != nil {
+		logger.Error("invalid user id", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
+
+	expectedVersion, err := web.IfMatchVersion(r)
+	if err != nil {
+		web.RespondError(w, r, err)
+		return
+	}
 
 	var req UpdateUserRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		logger.Error("failed to decode request body for update", "error", err, "user_id", userID)
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if err := web.Decode(w, r, &req); err != nil {
+		logger.Error("failed to decode request body", "error", err)
+		web.RespondError(w, r, err)
 		return
 	}
 
-	logger.Info("update user request received", "user_id", userID, "new_name", req.Name)
+	if err := h.validator.Validate(&req); err != nil {
+		logger.Error("request validation failed", "error", err)
+		web.RespondError(w, r, err)
+		return
+	}
 
-	response := map[string]interface{}{
-		"status":       "user updated successfully",
-		"user_id":      userID,
-		"updated_data": req,
+	commandInput := user.UpdateUserCommandInput{
+		CorrelationID:   web.GetCorrelationID(r.Context()),
+		TraceID:         web.GetTraceID(r.Context()),
+		UserAuthorID:    web.GetUserAuthorID(r.Context()),
+		UserID:          userID,
+		ExpectedVersion: types.Version(expectedVersion),
+		Changes: user.UpdateUserInput{
+			Name:        req.Name,
+			Email:       req.Email,
+			Phone:       req.Phone,
+			Preferences: req.Preferences,
+		},
+	}
+
+	output, err := bus.SendCommand[user.UpdateUserOutput](r.Context(), h.commands, commandInput)
+	if err != nil {
+		logger.Error("failed to execute update user command", "error", err)
+		web.RespondError(w, r, err)
+		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(response)
+	w.Header().Set(web.HeaderETag, web.ETag(output.User.Version.Int()))
+	web.Respond(w, r, http.StatusOK, output.User)
 }
@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/database"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type EmailVerificationTokenRepository struct {
+	db database.DB
+}
+
+func NewEmailVerificationTokenRepository(db database.DB) user.EmailVerificationTokenRepository {
+	return &EmailVerificationTokenRepository{db: db}
+}
+
+const emailVerificationTokenColumns = `id, user_id, email, secret_hash, created_at, expires_at, used_at`
+
+func (r *EmailVerificationTokenRepository) CreateEmailVerificationToken(ctx context.Context, t *user.EmailVerificationToken) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `INSERT INTO email_verification_tokens (` + emailVerificationTokenColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := database.ExecutorFrom(ctx, r.db).ExecContext(
+		queryCtx,
+		query,
+		t.ID,
+		t.UserID,
+		t.Email,
+		t.SecretHash,
+		t.CreatedAt,
+		t.ExpiresAt,
+		t.UsedAt,
+	)
+	return err
+}
+
+func (r *EmailVerificationTokenRepository) FindEmailVerificationTokenByID(ctx context.Context, id types.UUID) (*user.EmailVerificationToken, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + emailVerificationTokenColumns + ` FROM email_verification_tokens WHERE id = $1`
+
+	t, err := scanEmailVerificationToken(database.ExecutorFrom(ctx, r.db).QueryRowContext(queryCtx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, msg.NewMessageError(err, user.ErrEmailVerificationTokenInvalid, msg.CodeNotFound, nil)
+		}
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// FindLatestEmailVerificationToken returns the last token issued to the user,
+// used or not, to pace resends.
+func (r *EmailVerificationTokenRepository) FindLatestEmailVerificationToken(ctx context.Context, userID types.UUID) (*user.EmailVerificationToken, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + emailVerificationTokenColumns + ` FROM email_verification_tokens
+		WHERE user_id = $1 ORDER BY created_at DESC LIMIT 1`
+
+	t, err := scanEmailVerificationToken(database.ExecutorFrom(ctx, r.db).QueryRowContext(queryCtx, query, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, msg.NewMessageError(err, user.ErrEmailVerificationTokenInvalid, msg.CodeNotFound, map[string]any{"user_id": userID.String()})
+		}
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func scanEmailVerificationToken(row rowScanner) (*user.EmailVerificationToken, error) {
+	var t user.EmailVerificationToken
+	err := row.Scan(
+		&t.ID,
+		&t.UserID,
+		&t.Email,
+		&t.SecretHash,
+		&t.CreatedAt,
+		&t.ExpiresAt,
+		&t.UsedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *EmailVerificationTokenRepository) UseEmailVerificationToken(ctx context.Context, input user.UseEmailVerificationTokenRepoInput) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	use := func(exec database.Executor) error {
+		result, err := exec.ExecContext(queryCtx, `
+			UPDATE email_verification_tokens
+			SET used_at = $2
+			WHERE id = $1 AND used_at IS NULL
+		`, input.ID, input.UsedAt)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return msg.NewMessageError(nil, user.ErrEmailVerificationTokenInvalid, msg.CodeConflict, map[string]any{
+				"email_verification_token_id": input.ID.String(),
+			})
+		}
+
+		// The other links sent to the user are no longer needed.
+		_, err = exec.ExecContext(queryCtx, `
+			UPDATE email_verification_tokens
+			SET used_at = $2
+			WHERE user_id = $1 AND used_at IS NULL
+		`, input.UserID, input.UsedAt)
+		return err
+	}
+
+	if tx, ok := database.TxFromContext(ctx); ok {
+		return use(tx)
+	}
+	return r.db.WithTransaction(ctx, nil, func(tx *sql.Tx) error {
+		return use(tx)
+	})
+}
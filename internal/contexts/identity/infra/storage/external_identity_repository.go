@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/database"
+)
+
+type ExternalIdentityRepository struct {
+	db database.DB
+}
+
+func NewExternalIdentityRepository(db database.DB) user.ExternalIdentityRepository {
+	return &ExternalIdentityRepository{db: db}
+}
+
+func (r *ExternalIdentityRepository) FindExternalIdentity(ctx context.Context, input user.FindExternalIdentityRepoInput) (*user.ExternalIdentity, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT id, user_id, provider, subject, email, created_at
+		FROM user_identities
+		WHERE provider = $1 AND subject = $2
+	`
+
+	var identity user.ExternalIdentity
+	err := database.ExecutorFrom(ctx, r.db).QueryRowContext(queryCtx, query, input.Provider, input.Subject).Scan(
+		&identity.ID,
+		&identity.UserID,
+		&identity.Provider,
+		&identity.Subject,
+		&identity.Email,
+		&identity.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, msg.NewMessageError(err, user.ErrUserNotFound, msg.CodeNotFound, map[string]any{"provider": input.Provider})
+		}
+		return nil, err
+	}
+
+	return &identity, nil
+}
+
+func (r *ExternalIdentityRepository) LinkExternalIdentity(ctx context.Context, input user.LinkExternalIdentityRepoInput) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	link := func(exec database.Executor) error {
+		if input.NewUser != nil {
+			if err := insertUser(queryCtx, exec, input.NewUser); err != nil {
+				return err
+			}
+		}
+		return insertExternalIdentity(queryCtx, exec, input.Identity)
+	}
+
+	if input.NewUser == nil {
+		return link(database.ExecutorFrom(ctx, r.db))
+	}
+	if tx, ok := database.TxFromContext(ctx); ok {
+		return link(tx)
+	}
+	return r.db.WithTransaction(ctx, nil, func(tx *sql.Tx) error {
+		return link(tx)
+	})
+}
+
+func insertExternalIdentity(ctx context.Context, exec database.Executor, identity *user.ExternalIdentity) error {
+	query := `
+		INSERT INTO user_identities (id, user_id, provider, subject, email, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := exec.ExecContext(
+		ctx,
+		query,
+		identity.ID,
+		identity.UserID,
+		identity.Provider,
+		identity.Subject,
+		identity.Email,
+		identity.CreatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == database.ErrCodeUniqueViolation {
+			return msg.NewMessageError(err, user.ErrUserAlreadyExists, msg.CodeConflict, map[string]any{"provider": identity.Provider})
+		}
+		return err
+	}
+
+	return nil
+}
+
+// SaveExternalLoginState also drops expired states, which keeps the table
+// small without a separate job.
+func (r *ExternalIdentityRepository) SaveExternalLoginState(ctx context.Context, state *user.ExternalLoginState) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	exec := database.ExecutorFrom(ctx, r.db)
+
+	if _, err := exec.ExecContext(queryCtx, `DELETE FROM external_login_states WHERE expires_at <= $1`, state.CreatedAt); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO external_login_states (state, provider, nonce, code_verifier, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := exec.ExecContext(
+		queryCtx,
+		query,
+		state.State,
+		state.Provider,
+		state.Nonce,
+		state.CodeVerifier,
+		state.CreatedAt,
+		state.ExpiresAt,
+	)
+	return err
+}
+
+func (r *ExternalIdentityRepository) ConsumeExternalLoginState(ctx context.Context, state string) (*user.ExternalLoginState, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		DELETE FROM external_login_states
+		WHERE state = $1
+		RETURNING state, provider, nonce, code_verifier, created_at, expires_at
+	`
+
+	var s user.ExternalLoginState
+	err := database.ExecutorFrom(ctx, r.db).QueryRowContext(queryCtx, query, state).Scan(
+		&s.State,
+		&s.Provider,
+		&s.Nonce,
+		&s.CodeVerifier,
+		&s.CreatedAt,
+		&s.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, msg.NewMessageError(err, user.ErrExternalLoginStateInvalid, msg.CodeNotFound, nil)
+		}
+		return nil, err
+	}
+
+	return &s, nil
+}
@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/database"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type PasswordResetTokenRepository struct {
+	db database.DB
+}
+
+func NewPasswordResetTokenRepository(db database.DB) user.PasswordResetTokenRepository {
+	return &PasswordResetTokenRepository{db: db}
+}
+
+const passwordResetTokenColumns = `id, user_id, secret_hash, created_at, expires_at, used_at`
+
+func (r *PasswordResetTokenRepository) CreatePasswordResetToken(ctx context.Context, t *user.PasswordResetToken) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `INSERT INTO password_reset_tokens (` + passwordResetTokenColumns + `) VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := database.ExecutorFrom(ctx, r.db).ExecContext(
+		queryCtx,
+		query,
+		t.ID,
+		t.UserID,
+		t.SecretHash,
+		t.CreatedAt,
+		t.ExpiresAt,
+		t.UsedAt,
+	)
+	return err
+}
+
+func (r *PasswordResetTokenRepository) FindPasswordResetTokenByID(ctx context.Context, id types.UUID) (*user.PasswordResetToken, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + passwordResetTokenColumns + ` FROM password_reset_tokens WHERE id = $1`
+
+	var t user.PasswordResetToken
+	err := database.ExecutorFrom(ctx, r.db).QueryRowContext(queryCtx, query, id).Scan(
+		&t.ID,
+		&t.UserID,
+		&t.SecretHash,
+		&t.CreatedAt,
+		&t.ExpiresAt,
+		&t.UsedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, msg.NewMessageError(err, user.ErrPasswordResetTokenInvalid, msg.CodeNotFound, nil)
+		}
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+func (r *PasswordResetTokenRepository) UsePasswordResetToken(ctx context.Context, input user.UsePasswordResetTokenRepoInput) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	use := func(exec database.Executor) error {
+		result, err := exec.ExecContext(queryCtx, `
+			UPDATE password_reset_tokens
+			SET used_at = $2
+			WHERE id = $1 AND used_at IS NULL
+		`, input.ID, input.UsedAt)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return msg.NewMessageError(nil, user.ErrPasswordResetTokenInvalid, msg.CodeConflict, map[string]any{
+				"password_reset_token_id": input.ID.String(),
+			})
+		}
+
+		// Older links sent to the user stop working once one of them was used.
+		_, err = exec.ExecContext(queryCtx, `
+			UPDATE password_reset_tokens
+			SET used_at = $2
+			WHERE user_id = $1 AND used_at IS NULL
+		`, input.UserID, input.UsedAt)
+		return err
+	}
+
+	if tx, ok := database.TxFromContext(ctx); ok {
+		return use(tx)
+	}
+	return r.db.WithTransaction(ctx, nil, func(tx *sql.Tx) error {
+		return use(tx)
+	})
+}
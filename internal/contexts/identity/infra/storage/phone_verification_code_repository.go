@@ -0,0 +1,148 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/database"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type PhoneVerificationCodeRepository struct {
+	db database.DB
+}
+
+func NewPhoneVerificationCodeRepository(db database.DB) user.PhoneVerificationCodeRepository {
+	return &PhoneVerificationCodeRepository{db: db}
+}
+
+const phoneVerificationCodeColumns = `id, user_id, phone, code_hash, attempts, created_at, expires_at, used_at`
+
+func (r *PhoneVerificationCodeRepository) CreatePhoneVerificationCode(ctx context.Context, c *user.PhoneVerificationCode) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `INSERT INTO phone_verification_codes (` + phoneVerificationCodeColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err := database.ExecutorFrom(ctx, r.db).ExecContext(
+		queryCtx,
+		query,
+		c.ID,
+		c.UserID,
+		c.Phone,
+		c.CodeHash,
+		c.Attempts,
+		c.CreatedAt,
+		c.ExpiresAt,
+		c.UsedAt,
+	)
+	return err
+}
+
+// FindLatestPhoneVerificationCode returns the last code issued to the user,
+// used or not. Only that code can be verified, and it paces resends.
+func (r *PhoneVerificationCodeRepository) FindLatestPhoneVerificationCode(ctx context.Context, userID types.UUID) (*user.PhoneVerificationCode, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + phoneVerificationCodeColumns + ` FROM phone_verification_codes
+		WHERE user_id = $1 ORDER BY created_at DESC LIMIT 1`
+
+	c, err := scanPhoneVerificationCode(database.ExecutorFrom(ctx, r.db).QueryRowContext(queryCtx, query, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, msg.NewMessageError(err, user.ErrPhoneVerificationCodeInvalid, msg.CodeNotFound, map[string]any{"user_id": userID.String()})
+		}
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func scanPhoneVerificationCode(row rowScanner) (*user.PhoneVerificationCode, error) {
+	var c user.PhoneVerificationCode
+	err := row.Scan(
+		&c.ID,
+		&c.UserID,
+		&c.Phone,
+		&c.CodeHash,
+		&c.Attempts,
+		&c.CreatedAt,
+		&c.ExpiresAt,
+		&c.UsedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// RecordPhoneVerificationAttempt counts the attempt in a single statement, so
+// concurrent guesses cannot go past the limit.
+func (r *PhoneVerificationCodeRepository) RecordPhoneVerificationAttempt(ctx context.Context, input user.RecordPhoneVerificationAttemptRepoInput) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := database.ExecutorFrom(ctx, r.db).ExecContext(queryCtx, `
+		UPDATE phone_verification_codes
+		SET attempts = attempts + 1
+		WHERE id = $1 AND used_at IS NULL AND attempts < $2
+	`, input.ID, input.MaxAttempts)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return msg.NewMessageError(nil, user.ErrPhoneVerificationAttemptsExceeded, msg.CodeConflict, map[string]any{
+			"phone_verification_code_id": input.ID.String(),
+		})
+	}
+	return nil
+}
+
+func (r *PhoneVerificationCodeRepository) UsePhoneVerificationCode(ctx context.Context, input user.UsePhoneVerificationCodeRepoInput) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	use := func(exec database.Executor) error {
+		result, err := exec.ExecContext(queryCtx, `
+			UPDATE phone_verification_codes
+			SET used_at = $2
+			WHERE id = $1 AND used_at IS NULL
+		`, input.ID, input.UsedAt)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return msg.NewMessageError(nil, user.ErrPhoneVerificationCodeInvalid, msg.CodeConflict, map[string]any{
+				"phone_verification_code_id": input.ID.String(),
+			})
+		}
+
+		// Older codes sent to the user can no longer be used.
+		_, err = exec.ExecContext(queryCtx, `
+			UPDATE phone_verification_codes
+			SET used_at = $2
+			WHERE user_id = $1 AND used_at IS NULL
+		`, input.UserID, input.UsedAt)
+		return err
+	}
+
+	if tx, ok := database.TxFromContext(ctx); ok {
+		return use(tx)
+	}
+	return r.db.WithTransaction(ctx, nil, func(tx *sql.Tx) error {
+		return use(tx)
+	})
+}
@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/database"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type RefreshTokenRepository struct {
+	db database.DB
+}
+
+func NewRefreshTokenRepository(db database.DB) user.RefreshTokenRepository {
+	return &RefreshTokenRepository{db: db}
+}
+
+const refreshTokenColumns = `id, family_id, user_id, secret_hash, created_at, expires_at, rotated_at, replaced_by_id, revoked_at`
+
+func (r *RefreshTokenRepository) CreateRefreshToken(ctx context.Context, input user.CreateRefreshTokenRepoInput) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return insertRefreshToken(queryCtx, database.ExecutorFrom(ctx, r.db), input.Token)
+}
+
+func (r *RefreshTokenRepository) FindRefreshTokenByID(ctx context.Context, id types.UUID) (*user.RefreshToken, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + refreshTokenColumns + ` FROM refresh_tokens WHERE id = $1`
+
+	var t user.RefreshToken
+	err := database.ExecutorFrom(ctx, r.db).QueryRowContext(queryCtx, query, id).Scan(
+		&t.ID,
+		&t.FamilyID,
+		&t.UserID,
+		&t.SecretHash,
+		&t.CreatedAt,
+		&t.ExpiresAt,
+		&t.RotatedAt,
+		&t.ReplacedByID,
+		&t.RevokedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, msg.NewMessageError(err, user.ErrRefreshTokenInvalid, msg.CodeNotFound, nil)
+		}
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+func (r *RefreshTokenRepository) RotateRefreshToken(ctx context.Context, input user.RotateRefreshTokenRepoInput) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	rotate := func(exec database.Executor) error {
+		result, err := exec.ExecContext(queryCtx, `
+			UPDATE refresh_tokens
+			SET rotated_at = $2, replaced_by_id = $3
+			WHERE id = $1 AND rotated_at IS NULL AND revoked_at IS NULL
+		`, input.CurrentID, input.Next.CreatedAt, input.Next.ID)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return msg.NewMessageError(nil, user.ErrRefreshTokenReused, msg.CodeConflict, map[string]any{
+				"refresh_token_id": input.CurrentID.String(),
+			})
+		}
+		return insertRefreshToken(queryCtx, exec, input.Next)
+	}
+
+	if tx, ok := database.TxFromContext(ctx); ok {
+		return rotate(tx)
+	}
+	return r.db.WithTransaction(ctx, nil, func(tx *sql.Tx) error {
+		return rotate(tx)
+	})
+}
+
+func (r *RefreshTokenRepository) RevokeRefreshTokens(ctx context.Context, input user.RevokeRefreshTokensRepoInput) (int, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	column, value := "family_id", input.FamilyID
+	if input.FamilyID.IsNil() {
+		column, value = "user_id", input.UserID
+	}
+	query := `UPDATE refresh_tokens SET revoked_at = $2 WHERE ` + column + ` = $1 AND revoked_at IS NULL`
+
+	result, err := database.ExecutorFrom(ctx, r.db).ExecContext(queryCtx, query, value, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(affected), nil
+}
+
+func insertRefreshToken(ctx context.Context, exec database.Executor, t *user.RefreshToken) error {
+	query := `INSERT INTO refresh_tokens (` + refreshTokenColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := exec.ExecContext(
+		ctx,
+		query,
+		t.ID,
+		t.FamilyID,
+		t.UserID,
+		t.SecretHash,
+		t.CreatedAt,
+		t.ExpiresAt,
+		t.RotatedAt,
+		t.ReplacedByID,
+		t.RevokedAt,
+	)
+	return err
+}
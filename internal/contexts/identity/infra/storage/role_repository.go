@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/role"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/database"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type RoleRepository struct {
+	db database.DB
+}
+
+func NewRoleRepository(db database.DB) role.RoleRepository {
+	return &RoleRepository{db: db}
+}
+
+func (r *RoleRepository) FindRoleByName(ctx context.Context, name string) (*role.Role, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	rows, err := database.ExecutorFrom(ctx, r.db).QueryContext(queryCtx, `
+		SELECT r.id, r.name, r.description, r.created_at, p.permission
+		FROM roles r
+		LEFT JOIN role_permissions p ON p.role_id = r.id
+		WHERE r.name = $1
+		ORDER BY p.permission
+	`, role.NormalizeName(name))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var found *role.Role
+	for rows.Next() {
+		var rl role.Role
+		var permission sql.NullString
+		if err := rows.Scan(&rl.ID, &rl.Name, &rl.Description, &rl.CreatedAt, &permission); err != nil {
+			return nil, err
+		}
+		if found == nil {
+			found = &rl
+		}
+		if permission.Valid {
+			found.Permissions = append(found.Permissions, permission.String)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, msg.NewMessageError(nil, role.ErrRoleNotFound, msg.CodeNotFound, map[string]any{"role": name})
+	}
+
+	return found, nil
+}
+
+func (r *RoleRepository) FindUserPermissions(ctx context.Context, userID types.UUID) ([]string, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	rows, err := database.ExecutorFrom(ctx, r.db).QueryContext(queryCtx, `
+		SELECT DISTINCT p.permission
+		FROM user_roles ur
+		JOIN role_permissions p ON p.role_id = ur.role_id
+		WHERE ur.user_id = $1
+		ORDER BY p.permission
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var permissions []string
+	for rows.Next() {
+		var permission string
+		if err := rows.Scan(&permission); err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, permission)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+func (r *RoleRepository) AssignRole(ctx context.Context, assignment *role.Assignment) (bool, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := database.ExecutorFrom(ctx, r.db).ExecContext(queryCtx, `
+		INSERT INTO user_roles (user_id, role_id, assigned_at, assigned_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, role_id) DO NOTHING
+	`, assignment.UserID, assignment.Role.ID, assignment.AssignedAt, assignment.AssignedBy)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (r *RoleRepository) RevokeRole(ctx context.Context, input role.RevokeRoleRepoInput) (bool, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := database.ExecutorFrom(ctx, r.db).ExecContext(queryCtx, `
+		DELETE FROM user_roles WHERE user_id = $1 AND role_id = $2
+	`, input.UserID, input.RoleID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
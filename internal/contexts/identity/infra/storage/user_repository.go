@@ -2,7 +2,11 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgconn"
@@ -10,6 +14,7 @@ import (
 	"github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
 	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
 	"github.com/marcelofabianov/redtogreen/internal/platform/port/database"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
 )
 
 type UserRepository struct {
@@ -27,7 +32,7 @@ func (r *UserRepository) UserExists(ctx context.Context, input user.UserExistsRe
 	query := `SELECT EXISTS(SELECT 1 FROM users WHERE email = $1 OR phone = $2)`
 
 	var exists bool
-	err := r.db.Conn().QueryRowContext(queryCtx, query, input.Email, input.Phone).Scan(&exists)
+	err := database.ExecutorFrom(ctx, r.db).QueryRowContext(queryCtx, query, input.Email, input.Phone).Scan(&exists)
 	if err != nil {
 		return false, err
 	}
@@ -35,28 +40,252 @@ func (r *UserRepository) UserExists(ctx context.Context, input user.UserExistsRe
 	return exists, nil
 }
 
+// UserStatus reports an archived or deleted user as existing but inactive.
+func (r *UserRepository) UserStatus(ctx context.Context, userID types.UUID) (user.UserStatus, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `SELECT archived_at IS NULL AND deleted_at IS NULL FROM users WHERE id = $1`
+
+	var active bool
+	err := database.ExecutorFrom(ctx, r.db).QueryRowContext(queryCtx, query, userID).Scan(&active)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return user.UserStatus{}, nil
+		}
+		return user.UserStatus{}, err
+	}
+
+	return user.UserStatus{Exists: true, Active: active}, nil
+}
+
+const userColumns = `id, name, email, email_verified_at, phone, phone_verified_at, password, preferences, created_at, updated_at, version, archived_at, deleted_at, forgotten_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanUser(row rowScanner) (*user.User, error) {
+	var (
+		input user.FromUserInput
+		phone sql.NullString
+	)
+	err := row.Scan(
+		&input.ID,
+		&input.Name,
+		&input.Email,
+		&input.EmailVerifiedAt,
+		&phone,
+		&input.PhoneVerifiedAt,
+		&input.Password,
+		&input.Preferences,
+		&input.CreatedAt,
+		&input.UpdatedAt,
+		&input.Version,
+		&input.ArchivedAt,
+		&input.DeletedAt,
+		&input.ForgottenAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if phone.Valid {
+		if err := input.Phone.Scan(phone.String); err != nil {
+			return nil, err
+		}
+	}
+
+	return user.FromUser(input), nil
+}
+
+// nullablePhone stores users without a phone, created from an external
+// identity, as NULL.
+func nullablePhone(p types.Phone) any {
+	if p.IsEmpty() {
+		return nil
+	}
+	return p
+}
+
+func (r *UserRepository) FindUserByID(ctx context.Context, input user.FindUserByIDRepoInput) (*user.User, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1`
+	if !input.IncludeDeleted {
+		query += ` AND deleted_at IS NULL`
+	}
+
+	u, err := scanUser(database.ExecutorFrom(ctx, r.db).QueryRowContext(queryCtx, query, input.UserID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, msg.NewMessageError(err, user.ErrUserNotFound, msg.CodeNotFound, map[string]any{"user_id": input.UserID.String()})
+		}
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// FindUserByLogin looks the user up by email, or by phone when no email is given.
+func (r *UserRepository) FindUserByLogin(ctx context.Context, input user.FindUserByLoginRepoInput) (*user.User, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	column, value := "email", any(input.Email)
+	if input.Email.IsEmpty() {
+		column, value = "phone", input.Phone
+	}
+	query := `SELECT ` + userColumns + ` FROM users WHERE ` + column + ` = $1`
+
+	u, err := scanUser(database.ExecutorFrom(ctx, r.db).QueryRowContext(queryCtx, query, value))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, msg.NewMessageError(err, user.ErrUserNotFound, msg.CodeNotFound, map[string]any{"login_by": column})
+		}
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// ListUsers pages with a keyset on (sort column, id). Keeping deleted_at IS
+// NULL in the default filter lets the planner use the partial indexes on
+// email and phone.
+func (r *UserRepository) ListUsers(ctx context.Context, input user.ListUsersRepoInput) ([]*user.User, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var (
+		conditions []string
+		args       []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	conditions = appendStatusCondition(conditions, "archived_at", input.Filter.Archived)
+	conditions = appendStatusCondition(conditions, "deleted_at", input.Filter.Deleted)
+
+	if input.Filter.NamePrefix != "" {
+		conditions = append(conditions, "name ILIKE "+arg(likePrefix(input.Filter.NamePrefix)))
+	}
+	if input.Filter.EmailPrefix != "" {
+		conditions = append(conditions, "email LIKE "+arg(likePrefix(input.Filter.EmailPrefix)))
+	}
+	if input.Filter.PhonePrefix != "" {
+		conditions = append(conditions, "phone LIKE "+arg(likePrefix(input.Filter.PhonePrefix)))
+	}
+	if !input.Filter.CreatedFrom.IsZero() {
+		conditions = append(conditions, "created_at >= "+arg(input.Filter.CreatedFrom))
+	}
+	if !input.Filter.CreatedTo.IsZero() {
+		conditions = append(conditions, "created_at < "+arg(input.Filter.CreatedTo))
+	}
+
+	column := sortColumn(input.Sort)
+	direction, comparison := "ASC", ">"
+	if input.Direction == user.ListDirectionDesc {
+		direction, comparison = "DESC", "<"
+	}
+
+	if input.After != nil {
+		if column == "id" {
+			conditions = append(conditions, "id "+comparison+" "+arg(input.After.ID))
+		} else {
+			conditions = append(conditions, fmt.Sprintf("(%s, id) %s (%s, %s)", column, comparison, arg(input.After.Value), arg(input.After.ID)))
+		}
+	}
+
+	query := `SELECT ` + userColumns + ` FROM users`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	if column == "id" {
+		query += fmt.Sprintf(" ORDER BY id %s", direction)
+	} else {
+		query += fmt.Sprintf(" ORDER BY %s %s, id %s", column, direction, direction)
+	}
+	query += " LIMIT " + arg(input.Limit)
+
+	rows, err := database.ExecutorFrom(ctx, r.db).QueryContext(queryCtx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := make([]*user.User, 0, input.Limit)
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// sortColumn maps a sort option to its column. Creation order is served by
+// the UUIDv7 primary key, which avoids a tie-breaker on created_at.
+func sortColumn(sort user.ListSort) string {
+	switch sort {
+	case user.ListSortName:
+		return "name"
+	case user.ListSortEmail:
+		return "email"
+	default:
+		return "id"
+	}
+}
+
+func appendStatusCondition(conditions []string, column string, filter user.StatusFilter) []string {
+	switch filter {
+	case user.StatusExclude:
+		return append(conditions, column+" IS NULL")
+	case user.StatusOnly:
+		return append(conditions, column+" IS NOT NULL")
+	default:
+		return conditions
+	}
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func likePrefix(prefix string) string {
+	return likeEscaper.Replace(prefix) + "%"
+}
+
 func (r *UserRepository) CreateUser(ctx context.Context, input user.CreateUserRepoInput) error {
 	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
+	return insertUser(queryCtx, database.ExecutorFrom(ctx, r.db), input.User)
+}
+
+func insertUser(ctx context.Context, exec database.Executor, u *user.User) error {
 	query := `
-		INSERT INTO users (id, name, email, phone, password, preferences, created_at, updated_at, version)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO users (id, name, email, phone, password, preferences, created_at, updated_at, version, email_verified_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 	`
-	u := input.User
 
-	_, err := r.db.Conn().ExecContext(
-		queryCtx,
+	_, err := exec.ExecContext(
+		ctx,
 		query,
 		u.ID,
 		u.Name,
 		u.Email,
-		u.Phone,
+		nullablePhone(u.Phone),
 		u.Password,
 		u.Preferences,
 		u.CreatedAt,
 		u.UpdatedAt,
 		u.Version,
+		u.EmailVerifiedAt,
 	)
 
 	if err != nil {
@@ -69,3 +298,205 @@ func (r *UserRepository) CreateUser(ctx context.Context, input user.CreateUserRe
 
 	return nil
 }
+
+func (r *UserRepository) UpdateUser(ctx context.Context, input user.UpdateUserRepoInput) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE users
+		SET name = $2, email = $3, phone = $4, preferences = $5, updated_at = $6, version = $7, archived_at = $8, deleted_at = $9,
+			email_verified_at = $11, phone_verified_at = $12
+		WHERE id = $1 AND version = $10
+	`
+	u := input.User
+
+	result, err := database.ExecutorFrom(ctx, r.db).ExecContext(
+		queryCtx,
+		query,
+		u.ID,
+		u.Name,
+		u.Email,
+		nullablePhone(u.Phone),
+		u.Preferences,
+		u.UpdatedAt,
+		u.Version,
+		u.ArchivedAt,
+		u.DeletedAt,
+		input.ExpectedVersion,
+		u.EmailVerifiedAt,
+		u.PhoneVerifiedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == database.ErrCodeUniqueViolation {
+			return msg.NewMessageError(err, user.ErrUserAlreadyExists, msg.CodeConflict, nil)
+		}
+		return err
+	}
+
+	return checkVersionedWrite(result, input)
+}
+
+func (r *UserRepository) UpdateUserStatus(ctx context.Context, input user.UpdateUserRepoInput) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE users
+		SET archived_at = $2, deleted_at = $3, updated_at = $4, version = $5, forgotten_at = $7
+		WHERE id = $1 AND version = $6
+	`
+	u := input.User
+
+	result, err := database.ExecutorFrom(ctx, r.db).ExecContext(
+		queryCtx,
+		query,
+		u.ID,
+		u.ArchivedAt,
+		u.DeletedAt,
+		u.UpdatedAt,
+		u.Version,
+		input.ExpectedVersion,
+		u.ForgottenAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkVersionedWrite(result, input)
+}
+
+func (r *UserRepository) UpdateUserPassword(ctx context.Context, input user.UpdateUserRepoInput) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE users
+		SET password = $2, updated_at = $3, version = $4
+		WHERE id = $1 AND version = $5
+	`
+	u := input.User
+
+	result, err := database.ExecutorFrom(ctx, r.db).ExecContext(
+		queryCtx,
+		query,
+		u.ID,
+		u.Password,
+		u.UpdatedAt,
+		u.Version,
+		input.ExpectedVersion,
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkVersionedWrite(result, input)
+}
+
+func (r *UserRepository) VerifyUserEmail(ctx context.Context, input user.UpdateUserRepoInput) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE users
+		SET email_verified_at = $2, updated_at = $3, version = $4
+		WHERE id = $1 AND version = $5
+	`
+	u := input.User
+
+	result, err := database.ExecutorFrom(ctx, r.db).ExecContext(
+		queryCtx,
+		query,
+		u.ID,
+		u.EmailVerifiedAt,
+		u.UpdatedAt,
+		u.Version,
+		input.ExpectedVersion,
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkVersionedWrite(result, input)
+}
+
+func (r *UserRepository) VerifyUserPhone(ctx context.Context, input user.UpdateUserRepoInput) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE users
+		SET phone_verified_at = $2, updated_at = $3, version = $4
+		WHERE id = $1 AND version = $5
+	`
+	u := input.User
+
+	result, err := database.ExecutorFrom(ctx, r.db).ExecContext(
+		queryCtx,
+		query,
+		u.ID,
+		u.PhoneVerifiedAt,
+		u.UpdatedAt,
+		u.Version,
+		input.ExpectedVersion,
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkVersionedWrite(result, input)
+}
+
+// checkVersionedWrite turns a write that matched no row into a conflict: the
+// user exists, since it was just loaded, so its version moved on.
+func checkVersionedWrite(result sql.Result, input user.UpdateUserRepoInput) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return msg.NewMessageError(nil, user.ErrUserConcurrentUpdate, msg.CodeConflict, map[string]any{
+			"user_id":          input.User.ID.String(),
+			"expected_version": input.ExpectedVersion.Int(),
+		})
+	}
+	return nil
+}
+
+func (r *UserRepository) PurgeDeletedUsers(ctx context.Context, input user.PurgeDeletedUsersRepoInput) ([]user.PurgedUser, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	query := `
+		DELETE FROM users
+		WHERE id IN (
+			SELECT id FROM users
+			WHERE deleted_at IS NOT NULL AND deleted_at < $1
+			ORDER BY deleted_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, deleted_at
+	`
+
+	rows, err := database.ExecutorFrom(ctx, r.db).QueryContext(queryCtx, query, input.DeletedBefore, input.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var purged []user.PurgedUser
+	for rows.Next() {
+		var p user.PurgedUser
+		if err := rows.Scan(&p.ID, &p.DeletedAt); err != nil {
+			return nil, err
+		}
+		purged = append(purged, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,130 @@
+package subscriber
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	identityUser "github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/notification/domain/notification"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/logger"
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+)
+
+// UserNotificationSubscriber delivers the secrets identity issues to its
+// users. Its handlers read the recipient and the secret in clear, so they must
+// be subscribed through pii.DecryptingHandler.
+type UserNotificationSubscriber struct {
+	email  notification.EmailSender
+	sms    notification.SMSSender
+	logger *slog.Logger
+	tracer trace.Tracer
+}
+
+func NewUserNotificationSubscriber(email notification.EmailSender, sms notification.SMSSender, logger *slog.Logger) *UserNotificationSubscriber {
+	return &UserNotificationSubscriber{
+		email:  email,
+		sms:    sms,
+		logger: logger,
+		tracer: otel.Tracer("notification-subscriber"),
+	}
+}
+
+func (s *UserNotificationSubscriber) HandlePasswordResetRequested(ctx context.Context, e *event.Event) error {
+	return s.handle(ctx, e, func() (notification.Message, error) {
+		p, err := identityUser.UserPasswordResetRequestedEvent.Decode(e)
+		return notification.Message{
+			Template:  notification.TemplatePasswordReset,
+			UserID:    p.UserID,
+			Name:      p.Name,
+			Recipient: p.Email,
+			Secret:    p.ResetToken,
+			ExpiresAt: p.ExpiresAt,
+		}, err
+	}, s.email.SendEmail)
+}
+
+func (s *UserNotificationSubscriber) HandleEmailVerificationRequested(ctx context.Context, e *event.Event) error {
+	return s.handle(ctx, e, func() (notification.Message, error) {
+		p, err := identityUser.UserEmailVerificationRequestedEvent.Decode(e)
+		return notification.Message{
+			Template:  notification.TemplateEmailVerification,
+			UserID:    p.UserID,
+			Name:      p.Name,
+			Recipient: p.Email,
+			Secret:    p.VerificationToken,
+			ExpiresAt: p.ExpiresAt,
+		}, err
+	}, s.email.SendEmail)
+}
+
+func (s *UserNotificationSubscriber) HandlePhoneVerificationRequested(ctx context.Context, e *event.Event) error {
+	return s.handle(ctx, e, func() (notification.Message, error) {
+		p, err := identityUser.UserPhoneVerificationRequestedEvent.Decode(e)
+		return notification.Message{
+			Template:  notification.TemplatePhoneVerification,
+			UserID:    p.UserID,
+			Recipient: p.Phone,
+			Secret:    p.Code,
+			ExpiresAt: p.ExpiresAt,
+		}, err
+	}, s.sms.SendSMS)
+}
+
+func (s *UserNotificationSubscriber) handle(
+	ctx context.Context,
+	e *event.Event,
+	decode func() (notification.Message, error),
+	send func(context.Context, notification.Message) error,
+) error {
+	ctx, span := s.tracer.Start(ctx, "UserNotificationSubscriber.Handle",
+		trace.WithAttributes(
+			attribute.String("event.type", string(e.Header.EventType)),
+			attribute.String("event.id", e.Header.EventID.String()),
+			attribute.String("notification.component", "subscriber"),
+		),
+	)
+	defer span.End()
+
+	loggerWithTrace := logger.WithContext(ctx, s.logger).With(
+		logger.Component("notification_subscriber"),
+		logger.EventID(e.Header.EventID),
+		logger.EventType(string(e.Header.EventType)),
+		logger.TraceID(e.Metadata.TraceID.String()),
+	)
+
+	// The payload is never logged: it carries the secret in clear.
+	message, err := decode()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to unmarshal event payload")
+		loggerWithTrace.Error("failed to unmarshal event payload for notification", logger.Err(err))
+		return err
+	}
+
+	// A forgotten user's fields arrive as null: there is nobody left to notify.
+	if message.Recipient == "" || message.Secret == "" {
+		span.SetStatus(codes.Ok, "Recipient forgotten")
+		loggerWithTrace.Info("notification skipped, the recipient was forgotten",
+			slog.String("user_id", message.UserID.String()),
+		)
+		return nil
+	}
+
+	if err := send(ctx, message); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to send notification")
+		loggerWithTrace.Error("failed to send notification",
+			logger.Err(err),
+			slog.String("template", string(message.Template)),
+		)
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "Notification sent")
+	return nil
+}
@@ -0,0 +1,127 @@
+package subscriber_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	identityUser "github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/notification/app/subscriber"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/notification/domain/notification"
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type mockSender struct {
+	SendFunc func(ctx context.Context, message notification.Message) error
+	emails   []notification.Message
+	sms      []notification.Message
+}
+
+func (m *mockSender) SendEmail(ctx context.Context, message notification.Message) error {
+	m.emails = append(m.emails, message)
+	if m.SendFunc != nil {
+		return m.SendFunc(ctx, message)
+	}
+	return nil
+}
+
+func (m *mockSender) SendSMS(ctx context.Context, message notification.Message) error {
+	m.sms = append(m.sms, message)
+	if m.SendFunc != nil {
+		return m.SendFunc(ctx, message)
+	}
+	return nil
+}
+
+func newPhoneVerificationEvent(t *testing.T, payload identityUser.UserPhoneVerificationRequestedPayload) *event.Event {
+	t.Helper()
+	payloadBytes, err := json.Marshal(payload)
+	require.NoError(t, err)
+	return &event.Event{
+		Header: event.EventHeader{
+			EventID:       types.MustNewUUID(),
+			EventType:     identityUser.UserPhoneVerificationRequestedEventType,
+			Timestamp:     time.Now().UTC(),
+			Source:        identityUser.UserEventSource,
+			SchemaVersion: identityUser.UserPhoneVerificationRequestedEventVersion,
+		},
+		Metadata: event.EventMetadata{TraceID: types.MustNewUUID()},
+		Payload:  payloadBytes,
+	}
+}
+
+func TestUserNotificationSubscriber_HandlePhoneVerificationRequested(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	userID := types.MustNewUUID()
+
+	t.Run("Success: should send the code by SMS", func(t *testing.T) {
+		sender := &mockSender{}
+		s := subscriber.NewUserNotificationSubscriber(sender, sender, logger)
+
+		err := s.HandlePhoneVerificationRequested(context.Background(), newPhoneVerificationEvent(t, identityUser.UserPhoneVerificationRequestedPayload{
+			UserID:    userID,
+			Channel:   "sms",
+			Phone:     "5562999998888",
+			Code:      "123456",
+			ExpiresAt: time.Now().Add(10 * time.Minute).UTC(),
+		}))
+
+		require.NoError(t, err)
+		assert.Empty(t, sender.emails)
+		require.Len(t, sender.sms, 1)
+		assert.Equal(t, notification.TemplatePhoneVerification, sender.sms[0].Template)
+		assert.Equal(t, userID, sender.sms[0].UserID)
+		assert.Equal(t, "5562999998888", sender.sms[0].Recipient)
+		assert.Equal(t, "123456", sender.sms[0].Secret)
+	})
+
+	t.Run("Success: should skip a forgotten user", func(t *testing.T) {
+		sender := &mockSender{}
+		s := subscriber.NewUserNotificationSubscriber(sender, sender, logger)
+
+		err := s.HandlePhoneVerificationRequested(context.Background(), newPhoneVerificationEvent(t, identityUser.UserPhoneVerificationRequestedPayload{
+			UserID:    userID,
+			Channel:   "sms",
+			ExpiresAt: time.Now().Add(10 * time.Minute).UTC(),
+		}))
+
+		require.NoError(t, err)
+		assert.Empty(t, sender.sms)
+	})
+
+	t.Run("Failure: should return the sender error so the event is retried", func(t *testing.T) {
+		sendErr := errors.New("provider unavailable")
+		sender := &mockSender{SendFunc: func(ctx context.Context, message notification.Message) error { return sendErr }}
+		s := subscriber.NewUserNotificationSubscriber(sender, sender, logger)
+
+		err := s.HandlePhoneVerificationRequested(context.Background(), newPhoneVerificationEvent(t, identityUser.UserPhoneVerificationRequestedPayload{
+			UserID:    userID,
+			Channel:   "sms",
+			Phone:     "5562999998888",
+			Code:      "123456",
+			ExpiresAt: time.Now().Add(10 * time.Minute).UTC(),
+		}))
+
+		assert.ErrorIs(t, err, sendErr)
+	})
+
+	t.Run("Failure: should reject an event of another type", func(t *testing.T) {
+		sender := &mockSender{}
+		s := subscriber.NewUserNotificationSubscriber(sender, sender, logger)
+		evt := newPhoneVerificationEvent(t, identityUser.UserPhoneVerificationRequestedPayload{UserID: userID})
+		evt.Header.EventType = identityUser.UserPasswordResetRequestedEventType
+
+		err := s.HandlePhoneVerificationRequested(context.Background(), evt)
+
+		assert.Error(t, err)
+		assert.Empty(t, sender.sms)
+	})
+}
@@ -0,0 +1,36 @@
+package container
+
+import (
+	identityUser "github.com/marcelofabianov/redtogreen/internal/contexts/identity/domain/user"
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+)
+
+const contextName = "notification"
+
+func RegisterEvents(catalog *event.Catalog) error {
+	subscriptions := []event.Subscription{
+		{
+			EventType:   identityUser.UserPasswordResetRequestedEventType,
+			Context:     contextName,
+			Description: "Emails the password reset token to the user.",
+		},
+		{
+			EventType:   identityUser.UserEmailVerificationRequestedEventType,
+			Context:     contextName,
+			Description: "Emails the verification token to the user.",
+		},
+		{
+			EventType:   identityUser.UserPhoneVerificationRequestedEventType,
+			Context:     contextName,
+			Description: "Sends the verification code to the user's phone by SMS.",
+		},
+	}
+
+	for _, s := range subscriptions {
+		if err := catalog.RegisterSubscription(s); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
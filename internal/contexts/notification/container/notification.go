@@ -0,0 +1,28 @@
+package container
+
+import (
+	"go.uber.org/dig"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/notification/app/subscriber"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/notification/domain/notification"
+	"github.com/marcelofabianov/redtogreen/internal/contexts/notification/infra/sender"
+)
+
+func Register(container *dig.Container) error {
+	// No email or SMS provider is integrated yet; the log sender stands in for both.
+	if err := container.Provide(sender.NewLogSender); err != nil {
+		return err
+	}
+	if err := container.Provide(func(s *sender.LogSender) notification.EmailSender { return s }); err != nil {
+		return err
+	}
+	if err := container.Provide(func(s *sender.LogSender) notification.SMSSender { return s }); err != nil {
+		return err
+	}
+
+	if err := container.Provide(subscriber.NewUserNotificationSubscriber); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type Template string
+
+const (
+	TemplatePasswordReset     Template = "password_reset"
+	TemplateEmailVerification Template = "email_verification"
+	TemplatePhoneVerification Template = "phone_verification"
+)
+
+// Message is a delivery request for a single recipient. Secret is the token or
+// code the recipient needs to act: adapters hand it to the channel and must
+// never log or persist it.
+type Message struct {
+	Template  Template
+	UserID    types.UUID
+	Name      string
+	Recipient string
+	Secret    string
+	ExpiresAt time.Time
+}
+
+type EmailSender interface {
+	SendEmail(ctx context.Context, message Message) error
+}
+
+type SMSSender interface {
+	SendSMS(ctx context.Context, message Message) error
+}
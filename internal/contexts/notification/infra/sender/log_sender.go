@@ -0,0 +1,40 @@
+package sender
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/marcelofabianov/redtogreen/internal/contexts/notification/domain/notification"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/logger"
+)
+
+// LogSender stands in for the email and SMS providers: it only records that a
+// message would have been delivered. The recipient and the secret stay out of
+// the log.
+type LogSender struct {
+	logger *slog.Logger
+}
+
+func NewLogSender(logger *slog.Logger) *LogSender {
+	return &LogSender{logger: logger}
+}
+
+func (s *LogSender) SendEmail(ctx context.Context, message notification.Message) error {
+	s.log(ctx, "email", message)
+	return nil
+}
+
+func (s *LogSender) SendSMS(ctx context.Context, message notification.Message) error {
+	s.log(ctx, "sms", message)
+	return nil
+}
+
+func (s *LogSender) log(ctx context.Context, channel string, message notification.Message) {
+	logger.WithContext(ctx, s.logger).Info("notification delivered to the log sender",
+		logger.Component("notification_log_sender"),
+		slog.String("channel", channel),
+		slog.String("template", string(message.Template)),
+		slog.String("user_id", message.UserID.String()),
+		slog.Time("expires_at", message.ExpiresAt),
+	)
+}
@@ -0,0 +1,176 @@
+package asyncapi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+)
+
+const (
+	Version            = "3.0.0"
+	defaultContentType = "application/json"
+)
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type Server struct {
+	Host     string `json:"host"`
+	Protocol string `json:"protocol"`
+}
+
+type Reference struct {
+	Ref string `json:"$ref"`
+}
+
+type Tag struct {
+	Name string `json:"name"`
+}
+
+type Channel struct {
+	Address     string               `json:"address"`
+	Description string               `json:"description,omitempty"`
+	Messages    map[string]Reference `json:"messages"`
+	Stream      string               `json:"x-stream,omitempty"`
+}
+
+type Operation struct {
+	Action   string      `json:"action"`
+	Channel  Reference   `json:"channel"`
+	Summary  string      `json:"summary,omitempty"`
+	Messages []Reference `json:"messages"`
+	Tags     []Tag       `json:"tags,omitempty"`
+}
+
+type Message struct {
+	Name        string  `json:"name"`
+	Title       string  `json:"title,omitempty"`
+	Summary     string  `json:"summary,omitempty"`
+	ContentType string  `json:"contentType"`
+	Payload     *Schema `json:"payload"`
+	Version     string  `json:"x-event-version"`
+	Source      string  `json:"x-event-source"`
+}
+
+type Components struct {
+	Messages map[string]Message `json:"messages"`
+	Schemas  map[string]*Schema `json:"schemas"`
+}
+
+type Document struct {
+	AsyncAPI           string               `json:"asyncapi"`
+	Info               Info                 `json:"info"`
+	Servers            map[string]Server    `json:"servers,omitempty"`
+	DefaultContentType string               `json:"defaultContentType"`
+	Channels           map[string]Channel   `json:"channels"`
+	Operations         map[string]Operation `json:"operations"`
+	Components         Components           `json:"components"`
+}
+
+// Generate describes every event in the catalog as an AsyncAPI channel whose
+// message is the common event envelope carrying the registered payload schema.
+func Generate(catalog *event.Catalog, info Info, servers map[string]Server) Document {
+	doc := Document{
+		AsyncAPI:           Version,
+		Info:               info,
+		Servers:            servers,
+		DefaultContentType: defaultContentType,
+		Channels:           make(map[string]Channel),
+		Operations:         make(map[string]Operation),
+		Components: Components{
+			Messages: make(map[string]Message),
+			Schemas: map[string]*Schema{
+				"EventHeader":   SchemaOf(event.EventHeader{}),
+				"EventContext":  SchemaOf(event.EventContext{}),
+				"EventMetadata": SchemaOf(event.EventMetadata{}),
+			},
+		},
+	}
+
+	for _, def := range catalog.Definitions() {
+		key := componentKey(def.Type)
+		payloadName := schemaName(def.Payload)
+
+		doc.Components.Schemas[payloadName] = SchemaOf(def.Payload)
+		doc.Components.Messages[key] = Message{
+			Name:        string(def.Type),
+			Title:       string(def.Type),
+			Summary:     def.Description,
+			ContentType: defaultContentType,
+			Payload:     messageSchema(payloadName),
+			Version:     string(def.Version),
+			Source:      def.Source,
+		}
+
+		messageRef := Reference{Ref: "#/components/messages/" + key}
+		channelRef := Reference{Ref: "#/channels/" + key}
+
+		doc.Channels[key] = Channel{
+			Address:     string(def.Type),
+			Description: def.Description,
+			Messages:    map[string]Reference{key: messageRef},
+			Stream:      def.Stream,
+		}
+
+		doc.Operations[fmt.Sprintf("%s.publish", key)] = Operation{
+			Action:   "send",
+			Channel:  channelRef,
+			Summary:  fmt.Sprintf("%s publishes %s", def.Source, def.Type),
+			Messages: []Reference{{Ref: "#/channels/" + key + "/messages/" + key}},
+			Tags:     contextTags(def.Context),
+		}
+
+		for _, sub := range catalog.Subscriptions(def.Type) {
+			summary := sub.Description
+			if summary == "" {
+				summary = fmt.Sprintf("%s context consumes %s", sub.Context, def.Type)
+			}
+			doc.Operations[fmt.Sprintf("%s.%s.receive", key, sub.Context)] = Operation{
+				Action:   "receive",
+				Channel:  channelRef,
+				Summary:  summary,
+				Messages: []Reference{{Ref: "#/channels/" + key + "/messages/" + key}},
+				Tags:     contextTags(sub.Context),
+			}
+		}
+	}
+
+	return doc
+}
+
+func messageSchema(payloadName string) *Schema {
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"header":   {Ref: "#/components/schemas/EventHeader"},
+			"context":  {Ref: "#/components/schemas/EventContext"},
+			"metadata": {Ref: "#/components/schemas/EventMetadata"},
+			"payload":  {Ref: "#/components/schemas/" + payloadName},
+		},
+		Required: []string{"header", "context", "metadata", "payload"},
+	}
+}
+
+func contextTags(context string) []Tag {
+	if context == "" {
+		return nil
+	}
+	return []Tag{{Name: context}}
+}
+
+func componentKey(eventType event.EventType) string {
+	return strings.NewReplacer("/", "-", " ", "-").Replace(string(eventType))
+}
+
+func schemaName(payload any) string {
+	t := reflect.TypeOf(payload)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Name()
+}
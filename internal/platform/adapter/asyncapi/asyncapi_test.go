@@ -0,0 +1,70 @@
+package asyncapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/asyncapi"
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type orderPlacedPayload struct {
+	OrderID  types.UUID         `json:"orderId"`
+	Email    string             `json:"email" pii:"true"`
+	Total    float64            `json:"total"`
+	Items    []string           `json:"items"`
+	CouponID types.NullableUUID `json:"couponId,omitempty"`
+}
+
+func TestGenerate(t *testing.T) {
+	catalog := event.NewCatalog()
+	require.NoError(t, catalog.Register(event.Definition{
+		Type:    "order.placed",
+		Version: "v1.0.0",
+		Source:  "OrderService",
+		Stream:  "order-stream",
+		Context: "order",
+		Payload: orderPlacedPayload{},
+	}))
+	require.NoError(t, catalog.RegisterSubscription(event.Subscription{EventType: "order.placed", Context: "audit"}))
+
+	doc := asyncapi.Generate(catalog, asyncapi.Info{Title: "test", Version: "1.0.0"}, nil)
+
+	t.Run("Success: should describe the channel and its stream", func(t *testing.T) {
+		assert.Equal(t, asyncapi.Version, doc.AsyncAPI, "Document should declare the AsyncAPI version")
+		channel, ok := doc.Channels["order.placed"]
+		require.True(t, ok, "Channel for the event should exist")
+		assert.Equal(t, "order.placed", channel.Address, "Channel address should be the event type subject")
+		assert.Equal(t, "order-stream", channel.Stream, "Channel should carry the JetStream stream")
+	})
+
+	t.Run("Success: should describe publishers and subscribers", func(t *testing.T) {
+		publish, ok := doc.Operations["order.placed.publish"]
+		require.True(t, ok, "Publish operation should exist")
+		assert.Equal(t, "send", publish.Action)
+		assert.Equal(t, []asyncapi.Tag{{Name: "order"}}, publish.Tags, "Publish operation should be tagged with the producer context")
+
+		receive, ok := doc.Operations["order.placed.audit.receive"]
+		require.True(t, ok, "Receive operation should exist for the subscriber")
+		assert.Equal(t, "receive", receive.Action)
+	})
+
+	t.Run("Success: should derive the payload schema from the struct", func(t *testing.T) {
+		schema, ok := doc.Components.Schemas["orderPlacedPayload"]
+		require.True(t, ok, "Payload schema should be registered under the struct name")
+
+		assert.Equal(t, "uuid", schema.Properties["orderId"].Format)
+		assert.Equal(t, "number", schema.Properties["total"].Type)
+		assert.Equal(t, "array", schema.Properties["items"].Type)
+		assert.True(t, schema.Properties["email"].PII, "PII fields should be flagged")
+		assert.Contains(t, schema.Required, "orderId")
+		assert.NotContains(t, schema.Required, "couponId", "omitempty fields should not be required")
+
+		message := doc.Components.Messages["order.placed"]
+		assert.Equal(t, "v1.0.0", message.Version)
+		assert.Equal(t, "#/components/schemas/orderPlacedPayload", message.Payload.Properties["payload"].Ref)
+	})
+}
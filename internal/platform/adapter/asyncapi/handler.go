@@ -0,0 +1,24 @@
+package asyncapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/web"
+)
+
+// Handler serves the document as-is instead of wrapping it in the API data envelope,
+// so AsyncAPI tooling can consume the endpoint directly.
+func Handler(doc Document) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, err := json.Marshal(doc)
+		if err != nil {
+			web.RespondError(w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	}
+}
@@ -0,0 +1,145 @@
+package asyncapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/pii"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 any                `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	PII                  bool               `json:"x-pii,omitempty"`
+}
+
+var (
+	timeType         = reflect.TypeOf(time.Time{})
+	rawMessageType   = reflect.TypeOf(json.RawMessage{})
+	uuidType         = reflect.TypeOf(types.UUID{})
+	nullableUUIDType = reflect.TypeOf(types.NullableUUID{})
+	nullableTimeType = reflect.TypeOf(types.NullableTime{})
+	archivedAtType   = reflect.TypeOf(types.ArchivedAt{})
+	deletedAtType    = reflect.TypeOf(types.DeletedAt{})
+	textMarshaler    = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonMarshaler    = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// SchemaOf builds a JSON schema for v following its encoding/json tags.
+func SchemaOf(v any) *Schema {
+	return schemaFor(reflect.TypeOf(v))
+}
+
+func schemaFor(t reflect.Type) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawMessageType:
+		return &Schema{}
+	case uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	case nullableUUIDType:
+		return &Schema{Type: []string{"string", "null"}, Format: "uuid"}
+	case nullableTimeType, archivedAtType, deletedAtType:
+		return &Schema{Type: []string{"string", "null"}, Format: "date-time"}
+	}
+
+	if t.Implements(textMarshaler) || reflect.PointerTo(t).Implements(textMarshaler) {
+		return &Schema{Type: "string"}
+	}
+	if t.Kind() == reflect.Struct && (t.Implements(jsonMarshaler) || reflect.PointerTo(t).Implements(jsonMarshaler)) {
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: schemaFor(t.Elem())}
+	case reflect.Struct:
+		return structSchema(t)
+	default:
+		return &Schema{}
+	}
+}
+
+func structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, omitEmpty := jsonFieldName(field)
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			embedded := schemaFor(field.Type)
+			for k, v := range embedded.Properties {
+				schema.Properties[k] = v
+			}
+			schema.Required = append(schema.Required, embedded.Required...)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldSchema := schemaFor(field.Type)
+		if field.Tag.Get(pii.TagName) == "true" {
+			fieldSchema.PII = true
+		}
+		schema.Properties[name] = fieldSchema
+
+		if !omitEmpty && field.Type.Kind() != reflect.Pointer {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return schema
+}
+
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	parts := strings.Split(tag, ",")
+	omitEmpty := false
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" || opt == "omitzero" {
+			omitEmpty = true
+		}
+	}
+	return parts[0], omitEmpty
+}
@@ -0,0 +1,60 @@
+package bus
+
+import (
+	"context"
+	"sync"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	platformBus "github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+)
+
+const (
+	ErrCommandAlreadyHandled = "A handler is already registered for this command type."
+	ErrCommandNotHandled     = "No handler is registered for this command type."
+)
+
+// InProcessCommandBus dispatches commands to the handler registered for their
+// type, through the middleware it was built with.
+type InProcessCommandBus struct {
+	mu         sync.RWMutex
+	handlers   map[platformBus.CommandType]platformBus.CommandHandler
+	middleware []platformBus.CommandMiddleware
+}
+
+// NewInProcessCommandBus builds a bus whose middleware runs in the given
+// order, the first one being the outermost.
+func NewInProcessCommandBus(middleware ...platformBus.CommandMiddleware) *InProcessCommandBus {
+	return &InProcessCommandBus{
+		handlers:   make(map[platformBus.CommandType]platformBus.CommandHandler),
+		middleware: middleware,
+	}
+}
+
+var _ platformBus.CommandBus = (*InProcessCommandBus)(nil)
+
+func (b *InProcessCommandBus) Register(commandType platformBus.CommandType, handler platformBus.CommandHandler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, exists := b.handlers[commandType]; exists {
+		return msg.NewMessageError(nil, ErrCommandAlreadyHandled, msg.CodeConflict, map[string]any{"command_type": commandType})
+	}
+
+	for i := len(b.middleware) - 1; i >= 0; i-- {
+		handler = b.middleware[i](handler)
+	}
+	b.handlers[commandType] = handler
+
+	return nil
+}
+
+func (b *InProcessCommandBus) Dispatch(ctx context.Context, cmd platformBus.Command) (any, error) {
+	b.mu.RLock()
+	handler, ok := b.handlers[cmd.CommandType()]
+	b.mu.RUnlock()
+	if !ok {
+		return nil, msg.NewMessageError(nil, ErrCommandNotHandled, msg.CodeInternal, map[string]any{"command_type": cmd.CommandType()})
+	}
+
+	return handler(ctx, cmd)
+}
@@ -0,0 +1,184 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/logger"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/validator"
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	platformBus "github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+	platformDB "github.com/marcelofabianov/redtogreen/internal/platform/port/database"
+)
+
+const (
+	logCommandSucceeded = "Command handled"
+	logCommandFailed    = "Command failed"
+
+	ErrCommandInvalid = "The command is invalid."
+)
+
+// CommandTracing opens a span around each command.
+func CommandTracing() platformBus.CommandMiddleware {
+	tracer := otel.Tracer("command-bus")
+
+	return func(next platformBus.CommandHandler) platformBus.CommandHandler {
+		return func(ctx context.Context, cmd platformBus.Command) (any, error) {
+			ctx, span := tracer.Start(ctx, fmt.Sprintf("Command %s", cmd.CommandType()),
+				trace.WithAttributes(attribute.String("command.type", string(cmd.CommandType()))),
+			)
+			defer span.End()
+
+			result, err := next(ctx, cmd)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "Command failed")
+				return nil, err
+			}
+
+			span.SetStatus(codes.Ok, "Command handled")
+			return result, nil
+		}
+	}
+}
+
+// CommandLogging logs the outcome and duration of each command.
+func CommandLogging(sl *slog.Logger) platformBus.CommandMiddleware {
+	return func(next platformBus.CommandHandler) platformBus.CommandHandler {
+		return func(ctx context.Context, cmd platformBus.Command) (any, error) {
+			start := time.Now()
+			result, err := next(ctx, cmd)
+
+			attrs := []any{
+				slog.String("command_type", string(cmd.CommandType())),
+				slog.Duration("duration", time.Since(start)),
+			}
+			if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.HasTraceID() {
+				attrs = append(attrs, logger.TraceID(spanCtx.TraceID().String()))
+			}
+
+			if err != nil {
+				errMsg := toMessageError(err)
+				attrs = append(attrs, logger.ErrorCode(errMsg.Code), logger.Err(err))
+				if errMsg.Code == msg.CodeInternal {
+					sl.Error(logCommandFailed, attrs...)
+				} else {
+					sl.Warn(logCommandFailed, attrs...)
+				}
+				return nil, err
+			}
+
+			sl.Info(logCommandSucceeded, attrs...)
+			return result, nil
+		}
+	}
+}
+
+// CommandAuthorization rejects commands implementing AuthorizableCommand whose
+// Authorize fails.
+func CommandAuthorization() platformBus.CommandMiddleware {
+	return func(next platformBus.CommandHandler) platformBus.CommandHandler {
+		return func(ctx context.Context, cmd platformBus.Command) (any, error) {
+			if authorizable, ok := cmd.(platformBus.AuthorizableCommand); ok {
+				if err := authorizable.Authorize(ctx); err != nil {
+					return nil, err
+				}
+			}
+			return next(ctx, cmd)
+		}
+	}
+}
+
+// CommandValidation checks the validate tags of the command and, when it
+// implements ValidatableCommand, its own rules.
+func CommandValidation(v *validator.Validator) platformBus.CommandMiddleware {
+	return func(next platformBus.CommandHandler) platformBus.CommandHandler {
+		return func(ctx context.Context, cmd platformBus.Command) (any, error) {
+			if err := v.Validate(cmd); err != nil {
+				return nil, err
+			}
+			if validatable, ok := cmd.(platformBus.ValidatableCommand); ok {
+				if err := validatable.Validate(); err != nil {
+					var msgErr *msg.MessageError
+					if errors.As(err, &msgErr) {
+						return nil, err
+					}
+					return nil, msg.NewValidationError(err, map[string]any{"command_type": cmd.CommandType()}, ErrCommandInvalid)
+				}
+			}
+			return next(ctx, cmd)
+		}
+	}
+}
+
+// CommandIdempotency replays the result of an earlier command carrying the
+// same idempotency key, taken from the event scope of ctx. Keys are scoped to
+// the tenant and authenticated subject, so callers never see each other's
+// results, and a key reused with a different request hash is a conflict.
+// Failed commands are not remembered so they can be retried. The store decides
+// how far replays reach: MemoryIdempotencyStore only covers one instance.
+func CommandIdempotency(store IdempotencyStore) platformBus.CommandMiddleware {
+	return func(next platformBus.CommandHandler) platformBus.CommandHandler {
+		return func(ctx context.Context, cmd platformBus.Command) (any, error) {
+			scope := event.ScopeFromContext(ctx)
+			key := scope.Attributes[event.IdempotencyKeyAttribute]
+			if key == "" {
+				return next(ctx, cmd)
+			}
+			key = fmt.Sprintf("%s:%s:%s:%s", scope.TenantID, scope.SubjectID, cmd.CommandType(), key)
+
+			result, done, err := store.Reserve(ctx, key, scope.RequestHash)
+			if err != nil {
+				return nil, err
+			}
+			if done {
+				return result, nil
+			}
+
+			result, err = next(ctx, cmd)
+			if err != nil {
+				store.Release(ctx, key)
+				return nil, err
+			}
+
+			store.Complete(ctx, key, result)
+			return result, nil
+		}
+	}
+}
+
+// CommandTransaction runs commands implementing TransactionalCommand inside a
+// transaction of db. Repositories join it through database.ExecutorFrom, and
+// events are only delivered once it commits: the Postgres bus writes them in
+// the same transaction and the NATS bus publishes them after the commit.
+func CommandTransaction(db platformDB.DB) platformBus.CommandMiddleware {
+	return func(next platformBus.CommandHandler) platformBus.CommandHandler {
+		return func(ctx context.Context, cmd platformBus.Command) (any, error) {
+			transactional, ok := cmd.(platformBus.TransactionalCommand)
+			if !ok || !transactional.Transactional() {
+				return next(ctx, cmd)
+			}
+
+			var result any
+			err := platformDB.InTransaction(ctx, db, func(ctx context.Context) error {
+				var err error
+				result, err = next(ctx, cmd)
+				return err
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return result, nil
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package bus_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/bus"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/validator"
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	platformBus "github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+	platformDB "github.com/marcelofabianov/redtogreen/internal/platform/port/database"
+)
+
+type greetCommand struct {
+	Name       string `validate:"required"`
+	forbidden  bool
+	validation error
+	inTx       bool
+}
+
+func (greetCommand) CommandType() platformBus.CommandType { return "test.greet" }
+
+func (c greetCommand) Authorize(context.Context) error {
+	if c.forbidden {
+		return msg.NewMessageError(nil, "Forbidden.", msg.CodeForbidden, nil)
+	}
+	return nil
+}
+
+func (c greetCommand) Validate() error { return c.validation }
+
+func (c greetCommand) Transactional() bool { return c.inTx }
+
+type fakeDB struct {
+	transactions int
+}
+
+func (d *fakeDB) Conn() *sql.DB { return nil }
+
+func (d *fakeDB) WithTransaction(ctx context.Context, opts *sql.TxOptions, fn func(tx *sql.Tx) error) error {
+	d.transactions++
+	return fn(&sql.Tx{})
+}
+
+func (d *fakeDB) Close() error { return nil }
+
+func newCommandBus(db platformDB.DB) *bus.InProcessCommandBus {
+	return bus.NewInProcessCommandBus(
+		bus.CommandTracing(),
+		bus.CommandLogging(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		bus.CommandAuthorization(),
+		bus.CommandValidation(validator.New()),
+		bus.CommandIdempotency(bus.NewMemoryIdempotencyStore(bus.DefaultIdempotencyTTL)),
+		bus.CommandTransaction(db),
+	)
+}
+
+func withIdempotencyKey(key string) context.Context {
+	return withIdempotentRequest(key, "subject-a", "hash-a")
+}
+
+func withIdempotentRequest(key, subjectID, requestHash string) context.Context {
+	return event.WithScope(context.Background(), event.Scope{
+		Attributes:  map[string]string{event.IdempotencyKeyAttribute: key},
+		SubjectID:   subjectID,
+		RequestHash: requestHash,
+	})
+}
+
+func TestInProcessCommandBus(t *testing.T) {
+	t.Run("Success: should dispatch to the typed handler", func(t *testing.T) {
+		commandBus := newCommandBus(&fakeDB{})
+		require.NoError(t, platformBus.HandleCommand(commandBus, func(ctx context.Context, cmd greetCommand) (string, error) {
+			return "hello " + cmd.Name, nil
+		}))
+
+		res, err := platformBus.SendCommand[string](context.Background(), commandBus, greetCommand{Name: "ana"})
+		require.NoError(t, err)
+		assert.Equal(t, "hello ana", res)
+	})
+
+	t.Run("Success: middleware should run in registration order", func(t *testing.T) {
+		var calls []string
+		record := func(name string) platformBus.CommandMiddleware {
+			return func(next platformBus.CommandHandler) platformBus.CommandHandler {
+				return func(ctx context.Context, cmd platformBus.Command) (any, error) {
+					calls = append(calls, name)
+					return next(ctx, cmd)
+				}
+			}
+		}
+		commandBus := bus.NewInProcessCommandBus(record("first"), record("second"))
+		require.NoError(t, commandBus.Register("test.greet", func(ctx context.Context, cmd platformBus.Command) (any, error) {
+			calls = append(calls, "handler")
+			return nil, nil
+		}))
+
+		_, err := commandBus.Dispatch(context.Background(), greetCommand{Name: "ana"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"first", "second", "handler"}, calls)
+	})
+
+	t.Run("Success: same idempotency key should replay the first result", func(t *testing.T) {
+		commandBus := newCommandBus(&fakeDB{})
+		var executions int
+		require.NoError(t, platformBus.HandleCommand(commandBus, func(ctx context.Context, cmd greetCommand) (int, error) {
+			executions++
+			return executions, nil
+		}))
+
+		ctx := withIdempotencyKey("key-1")
+		first, err := platformBus.SendCommand[int](ctx, commandBus, greetCommand{Name: "ana"})
+		require.NoError(t, err)
+		second, err := platformBus.SendCommand[int](ctx, commandBus, greetCommand{Name: "ana"})
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, executions, "Handler should run once per idempotency key")
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("Success: same idempotency key from another subject should not replay", func(t *testing.T) {
+		commandBus := newCommandBus(&fakeDB{})
+		var executions int
+		require.NoError(t, platformBus.HandleCommand(commandBus, func(ctx context.Context, cmd greetCommand) (int, error) {
+			executions++
+			return executions, nil
+		}))
+
+		first, err := platformBus.SendCommand[int](withIdempotentRequest("key-1", "subject-a", "hash-a"), commandBus, greetCommand{Name: "ana"})
+		require.NoError(t, err)
+		second, err := platformBus.SendCommand[int](withIdempotentRequest("key-1", "subject-b", "hash-a"), commandBus, greetCommand{Name: "ana"})
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, executions, "Keys should be scoped to the authenticated subject")
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("Failure: same idempotency key with a different request should conflict", func(t *testing.T) {
+		commandBus := newCommandBus(&fakeDB{})
+		require.NoError(t, platformBus.HandleCommand(commandBus, func(ctx context.Context, cmd greetCommand) (int, error) {
+			return 1, nil
+		}))
+
+		_, err := platformBus.SendCommand[int](withIdempotentRequest("key-1", "subject-a", "hash-a"), commandBus, greetCommand{Name: "ana"})
+		require.NoError(t, err)
+		_, err = platformBus.SendCommand[int](withIdempotentRequest("key-1", "subject-a", "hash-b"), commandBus, greetCommand{Name: "bia"})
+
+		var msgErr *msg.MessageError
+		require.ErrorAs(t, err, &msgErr)
+		assert.Equal(t, msg.CodeConflict, msgErr.Code)
+		assert.Equal(t, bus.ErrIdempotencyKeyReuse, msgErr.Message)
+	})
+
+	t.Run("Success: failed command should be retried with the same idempotency key", func(t *testing.T) {
+		commandBus := newCommandBus(&fakeDB{})
+		var executions int
+		require.NoError(t, platformBus.HandleCommand(commandBus, func(ctx context.Context, cmd greetCommand) (int, error) {
+			executions++
+			if executions == 1 {
+				return 0, errors.New("temporary failure")
+			}
+			return executions, nil
+		}))
+
+		ctx := withIdempotencyKey("key-1")
+		_, err := platformBus.SendCommand[int](ctx, commandBus, greetCommand{Name: "ana"})
+		require.Error(t, err)
+		res, err := platformBus.SendCommand[int](ctx, commandBus, greetCommand{Name: "ana"})
+		require.NoError(t, err)
+		assert.Equal(t, 2, res)
+	})
+
+	t.Run("Success: transactional command should run with a transaction in context", func(t *testing.T) {
+		db := &fakeDB{}
+		commandBus := newCommandBus(db)
+		require.NoError(t, platformBus.HandleCommand(commandBus, func(ctx context.Context, cmd greetCommand) (bool, error) {
+			_, inTx := platformDB.TxFromContext(ctx)
+			return inTx, nil
+		}))
+
+		inTx, err := platformBus.SendCommand[bool](context.Background(), commandBus, greetCommand{Name: "ana", inTx: true})
+		require.NoError(t, err)
+		assert.True(t, inTx)
+		assert.Equal(t, 1, db.transactions)
+
+		inTx, err = platformBus.SendCommand[bool](context.Background(), commandBus, greetCommand{Name: "ana"})
+		require.NoError(t, err)
+		assert.False(t, inTx, "Non transactional commands should use the plain connection")
+		assert.Equal(t, 1, db.transactions)
+	})
+
+	t.Run("Success: after commit hooks should only run once the transaction commits", func(t *testing.T) {
+		commandBus := newCommandBus(&fakeDB{})
+		var ran []string
+		require.NoError(t, platformBus.HandleCommand(commandBus, func(ctx context.Context, cmd greetCommand) (bool, error) {
+			deferred := platformDB.AfterCommit(ctx, func() { ran = append(ran, cmd.Name) })
+			if cmd.Name == "fail" {
+				return false, errors.New("handler failed")
+			}
+			assert.NotContains(t, ran, cmd.Name, "Hooks should wait for the commit")
+			return deferred, nil
+		}))
+
+		deferred, err := platformBus.SendCommand[bool](context.Background(), commandBus, greetCommand{Name: "ana", inTx: true})
+		require.NoError(t, err)
+		assert.True(t, deferred)
+		assert.Equal(t, []string{"ana"}, ran)
+
+		_, err = platformBus.SendCommand[bool](context.Background(), commandBus, greetCommand{Name: "fail", inTx: true})
+		require.Error(t, err)
+		assert.Equal(t, []string{"ana"}, ran, "Hooks of a rolled back transaction should be dropped")
+
+		deferred, err = platformBus.SendCommand[bool](context.Background(), commandBus, greetCommand{Name: "bia"})
+		require.NoError(t, err)
+		assert.False(t, deferred, "Without a transaction the caller runs the hook itself")
+	})
+
+	t.Run("Failure: invalid command should not reach the handler", func(t *testing.T) {
+		commandBus := newCommandBus(&fakeDB{})
+		var executions int
+		require.NoError(t, platformBus.HandleCommand(commandBus, func(ctx context.Context, cmd greetCommand) (string, error) {
+			executions++
+			return "", nil
+		}))
+
+		_, err := commandBus.Dispatch(context.Background(), greetCommand{})
+		var msgErr *msg.MessageError
+		require.ErrorAs(t, err, &msgErr)
+		assert.Equal(t, msg.CodeInvalid, msgErr.Code)
+
+		_, err = commandBus.Dispatch(context.Background(), greetCommand{Name: "ana", validation: errors.New("bad name")})
+		require.ErrorAs(t, err, &msgErr)
+		assert.Equal(t, msg.CodeInvalid, msgErr.Code)
+		assert.Equal(t, bus.ErrCommandInvalid, msgErr.Message)
+
+		assert.Zero(t, executions)
+	})
+
+	t.Run("Failure: unauthorized command should not reach the handler", func(t *testing.T) {
+		commandBus := newCommandBus(&fakeDB{})
+		var executions int
+		require.NoError(t, platformBus.HandleCommand(commandBus, func(ctx context.Context, cmd greetCommand) (string, error) {
+			executions++
+			return "", nil
+		}))
+
+		_, err := commandBus.Dispatch(context.Background(), greetCommand{Name: "ana", forbidden: true})
+		var msgErr *msg.MessageError
+		require.ErrorAs(t, err, &msgErr)
+		assert.Equal(t, msg.CodeForbidden, msgErr.Code)
+		assert.Zero(t, executions)
+	})
+
+	t.Run("Failure: registering a command type twice should conflict", func(t *testing.T) {
+		commandBus := newCommandBus(&fakeDB{})
+		handler := func(ctx context.Context, cmd greetCommand) (string, error) { return "", nil }
+		require.NoError(t, platformBus.HandleCommand(commandBus, handler))
+
+		err := platformBus.HandleCommand(commandBus, handler)
+		var msgErr *msg.MessageError
+		require.ErrorAs(t, err, &msgErr)
+		assert.Equal(t, msg.CodeConflict, msgErr.Code)
+	})
+
+	t.Run("Failure: dispatching an unregistered command should fail", func(t *testing.T) {
+		_, err := newCommandBus(&fakeDB{}).Dispatch(context.Background(), greetCommand{Name: "ana"})
+		var msgErr *msg.MessageError
+		require.ErrorAs(t, err, &msgErr)
+		assert.Equal(t, bus.ErrCommandNotHandled, msgErr.Message)
+	})
+}
@@ -0,0 +1,104 @@
+package bus
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+)
+
+const (
+	ErrCommandInProgress   = "A command with this idempotency key is still being processed."
+	ErrIdempotencyKeyReuse = "This idempotency key was already used for a different request."
+
+	// DefaultIdempotencyTTL is how long a command result is replayed.
+	DefaultIdempotencyTTL = 24 * time.Hour
+)
+
+// IdempotencyStore remembers command results by idempotency key, along with a
+// fingerprint of the request that produced them.
+type IdempotencyStore interface {
+	// Reserve returns the stored result when done is true. Otherwise it marks
+	// the key as in progress, failing if another caller holds it. A key held
+	// for a different fingerprint fails with a conflict.
+	Reserve(ctx context.Context, key, fingerprint string) (result any, done bool, err error)
+	Complete(ctx context.Context, key string, result any)
+	Release(ctx context.Context, key string)
+}
+
+type idempotencyEntry struct {
+	fingerprint string
+	result      any
+	done        bool
+	expiresAt   time.Time
+}
+
+// MemoryIdempotencyStore keeps results in process memory, per instance: a
+// retry that reaches another instance runs the command again, so idempotency
+// keys are only honored by single instance deployments. A shared store would
+// have to serialize results, which handlers read back as their Go types.
+type MemoryIdempotencyStore struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[string]idempotencyEntry
+}
+
+func NewMemoryIdempotencyStore(ttl time.Duration) *MemoryIdempotencyStore {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+	return &MemoryIdempotencyStore{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]idempotencyEntry),
+	}
+}
+
+var _ IdempotencyStore = (*MemoryIdempotencyStore)(nil)
+
+func (s *MemoryIdempotencyStore) Reserve(_ context.Context, key, fingerprint string) (any, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.evictExpired(now)
+
+	if entry, ok := s.entries[key]; ok {
+		if entry.fingerprint != fingerprint {
+			return nil, false, msg.NewMessageError(nil, ErrIdempotencyKeyReuse, msg.CodeConflict, map[string]any{"idempotency_key": key})
+		}
+		if entry.done {
+			return entry.result, true, nil
+		}
+		return nil, false, msg.NewMessageError(nil, ErrCommandInProgress, msg.CodeConflict, map[string]any{"idempotency_key": key})
+	}
+
+	s.entries[key] = idempotencyEntry{fingerprint: fingerprint, expiresAt: now.Add(s.ttl)}
+	return nil, false, nil
+}
+
+func (s *MemoryIdempotencyStore) Complete(_ context.Context, key string, result any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry := s.entries[key]
+	entry.result, entry.done, entry.expiresAt = result, true, s.now().Add(s.ttl)
+	s.entries[key] = entry
+}
+
+func (s *MemoryIdempotencyStore) Release(_ context.Context, key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+}
+
+func (s *MemoryIdempotencyStore) evictExpired(now time.Time) {
+	for key, entry := range s.entries {
+		if now.After(entry.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+}
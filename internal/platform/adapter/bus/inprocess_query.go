@@ -0,0 +1,81 @@
+package bus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	platformBus "github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+)
+
+const ErrQueryAlreadyHandled = "A handler is already registered for this query type."
+
+// InProcessQueryBus answers queries with handlers registered in the same
+// process. Requests and responses still go through JSON so both sides only
+// share the query contract, exactly as over NATS.
+type InProcessQueryBus struct {
+	mu       sync.RWMutex
+	handlers map[platformBus.QueryType]platformBus.QueryHandler
+	tracer   trace.Tracer
+}
+
+func NewInProcessQueryBus() *InProcessQueryBus {
+	return &InProcessQueryBus{
+		handlers: make(map[platformBus.QueryType]platformBus.QueryHandler),
+		tracer:   otel.Tracer("inprocess-query-bus"),
+	}
+}
+
+var _ platformBus.QueryBus = (*InProcessQueryBus)(nil)
+
+func (b *InProcessQueryBus) Request(ctx context.Context, queryType platformBus.QueryType, request json.RawMessage) (json.RawMessage, error) {
+	ctx, span := b.tracer.Start(ctx, fmt.Sprintf("InProcess Query %s", queryType),
+		trace.WithAttributes(attribute.String("query.type", string(queryType))),
+	)
+	defer span.End()
+
+	b.mu.RLock()
+	handler, ok := b.handlers[queryType]
+	b.mu.RUnlock()
+	if !ok {
+		err := msg.NewMessageError(nil, ErrQueryNoResponders, msg.CodeUnavailable, map[string]any{"query_type": queryType})
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "No responder")
+		return nil, err
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultQueryTimeout)
+		defer cancel()
+	}
+
+	response, err := handler(ctx, request)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Query handler failed")
+		return nil, toMessageError(err)
+	}
+
+	span.SetStatus(codes.Ok, "Query answered")
+	return response, nil
+}
+
+func (b *InProcessQueryBus) Respond(queryType platformBus.QueryType, handler platformBus.QueryHandler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, exists := b.handlers[queryType]; exists {
+		return msg.NewMessageError(nil, ErrQueryAlreadyHandled, msg.CodeConflict, map[string]any{"query_type": queryType})
+	}
+	b.handlers[queryType] = handler
+
+	return nil
+}
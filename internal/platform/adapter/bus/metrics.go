@@ -0,0 +1,162 @@
+package bus
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+)
+
+const (
+	MetricHandled             = "eventbus.consumer.handled"
+	MetricHandlerDuration     = "eventbus.consumer.handler.duration"
+	MetricConsumerPending     = "eventbus.consumer.pending"
+	MetricConsumerAckPending  = "eventbus.consumer.ack_pending"
+	MetricConsumerRedelivered = "eventbus.consumer.redelivered"
+	MetricStreamMessages      = "eventbus.stream.messages"
+	MetricStreamBytes         = "eventbus.stream.bytes"
+	MetricPublishDropped      = "eventbus.publish.dropped"
+
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+
+	meterName             = "event-bus"
+	metricsCollectTimeout = 5 * time.Second
+)
+
+// ConsumerStats is what a bus knows about the backlog of one consumer.
+type ConsumerStats struct {
+	Consumer    string
+	EventType   event.EventType
+	Pending     int64
+	AckPending  int64
+	Redelivered int64
+}
+
+// StreamStats is the size of one stream, or of the event table on Postgres.
+type StreamStats struct {
+	Stream   string
+	Messages int64
+	Bytes    int64
+}
+
+// busMetrics records handler outcomes as they happen and reads consumer and
+// stream sizes from stats when the meter collects.
+type busMetrics struct {
+	system       string
+	handled      metric.Int64Counter
+	dropped      metric.Int64Counter
+	duration     metric.Float64Histogram
+	registration metric.Registration
+}
+
+func newBusMetrics(
+	system string,
+	consumerStats func(ctx context.Context) ([]ConsumerStats, error),
+	streamStats func(ctx context.Context) ([]StreamStats, error),
+) *busMetrics {
+	meter := otel.Meter(meterName)
+	m := &busMetrics{system: system}
+
+	// Instrument creation only fails on invalid names, and the API returns a
+	// no-op instrument alongside the error, so errors are ignored here.
+	m.handled, _ = meter.Int64Counter(MetricHandled,
+		metric.WithDescription("Events handled by consumers, by outcome."),
+	)
+	m.dropped, _ = meter.Int64Counter(MetricPublishDropped,
+		metric.WithDescription("Events of committed transactions that could not be published."),
+	)
+	m.duration, _ = meter.Float64Histogram(MetricHandlerDuration,
+		metric.WithDescription("Time spent in event handlers."),
+		metric.WithUnit("s"),
+	)
+
+	pending, _ := meter.Int64ObservableGauge(MetricConsumerPending,
+		metric.WithDescription("Events not yet delivered to the consumer."),
+	)
+	ackPending, _ := meter.Int64ObservableGauge(MetricConsumerAckPending,
+		metric.WithDescription("Events delivered but not yet acknowledged."),
+	)
+	redelivered, _ := meter.Int64ObservableGauge(MetricConsumerRedelivered,
+		metric.WithDescription("Events delivered more than once."),
+	)
+	messages, _ := meter.Int64ObservableGauge(MetricStreamMessages,
+		metric.WithDescription("Events stored in the stream."),
+	)
+	bytes, _ := meter.Int64ObservableGauge(MetricStreamBytes,
+		metric.WithDescription("Size of the stream."),
+		metric.WithUnit("By"),
+	)
+
+	m.registration, _ = meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		ctx, cancel := context.WithTimeout(ctx, metricsCollectTimeout)
+		defer cancel()
+
+		consumers, err := consumerStats(ctx)
+		if err != nil {
+			return err
+		}
+		for _, c := range consumers {
+			attrs := metric.WithAttributes(
+				attribute.String("messaging.system", system),
+				attribute.String("consumer", c.Consumer),
+				attribute.String("event.type", string(c.EventType)),
+			)
+			o.ObserveInt64(pending, c.Pending, attrs)
+			o.ObserveInt64(ackPending, c.AckPending, attrs)
+			o.ObserveInt64(redelivered, c.Redelivered, attrs)
+		}
+
+		streams, err := streamStats(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range streams {
+			attrs := metric.WithAttributes(
+				attribute.String("messaging.system", system),
+				attribute.String("stream", s.Stream),
+			)
+			o.ObserveInt64(messages, s.Messages, attrs)
+			o.ObserveInt64(bytes, s.Bytes, attrs)
+		}
+
+		return nil
+	}, pending, ackPending, redelivered, messages, bytes)
+
+	return m
+}
+
+// close stops observing the bus so a closed bus is not queried on collect.
+func (m *busMetrics) close() {
+	if m.registration != nil {
+		_ = m.registration.Unregister()
+	}
+}
+
+func (m *busMetrics) recordHandled(ctx context.Context, eventType event.EventType, start time.Time, err error) {
+	outcome := OutcomeSuccess
+	if err != nil {
+		outcome = OutcomeFailure
+	}
+
+	m.duration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(
+		attribute.String("messaging.system", m.system),
+		attribute.String("event.type", string(eventType)),
+	))
+	m.handled.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("messaging.system", m.system),
+		attribute.String("event.type", string(eventType)),
+		attribute.String("outcome", outcome),
+	))
+}
+
+func (m *busMetrics) recordPublishDropped(ctx context.Context, eventType event.EventType) {
+	m.dropped.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("messaging.system", m.system),
+		attribute.String("event.type", string(eventType)),
+	))
+}
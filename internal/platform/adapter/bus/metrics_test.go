@@ -0,0 +1,161 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	metricsdk "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/bus"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/bus/natstest"
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	platformDB "github.com/marcelofabianov/redtogreen/internal/platform/port/database"
+)
+
+// useManualReader installs a meter provider whose metrics the test collects on
+// demand. It must run before the bus is created.
+func useManualReader(t *testing.T) *metricsdk.ManualReader {
+	t.Helper()
+
+	previous := otel.GetMeterProvider()
+	reader := metricsdk.NewManualReader()
+	otel.SetMeterProvider(metricsdk.NewMeterProvider(metricsdk.WithReader(reader)))
+	t.Cleanup(func() { otel.SetMeterProvider(previous) })
+
+	return reader
+}
+
+func collect(t *testing.T, reader *metricsdk.ManualReader) map[string]metricdata.Aggregation {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	metrics := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+	return metrics
+}
+
+func int64Value(t *testing.T, data metricdata.Aggregation, attrs ...attribute.KeyValue) int64 {
+	t.Helper()
+
+	var points []metricdata.DataPoint[int64]
+	switch d := data.(type) {
+	case metricdata.Sum[int64]:
+		points = d.DataPoints
+	case metricdata.Gauge[int64]:
+		points = d.DataPoints
+	default:
+		t.Fatalf("unexpected aggregation %T", data)
+	}
+
+	for _, p := range points {
+		matches := true
+		for _, attr := range attrs {
+			if v, ok := p.Attributes.Value(attr.Key); !ok || v != attr.Value {
+				matches = false
+			}
+		}
+		if matches {
+			return p.Value
+		}
+	}
+	t.Fatalf("no data point with attributes %v", attrs)
+	return 0
+}
+
+func TestNatsEventBus_Metrics(t *testing.T) {
+	t.Run("Success: handler outcomes and durations should be recorded", func(t *testing.T) {
+		reader := useManualReader(t)
+		h := natstest.Start(t)
+		recorder := natstest.FailTimes(1, errors.New("temporary failure"))
+		require.NoError(t, h.Bus.Subscribe(testEventType, recorder.Handler()))
+
+		require.NoError(t, h.Bus.Publish(context.Background(), newTestEvent(t)))
+		recorder.Await(t, deliveryTimeout)
+		h.AwaitAcked(t, testEventType, 1)
+
+		metrics := collect(t, reader)
+		eventType := attribute.String("event.type", string(testEventType))
+		assert.Equal(t, int64(1), int64Value(t, metrics[bus.MetricHandled], eventType, attribute.String("outcome", bus.OutcomeSuccess)))
+		assert.Equal(t, int64(1), int64Value(t, metrics[bus.MetricHandled], eventType, attribute.String("outcome", bus.OutcomeFailure)))
+
+		duration, ok := metrics[bus.MetricHandlerDuration].(metricdata.Histogram[float64])
+		require.True(t, ok, "Handler duration should be a histogram")
+		require.Len(t, duration.DataPoints, 1)
+		assert.Equal(t, uint64(2), duration.DataPoints[0].Count)
+	})
+
+	t.Run("Success: consumer backlog and stream size should be observed", func(t *testing.T) {
+		reader := useManualReader(t)
+		h := natstest.Start(t)
+		recorder := natstest.NewRecorder(nil)
+		require.NoError(t, h.Bus.Subscribe(testEventType, recorder.Handler()))
+
+		for range 3 {
+			require.NoError(t, h.Bus.Publish(context.Background(), newTestEvent(t)))
+		}
+		h.AwaitAcked(t, testEventType, 3)
+
+		metrics := collect(t, reader)
+		consumer := attribute.String("consumer", bus.ConsumerName(testEventType))
+		assert.Zero(t, int64Value(t, metrics[bus.MetricConsumerPending], consumer))
+		assert.Zero(t, int64Value(t, metrics[bus.MetricConsumerAckPending], consumer))
+		assert.Zero(t, int64Value(t, metrics[bus.MetricConsumerRedelivered], consumer))
+
+		stream := attribute.String("stream", testStreamName)
+		assert.Equal(t, int64(3), int64Value(t, metrics[bus.MetricStreamMessages], stream))
+		assert.Positive(t, int64Value(t, metrics[bus.MetricStreamBytes], stream))
+	})
+
+	t.Run("Failure: committed event that cannot be published should be counted as dropped", func(t *testing.T) {
+		reader := useManualReader(t)
+		h := natstest.Start(t)
+
+		const unroutable event.EventType = "unknown.created"
+		evt := newTestEvent(t)
+		evt.Header.EventType = unroutable
+		err := platformDB.InTransaction(context.Background(), &fakeDB{}, func(ctx context.Context) error {
+			return h.Bus.Publish(ctx, evt)
+		})
+		require.NoError(t, err, "A failed publish after the commit should not fail the command")
+
+		// Closing stops the retries and waits for the publish to give up.
+		require.NoError(t, h.Bus.Close())
+
+		metrics := collect(t, reader)
+		assert.Equal(t, int64(1), int64Value(t, metrics[bus.MetricPublishDropped], attribute.String("event.type", string(unroutable))))
+	})
+}
+
+func TestNatsEventBus_Health(t *testing.T) {
+	t.Run("Success: connected bus with JetStream should be healthy", func(t *testing.T) {
+		h := natstest.Start(t)
+
+		health := h.Bus.Health(context.Background())
+		assert.True(t, health.Healthy())
+		assert.Equal(t, "nats", health.Driver)
+		assert.Equal(t, "CONNECTED", health.ConnectionState)
+	})
+
+	t.Run("Failure: bus should become unhealthy when the server goes away", func(t *testing.T) {
+		h := natstest.Start(t)
+		h.Server.Shutdown()
+
+		assert.Eventually(t, func() bool {
+			return !h.Bus.Health(context.Background()).Connected
+		}, deliveryTimeout, 20*time.Millisecond)
+		assert.False(t, h.Bus.Health(context.Background()).Healthy())
+	})
+}
@@ -3,9 +3,12 @@ package bus
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/nats-io/nats.go"
@@ -22,6 +25,7 @@ import (
 	"github.com/marcelofabianov/redtogreen/internal/platform/event"
 	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
 	platformBus "github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+	platformDB "github.com/marcelofabianov/redtogreen/internal/platform/port/database"
 )
 
 const (
@@ -29,29 +33,55 @@ const (
 	logFailedToInitJetStream  = "Failed to initialize JetStream"
 	logFailedToCreateStream   = "Failed to create stream"
 	logStreamCreated          = "Stream created successfully"
+	logFailedToUpdateStream   = "Failed to update stream subjects"
+	logStreamUpdated          = "Stream subjects updated"
 	logNatsBusInitialized     = "NATS EventBus successfully initialized"
 	logFailedToMarshalEvent   = "Failed to marshal event"
 	logFailedToPublishEvent   = "Failed to publish event"
 	logEventPublished         = "Event successfully published"
+	logCommittedEventDropped  = "Event of a committed transaction could not be published, dropping it"
 	logNoStreamForSubject     = "Configuration error: could not find a stream for the subject"
 	logFoundStreamForSub      = "Found matching stream for subscription"
 	logFailedToCreateConsumer = "Failed to create or update consumer"
 	logFailedToUnmarshalMsg   = "Failed to unmarshal NATS message into event struct, terminating message"
 	logFailedToTermMsg        = "Failed to terminate message"
 	logEventHandlerFailed     = "Event handler failed, will allow retry"
+	logEventRejected          = "Event handler failed permanently, terminating message"
 	logFailedToAckMsg         = "Failed to acknowledge message"
 	logEventProcessed         = "Event successfully processed"
 	logFailedToConsume        = "Failed to start consuming messages"
 	logSubscribedSuccessfully = "Subscribed successfully to event type"
 
 	consumerNameSuffix = "-processor"
+
+	// Envelope fields mirrored into message headers so consumers can route and
+	// filter without decoding the body.
+	HeaderEventType       = "Event-Type"
+	HeaderEventID         = "Event-Id"
+	HeaderPartitionKey    = "Event-Partition-Key"
+	HeaderTenantID        = "Event-Tenant-Id"
+	HeaderActorType       = "Event-Actor-Type"
+	HeaderAttributePrefix = "Event-Attr-"
 )
 
 type NatsEventBus struct {
-	nc     *nats.Conn
-	js     jetstream.JetStream
-	logger *slog.Logger
-	tracer trace.Tracer
+	nc      *nats.Conn
+	js      jetstream.JetStream
+	logger  *slog.Logger
+	tracer  trace.Tracer
+	metrics *busMetrics
+
+	mu        sync.Mutex
+	consumers map[event.EventType]natsConsumer
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	pending  sync.WaitGroup
+}
+
+type natsConsumer struct {
+	name   string
+	stream string
 }
 
 func NewNatsEventBus(config *config.NATSConfig, sl *slog.Logger) (*NatsEventBus, error) {
@@ -76,8 +106,32 @@ func NewNatsEventBus(config *config.NATSConfig, sl *slog.Logger) (*NatsEventBus,
 		return nil, errMsg
 	}
 
+	ensureStreams(js, sl)
+
+	sl.Info(logNatsBusInitialized,
+		slog.String("urls", config.URLs),
+	)
+
+	b := &NatsEventBus{
+		nc:        nc,
+		js:        js,
+		logger:    sl,
+		tracer:    otel.Tracer("nats-bus"),
+		consumers: make(map[event.EventType]natsConsumer),
+		stop:      make(chan struct{}),
+	}
+	b.metrics = newBusMetrics("nats", b.ConsumerStats, b.StreamStats)
+
+	return b, nil
+}
+
+// ensureStreams creates the configured streams that are missing and brings the
+// subjects of existing ones up to date, so a stream created by an older release
+// captures the event types added since. The rest of an existing stream's
+// config, such as its replicas, is left as deployed.
+func ensureStreams(js jetstream.JetStream, sl *slog.Logger) {
 	for _, stream := range GetStreamConfigs() {
-		_, err := js.Stream(context.Background(), stream.Name)
+		existing, err := js.Stream(context.Background(), stream.Name)
 		if err != nil {
 			jsStreamConfig := jetstream.StreamConfig{
 				Name:      stream.Config.Name,
@@ -99,22 +153,169 @@ func NewNatsEventBus(config *config.NATSConfig, sl *slog.Logger) (*NatsEventBus,
 					slog.String("stream", stream.Name),
 				)
 			}
+			continue
+		}
+
+		deployed := existing.CachedInfo().Config
+		if slices.Equal(deployed.Subjects, stream.Config.Subjects) {
+			continue
 		}
+		updated := deployed
+		updated.Subjects = stream.Config.Subjects
+		if _, err := js.UpdateStream(context.Background(), updated); err != nil {
+			sl.Warn(logFailedToUpdateStream,
+				slog.String("stream", stream.Name),
+				logattr.Err(err),
+			)
+			continue
+		}
+		sl.Info(logStreamUpdated,
+			slog.String("stream", stream.Name),
+			slog.Any("subjects", stream.Config.Subjects),
+		)
 	}
+}
 
-	sl.Info(logNatsBusInitialized,
-		slog.String("urls", config.URLs),
-	)
+// ConsumerName is the durable consumer name used by Subscribe for eventType.
+func ConsumerName(eventType event.EventType) string {
+	return strings.ReplaceAll(string(eventType), ".", "-") + consumerNameSuffix
+}
+
+// Close stops retrying committed events, waits for the publishes in flight,
+// then drains pending messages and closes the underlying NATS connection.
+func (b *NatsEventBus) Close() error {
+	b.stopOnce.Do(func() { close(b.stop) })
+	b.pending.Wait()
+	b.metrics.close()
+	return b.nc.Drain()
+}
+
+var _ platformBus.HealthChecker = (*NatsEventBus)(nil)
+
+// Health reports the NATS connection state and whether JetStream answers.
+func (b *NatsEventBus) Health(ctx context.Context) platformBus.Health {
+	health := platformBus.Health{
+		Driver:          config.EventBusDriverNATS,
+		Connected:       b.nc.IsConnected(),
+		ConnectionState: b.nc.Status().String(),
+	}
+	if !health.Connected {
+		return health
+	}
+
+	if _, err := b.js.AccountInfo(ctx); err != nil {
+		health.Error = err.Error()
+		return health
+	}
+	health.StreamingAvailable = true
 
-	return &NatsEventBus{
-		nc:     nc,
-		js:     js,
-		logger: sl,
-		tracer: otel.Tracer("nats-bus"),
-	}, nil
+	return health
 }
 
+// ConsumerStats reads the backlog of every consumer subscribed on this bus.
+func (b *NatsEventBus) ConsumerStats(ctx context.Context) ([]ConsumerStats, error) {
+	b.mu.Lock()
+	consumers := make(map[event.EventType]natsConsumer, len(b.consumers))
+	for eventType, c := range b.consumers {
+		consumers[eventType] = c
+	}
+	b.mu.Unlock()
+
+	stats := make([]ConsumerStats, 0, len(consumers))
+	for eventType, c := range consumers {
+		consumer, err := b.js.Consumer(ctx, c.stream, c.name)
+		if err != nil {
+			return nil, err
+		}
+		info, err := consumer.Info(ctx)
+		if err != nil {
+			return nil, err
+		}
+		stats = append(stats, ConsumerStats{
+			Consumer:    c.name,
+			EventType:   eventType,
+			Pending:     int64(info.NumPending),
+			AckPending:  int64(info.NumAckPending),
+			Redelivered: int64(info.NumRedelivered),
+		})
+	}
+
+	return stats, nil
+}
+
+// StreamStats reads the size of every configured stream that exists.
+func (b *NatsEventBus) StreamStats(ctx context.Context) ([]StreamStats, error) {
+	var stats []StreamStats
+	for _, s := range GetStreamConfigs() {
+		stream, err := b.js.Stream(ctx, s.Name)
+		if errors.Is(err, jetstream.ErrStreamNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		info, err := stream.Info(ctx)
+		if err != nil {
+			return nil, err
+		}
+		stats = append(stats, StreamStats{
+			Stream:   s.Name,
+			Messages: int64(info.State.Msgs),
+			Bytes:    int64(info.State.Bytes),
+		})
+	}
+
+	return stats, nil
+}
+
+// Publish sends evt to JetStream. Within a transaction started by
+// database.InTransaction the event is held until the commit, so consumers never
+// see changes that were rolled back or not yet visible, and is then published
+// by publishCommitted.
 func (b *NatsEventBus) Publish(ctx context.Context, evt *event.Event) error {
+	if platformDB.AfterCommit(ctx, func() { b.publishCommitted(context.WithoutCancel(ctx), evt) }) {
+		return nil
+	}
+	return b.publish(ctx, evt)
+}
+
+// publishCommitted publishes an event whose transaction already committed, so
+// a failure can no longer fail the command. It retries in the background with
+// the bus backoff, detached from the request that may return meanwhile; an
+// event still unpublished after defaultMaxDeliver attempts, or when the bus
+// closes, is logged and counted as dropped. Retries reuse the event ID as the
+// message ID, so the stream discards a copy that did arrive.
+func (b *NatsEventBus) publishCommitted(ctx context.Context, evt *event.Event) {
+	b.pending.Add(1)
+	go func() {
+		defer b.pending.Done()
+
+		var err error
+	retry:
+		for attempt := 1; ; attempt++ {
+			if err = b.publish(ctx, evt); err == nil {
+				return
+			}
+			if attempt >= defaultMaxDeliver {
+				break
+			}
+			select {
+			case <-b.stop:
+				break retry
+			case <-time.After(retryDelay(attempt)):
+			}
+		}
+
+		b.logger.Error(logCommittedEventDropped,
+			logger.EventType(string(evt.Header.EventType)),
+			logger.EventID(evt.Header.EventID),
+			logger.Err(err),
+		)
+		b.metrics.recordPublishDropped(ctx, evt.Header.EventType)
+	}()
+}
+
+func (b *NatsEventBus) publish(ctx context.Context, evt *event.Event) error {
 	ctx, span := b.tracer.Start(ctx, fmt.Sprintf("NATS Publish %s", evt.Header.EventType),
 		trace.WithSpanKind(trace.SpanKindProducer),
 		trace.WithAttributes(
@@ -130,6 +331,8 @@ func (b *NatsEventBus) Publish(ctx context.Context, evt *event.Event) error {
 	carrier := propagation.HeaderCarrier{}
 	otel.GetTextMapPropagator().Inject(ctx, carrier)
 
+	event.ApplyScope(ctx, evt)
+
 	data, err := json.Marshal(evt)
 	if err != nil {
 		span.RecordError(err)
@@ -146,7 +349,7 @@ func (b *NatsEventBus) Publish(ctx context.Context, evt *event.Event) error {
 	msgToPublish := nats.Msg{
 		Subject: string(evt.Header.EventType),
 		Data:    data,
-		Header:  make(nats.Header),
+		Header:  envelopeHeaders(evt),
 	}
 	for k, v := range carrier {
 		for _, val := range v {
@@ -175,12 +378,12 @@ func (b *NatsEventBus) Publish(ctx context.Context, evt *event.Event) error {
 	return nil
 }
 
-func (b *NatsEventBus) Subscribe(eventType event.EventType, handler platformBus.EventHandler) error {
-	safeConsumerPrefix := strings.ReplaceAll(string(eventType), ".", "-")
-	consumerName := safeConsumerPrefix + consumerNameSuffix
+func (b *NatsEventBus) Subscribe(eventType event.EventType, handler platformBus.EventHandler, opts ...platformBus.SubscribeOption) error {
+	options := platformBus.NewSubscribeOptions(opts...)
+	consumerName := ConsumerName(eventType)
 	subject := string(eventType)
 
-	streamName, err := findStreamNameForSubject(subject)
+	streamName, err := StreamNameForSubject(subject)
 	if err != nil {
 		b.logger.Error(logNoStreamForSubject,
 			slog.String("subject", subject),
@@ -193,14 +396,32 @@ func (b *NatsEventBus) Subscribe(eventType event.EventType, handler platformBus.
 		slog.String("stream", streamName),
 	)
 
-	consumer, err := b.js.CreateOrUpdateConsumer(context.Background(), streamName, jetstream.ConsumerConfig{
+	consumerConfig := jetstream.ConsumerConfig{
 		Durable:       consumerName,
 		FilterSubject: subject,
 		AckPolicy:     jetstream.AckExplicitPolicy,
-		MaxDeliver:    5,
+		MaxDeliver:    defaultMaxDeliver,
 		AckWait:       30 * time.Second,
-		BackOff:       []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second},
-	})
+		BackOff:       defaultBackOff,
+	}
+	var consumeOpts []jetstream.PullConsumeOpt
+	if options.Ordered() {
+		// Ordered subscriptions retry inside their partition worker so the
+		// events queued behind a failure wait for it, which a server side
+		// redelivery would not do.
+		consumerConfig.BackOff = nil
+		consumerConfig.AckWait = orderedAckWait
+		consumerConfig.MaxAckPending = OrderedMaxAckPending
+		// Order only holds within one process, so the server pins the consumer
+		// to a single instance; the others stand by and take over once it
+		// stops pulling.
+		consumerConfig.PriorityPolicy = jetstream.PriorityPolicyPinned
+		consumerConfig.PriorityGroups = []string{orderedPriorityGroup}
+		consumerConfig.PinnedTTL = orderedPinnedTTL
+		consumeOpts = append(consumeOpts, jetstream.PullPriorityGroup(orderedPriorityGroup))
+	}
+
+	consumer, err := b.js.CreateOrUpdateConsumer(context.Background(), streamName, consumerConfig)
 	if err != nil {
 		errMsg := msg.NewInternalError(err, map[string]any{"consumer": consumerName})
 		b.logger.Error(logFailedToCreateConsumer,
@@ -212,56 +433,142 @@ func (b *NatsEventBus) Subscribe(eventType event.EventType, handler platformBus.
 		return errMsg
 	}
 
-	_, err = consumer.Consume(func(natsMsg jetstream.Msg) {
-
-		carrier := propagation.HeaderCarrier(natsMsg.Headers())
-		ctx := otel.GetTextMapPropagator().Extract(context.Background(), carrier)
+	b.mu.Lock()
+	b.consumers[eventType] = natsConsumer{name: consumerName, stream: streamName}
+	b.mu.Unlock()
 
-		ctx, span := b.tracer.Start(ctx, fmt.Sprintf("NATS Consume %s", eventType),
-			trace.WithSpanKind(trace.SpanKindConsumer),
-			trace.WithAttributes(
-				attribute.String("messaging.system", "nats"),
-				attribute.String("messaging.destination", string(eventType)),
-				attribute.String("messaging.operation", "process"),
-			),
-		)
-		defer span.End()
-
-		var evt event.Event
-		if err := json.Unmarshal(natsMsg.Data(), &evt); err != nil {
-			span.RecordError(err)
-			span.SetStatus(codes.Error, "Failed to unmarshal NATS message")
-			b.logger.Error(logFailedToUnmarshalMsg,
-				logger.Err(err),
-				slog.String("data", string(natsMsg.Data())),
-			)
-			if termErr := natsMsg.Term(); termErr != nil {
-				b.logger.Error(logFailedToTermMsg, logger.Err(termErr))
-			}
+	process := func(natsMsg jetstream.Msg) {
+		evt, ok := b.decode(natsMsg)
+		if !ok {
 			return
 		}
-
-		if err := handler(ctx, &evt); err != nil {
-			span.RecordError(err)
-			span.SetStatus(codes.Error, "Event handler failed")
-			b.logger.Error(logEventHandlerFailed,
-				logger.Err(err),
-				logger.EventType(string(eventType)),
-			)
+		if err := b.handle(eventType, handler, natsMsg, evt); err != nil {
+			if platformBus.IsPermanent(err) {
+				b.reject(eventType, natsMsg, evt, err)
+			}
 			return
 		}
+		b.ack(eventType, natsMsg)
+	}
+	if options.Ordered() {
+		process = b.newPartitionedConsumer(eventType, handler, options).dispatch
+	}
 
-		if ackErr := natsMsg.Ack(); ackErr != nil {
-			b.logger.Error(logFailedToAckMsg,
-				logger.Err(ackErr),
-				logger.EventType(string(eventType)),
-			)
+	if _, err := consumer.Consume(process, consumeOpts...); err != nil {
+		errMsg := msg.NewInternalError(err, map[string]any{"consumer": consumerName})
+		b.logger.Error(logFailedToConsume,
+			logger.ErrorCode(errMsg.Code),
+			slog.String("consumer", consumerName),
+			logger.Err(err),
+		)
+		return errMsg
+	}
+
+	b.logger.Info(logSubscribedSuccessfully,
+		logger.EventType(string(eventType)),
+		slog.String("consumer", consumerName),
+		slog.Bool("ordered", options.Ordered()),
+	)
+
+	return nil
+}
+
+// decode reads the envelope of natsMsg. Malformed messages are terminated,
+// as no retry can fix them.
+func (b *NatsEventBus) decode(natsMsg jetstream.Msg) (*event.Event, bool) {
+	var evt event.Event
+	if err := json.Unmarshal(natsMsg.Data(), &evt); err != nil {
+		b.logger.Error(logFailedToUnmarshalMsg,
+			logger.Err(err),
+			slog.String("data", string(natsMsg.Data())),
+		)
+		if termErr := natsMsg.Term(); termErr != nil {
+			b.logger.Error(logFailedToTermMsg, logger.Err(termErr))
 		}
+		return nil, false
+	}
+
+	return &evt, true
+}
+
+// handle runs handler for evt within the trace and scope carried by natsMsg.
+// Acknowledging is left to the caller.
+func (b *NatsEventBus) handle(eventType event.EventType, handler platformBus.EventHandler, natsMsg jetstream.Msg, evt *event.Event) error {
+	carrier := propagation.HeaderCarrier(natsMsg.Headers())
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), carrier)
+
+	ctx, span := b.tracer.Start(ctx, fmt.Sprintf("NATS Consume %s", eventType),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "nats"),
+			attribute.String("messaging.destination", string(eventType)),
+			attribute.String("messaging.operation", "process"),
+		),
+	)
+	defer span.End()
 
-		span.SetStatus(codes.Ok, "Event processed successfully")
-		b.logger.Debug(logEventProcessed,
+	ctx = event.WithScope(ctx, event.ScopeOf(evt))
+
+	start := time.Now()
+	err := handler(ctx, evt)
+	b.metrics.recordHandled(ctx, eventType, start, err)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Event handler failed")
+		b.logger.Error(logEventHandlerFailed,
+			logger.Err(err),
 			logger.EventType(string(eventType)),
 		)
-	})
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "Event processed successfully")
 	return nil
 }
+
+// reject terminates a message whose handler failed in a way no redelivery
+// can fix.
+func (b *NatsEventBus) reject(eventType event.EventType, natsMsg jetstream.Msg, evt *event.Event, err error) {
+	b.logger.Error(logEventRejected,
+		logger.EventType(string(eventType)),
+		logger.EventID(evt.Header.EventID),
+		logger.Err(err),
+	)
+	if termErr := natsMsg.Term(); termErr != nil {
+		b.logger.Error(logFailedToTermMsg, logger.Err(termErr))
+	}
+}
+
+func (b *NatsEventBus) ack(eventType event.EventType, natsMsg jetstream.Msg) {
+	if ackErr := natsMsg.Ack(); ackErr != nil {
+		b.logger.Error(logFailedToAckMsg,
+			logger.Err(ackErr),
+			logger.EventType(string(eventType)),
+		)
+		return
+	}
+
+	b.logger.Debug(logEventProcessed,
+		logger.EventType(string(eventType)),
+	)
+}
+
+func envelopeHeaders(evt *event.Event) nats.Header {
+	header := make(nats.Header)
+	header.Set(HeaderEventType, string(evt.Header.EventType))
+	header.Set(HeaderEventID, evt.Header.EventID.String())
+	header.Set(nats.MsgIdHdr, evt.Header.EventID.String())
+	if evt.Header.PartitionKey != "" {
+		header.Set(HeaderPartitionKey, evt.Header.PartitionKey)
+	}
+	if evt.Context.TenantID != "" {
+		header.Set(HeaderTenantID, evt.Context.TenantID)
+	}
+	if evt.Context.ActorType != "" {
+		header.Set(HeaderActorType, string(evt.Context.ActorType))
+	}
+	for k, v := range evt.Context.Attributes {
+		header.Set(HeaderAttributePrefix+k, v)
+	}
+	return header
+}
@@ -0,0 +1,180 @@
+package bus
+
+import (
+	"hash/fnv"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/logger"
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	platformBus "github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+)
+
+const (
+	logOrderedEventDropped = "Ordered event exhausted its attempts, terminating message"
+
+	// OrderedMaxAckPending bounds the messages an ordered consumer holds, and
+	// each partition queue has room for all of them, so dispatch never blocks
+	// on a busy partition while the others wait.
+	OrderedMaxAckPending = 64
+
+	// Queued and retrying messages are kept from expiring by sending
+	// InProgress to every held message at orderedProgressInterval, well within
+	// orderedAckWait, so the server never redelivers them out of order.
+	orderedAckWait          = 30 * time.Second
+	orderedProgressInterval = 10 * time.Second
+
+	// Every instance binds the same durable consumer, but only the pinned one
+	// receives messages. The pin moves to another instance once the pinned one
+	// sends no pull request for orderedPinnedTTL, longer than the 30 second
+	// pull expiry of an idle instance and than orderedAckWait, so its
+	// unacknowledged messages are up for redelivery by then.
+	orderedPriorityGroup = "ordered"
+	orderedPinnedTTL     = 2 * orderedAckWait
+)
+
+type orderedMsg struct {
+	natsMsg jetstream.Msg
+	evt     *event.Event
+}
+
+// partitionedConsumer hashes each event's partition key to one of a fixed set
+// of workers. A worker handles its events one at a time and retries a failing
+// event before moving on, so events of the same key keep their order while
+// other keys proceed on the other workers. The consumer is pinned to one
+// instance at a time, so scaling out adds standbys rather than throughput.
+type partitionedConsumer struct {
+	bus       *NatsEventBus
+	eventType event.EventType
+	handler   platformBus.EventHandler
+	options   platformBus.SubscribeOptions
+	workers   []chan orderedMsg
+
+	mu   sync.Mutex
+	held map[jetstream.Msg]struct{}
+}
+
+func (b *NatsEventBus) newPartitionedConsumer(eventType event.EventType, handler platformBus.EventHandler, options platformBus.SubscribeOptions) *partitionedConsumer {
+	c := &partitionedConsumer{
+		bus:       b,
+		eventType: eventType,
+		handler:   handler,
+		options:   options,
+		workers:   make([]chan orderedMsg, options.Partitions),
+		held:      make(map[jetstream.Msg]struct{}),
+	}
+	for i := range c.workers {
+		c.workers[i] = make(chan orderedMsg, OrderedMaxAckPending)
+		go c.work(c.workers[i])
+	}
+	go c.keepAlive()
+	return c
+}
+
+func (c *partitionedConsumer) dispatch(natsMsg jetstream.Msg) {
+	evt, ok := c.bus.decode(natsMsg)
+	if !ok {
+		return
+	}
+
+	c.hold(natsMsg)
+	select {
+	case c.workers[partitionOf(c.options.KeyOf(evt), len(c.workers))] <- orderedMsg{natsMsg: natsMsg, evt: evt}:
+	case <-c.bus.stop:
+	}
+}
+
+// keepAlive extends the ack wait of every held message until the bus stops.
+func (c *partitionedConsumer) keepAlive() {
+	ticker := time.NewTicker(orderedProgressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.bus.stop:
+			return
+		case <-ticker.C:
+		}
+
+		c.mu.Lock()
+		held := make([]jetstream.Msg, 0, len(c.held))
+		for natsMsg := range c.held {
+			held = append(held, natsMsg)
+		}
+		c.mu.Unlock()
+
+		for _, natsMsg := range held {
+			_ = natsMsg.InProgress()
+		}
+	}
+}
+
+func (c *partitionedConsumer) hold(natsMsg jetstream.Msg) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.held[natsMsg] = struct{}{}
+}
+
+func (c *partitionedConsumer) release(natsMsg jetstream.Msg) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.held, natsMsg)
+}
+
+func (c *partitionedConsumer) work(queue <-chan orderedMsg) {
+	for {
+		select {
+		case <-c.bus.stop:
+			return
+		case m := <-queue:
+			c.process(m)
+		}
+	}
+}
+
+// process retries m with the bus backoff, unless the handler rejected it as
+// invalid. When the bus stops mid retry the message is left unacknowledged for
+// the server to redeliver.
+func (c *partitionedConsumer) process(m orderedMsg) {
+	defer c.release(m.natsMsg)
+
+	for attempt := 1; ; attempt++ {
+		err := c.bus.handle(c.eventType, c.handler, m.natsMsg, m.evt)
+		if err == nil {
+			c.bus.ack(c.eventType, m.natsMsg)
+			return
+		}
+
+		if platformBus.IsPermanent(err) {
+			c.bus.reject(c.eventType, m.natsMsg, m.evt, err)
+			return
+		}
+		if attempt >= defaultMaxDeliver {
+			c.bus.logger.Error(logOrderedEventDropped,
+				logger.EventType(string(c.eventType)),
+				logger.EventID(m.evt.Header.EventID),
+				slog.String("partition_key", c.options.KeyOf(m.evt)),
+				logger.Err(err),
+			)
+			if termErr := m.natsMsg.Term(); termErr != nil {
+				c.bus.logger.Error(logFailedToTermMsg, logger.Err(termErr))
+			}
+			return
+		}
+
+		select {
+		case <-c.bus.stop:
+			return
+		case <-time.After(retryDelay(attempt)):
+		}
+	}
+}
+
+func partitionOf(key string, partitions int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32() % uint32(partitions))
+}
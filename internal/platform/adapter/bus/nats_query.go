@@ -0,0 +1,218 @@
+package bus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/logger"
+	"github.com/marcelofabianov/redtogreen/internal/platform/config"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	platformBus "github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+)
+
+const (
+	logQueryBusInitialized   = "NATS QueryBus successfully initialized"
+	logQueryHandlerFailed    = "Query handler failed"
+	logFailedToRespondQuery  = "Failed to respond to query"
+	logFailedToSubscribeQry  = "Failed to subscribe to query"
+	logQueryResponderStarted = "Responding to query type"
+
+	ErrQueryTimeout      = "The query did not receive a response in time."
+	ErrQueryNoResponders = "No service is answering this query."
+
+	querySubjectPrefix  = "query."
+	queryResponderQueue = "query-responders"
+
+	// DefaultQueryTimeout bounds requests whose context has no deadline.
+	DefaultQueryTimeout = 5 * time.Second
+)
+
+// queryReply is the wire format of a query response: either the data or the
+// error the responder returned, keeping its msg code.
+type queryReply struct {
+	Data  json.RawMessage    `json:"data,omitempty"`
+	Error *msg.ErrorResponse `json:"error,omitempty"`
+}
+
+// NatsQueryBus answers queries with NATS core request/reply. Responders join a
+// queue group so each query is answered by a single instance.
+type NatsQueryBus struct {
+	nc     *nats.Conn
+	logger *slog.Logger
+	tracer trace.Tracer
+}
+
+func NewNatsQueryBus(config *config.NATSConfig, sl *slog.Logger) (*NatsQueryBus, error) {
+	nc, err := nats.Connect(config.URLs)
+	if err != nil {
+		errMsg := msg.NewInternalError(err, map[string]any{"urls": config.URLs})
+		sl.Error(logFailedToConnect,
+			logger.ErrorCode(errMsg.Code),
+			slog.String("urls", config.URLs),
+			logger.Err(err),
+		)
+		return nil, errMsg
+	}
+
+	sl.Info(logQueryBusInitialized, slog.String("urls", config.URLs))
+
+	return &NatsQueryBus{
+		nc:     nc,
+		logger: sl,
+		tracer: otel.Tracer("nats-query-bus"),
+	}, nil
+}
+
+var _ platformBus.QueryBus = (*NatsQueryBus)(nil)
+
+// Close drains pending requests and replies and closes the connection.
+func (b *NatsQueryBus) Close() error {
+	return b.nc.Drain()
+}
+
+func (b *NatsQueryBus) Request(ctx context.Context, queryType platformBus.QueryType, request json.RawMessage) (json.RawMessage, error) {
+	ctx, span := b.tracer.Start(ctx, fmt.Sprintf("NATS Query %s", queryType),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "nats"),
+			attribute.String("messaging.destination", querySubject(queryType)),
+			attribute.String("messaging.operation", "request"),
+		),
+	)
+	defer span.End()
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultQueryTimeout)
+		defer cancel()
+	}
+
+	carrier := propagation.HeaderCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+
+	natsMsg := nats.NewMsg(querySubject(queryType))
+	natsMsg.Data = request
+	for k, v := range carrier {
+		for _, val := range v {
+			natsMsg.Header.Add(k, val)
+		}
+	}
+
+	reply, err := b.nc.RequestMsgWithContext(ctx, natsMsg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Query request failed")
+		return nil, requestError(err, queryType)
+	}
+
+	var decoded queryReply
+	if err := json.Unmarshal(reply.Data, &decoded); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Invalid query reply")
+		return nil, msg.NewInternalError(err, map[string]any{"query_type": queryType})
+	}
+	if decoded.Error != nil {
+		errMsg := decoded.Error.ToMessageError()
+		span.RecordError(errMsg)
+		span.SetStatus(codes.Error, "Query returned an error")
+		return nil, errMsg
+	}
+
+	span.SetStatus(codes.Ok, "Query answered")
+	return decoded.Data, nil
+}
+
+func (b *NatsQueryBus) Respond(queryType platformBus.QueryType, handler platformBus.QueryHandler) error {
+	subject := querySubject(queryType)
+
+	_, err := b.nc.QueueSubscribe(subject, queryResponderQueue, func(natsMsg *nats.Msg) {
+		carrier := propagation.HeaderCarrier(natsMsg.Header)
+		ctx := otel.GetTextMapPropagator().Extract(context.Background(), carrier)
+
+		ctx, span := b.tracer.Start(ctx, fmt.Sprintf("NATS Answer %s", queryType),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("messaging.system", "nats"),
+				attribute.String("messaging.destination", subject),
+				attribute.String("messaging.operation", "process"),
+			),
+		)
+		defer span.End()
+
+		var reply queryReply
+		data, err := handler(ctx, natsMsg.Data)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "Query handler failed")
+			b.logger.Error(logQueryHandlerFailed,
+				slog.String("query_type", string(queryType)),
+				logger.Err(err),
+			)
+			response := toMessageError(err).ToResponse()
+			reply.Error = &response
+		} else {
+			reply.Data = data
+			span.SetStatus(codes.Ok, "Query answered")
+		}
+
+		replyData, err := json.Marshal(reply)
+		if err == nil {
+			err = natsMsg.Respond(replyData)
+		}
+		if err != nil {
+			b.logger.Error(logFailedToRespondQuery,
+				slog.String("query_type", string(queryType)),
+				logger.Err(err),
+			)
+		}
+	})
+	if err != nil {
+		errMsg := msg.NewInternalError(err, map[string]any{"query_type": queryType})
+		b.logger.Error(logFailedToSubscribeQry,
+			logger.ErrorCode(errMsg.Code),
+			slog.String("query_type", string(queryType)),
+			logger.Err(err),
+		)
+		return errMsg
+	}
+
+	b.logger.Info(logQueryResponderStarted, slog.String("query_type", string(queryType)))
+	return nil
+}
+
+func querySubject(queryType platformBus.QueryType) string {
+	return querySubjectPrefix + string(queryType)
+}
+
+func requestError(err error, queryType platformBus.QueryType) error {
+	errContext := map[string]any{"query_type": queryType}
+	switch {
+	case errors.Is(err, nats.ErrNoResponders):
+		return msg.NewMessageError(err, ErrQueryNoResponders, msg.CodeUnavailable, errContext)
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, nats.ErrTimeout):
+		return msg.NewMessageError(err, ErrQueryTimeout, msg.CodeTimeout, errContext)
+	default:
+		return msg.NewInternalError(err, errContext)
+	}
+}
+
+// toMessageError keeps msg errors as they are and hides anything else behind
+// an internal error, as the HTTP layer does.
+func toMessageError(err error) *msg.MessageError {
+	var msgErr *msg.MessageError
+	if errors.As(err, &msgErr) {
+		return msgErr
+	}
+	return msg.NewInternalError(err, nil)
+}
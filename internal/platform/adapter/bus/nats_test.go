@@ -0,0 +1,362 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/bus"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/bus/natstest"
+	"github.com/marcelofabianov/redtogreen/internal/platform/config"
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	platformBus "github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+	platformDB "github.com/marcelofabianov/redtogreen/internal/platform/port/database"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+const (
+	testEventType   event.EventType = "user.tested"
+	testStreamName                  = "identity-stream"
+	deliveryTimeout                 = 5 * time.Second
+)
+
+func newTestEvent(t *testing.T) *event.Event {
+	t.Helper()
+
+	evt, err := event.NewEvent(event.EventInput{
+		EventType:     testEventType,
+		EventVersion:  "v1.0.0",
+		Source:        "TestService",
+		CorrelationID: types.MustNewUUID(),
+		TraceID:       types.MustNewUUID(),
+		Payload:       []byte(`{"id":"abc"}`),
+	})
+	require.NoError(t, err, "Setup: failed to build test event")
+
+	return &evt
+}
+
+func newKeyedTestEvent(t *testing.T, key string) *event.Event {
+	t.Helper()
+
+	evt := newTestEvent(t)
+	evt.Header.PartitionKey = key
+	return evt
+}
+
+func TestNatsEventBus_PublishSubscribe(t *testing.T) {
+	t.Run("Success: published event should be delivered and acknowledged", func(t *testing.T) {
+		h := natstest.Start(t)
+		recorder := natstest.NewRecorder(nil)
+		require.NoError(t, h.Bus.Subscribe(testEventType, recorder.Handler()))
+
+		published := newTestEvent(t)
+		require.NoError(t, h.Bus.Publish(context.Background(), published))
+
+		received := recorder.Await(t, deliveryTimeout)
+		assert.Equal(t, published.Header.EventID, received.Header.EventID)
+		assert.JSONEq(t, string(published.Payload), string(received.Payload))
+
+		info := h.AwaitAcked(t, testEventType, 1)
+		assert.Zero(t, info.NumRedelivered, "Handled event should not be redelivered")
+	})
+
+	t.Run("Success: event published in a transaction should wait for the commit", func(t *testing.T) {
+		h := natstest.Start(t)
+		recorder := natstest.NewRecorder(nil)
+		require.NoError(t, h.Bus.Subscribe(testEventType, recorder.Handler()))
+
+		published := newTestEvent(t)
+		err := platformDB.InTransaction(context.Background(), &fakeDB{}, func(ctx context.Context) error {
+			require.NoError(t, h.Bus.Publish(ctx, published))
+			recorder.AssertNoDelivery(t, 200*time.Millisecond)
+			return nil
+		})
+		require.NoError(t, err)
+
+		received := recorder.Await(t, deliveryTimeout)
+		assert.Equal(t, published.Header.EventID, received.Header.EventID)
+	})
+
+	t.Run("Failure: event published in a rolled back transaction should not be delivered", func(t *testing.T) {
+		h := natstest.Start(t)
+		recorder := natstest.NewRecorder(nil)
+		require.NoError(t, h.Bus.Subscribe(testEventType, recorder.Handler()))
+
+		err := platformDB.InTransaction(context.Background(), &fakeDB{}, func(ctx context.Context) error {
+			require.NoError(t, h.Bus.Publish(ctx, newTestEvent(t)))
+			return errors.New("command failed")
+		})
+		require.Error(t, err)
+
+		recorder.AssertNoDelivery(t, 500*time.Millisecond)
+		assert.Empty(t, h.StreamEvents(t, testStreamName))
+	})
+
+	t.Run("Success: event of a committed transaction should outlive the request context", func(t *testing.T) {
+		h := natstest.Start(t)
+		recorder := natstest.NewRecorder(nil)
+		require.NoError(t, h.Bus.Subscribe(testEventType, recorder.Handler()))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		published := newTestEvent(t)
+		err := platformDB.InTransaction(ctx, &fakeDB{}, func(ctx context.Context) error {
+			require.NoError(t, h.Bus.Publish(ctx, published))
+			cancel()
+			return nil
+		})
+		require.NoError(t, err)
+
+		received := recorder.Await(t, deliveryTimeout)
+		assert.Equal(t, published.Header.EventID, received.Header.EventID)
+	})
+
+	t.Run("Success: republishing an event should not store it twice", func(t *testing.T) {
+		h := natstest.Start(t)
+
+		published := newTestEvent(t)
+		require.NoError(t, h.Bus.Publish(context.Background(), published))
+		require.NoError(t, h.Bus.Publish(context.Background(), published))
+
+		assert.Len(t, h.StreamEvents(t, testStreamName), 1, "The event ID should deduplicate retries")
+	})
+
+	t.Run("Success: published event should be stored in the matching stream", func(t *testing.T) {
+		h := natstest.Start(t)
+
+		published := newTestEvent(t)
+		require.NoError(t, h.Bus.Publish(context.Background(), published))
+
+		events := h.StreamEvents(t, testStreamName)
+		require.Len(t, events, 1)
+		assert.Equal(t, published.Header.EventID, events[0].Header.EventID)
+	})
+
+	t.Run("Success: envelope scope should be mirrored into message headers", func(t *testing.T) {
+		h := natstest.Start(t)
+
+		ctx := event.WithScope(context.Background(), event.Scope{
+			TenantID:   "tenant-a",
+			Attributes: map[string]string{"requestId": "req-1"},
+		})
+		published := newTestEvent(t)
+		published.Context.ActorType = event.ActorServiceAccount
+		require.NoError(t, h.Bus.Publish(ctx, published))
+
+		stream, err := h.JS.Stream(context.Background(), testStreamName)
+		require.NoError(t, err)
+		raw, err := stream.GetLastMsgForSubject(context.Background(), string(testEventType))
+		require.NoError(t, err)
+
+		assert.Equal(t, string(testEventType), raw.Header.Get(bus.HeaderEventType))
+		assert.Equal(t, published.Header.EventID.String(), raw.Header.Get(bus.HeaderEventID))
+		assert.Equal(t, "tenant-a", raw.Header.Get(bus.HeaderTenantID))
+		assert.Equal(t, string(event.ActorServiceAccount), raw.Header.Get(bus.HeaderActorType))
+		assert.Equal(t, "req-1", raw.Header.Get(bus.HeaderAttributePrefix+"requestId"))
+
+		events := h.StreamEvents(t, testStreamName)
+		require.Len(t, events, 1)
+		assert.Equal(t, "tenant-a", events[0].Context.TenantID, "Scope should also be stamped on the envelope")
+	})
+
+	t.Run("Success: handler context should carry the tenant and actor type of the event", func(t *testing.T) {
+		h := natstest.Start(t)
+		scopes := make(chan event.Scope, 1)
+		recorder := natstest.NewRecorder(func(ctx context.Context, evt *event.Event) error {
+			scopes <- event.ScopeFromContext(ctx)
+			return nil
+		})
+		require.NoError(t, h.Bus.Subscribe(testEventType, recorder.Handler()))
+
+		published := newTestEvent(t)
+		published.Context.TenantID = "tenant-a"
+		published.Context.ActorType = event.ActorSystem
+		require.NoError(t, h.Bus.Publish(context.Background(), published))
+
+		recorder.Await(t, deliveryTimeout)
+		scope := <-scopes
+		assert.Equal(t, "tenant-a", scope.TenantID)
+		assert.Equal(t, event.ActorSystem, scope.ActorType)
+	})
+
+	t.Run("Success: failed handler should be retried until it succeeds", func(t *testing.T) {
+		h := natstest.Start(t)
+		recorder := natstest.FailTimes(1, errors.New("temporary failure"))
+		require.NoError(t, h.Bus.Subscribe(testEventType, recorder.Handler()))
+
+		require.NoError(t, h.Bus.Publish(context.Background(), newTestEvent(t)))
+
+		recorder.Await(t, deliveryTimeout)
+		assert.Equal(t, 2, recorder.Attempts(), "Handler should be invoked again after failing")
+
+		info := h.AwaitAcked(t, testEventType, 1)
+		assert.Equal(t, uint64(2), info.Delivered.Consumer, "Message should be delivered twice")
+	})
+
+	t.Run("Failure: malformed message should be terminated without reaching the handler", func(t *testing.T) {
+		h := natstest.Start(t)
+		recorder := natstest.NewRecorder(nil)
+		require.NoError(t, h.Bus.Subscribe(testEventType, recorder.Handler()))
+
+		h.PublishRaw(t, string(testEventType), []byte("not-json"))
+
+		h.AwaitConsumer(t, testEventType, deliveryTimeout, func(info *jetstream.ConsumerInfo) bool {
+			return info.Delivered.Consumer >= 1 && info.NumAckPending == 0
+		})
+		recorder.AssertNoDelivery(t, 200*time.Millisecond)
+		assert.Zero(t, recorder.Attempts(), "Handler should not see malformed messages")
+	})
+
+	t.Run("Failure: event failing permanently should be terminated without retry", func(t *testing.T) {
+		h := natstest.Start(t)
+		recorder := natstest.FailTimes(1, platformBus.Permanent(msg.NewValidationError(nil, nil, "corrupt payload")))
+		require.NoError(t, h.Bus.Subscribe(testEventType, recorder.Handler()))
+
+		require.NoError(t, h.Bus.Publish(context.Background(), newTestEvent(t)))
+
+		h.AwaitConsumer(t, testEventType, deliveryTimeout, func(info *jetstream.ConsumerInfo) bool {
+			return info.Delivered.Consumer >= 1 && info.NumAckPending == 0
+		})
+		recorder.AssertNoDelivery(t, 1500*time.Millisecond)
+		assert.Equal(t, 1, recorder.Attempts(), "Permanent failures should not be redelivered")
+	})
+
+	t.Run("Success: other validation errors should be retried", func(t *testing.T) {
+		h := natstest.Start(t)
+		recorder := natstest.FailTimes(1, msg.NewValidationError(nil, nil, "referenced user not visible yet"))
+		require.NoError(t, h.Bus.Subscribe(testEventType, recorder.Handler()))
+
+		published := newTestEvent(t)
+		require.NoError(t, h.Bus.Publish(context.Background(), published))
+
+		received := recorder.Await(t, deliveryTimeout)
+		assert.Equal(t, published.Header.EventID, received.Header.EventID)
+		assert.Equal(t, 2, recorder.Attempts(), "A validation error alone should not drop the event")
+	})
+
+	t.Run("Success: should add new subjects to a stream created by an older release", func(t *testing.T) {
+		h := natstest.Start(t, natstest.WithStreamSubjects(testStreamName, "user.*"))
+
+		stream, err := h.JS.Stream(context.Background(), testStreamName)
+		require.NoError(t, err)
+		info, err := stream.Info(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"user.*", "role.*"}, info.Config.Subjects)
+		assert.Equal(t, 1, info.Config.Replicas, "Only the subjects should change")
+
+		const roleEventType event.EventType = "role.tested"
+		recorder := natstest.NewRecorder(nil)
+		require.NoError(t, h.Bus.Subscribe(roleEventType, recorder.Handler()))
+
+		published := newTestEvent(t)
+		published.Header.EventType = roleEventType
+		require.NoError(t, h.Bus.Publish(context.Background(), published))
+
+		received := recorder.Await(t, deliveryTimeout)
+		assert.Equal(t, published.Header.EventID, received.Header.EventID)
+	})
+
+	t.Run("Failure: subscribing to a subject without stream should fail", func(t *testing.T) {
+		h := natstest.Start(t)
+
+		err := h.Bus.Subscribe("unknown.created", natstest.NewRecorder(nil).Handler())
+		require.Error(t, err)
+	})
+}
+
+func TestNatsEventBus_OrderedSubscribe(t *testing.T) {
+	t.Run("Success: events of a key should wait for a failing predecessor", func(t *testing.T) {
+		h := natstest.Start(t)
+
+		// user-a and user-b hash to different partitions out of 4.
+		first := newKeyedTestEvent(t, "user-a")
+		second := newKeyedTestEvent(t, "user-a")
+		other := newKeyedTestEvent(t, "user-b")
+
+		var failed bool
+		recorder := natstest.NewRecorder(func(ctx context.Context, evt *event.Event) error {
+			if evt.Header.EventID == first.Header.EventID && !failed {
+				failed = true
+				return errors.New("temporary failure")
+			}
+			return nil
+		})
+		require.NoError(t, h.Bus.Subscribe(testEventType, recorder.Handler(), platformBus.OrderedByKey(4)))
+
+		for _, evt := range []*event.Event{first, second, other} {
+			require.NoError(t, h.Bus.Publish(context.Background(), evt))
+		}
+		for range 3 {
+			recorder.Await(t, deliveryTimeout)
+		}
+
+		var handled []types.UUID
+		for _, evt := range recorder.Events() {
+			handled = append(handled, evt.Header.EventID)
+		}
+		assert.Less(t, indexOf(handled, first.Header.EventID), indexOf(handled, second.Header.EventID),
+			"Events of the same key should keep their publish order")
+		assert.Equal(t, other.Header.EventID, handled[0], "Other keys should not wait for the retry")
+
+		info := h.AwaitAcked(t, testEventType, 3)
+		assert.Zero(t, info.NumRedelivered, "Retries should happen in the worker, not through redelivery")
+		assert.Equal(t, bus.OrderedMaxAckPending, info.Config.MaxAckPending,
+			"The server should never hand out more messages than a partition can queue")
+	})
+
+	t.Run("Success: only one instance should receive the events of an ordered subscription", func(t *testing.T) {
+		h := natstest.Start(t)
+		other, err := bus.NewNatsEventBus(&config.NATSConfig{URLs: h.URL}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = other.Close() })
+
+		first := natstest.NewRecorder(nil)
+		second := natstest.NewRecorder(nil)
+		require.NoError(t, h.Bus.Subscribe(testEventType, first.Handler(), platformBus.OrderedByKey(4)))
+		require.NoError(t, other.Subscribe(testEventType, second.Handler(), platformBus.OrderedByKey(4)))
+
+		for i := range 10 {
+			require.NoError(t, h.Bus.Publish(context.Background(), newKeyedTestEvent(t, fmt.Sprintf("user-%d", i))))
+		}
+
+		h.AwaitAcked(t, testEventType, 10)
+		handled := []int{first.Attempts(), second.Attempts()}
+		assert.ElementsMatch(t, []int{0, 10}, handled, "Every event should go to the pinned instance")
+	})
+
+	t.Run("Success: partition key can be derived from the event", func(t *testing.T) {
+		h := natstest.Start(t)
+		keys := make(chan string, 1)
+		recorder := natstest.NewRecorder(nil)
+		require.NoError(t, h.Bus.Subscribe(testEventType, recorder.Handler(),
+			platformBus.OrderedByKey(2),
+			platformBus.WithPartitionKey(func(evt *event.Event) string {
+				keys <- string(evt.Payload)
+				return string(evt.Payload)
+			}),
+		))
+
+		require.NoError(t, h.Bus.Publish(context.Background(), newTestEvent(t)))
+
+		recorder.Await(t, deliveryTimeout)
+		assert.JSONEq(t, `{"id":"abc"}`, <-keys)
+	})
+}
+
+func indexOf(ids []types.UUID, id types.UUID) int {
+	for i, candidate := range ids {
+		if candidate == id {
+			return i
+		}
+	}
+	return -1
+}
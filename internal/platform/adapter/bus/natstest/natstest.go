@@ -0,0 +1,315 @@
+// Package natstest runs an in-process NATS server with JetStream so the event
+// bus can be exercised end to end in tests without external services.
+package natstest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/bus"
+	"github.com/marcelofabianov/redtogreen/internal/platform/config"
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	platformBus "github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+)
+
+const (
+	DefaultTimeout = 5 * time.Second
+
+	pollInterval = 20 * time.Millisecond
+)
+
+type Harness struct {
+	Server *server.Server
+	Conn   *nats.Conn
+	JS     jetstream.JetStream
+	Bus    *bus.NatsEventBus
+	URL    string
+}
+
+type Option func(*options)
+
+type options struct {
+	logger   *slog.Logger
+	subjects map[string][]string
+}
+
+// WithLogger sends the bus logs to logger instead of discarding them.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithStreamSubjects creates stream with subjects instead of its configured
+// ones, as a server deployed by an older release would have it.
+func WithStreamSubjects(stream string, subjects ...string) Option {
+	return func(o *options) {
+		o.subjects[stream] = subjects
+	}
+}
+
+// Start runs a JetStream enabled server on a random port backed by a temporary
+// directory, creates the application streams and wires a NatsEventBus to it.
+// Everything is shut down when the test finishes.
+func Start(t testing.TB, opts ...Option) *Harness {
+	t.Helper()
+
+	o := options{
+		logger:   slog.New(slog.NewTextHandler(io.Discard, nil)),
+		subjects: make(map[string][]string),
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	srv, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      server.RANDOM_PORT,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	if err != nil {
+		t.Fatalf("natstest: failed to create server: %v", err)
+	}
+	go srv.Start()
+	if !srv.ReadyForConnections(DefaultTimeout) {
+		srv.Shutdown()
+		t.Fatalf("natstest: server was not ready for connections within %s", DefaultTimeout)
+	}
+	t.Cleanup(func() {
+		srv.Shutdown()
+		srv.WaitForShutdown()
+	})
+
+	h := &Harness{Server: srv, URL: srv.ClientURL()}
+
+	h.Conn, err = nats.Connect(h.URL)
+	if err != nil {
+		t.Fatalf("natstest: failed to connect: %v", err)
+	}
+	t.Cleanup(h.Conn.Close)
+
+	h.JS, err = jetstream.New(h.Conn)
+	if err != nil {
+		t.Fatalf("natstest: failed to initialize JetStream: %v", err)
+	}
+
+	// The production streams are replicated across a cluster; a single test
+	// server can only hold one replica, so they are created here before the bus
+	// looks them up.
+	for _, sc := range bus.GetStreamConfigs() {
+		subjects, ok := o.subjects[sc.Name]
+		if !ok {
+			subjects = sc.Config.Subjects
+		}
+		_, err := h.JS.CreateStream(context.Background(), jetstream.StreamConfig{
+			Name:      sc.Config.Name,
+			Subjects:  subjects,
+			Storage:   jetstream.StorageType(sc.Config.Storage),
+			Replicas:  1,
+			MaxMsgs:   sc.Config.MaxMsgs,
+			MaxAge:    sc.Config.MaxAge,
+			Retention: jetstream.RetentionPolicy(sc.Config.Retention),
+		})
+		if err != nil {
+			t.Fatalf("natstest: failed to create stream %s: %v", sc.Name, err)
+		}
+	}
+
+	h.Bus, err = bus.NewNatsEventBus(&config.NATSConfig{URLs: h.URL}, o.logger)
+	if err != nil {
+		t.Fatalf("natstest: failed to create event bus: %v", err)
+	}
+	t.Cleanup(func() { _ = h.Bus.Close() })
+
+	return h
+}
+
+// PublishRaw publishes data as is to subject, bypassing the event envelope.
+func (h *Harness) PublishRaw(t testing.TB, subject string, data []byte) {
+	t.Helper()
+
+	if _, err := h.JS.Publish(context.Background(), subject, data); err != nil {
+		t.Fatalf("natstest: failed to publish to %s: %v", subject, err)
+	}
+}
+
+// ConsumerInfo returns the state of the durable consumer Subscribe created for eventType.
+func (h *Harness) ConsumerInfo(t testing.TB, eventType event.EventType) *jetstream.ConsumerInfo {
+	t.Helper()
+
+	streamName, err := bus.StreamNameForSubject(string(eventType))
+	if err != nil {
+		t.Fatalf("natstest: %v", err)
+	}
+
+	consumer, err := h.JS.Consumer(context.Background(), streamName, bus.ConsumerName(eventType))
+	if err != nil {
+		t.Fatalf("natstest: consumer for %s not found: %v", eventType, err)
+	}
+
+	info, err := consumer.Info(context.Background())
+	if err != nil {
+		t.Fatalf("natstest: failed to read consumer info for %s: %v", eventType, err)
+	}
+
+	return info
+}
+
+// AwaitConsumer polls the consumer of eventType until cond holds or the timeout expires.
+func (h *Harness) AwaitConsumer(t testing.TB, eventType event.EventType, timeout time.Duration, cond func(*jetstream.ConsumerInfo) bool) *jetstream.ConsumerInfo {
+	t.Helper()
+
+	deadline := time.Now().Add(timeout)
+	for {
+		info := h.ConsumerInfo(t, eventType)
+		if cond(info) {
+			return info
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("natstest: consumer for %s did not reach the expected state within %s (delivered=%d, ack floor=%d, pending acks=%d, redelivered=%d)",
+				eventType, timeout, info.Delivered.Stream, info.AckFloor.Stream, info.NumAckPending, info.NumRedelivered)
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// AwaitAcked waits until the consumer of eventType has acknowledged or
+// terminated count messages and has nothing left in flight.
+func (h *Harness) AwaitAcked(t testing.TB, eventType event.EventType, count uint64) *jetstream.ConsumerInfo {
+	t.Helper()
+
+	return h.AwaitConsumer(t, eventType, DefaultTimeout, func(info *jetstream.ConsumerInfo) bool {
+		return info.AckFloor.Consumer >= count && info.NumAckPending == 0
+	})
+}
+
+// StreamEvents returns every event currently stored in the stream, oldest first.
+func (h *Harness) StreamEvents(t testing.TB, streamName string) []event.Event {
+	t.Helper()
+
+	ctx := context.Background()
+	stream, err := h.JS.Stream(ctx, streamName)
+	if err != nil {
+		t.Fatalf("natstest: stream %s not found: %v", streamName, err)
+	}
+
+	info, err := stream.Info(ctx)
+	if err != nil {
+		t.Fatalf("natstest: failed to read stream info for %s: %v", streamName, err)
+	}
+
+	events := make([]event.Event, 0, info.State.Msgs)
+	for seq := info.State.FirstSeq; info.State.Msgs > 0 && seq <= info.State.LastSeq; seq++ {
+		raw, err := stream.GetMsg(ctx, seq)
+		if err != nil {
+			continue
+		}
+		var evt event.Event
+		if err := json.Unmarshal(raw.Data, &evt); err != nil {
+			t.Fatalf("natstest: message %d in %s is not an event: %v", seq, streamName, err)
+		}
+		events = append(events, evt)
+	}
+
+	return events
+}
+
+// Recorder is an event handler that records deliveries so tests can wait for them.
+type Recorder struct {
+	mu       sync.Mutex
+	events   []event.Event
+	attempts int
+	delivery chan event.Event
+	handle   platformBus.EventHandler
+}
+
+// NewRecorder returns a recorder whose handler delegates to handle, or acknowledges
+// every event when handle is nil. Only successfully handled events are recorded.
+func NewRecorder(handle platformBus.EventHandler) *Recorder {
+	return &Recorder{
+		delivery: make(chan event.Event, 128),
+		handle:   handle,
+	}
+}
+
+// FailTimes returns a recorder that fails the first n deliveries, forcing redelivery.
+func FailTimes(n int, err error) *Recorder {
+	r := NewRecorder(nil)
+	r.handle = func(ctx context.Context, evt *event.Event) error {
+		if r.Attempts() <= n {
+			return err
+		}
+		return nil
+	}
+	return r
+}
+
+func (r *Recorder) Handler() platformBus.EventHandler {
+	return func(ctx context.Context, evt *event.Event) error {
+		r.mu.Lock()
+		r.attempts++
+		r.mu.Unlock()
+
+		if r.handle != nil {
+			if err := r.handle(ctx, evt); err != nil {
+				return err
+			}
+		}
+
+		r.mu.Lock()
+		r.events = append(r.events, *evt)
+		r.mu.Unlock()
+		r.delivery <- *evt
+
+		return nil
+	}
+}
+
+// Attempts is the number of times the handler was invoked, including failures.
+func (r *Recorder) Attempts() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.attempts
+}
+
+func (r *Recorder) Events() []event.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]event.Event(nil), r.events...)
+}
+
+// Await blocks until the next event is handled successfully or the timeout expires.
+func (r *Recorder) Await(t testing.TB, timeout time.Duration) event.Event {
+	t.Helper()
+
+	select {
+	case evt := <-r.delivery:
+		return evt
+	case <-time.After(timeout):
+		t.Fatalf("natstest: no event delivered within %s (%d attempts)", timeout, r.Attempts())
+		return event.Event{}
+	}
+}
+
+// AssertNoDelivery fails the test if an event is handled successfully within wait.
+func (r *Recorder) AssertNoDelivery(t testing.TB, wait time.Duration) {
+	t.Helper()
+
+	select {
+	case evt := <-r.delivery:
+		t.Fatalf("natstest: unexpected delivery of %s %s", evt.Header.EventType, evt.Header.EventID)
+	case <-time.After(wait):
+	}
+}
@@ -0,0 +1,739 @@
+package bus
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/stdlib"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/logger"
+	"github.com/marcelofabianov/redtogreen/internal/platform/config"
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	platformBus "github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/database"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+const (
+	logPgBusInitialized       = "Postgres EventBus successfully initialized"
+	logPgListenFailed         = "Failed to listen for event notifications, retrying"
+	logPgConsumeFailed        = "Failed to process next event"
+	logPgEventDeadLettered    = "Event handler exhausted its attempts or rejected the event, event moved to dead letters"
+	logPgFailedToRegisterCons = "Failed to register consumer"
+	logPgClaimLost            = "Consumer claim expired before the handler finished, event will be redelivered"
+	logPgEventsPruned         = "Pruned consumed events"
+	logPgPruneFailed          = "Failed to prune consumed events"
+
+	// NotifyChannel is the Postgres channel notified with the event type of
+	// every published event.
+	NotifyChannel = "bus_events"
+
+	defaultPollInterval   = time.Second
+	listenRetryInterval   = 5 * time.Second
+	defaultHandlerTimeout = 30 * time.Second
+	pruneInterval         = 10 * time.Minute
+	pruneBatchSize        = 1000
+
+	// claimMargin is added to the handler timeout to lease an offset, so the
+	// outcome of a handler that just met its deadline can still be recorded.
+	claimMargin = 30 * time.Second
+)
+
+var errNoEventToProcess = errors.New("no event to process")
+
+// PostgresEventBus stores events in the main database and delivers them to
+// durable consumers that keep their own offset. Consumers are woken by
+// LISTEN/NOTIFY and fall back to polling, lease their offset for the length of
+// one handler call so several instances can run side by side, and retry failed
+// events with the same backoff as the NATS bus before dead-lettering them.
+// Events whose handler fails with a bus.PermanentError are dead-lettered
+// without retry.
+//
+// Offsets follow commit order: an event is only read once every transaction
+// older than its own has finished, so a publisher that commits late is never
+// skipped. Events read by every consumer of their type are pruned after the
+// retention period, unless they were dead-lettered.
+type PostgresEventBus struct {
+	db             database.DB
+	logger         *slog.Logger
+	tracer         trace.Tracer
+	pollInterval   time.Duration
+	handlerTimeout time.Duration
+	retention      time.Duration
+	metrics        *busMetrics
+	listening      atomic.Bool
+
+	mu      sync.Mutex
+	wakeups map[event.EventType][]chan struct{}
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewPostgresEventBus(db database.DB, cfg config.EventBusConfig, sl *slog.Logger) *PostgresEventBus {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	b := &PostgresEventBus{
+		db:             db,
+		logger:         sl,
+		tracer:         otel.Tracer("postgres-bus"),
+		pollInterval:   defaultPollInterval,
+		handlerTimeout: defaultHandlerTimeout,
+		retention:      time.Duration(cfg.RetentionHours) * time.Hour,
+		wakeups:        make(map[event.EventType][]chan struct{}),
+		ctx:            ctx,
+		cancel:         cancel,
+	}
+	if cfg.HandlerTimeoutSeconds > 0 {
+		b.handlerTimeout = time.Duration(cfg.HandlerTimeoutSeconds) * time.Second
+	}
+	b.metrics = newBusMetrics("postgresql", b.ConsumerStats, b.StreamStats)
+
+	b.wg.Add(1)
+	go b.listen()
+
+	if b.retention > 0 {
+		b.wg.Add(1)
+		go b.prune()
+	}
+
+	sl.Info(logPgBusInitialized)
+
+	return b
+}
+
+var _ platformBus.EventBus = (*PostgresEventBus)(nil)
+
+// Close stops the listener and every consumer, waiting for in-flight handlers.
+func (b *PostgresEventBus) Close() error {
+	b.metrics.close()
+	b.cancel()
+	b.wg.Wait()
+	return nil
+}
+
+var _ platformBus.HealthChecker = (*PostgresEventBus)(nil)
+
+// Health pings the database and reports whether the LISTEN connection is up;
+// without it consumers still progress, but only by polling.
+func (b *PostgresEventBus) Health(ctx context.Context) platformBus.Health {
+	health := platformBus.Health{
+		Driver:          config.EventBusDriverPostgres,
+		ConnectionState: "DISCONNECTED",
+	}
+
+	if err := b.db.Conn().PingContext(ctx); err != nil {
+		health.Error = err.Error()
+		return health
+	}
+	health.Connected = true
+	health.ConnectionState = "CONNECTED"
+	health.StreamingAvailable = b.listening.Load()
+
+	return health
+}
+
+// ConsumerStats reads the backlog of every consumer registered in the
+// database. The event being handled or under retry, if any, counts as ack
+// pending.
+func (b *PostgresEventBus) ConsumerStats(ctx context.Context) ([]ConsumerStats, error) {
+	query := `
+		SELECT c.name, c.event_type, c.attempts, COALESCE(c.claimed_until > NOW(), FALSE),
+			(SELECT COUNT(*) FROM bus_events e
+			 WHERE e.event_type = c.event_type AND (e.xact_id, e.position) > (c.xact_id, c.position))
+		FROM bus_consumers c
+	`
+	rows, err := b.db.Conn().QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []ConsumerStats
+	for rows.Next() {
+		var name, eventType string
+		var attempts, backlog int64
+		var claimed bool
+		if err := rows.Scan(&name, &eventType, &attempts, &claimed, &backlog); err != nil {
+			return nil, err
+		}
+
+		var ackPending int64
+		if (attempts > 0 || claimed) && backlog > 0 {
+			ackPending = 1
+		}
+		stats = append(stats, ConsumerStats{
+			Consumer:    name,
+			EventType:   event.EventType(eventType),
+			Pending:     backlog - ackPending,
+			AckPending:  ackPending,
+			Redelivered: attempts,
+		})
+	}
+
+	return stats, rows.Err()
+}
+
+// StreamStats reports the event table as the single stream of the bus.
+func (b *PostgresEventBus) StreamStats(ctx context.Context) ([]StreamStats, error) {
+	stats := StreamStats{Stream: NotifyChannel}
+	query := `SELECT COUNT(*), pg_total_relation_size('bus_events') FROM bus_events`
+	if err := b.db.Conn().QueryRowContext(ctx, query).Scan(&stats.Messages, &stats.Bytes); err != nil {
+		return nil, err
+	}
+
+	return []StreamStats{stats}, nil
+}
+
+// Publish stores evt and notifies the listeners. Within a transaction of the
+// context the event is written as part of it, so it is only visible, and only
+// notified, if that transaction commits.
+func (b *PostgresEventBus) Publish(ctx context.Context, evt *event.Event) error {
+	ctx, span := b.tracer.Start(ctx, fmt.Sprintf("Postgres Publish %s", evt.Header.EventType),
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "postgresql"),
+			attribute.String("messaging.destination", string(evt.Header.EventType)),
+			attribute.String("messaging.operation", "publish"),
+			attribute.String("event.id", evt.Header.EventID.String()),
+			attribute.String("event.type", string(evt.Header.EventType)),
+		),
+	)
+	defer span.End()
+
+	carrier := propagation.HeaderCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+
+	event.ApplyScope(ctx, evt)
+
+	data, err := json.Marshal(evt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to marshal event")
+		errMsg := msg.NewValidationError(err, map[string]any{"event_type": evt.Header.EventType}, "Invalid event data")
+		b.logger.Error(logFailedToMarshalEvent,
+			logger.ErrorCode(errMsg.Code),
+			logger.EventType(string(evt.Header.EventType)),
+			logger.Err(err),
+		)
+		return errMsg
+	}
+
+	headers, err := json.Marshal(carrier)
+	if err != nil {
+		span.RecordError(err)
+		return msg.NewInternalError(err, map[string]any{"event_type": evt.Header.EventType})
+	}
+
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	insert := func(exec database.Executor) error {
+		query := `
+			INSERT INTO bus_events (event_id, event_type, data, headers)
+			VALUES ($1, $2, $3, $4)
+		`
+		if _, err := exec.ExecContext(queryCtx, query, evt.Header.EventID, string(evt.Header.EventType), data, headers); err != nil {
+			return err
+		}
+
+		_, err := exec.ExecContext(queryCtx, `SELECT pg_notify($1, $2)`, NotifyChannel, string(evt.Header.EventType))
+		return err
+	}
+	if tx, inTx := database.TxFromContext(ctx); inTx {
+		err = insert(tx)
+	} else {
+		err = b.db.WithTransaction(queryCtx, nil, func(tx *sql.Tx) error { return insert(tx) })
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to publish event")
+		errMsg := msg.NewInternalError(err, map[string]any{"event_type": evt.Header.EventType})
+		b.logger.Error(logFailedToPublishEvent,
+			logger.ErrorCode(errMsg.Code),
+			logger.EventType(string(evt.Header.EventType)),
+			logger.Err(err),
+		)
+		return errMsg
+	}
+
+	span.SetStatus(codes.Ok, "Event published successfully")
+	b.logger.Debug(logEventPublished,
+		logger.EventType(string(evt.Header.EventType)),
+	)
+
+	return nil
+}
+
+// Subscribe registers a durable consumer for eventType. A consumer walks its
+// offset one event at a time, so a failing event holds back the ones after it
+// until it succeeds or is dead-lettered, at most defaultMaxDeliver attempts
+// and their backoff later. Ordered subscriptions get one consumer per
+// partition instead, each handling only the keys that hash to it: a failing
+// event then holds back only the keys of its own partition.
+func (b *PostgresEventBus) Subscribe(eventType event.EventType, handler platformBus.EventHandler, opts ...platformBus.SubscribeOption) error {
+	options := platformBus.NewSubscribeOptions(opts...)
+	if !options.Ordered() {
+		return b.subscribe(ConsumerName(eventType), eventType, handler, nil)
+	}
+
+	for partition := range options.Partitions {
+		owns := func(evt *event.Event) bool {
+			return partitionOf(options.KeyOf(evt), options.Partitions) == partition
+		}
+		consumerName := fmt.Sprintf("%s-%d", ConsumerName(eventType), partition)
+		if err := b.subscribe(consumerName, eventType, handler, owns); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// subscribe starts a consumer that hands handler the events of eventType that
+// owns accepts, or all of them when owns is nil. The others only move the
+// offset forward.
+func (b *PostgresEventBus) subscribe(consumerName string, eventType event.EventType, handler platformBus.EventHandler, owns func(*event.Event) bool) error {
+	queryCtx, cancel := context.WithTimeout(b.ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO bus_consumers (name, event_type)
+		VALUES ($1, $2)
+		ON CONFLICT (name) DO NOTHING
+	`
+	if _, err := b.db.Conn().ExecContext(queryCtx, query, consumerName, string(eventType)); err != nil {
+		errMsg := msg.NewInternalError(err, map[string]any{"consumer": consumerName})
+		b.logger.Error(logPgFailedToRegisterCons,
+			logger.ErrorCode(errMsg.Code),
+			slog.String("consumer", consumerName),
+			logger.Err(err),
+		)
+		return errMsg
+	}
+
+	wake := make(chan struct{}, 1)
+	b.mu.Lock()
+	b.wakeups[eventType] = append(b.wakeups[eventType], wake)
+	b.mu.Unlock()
+
+	b.wg.Add(1)
+	go b.consume(consumerName, eventType, handler, owns, wake)
+
+	b.logger.Info(logSubscribedSuccessfully,
+		logger.EventType(string(eventType)),
+		slog.String("consumer", consumerName),
+	)
+
+	return nil
+}
+
+func (b *PostgresEventBus) consume(consumerName string, eventType event.EventType, handler platformBus.EventHandler, owns func(*event.Event) bool, wake <-chan struct{}) {
+	defer b.wg.Done()
+
+	for {
+		processed, err := b.processNext(consumerName, eventType, handler, owns)
+		if processed {
+			continue
+		}
+		if err != nil && b.ctx.Err() == nil {
+			b.logger.Error(logPgConsumeFailed,
+				slog.String("consumer", consumerName),
+				logger.EventType(string(eventType)),
+				logger.Err(err),
+			)
+		}
+
+		select {
+		case <-b.ctx.Done():
+			return
+		case <-wake:
+		case <-time.After(b.pollInterval):
+		}
+	}
+}
+
+// consumerClaim is the offset a consumer leased and the event after it.
+type consumerClaim struct {
+	id       types.UUID
+	attempts int
+	xactID   string
+	position int64
+	data     []byte
+	headers  []byte
+}
+
+// processNext leases the consumer offset, hands the next event to handler
+// outside any transaction and then records the outcome. It reports whether the
+// offset moved forward; a consumer that is caught up, waiting for a retry or
+// claimed by another instance is not an error. Events that cannot be decoded,
+// or that belong to another partition, are passed over.
+func (b *PostgresEventBus) processNext(consumerName string, eventType event.EventType, handler platformBus.EventHandler, owns func(*event.Event) bool) (bool, error) {
+	claim, err := b.claimNext(consumerName, eventType)
+	if err != nil {
+		if errors.Is(err, errNoEventToProcess) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	var handlerErr error
+	if evt, ok := b.decode(claim.data); ok && (owns == nil || owns(evt)) {
+		handlerCtx, cancel := context.WithTimeout(b.ctx, b.handlerTimeout)
+		start := time.Now()
+		handlerErr = b.handle(handlerCtx, eventType, handler, evt, claim.headers)
+		cancel()
+		b.metrics.recordHandled(b.ctx, eventType, start, handlerErr)
+	}
+
+	// The outcome is recorded even while shutting down, so a handler that
+	// finished is not run again by the next instance.
+	settleCtx, cancel := context.WithTimeout(context.WithoutCancel(b.ctx), 5*time.Second)
+	defer cancel()
+
+	if handlerErr != nil && b.ctx.Err() != nil {
+		return false, releaseClaim(settleCtx, b.db.Conn(), consumerName, claim.id)
+	}
+
+	var advanced bool
+	err = b.db.WithTransaction(settleCtx, nil, func(tx *sql.Tx) error {
+		if handlerErr == nil {
+			advanced = true
+			return advanceConsumer(settleCtx, tx, consumerName, claim)
+		}
+
+		attempts := claim.attempts + 1
+		if attempts >= defaultMaxDeliver || platformBus.IsPermanent(handlerErr) {
+			b.logger.Error(logPgEventDeadLettered,
+				slog.String("consumer", consumerName),
+				logger.EventType(string(eventType)),
+				slog.Int64("position", claim.position),
+				logger.Err(handlerErr),
+			)
+			query := `
+				INSERT INTO bus_dead_letters (consumer, position, attempts, last_error)
+				VALUES ($1, $2, $3, $4)
+				ON CONFLICT (consumer, position) DO NOTHING
+			`
+			if _, err := tx.ExecContext(settleCtx, query, consumerName, claim.position, attempts, handlerErr.Error()); err != nil {
+				return err
+			}
+			advanced = true
+			return advanceConsumer(settleCtx, tx, consumerName, claim)
+		}
+
+		query := `
+			UPDATE bus_consumers
+			SET attempts = $3, next_attempt_at = $4, last_error = $5,
+				claim_id = NULL, claimed_until = NULL, updated_at = NOW()
+			WHERE name = $1 AND claim_id = $2
+		`
+		result, err := tx.ExecContext(settleCtx, query, consumerName, claim.id, attempts, time.Now().UTC().Add(retryDelay(attempts)), handlerErr.Error())
+		if err != nil {
+			return err
+		}
+		return checkClaimHeld(result)
+	})
+	if errors.Is(err, errClaimLost) {
+		b.logger.Warn(logPgClaimLost,
+			slog.String("consumer", consumerName),
+			logger.EventType(string(eventType)),
+			slog.Int64("position", claim.position),
+		)
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return advanced, nil
+}
+
+// claimNext leases the offset of the consumer for one handler call and loads
+// the next event committed after it. The lease is a column rather than a row
+// lock so no transaction stays open while the handler runs.
+func (b *PostgresEventBus) claimNext(consumerName string, eventType event.EventType) (consumerClaim, error) {
+	claimID, err := types.NewUUID()
+	if err != nil {
+		return consumerClaim{}, err
+	}
+	claim := consumerClaim{id: claimID}
+
+	err = b.db.WithTransaction(b.ctx, nil, func(tx *sql.Tx) error {
+		var position int64
+		var xactID string
+		query := `
+			SELECT xact_id::text, position, attempts
+			FROM bus_consumers
+			WHERE name = $1 AND next_attempt_at <= NOW()
+				AND (claimed_until IS NULL OR claimed_until <= NOW())
+			FOR UPDATE SKIP LOCKED
+		`
+		if err := tx.QueryRowContext(b.ctx, query, consumerName).Scan(&xactID, &position, &claim.attempts); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errNoEventToProcess
+			}
+			return err
+		}
+
+		// Events of transactions newer than the oldest one still running stay
+		// hidden, since that one may yet commit an event ordered before them.
+		query = `
+			SELECT xact_id::text, position, data, headers
+			FROM bus_events
+			WHERE event_type = $1 AND (xact_id, position) > ($2::xid8, $3)
+				AND xact_id < pg_snapshot_xmin(pg_current_snapshot())
+			ORDER BY xact_id, position
+			LIMIT 1
+		`
+		if err := tx.QueryRowContext(b.ctx, query, string(eventType), xactID, position).
+			Scan(&claim.xactID, &claim.position, &claim.data, &claim.headers); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errNoEventToProcess
+			}
+			return err
+		}
+
+		query = `
+			UPDATE bus_consumers
+			SET claim_id = $2, claimed_until = NOW() + make_interval(secs => $3)
+			WHERE name = $1
+		`
+		_, err := tx.ExecContext(b.ctx, query, consumerName, claim.id, (b.handlerTimeout + claimMargin).Seconds())
+		return err
+	})
+	if err != nil {
+		return consumerClaim{}, err
+	}
+
+	return claim, nil
+}
+
+// decode reads a stored event. Events that cannot be decoded are skipped,
+// mirroring how the NATS bus terminates malformed messages.
+func (b *PostgresEventBus) decode(data []byte) (*event.Event, bool) {
+	var evt event.Event
+	if err := json.Unmarshal(data, &evt); err != nil {
+		b.logger.Error(logFailedToUnmarshalMsg,
+			logger.Err(err),
+			slog.String("data", string(data)),
+		)
+		return nil, false
+	}
+
+	return &evt, true
+}
+
+// handle dispatches one stored event within the trace it was published with.
+func (b *PostgresEventBus) handle(ctx context.Context, eventType event.EventType, handler platformBus.EventHandler, evt *event.Event, headers []byte) error {
+	carrier := propagation.HeaderCarrier{}
+	_ = json.Unmarshal(headers, &carrier)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, carrier)
+
+	ctx, span := b.tracer.Start(ctx, fmt.Sprintf("Postgres Consume %s", eventType),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "postgresql"),
+			attribute.String("messaging.destination", string(eventType)),
+			attribute.String("messaging.operation", "process"),
+		),
+	)
+	defer span.End()
+
+	ctx = event.WithScope(ctx, event.ScopeOf(evt))
+
+	if err := handler(ctx, evt); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Event handler failed")
+		b.logger.Error(logEventHandlerFailed,
+			logger.Err(err),
+			logger.EventType(string(eventType)),
+		)
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "Event processed successfully")
+	b.logger.Debug(logEventProcessed,
+		logger.EventType(string(eventType)),
+	)
+
+	return nil
+}
+
+// listen keeps a dedicated connection on LISTEN and wakes the consumers of the
+// notified event type. Missed notifications are covered by polling.
+func (b *PostgresEventBus) listen() {
+	defer b.wg.Done()
+
+	for {
+		err := b.waitForNotifications()
+		if b.ctx.Err() != nil {
+			return
+		}
+		b.logger.Warn(logPgListenFailed, logger.Err(err))
+
+		select {
+		case <-b.ctx.Done():
+			return
+		case <-time.After(listenRetryInterval):
+		}
+	}
+}
+
+func (b *PostgresEventBus) waitForNotifications() error {
+	conn, err := b.db.Conn().Conn(b.ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn any) error {
+		stdConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return fmt.Errorf("unsupported driver connection %T", driverConn)
+		}
+		pgConn := stdConn.Conn()
+
+		if _, err := pgConn.Exec(b.ctx, "LISTEN "+NotifyChannel); err != nil {
+			return err
+		}
+		b.listening.Store(true)
+		defer func() {
+			b.listening.Store(false)
+			unlistenCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_, _ = pgConn.Exec(unlistenCtx, "UNLISTEN "+NotifyChannel)
+		}()
+
+		for {
+			notification, err := pgConn.WaitForNotification(b.ctx)
+			if err != nil {
+				return err
+			}
+			b.wake(event.EventType(notification.Payload))
+		}
+	})
+}
+
+func (b *PostgresEventBus) wake(eventType event.EventType) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, wake := range b.wakeups[eventType] {
+		select {
+		case wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// prune deletes, in batches, the events older than the retention that every
+// consumer of their type has read. Dead-lettered events are kept for
+// inspection.
+func (b *PostgresEventBus) prune() {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		pruned, err := b.pruneConsumed(b.ctx)
+		if err != nil && b.ctx.Err() == nil {
+			b.logger.Error(logPgPruneFailed, logger.Err(err))
+		}
+		if pruned > 0 {
+			b.logger.Info(logPgEventsPruned, slog.Int64("events", pruned))
+		}
+	}
+}
+
+func (b *PostgresEventBus) pruneConsumed(ctx context.Context) (int64, error) {
+	query := `
+		DELETE FROM bus_events
+		WHERE position IN (
+			SELECT e.position
+			FROM bus_events e
+			WHERE e.created_at < $1
+				AND NOT EXISTS (
+					SELECT 1 FROM bus_consumers c
+					WHERE c.event_type = e.event_type AND (e.xact_id, e.position) > (c.xact_id, c.position)
+				)
+				AND NOT EXISTS (SELECT 1 FROM bus_dead_letters d WHERE d.position = e.position)
+			LIMIT $2
+		)
+	`
+	var total int64
+	for {
+		result, err := b.db.Conn().ExecContext(ctx, query, time.Now().UTC().Add(-b.retention), pruneBatchSize)
+		if err != nil {
+			return total, err
+		}
+		deleted, err := result.RowsAffected()
+		if err != nil {
+			return total, err
+		}
+		total += deleted
+		if deleted < pruneBatchSize {
+			return total, nil
+		}
+	}
+}
+
+var errClaimLost = errors.New("consumer claim lost")
+
+// advanceConsumer moves the offset past the claimed event, unless the lease
+// expired and another instance claimed the offset meanwhile.
+func advanceConsumer(ctx context.Context, tx *sql.Tx, consumerName string, claim consumerClaim) error {
+	query := `
+		UPDATE bus_consumers
+		SET xact_id = $3::xid8, position = $4, attempts = 0, next_attempt_at = NOW(), last_error = NULL,
+			claim_id = NULL, claimed_until = NULL, updated_at = NOW()
+		WHERE name = $1 AND claim_id = $2
+	`
+	result, err := tx.ExecContext(ctx, query, consumerName, claim.id, claim.xactID, claim.position)
+	if err != nil {
+		return err
+	}
+	return checkClaimHeld(result)
+}
+
+func releaseClaim(ctx context.Context, db *sql.DB, consumerName string, claimID types.UUID) error {
+	query := `
+		UPDATE bus_consumers
+		SET claim_id = NULL, claimed_until = NULL
+		WHERE name = $1 AND claim_id = $2
+	`
+	_, err := db.ExecContext(ctx, query, consumerName, claimID)
+	return err
+}
+
+func checkClaimHeld(result sql.Result) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errClaimLost
+	}
+	return nil
+}
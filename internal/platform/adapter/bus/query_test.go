@@ -0,0 +1,119 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/bus"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/bus/natstest"
+	"github.com/marcelofabianov/redtogreen/internal/platform/config"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	platformBus "github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+)
+
+type echoRequest struct {
+	Name string `json:"name"`
+}
+
+type echoResponse struct {
+	Greeting string `json:"greeting"`
+}
+
+var echoQuery = platformBus.NewQueryDefinition[echoRequest, echoResponse]("test.echo")
+
+func newNatsQueryBus(t *testing.T) platformBus.QueryBus {
+	t.Helper()
+
+	h := natstest.Start(t)
+	queryBus, err := bus.NewNatsQueryBus(&config.NATSConfig{URLs: h.URL}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err, "Setup: failed to create NATS query bus")
+	t.Cleanup(func() { _ = queryBus.Close() })
+
+	return queryBus
+}
+
+func TestQueryBus(t *testing.T) {
+	adapters := map[string]func(t *testing.T) platformBus.QueryBus{
+		"NATS": newNatsQueryBus,
+		"InProcess": func(t *testing.T) platformBus.QueryBus {
+			return bus.NewInProcessQueryBus()
+		},
+	}
+
+	for name, newQueryBus := range adapters {
+		t.Run(name, func(t *testing.T) {
+			t.Run("Success: should return the typed response", func(t *testing.T) {
+				queryBus := newQueryBus(t)
+				require.NoError(t, platformBus.Answer(queryBus, echoQuery, func(ctx context.Context, req echoRequest) (echoResponse, error) {
+					return echoResponse{Greeting: "hello " + req.Name}, nil
+				}))
+
+				res, err := platformBus.Ask(context.Background(), queryBus, echoQuery, echoRequest{Name: "ana"})
+				require.NoError(t, err)
+				assert.Equal(t, "hello ana", res.Greeting)
+			})
+
+			t.Run("Failure: should keep the message error code of the responder", func(t *testing.T) {
+				queryBus := newQueryBus(t)
+				require.NoError(t, platformBus.Answer(queryBus, echoQuery, func(ctx context.Context, req echoRequest) (echoResponse, error) {
+					return echoResponse{}, msg.NewMessageError(nil, "Name not found.", msg.CodeNotFound, map[string]any{"name": req.Name})
+				}))
+
+				_, err := platformBus.Ask(context.Background(), queryBus, echoQuery, echoRequest{Name: "ana"})
+				var msgErr *msg.MessageError
+				require.ErrorAs(t, err, &msgErr)
+				assert.Equal(t, msg.CodeNotFound, msgErr.Code)
+				assert.Equal(t, "Name not found.", msgErr.Message)
+				assert.Equal(t, "ana", msgErr.Context["name"])
+			})
+
+			t.Run("Failure: should hide unexpected errors behind an internal error", func(t *testing.T) {
+				queryBus := newQueryBus(t)
+				require.NoError(t, platformBus.Answer(queryBus, echoQuery, func(ctx context.Context, req echoRequest) (echoResponse, error) {
+					return echoResponse{}, errors.New("connection refused")
+				}))
+
+				_, err := platformBus.Ask(context.Background(), queryBus, echoQuery, echoRequest{})
+				var msgErr *msg.MessageError
+				require.ErrorAs(t, err, &msgErr)
+				assert.Equal(t, msg.CodeInternal, msgErr.Code)
+			})
+
+			t.Run("Failure: should fail when nobody answers the query", func(t *testing.T) {
+				queryBus := newQueryBus(t)
+
+				_, err := platformBus.Ask(context.Background(), queryBus, echoQuery, echoRequest{})
+				var msgErr *msg.MessageError
+				require.ErrorAs(t, err, &msgErr)
+				assert.Equal(t, bus.ErrQueryNoResponders, msgErr.Message)
+				assert.Equal(t, msg.CodeUnavailable, msgErr.Code)
+			})
+		})
+	}
+}
+
+func TestNatsQueryBus_Timeout(t *testing.T) {
+	t.Run("Failure: should time out when the responder is too slow", func(t *testing.T) {
+		queryBus := newNatsQueryBus(t)
+		require.NoError(t, platformBus.Answer(queryBus, echoQuery, func(ctx context.Context, req echoRequest) (echoResponse, error) {
+			time.Sleep(300 * time.Millisecond)
+			return echoResponse{}, nil
+		}))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := platformBus.Ask(ctx, queryBus, echoQuery, echoRequest{})
+		var msgErr *msg.MessageError
+		require.ErrorAs(t, err, &msgErr)
+		assert.Equal(t, bus.ErrQueryTimeout, msgErr.Message)
+		assert.Equal(t, msg.CodeTimeout, msgErr.Code)
+	})
+}
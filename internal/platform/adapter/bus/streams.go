@@ -8,7 +8,24 @@ import (
 	"github.com/nats-io/nats.go"
 )
 
-func findStreamNameForSubject(subject string) (string, error) {
+const defaultMaxDeliver = 5
+
+// defaultBackOff is the delay before each redelivery of an event whose handler failed.
+var defaultBackOff = []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second}
+
+// retryDelay returns the backoff after the given number of failed attempts.
+func retryDelay(attempts int) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+	if attempts > len(defaultBackOff) {
+		return defaultBackOff[len(defaultBackOff)-1]
+	}
+	return defaultBackOff[attempts-1]
+}
+
+// StreamNameForSubject returns the configured stream that captures subject.
+func StreamNameForSubject(subject string) (string, error) {
 	configs := GetStreamConfigs()
 	for _, sc := range configs {
 		for _, s := range sc.Subjects {
@@ -31,10 +48,10 @@ func GetStreamConfigs() []StreamConfig {
 	return []StreamConfig{
 		{
 			Name:     "identity-stream",
-			Subjects: []string{"user.*"},
+			Subjects: []string{"user.*", "role.*"},
 			Config: &nats.StreamConfig{
 				Name:     "identity-stream",
-				Subjects: []string{"user.*"},
+				Subjects: []string{"user.*", "role.*"},
 				Storage:  nats.FileStorage,
 				Replicas: 3,
 				MaxMsgs:  10000,
@@ -0,0 +1,49 @@
+package bus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryDelay(t *testing.T) {
+	t.Run("Success: should follow the backoff for each failed attempt", func(t *testing.T) {
+		assert.Equal(t, time.Duration(0), retryDelay(0))
+		assert.Equal(t, 1*time.Second, retryDelay(1))
+		assert.Equal(t, 4*time.Second, retryDelay(3))
+		assert.Equal(t, 16*time.Second, retryDelay(5))
+	})
+
+	t.Run("Success: should cap the delay at the last backoff step", func(t *testing.T) {
+		assert.Equal(t, 16*time.Second, retryDelay(10))
+	})
+}
+
+func TestStreamNameForSubject(t *testing.T) {
+	t.Run("Success: should find the stream capturing the subject", func(t *testing.T) {
+		name, err := StreamNameForSubject("user.created")
+		assert.NoError(t, err)
+		assert.Equal(t, "identity-stream", name)
+
+		name, err = StreamNameForSubject("role.assigned")
+		assert.NoError(t, err)
+		assert.Equal(t, "identity-stream", name)
+	})
+
+	t.Run("Failure: should reject subjects without stream", func(t *testing.T) {
+		_, err := StreamNameForSubject("unknown.created")
+		assert.Error(t, err)
+	})
+}
+
+func TestPartitionOf(t *testing.T) {
+	t.Run("Success: same key should always map to the same partition", func(t *testing.T) {
+		for _, key := range []string{"", "user-a", "0198c0de-0000-7000-8000-000000000000"} {
+			partition := partitionOf(key, 8)
+			assert.Equal(t, partition, partitionOf(key, 8))
+			assert.GreaterOrEqual(t, partition, 0)
+			assert.Less(t, partition, 8)
+		}
+	})
+}
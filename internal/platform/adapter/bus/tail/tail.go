@@ -0,0 +1,204 @@
+// Package tail follows events as they flow through JetStream, for debugging.
+// It attaches ephemeral ordered consumers, so it leaves no state on the server
+// and never competes with the application's durable consumers.
+package tail
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type Format string
+
+const (
+	FormatPretty Format = "pretty"
+	FormatJSON   Format = "json"
+)
+
+var ErrNoSubjects = errors.New("at least one subject is required")
+
+// Filter keeps only events whose context or metadata match every set field.
+type Filter struct {
+	CorrelationID types.NullableUUID
+	TraceID       types.NullableUUID
+	UserID        types.NullableUUID
+}
+
+func (f Filter) Match(evt *event.Event) bool {
+	if id, ok := f.CorrelationID.GetUUID(); ok && evt.Context.CorrelationID != id {
+		return false
+	}
+	if id, ok := f.TraceID.GetUUID(); ok && evt.Metadata.TraceID != id {
+		return false
+	}
+	if id, ok := f.UserID.GetUUID(); ok {
+		if userID, valid := evt.Context.UserID.GetUUID(); !valid || userID != id {
+			return false
+		}
+	}
+	return true
+}
+
+type Options struct {
+	// Subjects to follow; NATS wildcards such as "user.*" or ">" are allowed.
+	Subjects []string
+	Filter   Filter
+	Format   Format
+	// All replays the events already stored before following new ones.
+	All bool
+	// Out receives the events, Errors the messages that cannot be decoded.
+	Out    io.Writer
+	Errors io.Writer
+}
+
+// Run follows opts.Subjects until ctx is cancelled.
+func Run(ctx context.Context, js jetstream.JetStream, opts Options) error {
+	if len(opts.Subjects) == 0 {
+		return ErrNoSubjects
+	}
+	if opts.Errors == nil {
+		opts.Errors = io.Discard
+	}
+
+	subjectsByStream, err := streamsForSubjects(ctx, js, opts.Subjects)
+	if err != nil {
+		return err
+	}
+
+	deliver := jetstream.DeliverNewPolicy
+	if opts.All {
+		deliver = jetstream.DeliverAllPolicy
+	}
+
+	p := &printer{opts: opts}
+	for stream, subjects := range subjectsByStream {
+		consumer, err := js.OrderedConsumer(ctx, stream, jetstream.OrderedConsumerConfig{
+			FilterSubjects: subjects,
+			DeliverPolicy:  deliver,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to attach to stream %s: %w", stream, err)
+		}
+
+		consumeCtx, err := consumer.Consume(func(msg jetstream.Msg) {
+			p.print(stream, msg)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to consume stream %s: %w", stream, err)
+		}
+		defer consumeCtx.Stop()
+	}
+
+	<-ctx.Done()
+	return nil
+}
+
+// streamsForSubjects groups subjects by the streams that capture them. A
+// wildcard may span several streams.
+func streamsForSubjects(ctx context.Context, js jetstream.JetStream, subjects []string) (map[string][]string, error) {
+	subjectsByStream := make(map[string][]string)
+	for _, subject := range subjects {
+		names := js.StreamNames(ctx, jetstream.WithStreamListSubject(subject))
+		found := false
+		for name := range names.Name() {
+			subjectsByStream[name] = append(subjectsByStream[name], subject)
+			found = true
+		}
+		if err := names.Err(); err != nil {
+			return nil, fmt.Errorf("failed to look up streams for %s: %w", subject, err)
+		}
+		if !found {
+			return nil, fmt.Errorf("no stream captures subject %s", subject)
+		}
+	}
+	return subjectsByStream, nil
+}
+
+type printer struct {
+	mu   sync.Mutex
+	opts Options
+}
+
+func (p *printer) print(stream string, msg jetstream.Msg) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var evt event.Event
+	if err := json.Unmarshal(msg.Data(), &evt); err != nil {
+		fmt.Fprintf(p.opts.Errors, "skipping undecodable message on %s: %v\n", msg.Subject(), err)
+		return
+	}
+	if !p.opts.Filter.Match(&evt) {
+		return
+	}
+
+	if p.opts.Format == FormatJSON {
+		line, err := json.Marshal(evt)
+		if err != nil {
+			fmt.Fprintf(p.opts.Errors, "skipping event %s: %v\n", evt.Header.EventID, err)
+			return
+		}
+		fmt.Fprintf(p.opts.Out, "%s\n", line)
+		return
+	}
+
+	var sequence uint64
+	if meta, err := msg.Metadata(); err == nil {
+		sequence = meta.Sequence.Stream
+	}
+	fmt.Fprint(p.opts.Out, Pretty(&evt, stream, sequence))
+}
+
+// Pretty renders evt as a human readable block.
+func Pretty(evt *event.Event, stream string, sequence uint64) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "── %s %s  %s #%d\n", evt.Header.EventType, evt.Header.Timestamp.Format("2006-01-02T15:04:05.000Z07:00"), stream, sequence)
+	fmt.Fprintf(&b, "   id:          %s\n", evt.Header.EventID)
+	fmt.Fprintf(&b, "   source:      %s %s\n", evt.Header.Source, evt.Header.SchemaVersion)
+	if evt.Header.PartitionKey != "" {
+		fmt.Fprintf(&b, "   partition:   %s\n", evt.Header.PartitionKey)
+	}
+	fmt.Fprintf(&b, "   correlation: %s\n", evt.Context.CorrelationID)
+	fmt.Fprintf(&b, "   trace:       %s\n", evt.Metadata.TraceID)
+	if userID, ok := evt.Context.UserID.GetUUID(); ok {
+		fmt.Fprintf(&b, "   user:        %s (%s)\n", userID, evt.Context.ActorType)
+	}
+	if evt.Context.TenantID != "" {
+		fmt.Fprintf(&b, "   tenant:      %s\n", evt.Context.TenantID)
+	}
+	if id, ok := evt.Metadata.CausationID.GetUUID(); ok {
+		fmt.Fprintf(&b, "   causation:   %s\n", id)
+	}
+	if len(evt.Context.Attributes) > 0 {
+		keys := make([]string, 0, len(evt.Context.Attributes))
+		for k := range evt.Context.Attributes {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(&b, "   attr %s: %s\n", k, evt.Context.Attributes[k])
+		}
+	}
+
+	var payload bytes.Buffer
+	if err := json.Indent(&payload, evt.Payload, "   ", "  "); err != nil {
+		payload.Reset()
+		payload.Write(evt.Payload)
+	}
+	fmt.Fprintf(&b, "   payload: %s\n\n", payload.String())
+
+	return b.String()
+}
@@ -0,0 +1,125 @@
+package tail_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/bus/natstest"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/bus/tail"
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func publish(t *testing.T, h *natstest.Harness, eventType event.EventType, correlationID types.UUID) *event.Event {
+	t.Helper()
+
+	evt, err := event.NewEvent(event.EventInput{
+		EventType:     eventType,
+		EventVersion:  "v1.0.0",
+		Source:        "TestService",
+		CorrelationID: correlationID,
+		TraceID:       types.MustNewUUID(),
+		Payload:       []byte(`{"id":"abc"}`),
+	})
+	require.NoError(t, err, "Setup: failed to build test event")
+	require.NoError(t, h.Bus.Publish(context.Background(), &evt))
+
+	return &evt
+}
+
+// startTail runs the tail in the background and stops it when the test ends.
+func startTail(t *testing.T, h *natstest.Harness, opts tail.Options) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- tail.Run(ctx, h.JS, opts) }()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-done)
+	})
+}
+
+func TestRun(t *testing.T) {
+	t.Run("Success: JSON lines should contain only the events matching the filter", func(t *testing.T) {
+		h := natstest.Start(t)
+		wanted := types.MustNewUUID()
+		publish(t, h, "user.created", types.MustNewUUID())
+		matching := publish(t, h, "user.tested", wanted)
+
+		out := &syncBuffer{}
+		startTail(t, h, tail.Options{
+			Subjects: []string{"user.*"},
+			Filter:   tail.Filter{CorrelationID: types.NewValidNullableUUID(wanted)},
+			Format:   tail.FormatJSON,
+			All:      true,
+			Out:      out,
+		})
+
+		require.Eventually(t, func() bool { return out.String() != "" }, natstest.DefaultTimeout, 20*time.Millisecond)
+		time.Sleep(100 * time.Millisecond)
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 1, "Only the matching event should be printed")
+		var printed event.Event
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &printed))
+		assert.Equal(t, matching.Header.EventID, printed.Header.EventID)
+	})
+
+	t.Run("Success: new events should be pretty printed as they arrive", func(t *testing.T) {
+		h := natstest.Start(t)
+		out := &syncBuffer{}
+		startTail(t, h, tail.Options{
+			Subjects: []string{"user.created", "wallet.*"},
+			Format:   tail.FormatPretty,
+			Out:      out,
+		})
+
+		require.Eventually(t, func() bool {
+			publish(t, h, "user.created", types.MustNewUUID())
+			return strings.Contains(out.String(), "── user.created")
+		}, natstest.DefaultTimeout, 100*time.Millisecond)
+		assert.Contains(t, out.String(), `"id": "abc"`)
+		assert.Contains(t, out.String(), "identity-stream")
+	})
+
+	t.Run("Failure: subject without stream should be rejected", func(t *testing.T) {
+		h := natstest.Start(t)
+
+		err := tail.Run(context.Background(), h.JS, tail.Options{Subjects: []string{"unknown.*"}, Out: &syncBuffer{}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown.*")
+	})
+
+	t.Run("Failure: no subjects should be rejected", func(t *testing.T) {
+		h := natstest.Start(t)
+
+		err := tail.Run(context.Background(), h.JS, tail.Options{Out: &syncBuffer{}})
+		require.ErrorIs(t, err, tail.ErrNoSubjects)
+	})
+}
@@ -0,0 +1,147 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/config"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/oidc"
+)
+
+const (
+	ProviderGoogle      = "google"
+	DefaultGoogleIssuer = "https://accounts.google.com"
+	httpClientTimeout   = 10 * time.Second
+)
+
+// idTokenClaims are the profile claims read from the ID token; the
+// registered ones are checked by the verifier.
+type idTokenClaims struct {
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+}
+
+// Provider talks to an OpenID Connect issuer. Discovery runs on first use
+// and is retried until it succeeds, so the API starts even when the issuer
+// is unreachable.
+type Provider struct {
+	name   string
+	issuer string
+	oauth  oauth2.Config
+	client *http.Client
+
+	mu       sync.Mutex
+	endpoint *oauth2.Endpoint
+	verifier *gooidc.IDTokenVerifier
+}
+
+// NewGoogle signs users in with Google. It is disabled, not failing, while
+// the client ID is empty.
+func NewGoogle(cfg config.GoogleConfig) *Provider {
+	issuer := cfg.IssuerURL
+	if issuer == "" {
+		issuer = DefaultGoogleIssuer
+	}
+
+	return &Provider{
+		name:   ProviderGoogle,
+		issuer: issuer,
+		oauth: oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Scopes:       []string{gooidc.ScopeOpenID, "email", "profile"},
+		},
+		client: &http.Client{Timeout: httpClientTimeout},
+	}
+}
+
+func (p *Provider) Name() string {
+	return p.name
+}
+
+func (p *Provider) AuthURL(ctx context.Context, input oidc.AuthURLInput) (string, error) {
+	cfg, _, err := p.discover()
+	if err != nil {
+		return "", err
+	}
+
+	return cfg.AuthCodeURL(input.State,
+		gooidc.Nonce(input.Nonce),
+		oauth2.S256ChallengeOption(input.CodeVerifier),
+	), nil
+}
+
+func (p *Provider) Exchange(ctx context.Context, input oidc.ExchangeInput) (oidc.Identity, error) {
+	cfg, verifier, err := p.discover()
+	if err != nil {
+		return oidc.Identity{}, err
+	}
+
+	ctx = gooidc.ClientContext(ctx, p.client)
+
+	tok, err := cfg.Exchange(ctx, input.Code, oauth2.VerifierOption(input.CodeVerifier))
+	if err != nil {
+		return oidc.Identity{}, fmt.Errorf("%w: redeem code: %w", oidc.ErrExchange, err)
+	}
+
+	rawIDToken, ok := tok.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return oidc.Identity{}, fmt.Errorf("%w: token response has no id_token", oidc.ErrExchange)
+	}
+
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return oidc.Identity{}, fmt.Errorf("%w: verify id_token: %w", oidc.ErrExchange, err)
+	}
+	if idToken.Nonce != input.Nonce {
+		return oidc.Identity{}, fmt.Errorf("%w: id_token nonce mismatch", oidc.ErrExchange)
+	}
+
+	var claims idTokenClaims
+	if err := idToken.Claims(&claims); err != nil {
+		return oidc.Identity{}, fmt.Errorf("%w: decode id_token claims: %w", oidc.ErrExchange, err)
+	}
+
+	return oidc.Identity{
+		Subject:       idToken.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+	}, nil
+}
+
+// discover fetches the issuer metadata once. The key set keeps the
+// background context given here, so a cancelled request cannot break later
+// key refreshes.
+func (p *Provider) discover() (oauth2.Config, *gooidc.IDTokenVerifier, error) {
+	if p.oauth.ClientID == "" {
+		return oauth2.Config{}, nil, oidc.ErrNotConfigured
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.endpoint == nil {
+		provider, err := gooidc.NewProvider(gooidc.ClientContext(context.Background(), p.client), p.issuer)
+		if err != nil {
+			return oauth2.Config{}, nil, fmt.Errorf("%w: discover %s: %w", oidc.ErrExchange, p.issuer, err)
+		}
+		endpoint := provider.Endpoint()
+		p.endpoint = &endpoint
+		p.verifier = provider.Verifier(&gooidc.Config{ClientID: p.oauth.ClientID})
+	}
+
+	cfg := p.oauth
+	cfg.Endpoint = *p.endpoint
+	return cfg, p.verifier, nil
+}
+
+var _ oidc.Provider = (*Provider)(nil)
@@ -0,0 +1,183 @@
+package oidc_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/oidc"
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/oidc/oidctest"
+	"github.com/marcelofabianov/redtogreen/internal/platform/config"
+	platformOIDC "github.com/marcelofabianov/redtogreen/internal/platform/port/oidc"
+)
+
+const (
+	testClientID    = "client-123"
+	testRedirectURL = "http://localhost:8080/api/v1/identity/auth/google/callback"
+	testVerifier    = "verifier-verifier-verifier-verifier-verifier-0"
+)
+
+func newProvider(t *testing.T) (*oidc.Provider, *oidctest.Server) {
+	t.Helper()
+	server := oidctest.NewServer(t, testClientID)
+	provider := oidc.NewGoogle(config.GoogleConfig{
+		ClientID:     testClientID,
+		ClientSecret: "secret",
+		RedirectURL:  testRedirectURL,
+		IssuerURL:    server.URL,
+	})
+	return provider, server
+}
+
+// authorize follows the authorization URL the way the issuer would and
+// returns a code bound to its nonce and challenge.
+func authorize(t *testing.T, provider *oidc.Provider, server *oidctest.Server, grant oidctest.Grant) string {
+	t.Helper()
+	authURL, err := provider.AuthURL(context.Background(), platformOIDC.AuthURLInput{
+		State:        "state-1",
+		Nonce:        "nonce-1",
+		CodeVerifier: testVerifier,
+	})
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+	query := parsed.Query()
+
+	if grant.Nonce == "" {
+		grant.Nonce = query.Get("nonce")
+	}
+	grant.CodeChallenge = query.Get("code_challenge")
+	return server.IssueCode(grant)
+}
+
+func TestProvider_AuthURL(t *testing.T) {
+	t.Run("Success: should request the openid scopes with state, nonce and an S256 challenge", func(t *testing.T) {
+		provider, server := newProvider(t)
+
+		authURL, err := provider.AuthURL(context.Background(), platformOIDC.AuthURLInput{
+			State:        "state-1",
+			Nonce:        "nonce-1",
+			CodeVerifier: testVerifier,
+		})
+		require.NoError(t, err)
+
+		parsed, err := url.Parse(authURL)
+		require.NoError(t, err)
+		query := parsed.Query()
+
+		assert.Equal(t, server.URL+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+		assert.Equal(t, "code", query.Get("response_type"))
+		assert.Equal(t, testClientID, query.Get("client_id"))
+		assert.Equal(t, testRedirectURL, query.Get("redirect_uri"))
+		assert.Equal(t, "openid email profile", query.Get("scope"))
+		assert.Equal(t, "state-1", query.Get("state"))
+		assert.Equal(t, "nonce-1", query.Get("nonce"))
+		assert.Equal(t, "S256", query.Get("code_challenge_method"))
+		assert.NotEmpty(t, query.Get("code_challenge"))
+		assert.NotContains(t, authURL, testVerifier)
+	})
+
+	t.Run("Failure: should report not configured without a client ID", func(t *testing.T) {
+		provider := oidc.NewGoogle(config.GoogleConfig{})
+
+		_, err := provider.AuthURL(context.Background(), platformOIDC.AuthURLInput{State: "s"})
+
+		assert.ErrorIs(t, err, platformOIDC.ErrNotConfigured)
+	})
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	grant := oidctest.Grant{
+		Subject:       "google-sub-1",
+		Email:         "jane@example.com",
+		EmailVerified: true,
+		Name:          "Jane Doe",
+	}
+
+	t.Run("Success: should redeem the code and return the verified identity", func(t *testing.T) {
+		provider, server := newProvider(t)
+		code := authorize(t, provider, server, grant)
+
+		identity, err := provider.Exchange(context.Background(), platformOIDC.ExchangeInput{
+			Code:         code,
+			CodeVerifier: testVerifier,
+			Nonce:        "nonce-1",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, platformOIDC.Identity{
+			Subject:       "google-sub-1",
+			Email:         "jane@example.com",
+			EmailVerified: true,
+			Name:          "Jane Doe",
+		}, identity)
+		assert.Equal(t, oidc.ProviderGoogle, provider.Name())
+	})
+
+	t.Run("Failure: should reject a verifier that does not match the challenge", func(t *testing.T) {
+		provider, server := newProvider(t)
+		code := authorize(t, provider, server, grant)
+
+		_, err := provider.Exchange(context.Background(), platformOIDC.ExchangeInput{
+			Code:         code,
+			CodeVerifier: "another-verifier-another-verifier-another-00",
+			Nonce:        "nonce-1",
+		})
+
+		assert.ErrorIs(t, err, platformOIDC.ErrExchange)
+	})
+
+	t.Run("Failure: should reject a code redeemed twice", func(t *testing.T) {
+		provider, server := newProvider(t)
+		code := authorize(t, provider, server, grant)
+		input := platformOIDC.ExchangeInput{Code: code, CodeVerifier: testVerifier, Nonce: "nonce-1"}
+
+		_, err := provider.Exchange(context.Background(), input)
+		require.NoError(t, err)
+		_, err = provider.Exchange(context.Background(), input)
+
+		assert.ErrorIs(t, err, platformOIDC.ErrExchange)
+	})
+
+	t.Run("Failure: should reject an ID token with another nonce", func(t *testing.T) {
+		provider, server := newProvider(t)
+		replayed := grant
+		replayed.Nonce = "nonce-from-another-login"
+		code := authorize(t, provider, server, replayed)
+
+		_, err := provider.Exchange(context.Background(), platformOIDC.ExchangeInput{
+			Code:         code,
+			CodeVerifier: testVerifier,
+			Nonce:        "nonce-1",
+		})
+
+		assert.ErrorIs(t, err, platformOIDC.ErrExchange)
+	})
+
+	t.Run("Failure: should reject an ID token issued to another client", func(t *testing.T) {
+		provider, server := newProvider(t)
+		foreign := grant
+		foreign.Audience = "another-client"
+		code := authorize(t, provider, server, foreign)
+
+		_, err := provider.Exchange(context.Background(), platformOIDC.ExchangeInput{
+			Code:         code,
+			CodeVerifier: testVerifier,
+			Nonce:        "nonce-1",
+		})
+
+		assert.ErrorIs(t, err, platformOIDC.ErrExchange)
+	})
+
+	t.Run("Failure: should report not configured without a client ID", func(t *testing.T) {
+		provider := oidc.NewGoogle(config.GoogleConfig{})
+
+		_, err := provider.Exchange(context.Background(), platformOIDC.ExchangeInput{Code: "code"})
+
+		assert.ErrorIs(t, err, platformOIDC.ErrNotConfigured)
+	})
+}
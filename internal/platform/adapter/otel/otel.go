@@ -7,9 +7,11 @@ import (
 	"time"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/propagation"
+	metricsdk "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	tracesdk "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
@@ -54,12 +56,25 @@ func InitTracerProvider(cfg config.OtelConfig, logger *slog.Logger) (func(contex
 
 	otel.SetTracerProvider(tp)
 
+	metricExporter, err := otlpmetricgrpc.New(ctx, otlpmetricgrpc.WithGRPCConn(conn))
+	if err != nil {
+		logger.Error("Failed to create OTLP metric exporter", "error", err)
+		return nil, fmt.Errorf("failed to create OTLP metric exporter: %w", err)
+	}
+
+	mp := metricsdk.NewMeterProvider(
+		metricsdk.WithResource(resource),
+		metricsdk.WithReader(metricsdk.NewPeriodicReader(metricExporter)),
+	)
+
+	otel.SetMeterProvider(mp)
+
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
 		propagation.TraceContext{},
 		propagation.Baggage{},
 	))
 
-	logger.Info("OpenTelemetry TracerProvider initialized", "service_name", cfg.ServiceName, "endpoint", cfg.ExporterEndpoint)
+	logger.Info("OpenTelemetry TracerProvider and MeterProvider initialized", "service_name", cfg.ServiceName, "endpoint", cfg.ExporterEndpoint)
 
 	return func(ctx context.Context) error {
 		shutdownCtx, shutdownCancel := context.WithTimeout(ctx, 5*time.Second)
@@ -71,6 +86,12 @@ func InitTracerProvider(cfg config.OtelConfig, logger *slog.Logger) (func(contex
 			logger.Info("OpenTelemetry TracerProvider shut down successfully")
 		}
 
+		if err := mp.Shutdown(shutdownCtx); err != nil {
+			logger.Error("Error shutting down meter provider", "error", err)
+		} else {
+			logger.Info("OpenTelemetry MeterProvider shut down successfully")
+		}
+
 		if err := conn.Close(); err != nil {
 			logger.Error("Error closing OTLP gRPC client connection", "error", err)
 			return fmt.Errorf("error closing OTLP gRPC client connection: %w", err)
@@ -0,0 +1,204 @@
+package pii
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	platformPII "github.com/marcelofabianov/redtogreen/internal/platform/port/pii"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+const (
+	// ciphertextPrefix identifies an encrypted field value: pii:v1:<subject_id>:<base64(nonce|ciphertext)>
+	ciphertextPrefix = "pii:v1:"
+
+	ErrPayloadNotObject        = "Payload must be a JSON object to encrypt fields."
+	ErrInvalidCiphertext       = "Encrypted field value has an invalid format."
+	ErrSubjectIDRequired       = "Subject ID is required to encrypt payload fields."
+	ErrFailedToEncryptField    = "Failed to encrypt payload field."
+	ErrFailedToDecryptField    = "Failed to decrypt payload field."
+	ErrInvalidDataKeyLength    = "Data key must be 32 bytes long."
+	ErrDataKeyNotFound         = "Data key not found for subject."
+	ErrSubjectAlreadyForgotten = "Subject has been forgotten and can no longer be encrypted for."
+)
+
+type AESPayloadCipher struct {
+	keys platformPII.KeyStore
+}
+
+func NewAESPayloadCipher(keys platformPII.KeyStore) *AESPayloadCipher {
+	return &AESPayloadCipher{keys: keys}
+}
+
+var (
+	_ platformPII.PayloadCipher = (*AESPayloadCipher)(nil)
+	_ platformPII.Shredder      = (*AESPayloadCipher)(nil)
+)
+
+func (c *AESPayloadCipher) EncryptPayload(ctx context.Context, input platformPII.EncryptPayloadInput) (json.RawMessage, error) {
+	if len(input.Fields) == 0 {
+		return input.Payload, nil
+	}
+	if input.SubjectID.IsNil() {
+		return nil, msg.NewValidationError(nil, nil, ErrSubjectIDRequired)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(input.Payload, &fields); err != nil {
+		return nil, msg.NewValidationError(err, nil, ErrPayloadNotObject)
+	}
+
+	key, err := c.keys.GetOrCreateKey(ctx, input.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range input.Fields {
+		value, ok := fields[name]
+		if !ok || string(value) == "null" {
+			continue
+		}
+
+		nonce := make([]byte, aead.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return nil, msg.NewInternalError(err, map[string]any{"operation": ErrFailedToEncryptField, "field": name})
+		}
+
+		sealed := aead.Seal(nonce, nonce, value, additionalData(input.SubjectID, name))
+		encoded := ciphertextPrefix + input.SubjectID.String() + ":" + base64.RawStdEncoding.EncodeToString(sealed)
+
+		encrypted, err := json.Marshal(encoded)
+		if err != nil {
+			return nil, msg.NewInternalError(err, map[string]any{"operation": ErrFailedToEncryptField, "field": name})
+		}
+		fields[name] = encrypted
+	}
+
+	return json.Marshal(fields)
+}
+
+// DecryptPayload restores every encrypted top-level field of the payload. Fields
+// whose subject key was destroyed are replaced with null, so forgotten data stays
+// unreadable while the rest of the payload remains usable.
+func (c *AESPayloadCipher) DecryptPayload(ctx context.Context, payload json.RawMessage) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return payload, nil
+	}
+
+	aeads := make(map[types.UUID]cipher.AEAD)
+	changed := false
+
+	for name, value := range fields {
+		var encoded string
+		if err := json.Unmarshal(value, &encoded); err != nil || !IsEncrypted(encoded) {
+			continue
+		}
+
+		subjectID, sealed, err := parseCiphertext(encoded)
+		if err != nil {
+			return nil, err
+		}
+
+		aead, ok := aeads[subjectID]
+		if !ok {
+			key, err := c.keys.GetKey(ctx, subjectID)
+			if err != nil {
+				var msgErr *msg.MessageError
+				if errors.As(err, &msgErr) && msgErr.Code == msg.CodeNotFound {
+					aeads[subjectID] = nil
+					fields[name] = json.RawMessage("null")
+					changed = true
+					continue
+				}
+				return nil, err
+			}
+			aead, err = newAEAD(key)
+			if err != nil {
+				return nil, err
+			}
+			aeads[subjectID] = aead
+		}
+
+		if aead == nil {
+			fields[name] = json.RawMessage("null")
+			changed = true
+			continue
+		}
+
+		nonceSize := aead.NonceSize()
+		if len(sealed) < nonceSize {
+			return nil, msg.NewValidationError(nil, map[string]any{"field": name}, ErrInvalidCiphertext)
+		}
+
+		plaintext, err := aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], additionalData(subjectID, name))
+		if err != nil {
+			return nil, msg.NewValidationError(err, map[string]any{"field": name}, ErrFailedToDecryptField)
+		}
+		fields[name] = plaintext
+		changed = true
+	}
+
+	if !changed {
+		return payload, nil
+	}
+
+	return json.Marshal(fields)
+}
+
+func (c *AESPayloadCipher) ForgetSubject(ctx context.Context, subjectID types.UUID) error {
+	return c.keys.DestroyKey(ctx, subjectID)
+}
+
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, ciphertextPrefix)
+}
+
+func parseCiphertext(encoded string) (types.UUID, []byte, error) {
+	parts := strings.SplitN(strings.TrimPrefix(encoded, ciphertextPrefix), ":", 2)
+	if len(parts) != 2 {
+		return types.Nil, nil, msg.NewValidationError(nil, nil, ErrInvalidCiphertext)
+	}
+
+	subjectID, err := types.ParseUUID(parts[0])
+	if err != nil {
+		return types.Nil, nil, msg.NewValidationError(err, nil, ErrInvalidCiphertext)
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return types.Nil, nil, msg.NewValidationError(err, nil, ErrInvalidCiphertext)
+	}
+
+	return subjectID, sealed, nil
+}
+
+func additionalData(subjectID types.UUID, field string) []byte {
+	return []byte(fmt.Sprintf("%s:%s", subjectID.String(), field))
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, msg.NewInternalError(nil, map[string]any{"operation": ErrInvalidDataKeyLength, "length": len(key)})
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, msg.NewInternalError(err, nil)
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,140 @@
+package pii_test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/pii"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	platformPII "github.com/marcelofabianov/redtogreen/internal/platform/port/pii"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type inMemoryKeyStore struct {
+	keys map[types.UUID][]byte
+}
+
+func newInMemoryKeyStore() *inMemoryKeyStore {
+	return &inMemoryKeyStore{keys: make(map[types.UUID][]byte)}
+}
+
+func (s *inMemoryKeyStore) GetOrCreateKey(ctx context.Context, subjectID types.UUID) ([]byte, error) {
+	if key, ok := s.keys[subjectID]; ok {
+		return key, nil
+	}
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	s.keys[subjectID] = key
+	return key, nil
+}
+
+func (s *inMemoryKeyStore) GetKey(ctx context.Context, subjectID types.UUID) ([]byte, error) {
+	if key, ok := s.keys[subjectID]; ok {
+		return key, nil
+	}
+	return nil, msg.NewMessageError(nil, pii.ErrDataKeyNotFound, msg.CodeNotFound, nil)
+}
+
+func (s *inMemoryKeyStore) DestroyKey(ctx context.Context, subjectID types.UUID) error {
+	delete(s.keys, subjectID)
+	return nil
+}
+
+type samplePayload struct {
+	UserID types.UUID `json:"userId"`
+	Name   string     `json:"name"`
+	Email  string     `json:"email" pii:"true"`
+	Phone  string     `json:"phone" pii:"true"`
+}
+
+func TestAESPayloadCipher(t *testing.T) {
+	ctx := context.Background()
+	subjectID := types.MustNewUUID()
+	original := samplePayload{
+		UserID: subjectID,
+		Name:   "Crypto User",
+		Email:  "crypto@example.com",
+		Phone:  "+5562999998888",
+	}
+	originalBytes, err := json.Marshal(original)
+	require.NoError(t, err, "Setup: failed to marshal payload")
+
+	encryptInput := platformPII.EncryptPayloadInput{
+		SubjectID: subjectID,
+		Payload:   originalBytes,
+		Fields:    platformPII.SensitiveFields(original),
+	}
+
+	t.Run("Success: should encrypt only marked fields and decrypt them back", func(t *testing.T) {
+		c := pii.NewAESPayloadCipher(newInMemoryKeyStore())
+
+		encrypted, err := c.EncryptPayload(ctx, encryptInput)
+		require.NoError(t, err, "EncryptPayload should not return an error")
+
+		var encryptedPayload samplePayload
+		require.NoError(t, json.Unmarshal(encrypted, &encryptedPayload), "Encrypted payload should keep its shape")
+		assert.Equal(t, original.Name, encryptedPayload.Name, "Unmarked fields should stay in clear text")
+		assert.True(t, pii.IsEncrypted(encryptedPayload.Email), "Email should be encrypted")
+		assert.True(t, pii.IsEncrypted(encryptedPayload.Phone), "Phone should be encrypted")
+		assert.NotContains(t, string(encrypted), original.Email, "Clear text email should not leak")
+
+		decrypted, err := c.DecryptPayload(ctx, encrypted)
+		require.NoError(t, err, "DecryptPayload should not return an error")
+
+		var decryptedPayload samplePayload
+		require.NoError(t, json.Unmarshal(decrypted, &decryptedPayload), "Decrypted payload should be valid JSON")
+		assert.Equal(t, original, decryptedPayload, "Decrypted payload should match the original")
+	})
+
+	t.Run("Success: forgotten subject fields should decrypt to null", func(t *testing.T) {
+		c := pii.NewAESPayloadCipher(newInMemoryKeyStore())
+
+		encrypted, err := c.EncryptPayload(ctx, encryptInput)
+		require.NoError(t, err, "EncryptPayload should not return an error")
+
+		require.NoError(t, c.ForgetSubject(ctx, subjectID), "ForgetSubject should not return an error")
+
+		decrypted, err := c.DecryptPayload(ctx, encrypted)
+		require.NoError(t, err, "DecryptPayload should not fail for forgotten subjects")
+
+		var fields map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(decrypted, &fields), "Decrypted payload should be valid JSON")
+		assert.Equal(t, "null", string(fields["email"]), "Forgotten email should be null")
+		assert.Equal(t, "null", string(fields["phone"]), "Forgotten phone should be null")
+		assert.Equal(t, `"Crypto User"`, string(fields["name"]), "Unmarked fields should survive forgetting")
+	})
+
+	t.Run("Failure: tampered ciphertext should not decrypt", func(t *testing.T) {
+		c := pii.NewAESPayloadCipher(newInMemoryKeyStore())
+
+		encrypted, err := c.EncryptPayload(ctx, encryptInput)
+		require.NoError(t, err, "EncryptPayload should not return an error")
+
+		var fields map[string]string
+		require.NoError(t, json.Unmarshal(encrypted, &fields), "Setup: failed to read encrypted payload")
+		fields["phone"] = strings.Replace(fields["phone"], fields["phone"][len(fields["phone"])-4:], "AAAA", 1)
+		tampered, err := json.Marshal(fields)
+		require.NoError(t, err, "Setup: failed to marshal tampered payload")
+
+		_, err = c.DecryptPayload(ctx, tampered)
+		require.Error(t, err, "DecryptPayload should reject tampered ciphertext")
+
+		var msgErr *msg.MessageError
+		require.ErrorAs(t, err, &msgErr)
+		assert.Equal(t, msg.CodeInvalid, msgErr.Code, "Tampered ciphertext can never decrypt, so it should not be retried")
+	})
+
+	t.Run("Failure: should require a subject ID when fields are marked", func(t *testing.T) {
+		c := pii.NewAESPayloadCipher(newInMemoryKeyStore())
+
+		_, err := c.EncryptPayload(ctx, platformPII.EncryptPayloadInput{Payload: originalBytes, Fields: []string{"email"}})
+		require.Error(t, err, "EncryptPayload should fail without a subject ID")
+		assert.Contains(t, err.Error(), pii.ErrSubjectIDRequired, "Error should explain the missing subject")
+	})
+}
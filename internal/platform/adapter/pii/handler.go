@@ -0,0 +1,33 @@
+package pii
+
+import (
+	"context"
+	"errors"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	platformBus "github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+	platformPII "github.com/marcelofabianov/redtogreen/internal/platform/port/pii"
+)
+
+// DecryptingHandler wraps a subscriber that is authorized to read personal data,
+// handing it a copy of the event with the encrypted payload fields restored.
+// Ciphertext that can never decrypt fails permanently; other failures, such as
+// an unreachable key store, are left to the bus to retry.
+func DecryptingHandler(decrypter platformPII.PayloadDecrypter, next platformBus.EventHandler) platformBus.EventHandler {
+	return func(ctx context.Context, evt *event.Event) error {
+		payload, err := decrypter.DecryptPayload(ctx, evt.Payload)
+		if err != nil {
+			var msgErr *msg.MessageError
+			if errors.As(err, &msgErr) && msgErr.Code == msg.CodeInvalid {
+				return platformBus.Permanent(err)
+			}
+			return err
+		}
+
+		decrypted := *evt
+		decrypted.Payload = payload
+
+		return next(ctx, &decrypted)
+	}
+}
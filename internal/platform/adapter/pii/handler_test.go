@@ -0,0 +1,47 @@
+package pii_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/pii"
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	platformBus "github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+)
+
+type mockDecrypter struct {
+	err error
+}
+
+func (m *mockDecrypter) DecryptPayload(ctx context.Context, payload json.RawMessage) (json.RawMessage, error) {
+	return payload, m.err
+}
+
+func TestDecryptingHandler(t *testing.T) {
+	evt := &event.Event{Payload: []byte(`{"email":"enc:v1:..."}`)}
+	next := func(ctx context.Context, evt *event.Event) error { return nil }
+
+	t.Run("Failure: ciphertext that cannot decrypt should fail permanently", func(t *testing.T) {
+		decrypter := &mockDecrypter{err: msg.NewValidationError(nil, nil, pii.ErrFailedToDecryptField)}
+
+		err := pii.DecryptingHandler(decrypter, next)(context.Background(), evt)
+
+		require.Error(t, err)
+		assert.True(t, platformBus.IsPermanent(err))
+	})
+
+	t.Run("Failure: an unavailable key store should be left to retry", func(t *testing.T) {
+		decrypter := &mockDecrypter{err: msg.NewInternalError(errors.New("connection refused"), nil)}
+
+		err := pii.DecryptingHandler(decrypter, next)(context.Background(), evt)
+
+		require.Error(t, err)
+		assert.False(t, platformBus.IsPermanent(err))
+	})
+}
@@ -0,0 +1,145 @@
+package pii
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/database"
+	platformPII "github.com/marcelofabianov/redtogreen/internal/platform/port/pii"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+const (
+	dataKeyLength = 32
+
+	ErrInvalidMasterKey = "PII master key must be a base64 encoded 32 byte key."
+)
+
+// PostgresKeyStore keeps one data key per subject, wrapped with the master key.
+// Destroying a key keeps a tombstone row so a forgotten subject is never re-keyed.
+// Every method joins the transaction in ctx, so a key created for a command
+// that rolls back is rolled back with it, and a key is only gone once the purge
+// of its subject commits.
+type PostgresKeyStore struct {
+	db     database.DB
+	master []byte
+}
+
+func NewPostgresKeyStore(db database.DB, masterKey string) (*PostgresKeyStore, error) {
+	master, err := base64.StdEncoding.DecodeString(masterKey)
+	if err != nil || len(master) != dataKeyLength {
+		return nil, msg.NewValidationError(err, nil, ErrInvalidMasterKey)
+	}
+
+	return &PostgresKeyStore{db: db, master: master}, nil
+}
+
+var _ platformPII.KeyStore = (*PostgresKeyStore)(nil)
+
+func (s *PostgresKeyStore) GetOrCreateKey(ctx context.Context, subjectID types.UUID) ([]byte, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	key := make([]byte, dataKeyLength)
+	if _, err := rand.Read(key); err != nil {
+		return nil, msg.NewInternalError(err, nil)
+	}
+
+	wrapped, err := s.wrap(subjectID, key)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO subject_keys (subject_id, encrypted_key, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (subject_id) DO NOTHING
+	`
+	if _, err := database.ExecutorFrom(ctx, s.db).ExecContext(queryCtx, query, subjectID, wrapped, time.Now().UTC()); err != nil {
+		return nil, msg.NewInternalError(err, map[string]any{"subject_id": subjectID.String()})
+	}
+
+	key, err = s.GetKey(ctx, subjectID)
+	if err != nil {
+		var msgErr *msg.MessageError
+		if errors.As(err, &msgErr) && msgErr.Code == msg.CodeNotFound {
+			return nil, msg.NewMessageError(nil, ErrSubjectAlreadyForgotten, msg.CodeConflict, map[string]any{"subject_id": subjectID.String()})
+		}
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func (s *PostgresKeyStore) GetKey(ctx context.Context, subjectID types.UUID) ([]byte, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `SELECT encrypted_key FROM subject_keys WHERE subject_id = $1 AND destroyed_at IS NULL`
+
+	var wrapped []byte
+	err := database.ExecutorFrom(ctx, s.db).QueryRowContext(queryCtx, query, subjectID).Scan(&wrapped)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, msg.NewMessageError(err, ErrDataKeyNotFound, msg.CodeNotFound, map[string]any{"subject_id": subjectID.String()})
+		}
+		return nil, msg.NewInternalError(err, map[string]any{"subject_id": subjectID.String()})
+	}
+
+	return s.unwrap(subjectID, wrapped)
+}
+
+func (s *PostgresKeyStore) DestroyKey(ctx context.Context, subjectID types.UUID) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO subject_keys (subject_id, encrypted_key, created_at, destroyed_at)
+		VALUES ($1, NULL, $2, $2)
+		ON CONFLICT (subject_id) DO UPDATE
+		SET encrypted_key = NULL, destroyed_at = COALESCE(subject_keys.destroyed_at, EXCLUDED.destroyed_at)
+	`
+	if _, err := database.ExecutorFrom(ctx, s.db).ExecContext(queryCtx, query, subjectID, time.Now().UTC()); err != nil {
+		return msg.NewInternalError(err, map[string]any{"subject_id": subjectID.String()})
+	}
+
+	return nil
+}
+
+func (s *PostgresKeyStore) wrap(subjectID types.UUID, key []byte) ([]byte, error) {
+	aead, err := newAEAD(s.master)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, msg.NewInternalError(err, nil)
+	}
+
+	return aead.Seal(nonce, nonce, key, []byte(subjectID.String())), nil
+}
+
+func (s *PostgresKeyStore) unwrap(subjectID types.UUID, wrapped []byte) ([]byte, error) {
+	aead, err := newAEAD(s.master)
+	if err != nil {
+		return nil, err
+	}
+
+	nonceSize := aead.NonceSize()
+	if len(wrapped) < nonceSize {
+		return nil, msg.NewInternalError(nil, map[string]any{"subject_id": subjectID.String()})
+	}
+
+	key, err := aead.Open(nil, wrapped[:nonceSize], wrapped[nonceSize:], []byte(subjectID.String()))
+	if err != nil {
+		return nil, msg.NewInternalError(err, map[string]any{"subject_id": subjectID.String()})
+	}
+
+	return key, nil
+}
@@ -0,0 +1,157 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/config"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/token"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+// MinSecretLength is the minimum HS256 key size, matching the hash output.
+const MinSecretLength = 32
+
+var (
+	ErrSecretTooShort  = fmt.Errorf("jwt secret must have at least %d bytes", MinSecretLength)
+	ErrInvalidExpiry   = errors.New("jwt expiry must be greater than zero")
+	ErrSubjectRequired = errors.New("jwt subject is required")
+)
+
+// accessClaims adds the tenant and permissions claims to the registered ones.
+type accessClaims struct {
+	jwt.RegisteredClaims
+	TenantID    string   `json:"tid,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+// JWT signs and verifies access tokens with HS256 using the shared secret.
+type JWT struct {
+	secret   []byte
+	issuer   string
+	audience string
+	expiry   time.Duration
+	now      func() time.Time
+}
+
+func NewJWT(cfg config.JWTConfig) (*JWT, error) {
+	if len(cfg.Secret) < MinSecretLength {
+		return nil, ErrSecretTooShort
+	}
+	if cfg.ExpiryMinutes <= 0 {
+		return nil, ErrInvalidExpiry
+	}
+
+	return &JWT{
+		secret:   []byte(cfg.Secret),
+		issuer:   cfg.Issuer,
+		audience: cfg.Audience,
+		expiry:   time.Duration(cfg.ExpiryMinutes) * time.Minute,
+		now:      time.Now,
+	}, nil
+}
+
+func (i *JWT) Issue(ctx context.Context, input token.IssueInput) (token.AccessToken, error) {
+	if input.Subject.IsNil() {
+		return token.AccessToken{}, ErrSubjectRequired
+	}
+
+	id, err := types.NewUUID()
+	if err != nil {
+		return token.AccessToken{}, fmt.Errorf("failed to generate jwt id: %w", err)
+	}
+
+	issuedAt := i.now().Truncate(time.Second)
+	claims := token.Claims{
+		ID:          id.String(),
+		Subject:     input.Subject,
+		TenantID:    input.TenantID,
+		Issuer:      i.issuer,
+		IssuedAt:    issuedAt,
+		NotBefore:   issuedAt,
+		ExpiresAt:   issuedAt.Add(i.expiry),
+		Permissions: input.Permissions,
+	}
+	if i.audience != "" {
+		claims.Audience = []string{i.audience}
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        claims.ID,
+			Subject:   claims.Subject.String(),
+			Issuer:    claims.Issuer,
+			Audience:  claims.Audience,
+			IssuedAt:  jwt.NewNumericDate(claims.IssuedAt),
+			NotBefore: jwt.NewNumericDate(claims.NotBefore),
+			ExpiresAt: jwt.NewNumericDate(claims.ExpiresAt),
+		},
+		TenantID:    claims.TenantID,
+		Permissions: claims.Permissions,
+	}).SignedString(i.secret)
+	if err != nil {
+		return token.AccessToken{}, fmt.Errorf("failed to sign jwt: %w", err)
+	}
+
+	return token.AccessToken{
+		Value:     signed,
+		Type:      token.TypeBearer,
+		ExpiresAt: claims.ExpiresAt,
+		Claims:    claims,
+	}, nil
+}
+
+func (i *JWT) Verify(ctx context.Context, value string) (token.Claims, error) {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithTimeFunc(i.now),
+	}
+	if i.issuer != "" {
+		options = append(options, jwt.WithIssuer(i.issuer))
+	}
+	if i.audience != "" {
+		options = append(options, jwt.WithAudience(i.audience))
+	}
+
+	var parsed accessClaims
+	_, err := jwt.ParseWithClaims(value, &parsed, func(*jwt.Token) (any, error) {
+		return i.secret, nil
+	}, options...)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return token.Claims{}, fmt.Errorf("%w: %v", token.ErrExpired, err)
+		}
+		return token.Claims{}, fmt.Errorf("%w: %v", token.ErrInvalid, err)
+	}
+
+	registered := parsed.RegisteredClaims
+	subject, err := types.ParseUUID(registered.Subject)
+	if err != nil || subject.IsNil() {
+		return token.Claims{}, fmt.Errorf("%w: subject is not a user id", token.ErrInvalid)
+	}
+
+	return token.Claims{
+		ID:          registered.ID,
+		Subject:     subject,
+		TenantID:    parsed.TenantID,
+		Issuer:      registered.Issuer,
+		Audience:    registered.Audience,
+		IssuedAt:    numericTime(registered.IssuedAt),
+		NotBefore:   numericTime(registered.NotBefore),
+		ExpiresAt:   numericTime(registered.ExpiresAt),
+		Permissions: parsed.Permissions,
+	}, nil
+}
+
+func numericTime(d *jwt.NumericDate) time.Time {
+	if d == nil {
+		return time.Time{}
+	}
+	return d.Time
+}
@@ -0,0 +1,189 @@
+package token_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/token"
+	"github.com/marcelofabianov/redtogreen/internal/platform/config"
+	platformToken "github.com/marcelofabianov/redtogreen/internal/platform/port/token"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+const testSecret = "test-secret-with-at-least-thirty-two-bytes"
+
+func TestJWT_Issue(t *testing.T) {
+	cfg := config.JWTConfig{
+		Secret:        testSecret,
+		ExpiryMinutes: 15,
+		Issuer:        "redtogreen",
+		Audience:      "redtogreen-api",
+	}
+
+	t.Run("Success: should sign an HS256 token with the registered claims", func(t *testing.T) {
+		issuer, err := token.NewJWT(cfg)
+		require.NoError(t, err)
+		subject := types.MustNewUUID()
+
+		accessToken, err := issuer.Issue(context.Background(), platformToken.IssueInput{Subject: subject})
+		require.NoError(t, err)
+
+		assert.Equal(t, platformToken.TypeBearer, accessToken.Type)
+		assert.Equal(t, 15*time.Minute, accessToken.ExpiresAt.Sub(accessToken.Claims.IssuedAt))
+
+		var claims jwt.RegisteredClaims
+		parsed, err := jwt.ParseWithClaims(accessToken.Value, &claims, func(t *jwt.Token) (any, error) {
+			return []byte(testSecret), nil
+		}, jwt.WithValidMethods([]string{"HS256"}), jwt.WithIssuer("redtogreen"), jwt.WithAudience("redtogreen-api"))
+		require.NoError(t, err)
+		require.True(t, parsed.Valid)
+
+		assert.Equal(t, subject.String(), claims.Subject)
+		assert.Equal(t, accessToken.Claims.ID, claims.ID)
+		assert.NotEmpty(t, claims.ID, "Every token should have its own jti")
+		assert.Equal(t, accessToken.ExpiresAt.Unix(), claims.ExpiresAt.Unix())
+	})
+
+	t.Run("Success: should give each token a distinct id", func(t *testing.T) {
+		issuer, err := token.NewJWT(cfg)
+		require.NoError(t, err)
+		subject := types.MustNewUUID()
+
+		first, err := issuer.Issue(context.Background(), platformToken.IssueInput{Subject: subject})
+		require.NoError(t, err)
+		second, err := issuer.Issue(context.Background(), platformToken.IssueInput{Subject: subject})
+		require.NoError(t, err)
+
+		assert.NotEqual(t, first.Claims.ID, second.Claims.ID)
+	})
+
+	t.Run("Failure: should reject a nil subject", func(t *testing.T) {
+		issuer, err := token.NewJWT(cfg)
+		require.NoError(t, err)
+
+		_, err = issuer.Issue(context.Background(), platformToken.IssueInput{})
+		assert.ErrorIs(t, err, token.ErrSubjectRequired)
+	})
+}
+
+func TestNewJWT(t *testing.T) {
+	t.Run("Failure: should reject a short secret", func(t *testing.T) {
+		_, err := token.NewJWT(config.JWTConfig{Secret: "short", ExpiryMinutes: 15})
+		assert.ErrorIs(t, err, token.ErrSecretTooShort)
+	})
+
+	t.Run("Failure: should reject a non-positive expiry", func(t *testing.T) {
+		_, err := token.NewJWT(config.JWTConfig{Secret: testSecret})
+		assert.ErrorIs(t, err, token.ErrInvalidExpiry)
+	})
+}
+
+func TestJWT_Verify(t *testing.T) {
+	cfg := config.JWTConfig{
+		Secret:        testSecret,
+		ExpiryMinutes: 60,
+		Issuer:        "redtogreen",
+		Audience:      "redtogreen-api",
+	}
+	issuer, err := token.NewJWT(cfg)
+	require.NoError(t, err)
+
+	sign := func(t *testing.T, claims jwt.RegisteredClaims, secret string) string {
+		t.Helper()
+		signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+		require.NoError(t, err)
+		return signed
+	}
+	validClaims := func() jwt.RegisteredClaims {
+		now := time.Now()
+		return jwt.RegisteredClaims{
+			Subject:   types.MustNewUUID().String(),
+			Issuer:    "redtogreen",
+			Audience:  jwt.ClaimStrings{"redtogreen-api"},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		}
+	}
+
+	t.Run("Success: should return the claims of an issued token", func(t *testing.T) {
+		subject := types.MustNewUUID()
+		accessToken, err := issuer.Issue(context.Background(), platformToken.IssueInput{Subject: subject})
+		require.NoError(t, err)
+
+		claims, err := issuer.Verify(context.Background(), accessToken.Value)
+
+		require.NoError(t, err)
+		assert.Equal(t, subject, claims.Subject)
+		assert.Equal(t, accessToken.Claims.ID, claims.ID)
+		assert.True(t, accessToken.ExpiresAt.Equal(claims.ExpiresAt))
+		assert.Empty(t, claims.Permissions)
+	})
+
+	t.Run("Success: should carry the permissions of the subject", func(t *testing.T) {
+		permissions := []string{"users:read", "users:archive"}
+		accessToken, err := issuer.Issue(context.Background(), platformToken.IssueInput{
+			Subject:     types.MustNewUUID(),
+			Permissions: permissions,
+		})
+		require.NoError(t, err)
+
+		claims, err := issuer.Verify(context.Background(), accessToken.Value)
+
+		require.NoError(t, err)
+		assert.Equal(t, permissions, claims.Permissions)
+		assert.True(t, claims.HasPermission("users:archive"))
+		assert.False(t, claims.HasPermission("users:delete"))
+	})
+
+	t.Run("Success: should carry the tenant of the subject", func(t *testing.T) {
+		accessToken, err := issuer.Issue(context.Background(), platformToken.IssueInput{
+			Subject:  types.MustNewUUID(),
+			TenantID: "tenant-a",
+		})
+		require.NoError(t, err)
+
+		claims, err := issuer.Verify(context.Background(), accessToken.Value)
+
+		require.NoError(t, err)
+		assert.Equal(t, "tenant-a", claims.TenantID)
+	})
+
+	t.Run("Failure: should report expired tokens", func(t *testing.T) {
+		claims := validClaims()
+		claims.IssuedAt = jwt.NewNumericDate(time.Now().Add(-2 * time.Hour))
+		claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+
+		_, err := issuer.Verify(context.Background(), sign(t, claims, testSecret))
+
+		assert.ErrorIs(t, err, platformToken.ErrExpired)
+	})
+
+	t.Run("Failure: should reject invalid tokens", func(t *testing.T) {
+		wrongAudience := validClaims()
+		wrongAudience.Audience = jwt.ClaimStrings{"other-api"}
+		noExpiry := validClaims()
+		noExpiry.ExpiresAt = nil
+		badSubject := validClaims()
+		badSubject.Subject = "admin"
+		unsigned, err := jwt.NewWithClaims(jwt.SigningMethodNone, validClaims()).SignedString(jwt.UnsafeAllowNoneSignatureType)
+		require.NoError(t, err)
+
+		cases := map[string]string{
+			"malformed":      "not-a-jwt",
+			"wrong secret":   sign(t, validClaims(), "another-secret-with-thirty-two-bytes!!"),
+			"wrong audience": sign(t, wrongAudience, testSecret),
+			"no expiry":      sign(t, noExpiry, testSecret),
+			"bad subject":    sign(t, badSubject, testSecret),
+			"alg none":       unsigned,
+		}
+		for name, value := range cases {
+			_, err := issuer.Verify(context.Background(), value)
+			assert.ErrorIs(t, err, platformToken.ErrInvalid, name)
+		}
+	})
+}
@@ -0,0 +1,164 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/token"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+const (
+	HeaderAuthorization   = "Authorization"
+	HeaderWWWAuthenticate = "WWW-Authenticate"
+
+	AuthClaimsCtxKey contextKey = "authClaims"
+)
+
+const (
+	ErrAuthTokenRequired = "Authentication is required."
+	ErrAuthTokenInvalid  = "The access token is invalid."
+	ErrAuthTokenExpired  = "The access token has expired."
+	ErrAuthForbidden     = "You are not allowed to perform this action."
+	ErrAuthTenant        = "The tenant of the request does not match the access token."
+)
+
+// Authenticator turns bearer tokens into the authenticated user of the
+// request. Routers opt in per route group: Require for protected routes and
+// Optional for public ones that still record the author when a token is sent.
+// A TenantIDHeader that differs from the tenant of the token is forbidden.
+type Authenticator struct {
+	verifier token.Verifier
+}
+
+func NewAuthenticator(verifier token.Verifier) *Authenticator {
+	return &Authenticator{verifier: verifier}
+}
+
+// Require rejects requests without a valid bearer token.
+func (a *Authenticator) Require(next http.Handler) http.Handler {
+	return a.middleware(next, true)
+}
+
+// Optional authenticates requests that carry a bearer token and lets the rest
+// through anonymously. A token that is sent but invalid is still rejected.
+func (a *Authenticator) Optional(next http.Handler) http.Handler {
+	return a.middleware(next, false)
+}
+
+func (a *Authenticator) middleware(next http.Handler, required bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, ok := bearerToken(r)
+		if !ok {
+			if required {
+				respondUnauthorized(w, r, "", ErrAuthTokenRequired, nil)
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		claims, err := a.verifier.Verify(r.Context(), raw)
+		if err != nil {
+			message := ErrAuthTokenInvalid
+			if errors.Is(err, token.ErrExpired) {
+				message = ErrAuthTokenExpired
+			}
+			respondUnauthorized(w, r, "invalid_token", message, err)
+			return
+		}
+
+		// Tokens of a deployment without tenants carry none; the header is
+		// then ignored, as the scope never takes its tenant from it.
+		if tenantID := r.Header.Get(TenantIDHeader); tenantID != "" && claims.TenantID != "" && tenantID != claims.TenantID {
+			RespondError(w, r, msg.NewMessageError(nil, ErrAuthTenant, msg.CodeForbidden, map[string]any{
+				"tenant_id": tenantID,
+			}))
+			return
+		}
+
+		trace.SpanFromContext(r.Context()).SetAttributes(attribute.String("enduser.id", claims.Subject.String()))
+		next.ServeHTTP(w, r.WithContext(WithAuthClaims(r.Context(), claims)))
+	})
+}
+
+// WithAuthClaims stores the claims and makes their subject the author, and
+// their tenant the tenant, of the commands and events handled with ctx.
+func WithAuthClaims(ctx context.Context, claims token.Claims) context.Context {
+	scope := event.ScopeFromContext(ctx)
+	scope.TenantID = claims.TenantID
+	scope.SubjectID = claims.Subject.String()
+	ctx = event.WithScope(ctx, scope)
+	ctx = context.WithValue(ctx, AuthClaimsCtxKey, claims)
+	ctx = context.WithValue(ctx, UserAuthorIDCtxKey, types.NewValidNullableUUID(claims.Subject))
+	return context.WithValue(ctx, LoggerCtxKey, GetLogger(ctx).With("user_id", claims.Subject.String()))
+}
+
+func GetAuthClaims(ctx context.Context) (token.Claims, bool) {
+	claims, ok := ctx.Value(AuthClaimsCtxKey).(token.Claims)
+	return claims, ok
+}
+
+// RequirePermission rejects requests whose access token does not grant
+// permission. It runs after Authenticator.Require, which supplies the claims.
+func RequirePermission(permission string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := GetAuthClaims(r.Context())
+			if !ok {
+				respondUnauthorized(w, r, "", ErrAuthTokenRequired, nil)
+				return
+			}
+			if !claims.HasPermission(permission) {
+				RespondError(w, r, msg.NewMessageError(nil, ErrAuthForbidden, msg.CodeForbidden, map[string]any{
+					"permission": permission,
+				}))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequirePermissionOrSelf lets users act on their own resource, identified by
+// the userIDParam route parameter, and requires permission for anyone else's.
+func RequirePermissionOrSelf(permission, userIDParam string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		guarded := RequirePermission(permission)(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := GetAuthClaims(r.Context())
+			if ok && chi.URLParam(r, userIDParam) == claims.Subject.String() {
+				next.ServeHTTP(w, r)
+				return
+			}
+			guarded.ServeHTTP(w, r)
+		})
+	}
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, value, found := strings.Cut(r.Header.Get(HeaderAuthorization), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	value = strings.TrimSpace(value)
+	return value, value != ""
+}
+
+// respondUnauthorized adds the RFC 6750 challenge to the 401 response.
+func respondUnauthorized(w http.ResponseWriter, r *http.Request, code, message string, err error) {
+	challenge := `Bearer`
+	if code != "" {
+		challenge += ` error="` + code + `"`
+	}
+	w.Header().Set(HeaderWWWAuthenticate, challenge)
+	RespondError(w, r, msg.NewMessageError(err, message, msg.CodeUnauthorized, nil))
+}
@@ -0,0 +1,237 @@
+package web_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/web"
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/token"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type mockVerifier struct {
+	VerifyFunc func(ctx context.Context, value string) (token.Claims, error)
+}
+
+func (m *mockVerifier) Verify(ctx context.Context, value string) (token.Claims, error) {
+	return m.VerifyFunc(ctx, value)
+}
+
+func TestAuthenticator(t *testing.T) {
+	subject := types.MustNewUUID()
+	verifier := &mockVerifier{
+		VerifyFunc: func(ctx context.Context, value string) (token.Claims, error) {
+			switch value {
+			case "good":
+				return token.Claims{ID: "jti", Subject: subject}, nil
+			case "tenant-a":
+				return token.Claims{ID: "jti", Subject: subject, TenantID: "tenant-a"}, nil
+			case "expired":
+				return token.Claims{}, fmt.Errorf("%w: exp", token.ErrExpired)
+			default:
+				return token.Claims{}, fmt.Errorf("%w: signature", token.ErrInvalid)
+			}
+		},
+	}
+	auth := web.NewAuthenticator(verifier)
+
+	var author types.NullableUUID
+	var claims token.Claims
+	var authenticated bool
+	var scope event.Scope
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		author = web.GetUserAuthorID(r.Context())
+		claims, authenticated = web.GetAuthClaims(r.Context())
+		scope = event.ScopeFromContext(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	serveWithTenant := func(middleware func(http.Handler) http.Handler, authorization, tenantID string) *httptest.ResponseRecorder {
+		author, claims, authenticated, scope = types.NewNullUUID(), token.Claims{}, false, event.Scope{}
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if authorization != "" {
+			r.Header.Set(web.HeaderAuthorization, authorization)
+		}
+		if tenantID != "" {
+			r.Header.Set(web.TenantIDHeader, tenantID)
+		}
+		w := httptest.NewRecorder()
+		web.EventScopeMiddleware(middleware(next)).ServeHTTP(w, r)
+		return w
+	}
+	serve := func(middleware func(http.Handler) http.Handler, authorization string) *httptest.ResponseRecorder {
+		return serveWithTenant(middleware, authorization, "")
+	}
+
+	t.Run("Success: should store the authenticated user as the author", func(t *testing.T) {
+		w := serve(auth.Require, "Bearer good")
+
+		require.Equal(t, http.StatusNoContent, w.Code)
+		id, ok := author.GetUUID()
+		require.True(t, ok)
+		assert.Equal(t, subject, id)
+		assert.True(t, authenticated)
+		assert.Equal(t, "jti", claims.ID)
+	})
+
+	t.Run("Success: should accept the scheme in any case", func(t *testing.T) {
+		w := serve(auth.Require, "bearer good")
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("Failure: should require a token", func(t *testing.T) {
+		for _, authorization := range []string{"", "Basic dXNlcjpwYXNz", "Bearer "} {
+			w := serve(auth.Require, authorization)
+
+			assert.Equal(t, http.StatusUnauthorized, w.Code, authorization)
+			assert.Equal(t, "Bearer", w.Header().Get(web.HeaderWWWAuthenticate))
+			assert.Contains(t, w.Body.String(), web.ErrAuthTokenRequired)
+		}
+	})
+
+	t.Run("Failure: should reject invalid and expired tokens", func(t *testing.T) {
+		w := serve(auth.Require, "Bearer forged")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, `Bearer error="invalid_token"`, w.Header().Get(web.HeaderWWWAuthenticate))
+		assert.Contains(t, w.Body.String(), web.ErrAuthTokenInvalid)
+
+		w = serve(auth.Require, "Bearer expired")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), web.ErrAuthTokenExpired)
+	})
+
+	t.Run("Success: optional should let anonymous requests through", func(t *testing.T) {
+		w := serve(auth.Optional, "")
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.False(t, author.Valid, "Anonymous requests have no author")
+		assert.False(t, authenticated)
+	})
+
+	t.Run("Success: optional should authenticate when a token is sent", func(t *testing.T) {
+		w := serve(auth.Optional, "Bearer good")
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.True(t, author.Valid)
+	})
+
+	t.Run("Failure: optional should still reject an invalid token", func(t *testing.T) {
+		w := serve(auth.Optional, "Bearer forged")
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Success: should scope events to the tenant of the token", func(t *testing.T) {
+		w := serveWithTenant(auth.Require, "Bearer tenant-a", "tenant-a")
+
+		require.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "tenant-a", scope.TenantID)
+
+		w = serve(auth.Require, "Bearer tenant-a")
+		require.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "tenant-a", scope.TenantID, "The header is optional")
+	})
+
+	t.Run("Success: should not trust the tenant header of anonymous requests", func(t *testing.T) {
+		w := serveWithTenant(auth.Optional, "", "tenant-b")
+
+		require.Equal(t, http.StatusNoContent, w.Code)
+		assert.Empty(t, scope.TenantID)
+	})
+
+	t.Run("Success: should ignore the tenant header when the token has no tenant", func(t *testing.T) {
+		w := serveWithTenant(auth.Require, "Bearer good", "tenant-b")
+
+		require.Equal(t, http.StatusNoContent, w.Code)
+		assert.Empty(t, scope.TenantID, "The header should not set the tenant")
+	})
+
+	t.Run("Failure: should forbid a tenant header that does not match the token", func(t *testing.T) {
+		w := serveWithTenant(auth.Require, "Bearer tenant-a", "tenant-b")
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), web.ErrAuthTenant)
+	})
+}
+
+func TestRequirePermission(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	handler := web.RequirePermission("users:archive")(next)
+
+	serve := func(ctx context.Context) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPatch, "/", nil).WithContext(ctx)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+	withPermissions := func(permissions ...string) context.Context {
+		return web.WithAuthClaims(context.Background(), token.Claims{Subject: types.MustNewUUID(), Permissions: permissions})
+	}
+
+	t.Run("Success: should let through a token granting the permission", func(t *testing.T) {
+		w := serve(withPermissions("users:read", "users:archive"))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("Failure: should forbid a token without the permission", func(t *testing.T) {
+		w := serve(withPermissions("users:read"))
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), web.ErrAuthForbidden)
+		assert.Contains(t, w.Body.String(), "users:archive")
+	})
+
+	t.Run("Failure: should reject unauthenticated requests", func(t *testing.T) {
+		w := serve(context.Background())
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, "Bearer", w.Header().Get(web.HeaderWWWAuthenticate))
+	})
+}
+
+func TestRequirePermissionOrSelf(t *testing.T) {
+	self := types.MustNewUUID()
+	router := chi.NewRouter()
+	router.With(web.RequirePermissionOrSelf("users:read", "userID")).Get("/users/{userID}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	serve := func(userID types.UUID, permissions ...string) *httptest.ResponseRecorder {
+		ctx := web.WithAuthClaims(context.Background(), token.Claims{Subject: self, Permissions: permissions})
+		r := httptest.NewRequest(http.MethodGet, "/users/"+userID.String(), nil).WithContext(ctx)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("Success: should let users act on themselves without the permission", func(t *testing.T) {
+		w := serve(self)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("Success: should let through another user with the permission", func(t *testing.T) {
+		w := serve(types.MustNewUUID(), "users:read")
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("Failure: should forbid another user without the permission", func(t *testing.T) {
+		w := serve(types.MustNewUUID())
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "users:read")
+	})
+}
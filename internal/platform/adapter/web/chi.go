@@ -21,6 +21,7 @@ func NewPlatformRouter(cfg *config.AppConfig, logger *slog.Logger) *chi.Mux {
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
 	r.Use(SlogLoggerMiddleware(logger))
+	r.Use(EventScopeMiddleware)
 	r.Use(middleware.Heartbeat("/ping"))
 
 	r.Use(middleware.RequestSize(MaxBodySize))
@@ -0,0 +1,41 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+)
+
+const (
+	HeaderETag    = "ETag"
+	HeaderIfMatch = "If-Match"
+
+	ErrInvalidIfMatch = "If-Match must be a single strong ETag or *."
+)
+
+// ETag formats a resource version as a strong entity tag.
+func ETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// IfMatchVersion returns the version required by the If-Match header, or 0
+// when the header is absent or "*". Weak tags and tag lists are rejected
+// since the version check is a single strong comparison.
+func IfMatchVersion(r *http.Request) (int, error) {
+	raw := strings.TrimSpace(r.Header.Get(HeaderIfMatch))
+	if raw == "" || raw == "*" {
+		return 0, nil
+	}
+
+	if len(raw) < 3 || raw[0] != '"' || raw[len(raw)-1] != '"' {
+		return 0, msg.NewValidationError(nil, map[string]any{"header": HeaderIfMatch}, ErrInvalidIfMatch)
+	}
+
+	version, err := strconv.Atoi(raw[1 : len(raw)-1])
+	if err != nil || version < 1 {
+		return 0, msg.NewValidationError(err, map[string]any{"header": HeaderIfMatch}, ErrInvalidIfMatch)
+	}
+	return version, nil
+}
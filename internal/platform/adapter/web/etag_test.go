@@ -0,0 +1,46 @@
+package web_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/web"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+)
+
+func TestIfMatchVersion(t *testing.T) {
+	t.Run("Success: should parse the version of an ETag", func(t *testing.T) {
+		r := httptest.NewRequest("PUT", "/", nil)
+		r.Header.Set(web.HeaderIfMatch, web.ETag(7))
+
+		version, err := web.IfMatchVersion(r)
+		require.NoError(t, err)
+		assert.Equal(t, 7, version)
+	})
+
+	t.Run("Success: should not require a version without a precondition", func(t *testing.T) {
+		for _, header := range []string{"", "*"} {
+			r := httptest.NewRequest("PUT", "/", nil)
+			r.Header.Set(web.HeaderIfMatch, header)
+
+			version, err := web.IfMatchVersion(r)
+			require.NoError(t, err)
+			assert.Zero(t, version)
+		}
+	})
+
+	t.Run("Failure: should reject weak, listed or malformed tags", func(t *testing.T) {
+		for _, header := range []string{`W/"3"`, `"3", "4"`, `3`, `"abc"`, `"0"`} {
+			r := httptest.NewRequest("PUT", "/", nil)
+			r.Header.Set(web.HeaderIfMatch, header)
+
+			_, err := web.IfMatchVersion(r)
+			var msgErr *msg.MessageError
+			require.ErrorAs(t, err, &msgErr, header)
+			assert.Equal(t, msg.CodeInvalid, msgErr.Code)
+		}
+	})
+}
@@ -0,0 +1,64 @@
+package web
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+)
+
+const (
+	TenantIDHeader       = "X-Tenant-ID"
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	RequestIDAttribute      = event.RequestIDAttribute
+	IdempotencyKeyAttribute = event.IdempotencyKeyAttribute
+)
+
+// EventScopeMiddleware stores the request attributes in the request context,
+// so events published while handling the request carry them. The tenant is
+// not taken from TenantIDHeader: the Authenticator sets it from the claims.
+// Requests with an idempotency key also get a hash of their method, path and
+// body, so a key reused for a different request is refused.
+func EventScopeMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scope := event.Scope{
+			Attributes: make(map[string]string),
+		}
+		if requestID := middleware.GetReqID(r.Context()); requestID != "" {
+			scope.Attributes[RequestIDAttribute] = requestID
+		}
+		if key := r.Header.Get(IdempotencyKeyHeader); key != "" {
+			scope.Attributes[IdempotencyKeyAttribute] = key
+			hash, err := hashRequest(r)
+			if err != nil {
+				RespondError(w, r, msg.NewBadRequestError(err, nil))
+				return
+			}
+			scope.RequestHash = hash
+		}
+
+		next.ServeHTTP(w, r.WithContext(event.WithScope(r.Context(), scope)))
+	})
+}
+
+// hashRequest digests the method, path and body of r and puts the body back,
+// still subject to the size limit applied further down the chain.
+func hashRequest(r *http.Request) (string, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, MaxBodySize+1))
+	if err != nil {
+		return "", err
+	}
+	r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,57 @@
+package web_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/adapter/web"
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+)
+
+func TestEventScopeMiddleware(t *testing.T) {
+	var scope event.Scope
+	var body string
+	handler := web.EventScopeMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scope = event.ScopeFromContext(r.Context())
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	serve := func(payload, key string) {
+		scope, body = event.Scope{}, ""
+		r := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(payload))
+		if key != "" {
+			r.Header.Set(web.IdempotencyKeyHeader, key)
+		}
+		handler.ServeHTTP(httptest.NewRecorder(), r)
+	}
+
+	t.Run("Success: should hash idempotent requests and keep their body", func(t *testing.T) {
+		serve(`{"name":"ana"}`, "key-1")
+		first := scope.RequestHash
+
+		require.NotEmpty(t, first)
+		assert.Equal(t, "key-1", scope.Attributes[event.IdempotencyKeyAttribute])
+		assert.Equal(t, `{"name":"ana"}`, body)
+
+		serve(`{"name":"ana"}`, "key-1")
+		assert.Equal(t, first, scope.RequestHash)
+
+		serve(`{"name":"bia"}`, "key-1")
+		assert.NotEqual(t, first, scope.RequestHash)
+	})
+
+	t.Run("Success: should not hash requests without an idempotency key", func(t *testing.T) {
+		serve(`{"name":"ana"}`, "")
+
+		assert.Empty(t, scope.RequestHash)
+		assert.Equal(t, `{"name":"ana"}`, body)
+	})
+}
@@ -12,6 +12,13 @@ type SuccessResponse struct {
 	Data any `json:"data"`
 }
 
+// PageResponse is the envelope for cursor-paginated collections. NextCursor
+// is omitted on the last page.
+type PageResponse struct {
+	Data       any    `json:"data"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
 type ErrorResponse struct {
 	Error ErrorDetails `json:"error"`
 }
@@ -28,8 +35,15 @@ func Respond(w http.ResponseWriter, r *http.Request, code int, data any) {
 		return
 	}
 
-	responsePayload := SuccessResponse{Data: data}
-	jsonData, err := json.Marshal(responsePayload)
+	writeJSON(w, r, code, SuccessResponse{Data: data})
+}
+
+func RespondPage(w http.ResponseWriter, r *http.Request, data any, nextCursor string) {
+	writeJSON(w, r, http.StatusOK, PageResponse{Data: data, NextCursor: nextCursor})
+}
+
+func writeJSON(w http.ResponseWriter, r *http.Request, code int, payload any) {
+	jsonData, err := json.Marshal(payload)
 	if err != nil {
 		logger := GetLogger(r.Context())
 		logger.Error("failed to encode response", "error", err)
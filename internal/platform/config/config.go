@@ -2,10 +2,16 @@ package config
 
 import (
 	"encoding/json"
+	"os"
 
 	"github.com/spf13/viper"
 )
 
+const (
+	EventBusDriverNATS     = "nats"
+	EventBusDriverPostgres = "postgres"
+)
+
 type (
 	AppConfig struct {
 		Server        ServerConfig
@@ -14,8 +20,11 @@ type (
 		AuditDatabase DatabaseConfig
 		Cache         CacheConfig
 		NATS          NATSConfig
+		EventBus      EventBusConfig
 		Auth          AuthConfig
 		Otel          OtelConfig
+		PII           PIIConfig
+		Identity      IdentityConfig
 	}
 
 	ServerConfig struct {
@@ -50,20 +59,70 @@ type (
 		URLs string
 	}
 
+	// EventBusConfig selects the EventBus implementation: "nats" (JetStream)
+	// or "postgres" (events table on the main database with LISTEN/NOTIFY).
+	// The handler timeout and retention only apply to the postgres driver.
+	EventBusConfig struct {
+		Driver                string
+		HandlerTimeoutSeconds int
+		RetentionHours        int
+	}
+
 	AuthConfig struct {
-		JWT    JWTConfig
-		Google GoogleConfig
-		Cors   CorsConfig
+		JWT               JWTConfig
+		Refresh           RefreshTokenConfig
+		PasswordReset     PasswordResetConfig
+		EmailVerification EmailVerificationConfig
+		PhoneVerification PhoneVerificationConfig
+		Google            GoogleConfig
+		Cors              CorsConfig
 	}
 
+	// JWTConfig signs the access tokens. They are short lived, in minutes;
+	// sessions last through refresh tokens.
 	JWTConfig struct {
-		Secret      string
+		Secret        string
+		ExpiryMinutes int
+		Issuer        string
+		Audience      string
+	}
+
+	// RefreshTokenConfig sets how long a refresh token stays valid. Each
+	// rotation starts a new period.
+	RefreshTokenConfig struct {
 		ExpiryHours int
 	}
 
+	// PasswordResetConfig sets how long a password reset token stays valid.
+	PasswordResetConfig struct {
+		ExpiryMinutes int
+	}
+
+	// EmailVerificationConfig sets how long a verification token stays valid
+	// and how often one may be resent. Required rejects password logins of
+	// users whose email is not verified.
+	EmailVerificationConfig struct {
+		ExpiryHours           int
+		ResendIntervalSeconds int
+		Required              bool
+	}
+
+	// PhoneVerificationConfig sets how long a code sent by SMS stays valid, how
+	// often one may be resent and how many guesses a code allows.
+	PhoneVerificationConfig struct {
+		ExpiryMinutes         int
+		ResendIntervalSeconds int
+		MaxAttempts           int
+	}
+
+	// GoogleConfig enables sign-in with Google when ClientID is set.
+	// RedirectURL must match the callback registered with Google; IssuerURL
+	// only changes for tests.
 	GoogleConfig struct {
 		ClientID     string
 		ClientSecret string
+		RedirectURL  string
+		IssuerURL    string
 	}
 
 	CorsConfig struct {
@@ -79,6 +138,20 @@ type (
 		ServiceName      string
 		ServiceVersion   string
 	}
+
+	PIIConfig struct {
+		MasterKey string
+	}
+
+	// IdentityConfig controls the hard purge of soft-deleted users. A
+	// retention of zero days disables the purge job. TenantID names the
+	// tenant this deployment serves and is granted to every access token.
+	IdentityConfig struct {
+		TenantID             string
+		PurgeRetentionDays   int
+		PurgeIntervalMinutes int
+		PurgeBatchSize       int
+	}
 )
 
 func LoadConfig() (*AppConfig, error) {
@@ -114,8 +187,26 @@ func LoadConfig() (*AppConfig, error) {
 
 	v.BindEnv("cache.addr", "APP_CACHE_ADDR")
 	v.BindEnv("nats.urls", "APP_NATS_URLS")
+	v.BindEnv("eventbus.driver", "APP_EVENT_BUS_DRIVER")
+	v.BindEnv("eventbus.handlertimeoutseconds", "APP_EVENT_BUS_HANDLER_TIMEOUT_SECONDS")
+	v.BindEnv("eventbus.retentionhours", "APP_EVENT_BUS_RETENTION_HOURS")
 	v.BindEnv("auth.jwt.secret", "APP_AUTH_JWT_SECRET")
+	v.BindEnv("auth.jwt.expiryminutes", "APP_AUTH_JWT_EXPIRYMINUTES")
 	v.BindEnv("auth.jwt.expiryhours", "APP_AUTH_JWT_EXPIRYHOURS")
+	v.BindEnv("auth.jwt.issuer", "APP_AUTH_JWT_ISSUER")
+	v.BindEnv("auth.jwt.audience", "APP_AUTH_JWT_AUDIENCE")
+	v.BindEnv("auth.refresh.expiryhours", "APP_AUTH_REFRESH_EXPIRYHOURS")
+	v.BindEnv("auth.passwordreset.expiryminutes", "APP_AUTH_PASSWORDRESET_EXPIRYMINUTES")
+	v.BindEnv("auth.emailverification.expiryhours", "APP_AUTH_EMAILVERIFICATION_EXPIRYHOURS")
+	v.BindEnv("auth.emailverification.resendintervalseconds", "APP_AUTH_EMAILVERIFICATION_RESENDINTERVALSECONDS")
+	v.BindEnv("auth.emailverification.required", "APP_AUTH_EMAILVERIFICATION_REQUIRED")
+	v.BindEnv("auth.phoneverification.expiryminutes", "APP_AUTH_PHONEVERIFICATION_EXPIRYMINUTES")
+	v.BindEnv("auth.phoneverification.resendintervalseconds", "APP_AUTH_PHONEVERIFICATION_RESENDINTERVALSECONDS")
+	v.BindEnv("auth.phoneverification.maxattempts", "APP_AUTH_PHONEVERIFICATION_MAXATTEMPTS")
+	v.BindEnv("auth.google.clientid", "APP_AUTH_GOOGLE_CLIENTID")
+	v.BindEnv("auth.google.clientsecret", "APP_AUTH_GOOGLE_CLIENTSECRET")
+	v.BindEnv("auth.google.redirecturl", "APP_AUTH_GOOGLE_REDIRECTURL")
+	v.BindEnv("auth.google.issuerurl", "APP_AUTH_GOOGLE_ISSUERURL")
 	v.BindEnv("auth.cors.allowedorigins", "APP_AUTH_CORS_ALLOWEDORIGINS")
 	v.BindEnv("auth.cors.allowedmethods", "APP_AUTH_CORS_ALLOWEDMETHODS")
 	v.BindEnv("auth.cors.allowedheaders", "APP_AUTH_CORS_ALLOWEDHEADERS")
@@ -126,6 +217,13 @@ func LoadConfig() (*AppConfig, error) {
 	v.BindEnv("otel.servicename", "APP_OTEL_SERVICE_NAME")
 	v.BindEnv("otel.serviceversion", "APP_VERSION")
 
+	v.BindEnv("pii.masterkey", "APP_PII_MASTER_KEY")
+
+	v.BindEnv("identity.tenantid", "APP_IDENTITY_TENANT_ID")
+	v.BindEnv("identity.purgeretentiondays", "APP_IDENTITY_PURGE_RETENTION_DAYS")
+	v.BindEnv("identity.purgeintervalminutes", "APP_IDENTITY_PURGE_INTERVAL_MINUTES")
+	v.BindEnv("identity.purgebatchsize", "APP_IDENTITY_PURGE_BATCH_SIZE")
+
 	// defaults...
 	v.SetDefault("server.host", "0.0.0.0")
 	v.SetDefault("server.port", 8080)
@@ -136,16 +234,47 @@ func LoadConfig() (*AppConfig, error) {
 	v.SetDefault("database.connMaxLifetime", 5)
 	v.SetDefault("database.connMaxIdleTime", 5)
 	v.SetDefault("nats.urls", "nats://localhost:4222")
+	v.SetDefault("eventbus.driver", EventBusDriverNATS)
+	v.SetDefault("eventbus.handlerTimeoutSeconds", 30)
+	v.SetDefault("eventbus.retentionHours", 168)
 	v.SetDefault("otel.servicename", "redtogreen-api")
+	v.SetDefault("auth.jwt.expiryMinutes", 15)
+	v.SetDefault("auth.jwt.issuer", "redtogreen")
+	v.SetDefault("auth.jwt.audience", "redtogreen-api")
+	v.SetDefault("auth.refresh.expiryHours", 720)
+	v.SetDefault("auth.passwordReset.expiryMinutes", 30)
+	v.SetDefault("auth.emailVerification.expiryHours", 48)
+	v.SetDefault("auth.emailVerification.resendIntervalSeconds", 60)
+	v.SetDefault("auth.emailVerification.required", false)
+	v.SetDefault("auth.phoneVerification.expiryMinutes", 10)
+	v.SetDefault("auth.phoneVerification.resendIntervalSeconds", 60)
+	v.SetDefault("auth.phoneVerification.maxAttempts", 5)
+	v.SetDefault("auth.google.issuerURL", "https://accounts.google.com")
+	v.SetDefault("identity.purgeRetentionDays", 30)
+	v.SetDefault("identity.purgeIntervalMinutes", 60)
+	v.SetDefault("identity.purgeBatchSize", 100)
 
 	var cfg AppConfig
 	if err := v.Unmarshal(&cfg); err != nil {
 		return nil, err
 	}
+	applyDeprecatedJWTExpiry(v, &cfg)
 
 	return &cfg, nil
 }
 
+// applyDeprecatedJWTExpiry still honors APP_AUTH_JWT_EXPIRYHOURS, which set the
+// access token expiry before it moved to minutes, unless the new key is set.
+func applyDeprecatedJWTExpiry(v *viper.Viper, cfg *AppConfig) {
+	if !v.IsSet("auth.jwt.expiryhours") {
+		return
+	}
+	if _, ok := os.LookupEnv("APP_AUTH_JWT_EXPIRYMINUTES"); ok {
+		return
+	}
+	cfg.Auth.JWT.ExpiryMinutes = v.GetInt("auth.jwt.expiryhours") * 60
+}
+
 func (c *AppConfig) ToJSON() (string, error) {
 	bytes, err := json.MarshalIndent(c, "", "  ")
 	if err != nil {
@@ -0,0 +1,114 @@
+package event
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+type Definition struct {
+	Type        EventType
+	Version     EventVersion
+	Source      string
+	Stream      string
+	Context     string
+	Description string
+	Payload     any
+}
+
+type Subscription struct {
+	EventType   EventType
+	Context     string
+	Description string
+}
+
+type Catalog struct {
+	mu            sync.RWMutex
+	definitions   map[EventType]Definition
+	subscriptions map[EventType][]Subscription
+}
+
+func NewCatalog() *Catalog {
+	return &Catalog{
+		definitions:   make(map[EventType]Definition),
+		subscriptions: make(map[EventType][]Subscription),
+	}
+}
+
+func (c *Catalog) Register(def Definition) error {
+	if def.Type == "" {
+		return errors.New("catalog definition event type cannot be empty")
+	}
+	if def.Version == "" {
+		return fmt.Errorf("catalog definition %s: event version cannot be empty", def.Type)
+	}
+	if def.Source == "" {
+		return fmt.Errorf("catalog definition %s: event source cannot be empty", def.Type)
+	}
+	if def.Stream == "" {
+		return fmt.Errorf("catalog definition %s: event stream cannot be empty", def.Type)
+	}
+	if def.Payload == nil {
+		return fmt.Errorf("catalog definition %s: event payload cannot be nil", def.Type)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.definitions[def.Type]; exists {
+		return fmt.Errorf("catalog definition %s is already registered", def.Type)
+	}
+	c.definitions[def.Type] = def
+
+	return nil
+}
+
+func (c *Catalog) RegisterSubscription(sub Subscription) error {
+	if sub.Context == "" {
+		return fmt.Errorf("catalog subscription to %s: context cannot be empty", sub.EventType)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.definitions[sub.EventType]; !exists {
+		return fmt.Errorf("catalog subscription to %s: event type is not registered", sub.EventType)
+	}
+	c.subscriptions[sub.EventType] = append(c.subscriptions[sub.EventType], sub)
+
+	return nil
+}
+
+func (c *Catalog) Lookup(eventType EventType) (Definition, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	def, ok := c.definitions[eventType]
+	return def, ok
+}
+
+// Definitions returns every registered event sorted by type, so generated documents are stable.
+func (c *Catalog) Definitions() []Definition {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	defs := make([]Definition, 0, len(c.definitions))
+	for _, def := range c.definitions {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Type < defs[j].Type })
+
+	return defs
+}
+
+func (c *Catalog) Subscriptions(eventType EventType) []Subscription {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	subs := make([]Subscription, len(c.subscriptions[eventType]))
+	copy(subs, c.subscriptions[eventType])
+	sort.Slice(subs, func(i, j int) bool { return subs[i].Context < subs[j].Context })
+
+	return subs
+}
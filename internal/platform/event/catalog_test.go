@@ -0,0 +1,89 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type catalogTestPayload struct {
+	ID string `json:"id"`
+}
+
+func newCatalogTestDefinition(eventType EventType) Definition {
+	return Definition{
+		Type:    eventType,
+		Version: EventVersion("v1.0.0"),
+		Source:  "TestService",
+		Stream:  "test-stream",
+		Context: "test",
+		Payload: catalogTestPayload{},
+	}
+}
+
+func TestCatalog_Register(t *testing.T) {
+	t.Run("Success_ShouldRegisterAndLookupDefinition", func(t *testing.T) {
+		catalog := NewCatalog()
+		def := newCatalogTestDefinition("test.created")
+
+		require.NoError(t, catalog.Register(def), "Register should not return an error for a valid definition")
+
+		found, ok := catalog.Lookup("test.created")
+		assert.True(t, ok, "Registered definition should be found")
+		assert.Equal(t, def.Version, found.Version, "Found definition should match the registered one")
+	})
+
+	t.Run("Success_DefinitionsShouldBeSortedByType", func(t *testing.T) {
+		catalog := NewCatalog()
+		require.NoError(t, catalog.Register(newCatalogTestDefinition("test.updated")))
+		require.NoError(t, catalog.Register(newCatalogTestDefinition("test.created")))
+
+		defs := catalog.Definitions()
+		require.Len(t, defs, 2, "Both definitions should be returned")
+		assert.Equal(t, EventType("test.created"), defs[0].Type, "Definitions should be sorted by type")
+		assert.Equal(t, EventType("test.updated"), defs[1].Type, "Definitions should be sorted by type")
+	})
+
+	t.Run("Failure_ShouldRejectDuplicateType", func(t *testing.T) {
+		catalog := NewCatalog()
+		require.NoError(t, catalog.Register(newCatalogTestDefinition("test.created")))
+
+		err := catalog.Register(newCatalogTestDefinition("test.created"))
+		require.Error(t, err, "Register should reject a duplicate event type")
+		assert.Contains(t, err.Error(), "already registered")
+	})
+
+	t.Run("Failure_ShouldRejectIncompleteDefinition", func(t *testing.T) {
+		catalog := NewCatalog()
+
+		def := newCatalogTestDefinition("test.created")
+		def.Stream = ""
+		assert.Error(t, catalog.Register(def), "Register should require a stream")
+
+		def = newCatalogTestDefinition("test.created")
+		def.Payload = nil
+		assert.Error(t, catalog.Register(def), "Register should require a payload")
+	})
+}
+
+func TestCatalog_RegisterSubscription(t *testing.T) {
+	t.Run("Success_ShouldListSubscriptionsByContext", func(t *testing.T) {
+		catalog := NewCatalog()
+		require.NoError(t, catalog.Register(newCatalogTestDefinition("test.created")))
+
+		require.NoError(t, catalog.RegisterSubscription(Subscription{EventType: "test.created", Context: "wallet"}))
+		require.NoError(t, catalog.RegisterSubscription(Subscription{EventType: "test.created", Context: "audit"}))
+
+		subs := catalog.Subscriptions("test.created")
+		require.Len(t, subs, 2, "Both subscriptions should be returned")
+		assert.Equal(t, "audit", subs[0].Context, "Subscriptions should be sorted by context")
+	})
+
+	t.Run("Failure_ShouldRejectUnknownEventType", func(t *testing.T) {
+		catalog := NewCatalog()
+
+		err := catalog.RegisterSubscription(Subscription{EventType: "test.unknown", Context: "audit"})
+		require.Error(t, err, "RegisterSubscription should reject unregistered event types")
+	})
+}
@@ -0,0 +1,211 @@
+package contract
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+)
+
+const (
+	TypeString  = "string"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+	TypeObject  = "object"
+	TypeArray   = "array"
+	TypeNull    = "null"
+
+	// ContractsGlob locates consumer contracts relative to the repository root.
+	ContractsGlob = "internal/contexts/*/contracts/*.json"
+)
+
+// Field is a dependency of a consumer on one value of the event envelope,
+// addressed by a dotted path such as "payload.email" or "header.eventId".
+type Field struct {
+	Path     string `json:"path"`
+	Type     string `json:"type,omitempty"`
+	Nullable bool   `json:"nullable,omitempty"`
+}
+
+type Contract struct {
+	Consumer     string             `json:"consumer"`
+	EventType    event.EventType    `json:"eventType"`
+	EventVersion event.EventVersion `json:"eventVersion"`
+	Fields       []Field            `json:"fields"`
+}
+
+// Record builds a contract from a sample event, inferring the type of every path
+// the consumer reads. Paths whose sample value is null are recorded as nullable.
+func Record(consumer string, evt *event.Event, paths ...string) (Contract, error) {
+	doc, err := decode(evt)
+	if err != nil {
+		return Contract{}, err
+	}
+
+	c := Contract{
+		Consumer:     consumer,
+		EventType:    evt.Header.EventType,
+		EventVersion: evt.Header.SchemaVersion,
+	}
+	for _, path := range paths {
+		value, ok := lookup(doc, path)
+		if !ok {
+			return Contract{}, fmt.Errorf("contract %s: path %q not found in sample event", consumer, path)
+		}
+		valueType := typeOf(value)
+		field := Field{Path: path, Type: valueType}
+		if valueType == TypeNull {
+			field.Type = ""
+			field.Nullable = true
+		}
+		c.Fields = append(c.Fields, field)
+	}
+
+	return c, nil
+}
+
+func (c Contract) FileName() string {
+	return fmt.Sprintf("%s.%s.json", c.EventType, c.EventVersion)
+}
+
+func (c Contract) Save(dir string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, c.FileName()), append(data, '\n'), 0o644)
+}
+
+func Load(path string) (Contract, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Contract{}, err
+	}
+
+	var c Contract
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Contract{}, fmt.Errorf("contract %s: %w", path, err)
+	}
+	if c.Consumer == "" || c.EventType == "" || c.EventVersion == "" {
+		return Contract{}, fmt.Errorf("contract %s: consumer, eventType and eventVersion are required", path)
+	}
+
+	return c, nil
+}
+
+func LoadGlob(pattern string) ([]Contract, error) {
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	contracts := make([]Contract, 0, len(paths))
+	for _, path := range paths {
+		c, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		contracts = append(contracts, c)
+	}
+
+	return contracts, nil
+}
+
+// Verify checks that evt still provides every field the consumer depends on.
+// Producers may add fields and minor versions freely; a different major version
+// or a missing or retyped field breaks the contract.
+func (c Contract) Verify(evt *event.Event) error {
+	if evt.Header.EventType != c.EventType {
+		return fmt.Errorf("contract %s: expected event type %s, got %s", c.Consumer, c.EventType, evt.Header.EventType)
+	}
+	if event.MajorVersion(evt.Header.SchemaVersion) != event.MajorVersion(c.EventVersion) {
+		return fmt.Errorf("contract %s: %s consumer expects version %s, producer emits %s",
+			c.Consumer, c.EventType, c.EventVersion, evt.Header.SchemaVersion)
+	}
+
+	doc, err := decode(evt)
+	if err != nil {
+		return err
+	}
+
+	var violations []error
+	for _, field := range c.Fields {
+		value, ok := lookup(doc, field.Path)
+		if !ok {
+			violations = append(violations, fmt.Errorf("field %q is missing", field.Path))
+			continue
+		}
+
+		actual := typeOf(value)
+		if actual == TypeNull {
+			if !field.Nullable {
+				violations = append(violations, fmt.Errorf("field %q is null but the consumer requires a %s", field.Path, field.Type))
+			}
+			continue
+		}
+		if field.Type != "" && actual != field.Type {
+			violations = append(violations, fmt.Errorf("field %q is a %s but the consumer requires a %s", field.Path, actual, field.Type))
+		}
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("contract %s for %s %s is broken: %w", c.Consumer, c.EventType, c.EventVersion, errors.Join(violations...))
+	}
+
+	return nil
+}
+
+func decode(evt *event.Event) (map[string]any, error) {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode event: %w", err)
+	}
+
+	return doc, nil
+}
+
+func lookup(doc map[string]any, path string) (any, bool) {
+	var current any = doc
+	for _, key := range strings.Split(path, ".") {
+		obj, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		current, ok = obj[key]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func typeOf(value any) string {
+	switch value.(type) {
+	case nil:
+		return TypeNull
+	case string:
+		return TypeString
+	case float64:
+		return TypeNumber
+	case bool:
+		return TypeBoolean
+	case []any:
+		return TypeArray
+	default:
+		return TypeObject
+	}
+}
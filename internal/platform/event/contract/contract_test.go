@@ -0,0 +1,75 @@
+package contract_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	"github.com/marcelofabianov/redtogreen/internal/platform/event/contract"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+func newSampleEvent(t *testing.T, version event.EventVersion, payload any) *event.Event {
+	t.Helper()
+
+	payloadBytes, err := json.Marshal(payload)
+	require.NoError(t, err, "Setup: failed to marshal sample payload")
+
+	evt, err := event.NewEvent(event.EventInput{
+		EventType:     "sample.created",
+		EventVersion:  version,
+		Source:        "SampleService",
+		CorrelationID: types.MustNewUUID(),
+		TraceID:       types.MustNewUUID(),
+		Payload:       payloadBytes,
+	})
+	require.NoError(t, err, "Setup: failed to build sample event")
+
+	return &evt
+}
+
+func TestContract_Verify(t *testing.T) {
+	original := newSampleEvent(t, "v1.0.0", map[string]any{"id": "abc", "amount": 10})
+
+	c, err := contract.Record("consumer", original, "header.eventId", "context.userId", "payload.id", "payload.amount")
+	require.NoError(t, err, "Record should not fail for existing paths")
+
+	t.Run("Success: should record types and nullability from the sample", func(t *testing.T) {
+		assert.Equal(t, contract.Field{Path: "payload.amount", Type: contract.TypeNumber}, c.Fields[3])
+		assert.Equal(t, contract.Field{Path: "context.userId", Nullable: true}, c.Fields[1])
+	})
+
+	t.Run("Success: additive changes and minor versions should keep the contract", func(t *testing.T) {
+		evt := newSampleEvent(t, "v1.1.0", map[string]any{"id": "abc", "amount": 10, "currency": "BRL"})
+		assert.NoError(t, c.Verify(evt))
+	})
+
+	t.Run("Failure: removed field should break the contract", func(t *testing.T) {
+		evt := newSampleEvent(t, "v1.0.0", map[string]any{"id": "abc"})
+		err := c.Verify(evt)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `field "payload.amount" is missing`)
+	})
+
+	t.Run("Failure: retyped field should break the contract", func(t *testing.T) {
+		evt := newSampleEvent(t, "v1.0.0", map[string]any{"id": "abc", "amount": "10"})
+		err := c.Verify(evt)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `field "payload.amount" is a string but the consumer requires a number`)
+	})
+
+	t.Run("Failure: new major version should break the contract", func(t *testing.T) {
+		evt := newSampleEvent(t, "v2.0.0", map[string]any{"id": "abc", "amount": 10})
+		err := c.Verify(evt)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "expects version v1.0.0")
+	})
+
+	t.Run("Failure: unknown path should not be recorded", func(t *testing.T) {
+		_, err := contract.Record("consumer", original, "payload.missing")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,88 @@
+package contract
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+)
+
+// UpdateEnv rewrites recorded contracts instead of comparing them when set to "1".
+const UpdateEnv = "UPDATE_CONTRACTS"
+
+type EventFactory func() (*event.Event, error)
+
+// VerifyProducer checks the events built by the producer's constructors against
+// every consumer contract recorded for those event types.
+func VerifyProducer(t testing.TB, factories map[event.EventType]EventFactory) {
+	t.Helper()
+
+	root, err := RepositoryRoot()
+	if err != nil {
+		t.Fatalf("contract: %v", err)
+	}
+
+	contracts, err := LoadGlob(filepath.Join(root, ContractsGlob))
+	if err != nil {
+		t.Fatalf("contract: failed to load consumer contracts: %v", err)
+	}
+
+	for eventType, factory := range factories {
+		evt, err := factory()
+		if err != nil {
+			t.Errorf("contract: failed to build %s sample event: %v", eventType, err)
+			continue
+		}
+
+		for _, c := range contracts {
+			if c.EventType != eventType {
+				continue
+			}
+			if err := c.Verify(evt); err != nil {
+				t.Errorf("%v", err)
+			}
+		}
+	}
+}
+
+// AssertRecorded compares c with the contract the consumer committed in dir,
+// or records it when UPDATE_CONTRACTS=1.
+func AssertRecorded(t testing.TB, dir string, c Contract) {
+	t.Helper()
+
+	if os.Getenv(UpdateEnv) == "1" {
+		if err := c.Save(dir); err != nil {
+			t.Fatalf("contract: failed to record %s: %v", c.FileName(), err)
+		}
+		return
+	}
+
+	recorded, err := Load(filepath.Join(dir, c.FileName()))
+	if err != nil {
+		t.Fatalf("contract: %v (run the test with %s=1 to record it)", err, UpdateEnv)
+	}
+	if !reflect.DeepEqual(recorded, c) {
+		t.Errorf("contract: %s differs from the consumer's current dependencies (run the test with %s=1 to record it)", c.FileName(), UpdateEnv)
+	}
+}
+
+func RepositoryRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("go.mod not found in any parent directory")
+		}
+		dir = parent
+	}
+}
@@ -19,6 +19,9 @@ type EventHeader struct {
 	Timestamp     time.Time    `json:"timestamp"`
 	Source        string       `json:"source"`
 	SchemaVersion EventVersion `json:"jsonSchemaVersion"`
+	// PartitionKey groups events that must be handled in order, usually the
+	// ID of the aggregate they belong to.
+	PartitionKey string `json:"partitionKey,omitempty"`
 }
 
 func NewEventHeader(eventType EventType, eventVersion EventVersion, source string) (EventHeader, error) {
@@ -36,16 +39,56 @@ func NewEventHeader(eventType EventType, eventVersion EventVersion, source strin
 	}, nil
 }
 
+type ActorType string
+
+const (
+	ActorUser           ActorType = "user"
+	ActorSystem         ActorType = "system"
+	ActorServiceAccount ActorType = "service_account"
+)
+
+// EventContext identifies who caused the event and on behalf of which tenant.
+// Tenant, actor type and attributes are optional so envelopes written before
+// they existed still decode.
 type EventContext struct {
 	CorrelationID types.UUID         `json:"correlationId"`
 	UserID        types.NullableUUID `json:"userId,omitempty"`
+	TenantID      string             `json:"tenantId,omitempty"`
+	ActorType     ActorType          `json:"actorType,omitempty"`
+	Attributes    map[string]string  `json:"attributes,omitempty"`
 }
 
 func NewEventContext(correlationID types.UUID, userID types.NullableUUID) EventContext {
-	return EventContext{
+	context := EventContext{
 		CorrelationID: correlationID,
 		UserID:        userID,
 	}
+	if userID.Valid {
+		context.ActorType = ActorUser
+	}
+	return context
+}
+
+// WithScope fills the tenant, actor type and attributes that are not yet set
+// on the context. Values already on the event take precedence.
+func (c EventContext) WithScope(scope Scope) EventContext {
+	if c.TenantID == "" {
+		c.TenantID = scope.TenantID
+	}
+	if c.ActorType == "" {
+		c.ActorType = scope.ActorType
+	}
+	if len(scope.Attributes) > 0 {
+		attributes := make(map[string]string, len(c.Attributes)+len(scope.Attributes))
+		for k, v := range scope.Attributes {
+			attributes[k] = v
+		}
+		for k, v := range c.Attributes {
+			attributes[k] = v
+		}
+		c.Attributes = attributes
+	}
+	return c
 }
 
 type EventMetadata struct {
@@ -71,6 +114,10 @@ type EventInput struct {
 	TraceID         types.UUID
 	PreviousEventID types.NullableUUID
 	CausationID     types.NullableUUID
+	TenantID        string
+	ActorType       ActorType
+	Attributes      map[string]string
+	PartitionKey    string
 	Payload         json.RawMessage
 }
 
@@ -108,8 +155,13 @@ func NewEvent(input EventInput) (Event, error) {
 	if err != nil {
 		return Event{}, fmt.Errorf("failed to create event: %w", err)
 	}
+	header.PartitionKey = input.PartitionKey
 
 	context := NewEventContext(input.CorrelationID, input.UserID)
+	if input.ActorType != "" {
+		context.ActorType = input.ActorType
+	}
+	context = context.WithScope(Scope{TenantID: input.TenantID, Attributes: input.Attributes})
 	metadata := NewEventMetadata(input.TraceID, input.PreviousEventID, input.CausationID)
 
 	return Event{
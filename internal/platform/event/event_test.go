@@ -1,6 +1,7 @@
 package event
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 	"time"
@@ -315,3 +316,69 @@ func TestNewEvent(t *testing.T) {
 		assert.EqualError(t, err, "event payload must be valid JSON")
 	})
 }
+
+func TestEventContext_Scope(t *testing.T) {
+	t.Run("Success_UserAuthorShouldDefaultActorTypeToUser", func(t *testing.T) {
+		context := NewEventContext(types.MustNewUUID(), types.NewValidNullableUUID(types.MustNewUUID()))
+		assert.Equal(t, ActorUser, context.ActorType, "Events authored by a user should have the user actor type")
+	})
+
+	t.Run("Success_NewEventShouldCarryTenantActorAndAttributes", func(t *testing.T) {
+		input := EventInput{
+			EventType:     EventType("test.event"),
+			EventVersion:  EventVersion("v1"),
+			Source:        "TestService",
+			CorrelationID: types.MustNewUUID(),
+			UserID:        types.NewValidNullableUUID(types.MustNewUUID()),
+			TraceID:       types.MustNewUUID(),
+			TenantID:      "tenant-a",
+			ActorType:     ActorServiceAccount,
+			Attributes:    map[string]string{"origin": "import"},
+			Payload:       json.RawMessage(`{}`),
+		}
+		evt, err := NewEvent(input)
+		require.NoError(t, err)
+
+		assert.Equal(t, "tenant-a", evt.Context.TenantID)
+		assert.Equal(t, ActorServiceAccount, evt.Context.ActorType, "An explicit actor type should win over the default")
+		assert.Equal(t, map[string]string{"origin": "import"}, evt.Context.Attributes)
+	})
+
+	t.Run("Success_WithScopeShouldKeepValuesAlreadySet", func(t *testing.T) {
+		context := EventContext{
+			TenantID:   "tenant-a",
+			Attributes: map[string]string{"origin": "event"},
+		}
+		scoped := context.WithScope(Scope{
+			TenantID:   "tenant-b",
+			ActorType:  ActorSystem,
+			Attributes: map[string]string{"origin": "request", "requestId": "abc"},
+		})
+
+		assert.Equal(t, "tenant-a", scoped.TenantID)
+		assert.Equal(t, ActorSystem, scoped.ActorType)
+		assert.Equal(t, map[string]string{"origin": "event", "requestId": "abc"}, scoped.Attributes)
+	})
+
+	t.Run("Success_ApplyScopeShouldUseScopeFromContext", func(t *testing.T) {
+		evt := Event{Context: EventContext{CorrelationID: types.MustNewUUID()}}
+		ctx := WithScope(context.Background(), Scope{TenantID: "tenant-a"})
+
+		ApplyScope(ctx, &evt)
+		assert.Equal(t, "tenant-a", evt.Context.TenantID)
+	})
+
+	t.Run("Success_EnvelopeWithoutScopeShouldRoundTrip", func(t *testing.T) {
+		legacy := `{"correlationId":"0197a6a4-9a3c-7a4e-8f3b-3c1f4b5d6e7f","userId":null}`
+
+		var context EventContext
+		require.NoError(t, json.Unmarshal([]byte(legacy), &context), "Envelopes written before the scope fields should still decode")
+		assert.Empty(t, context.TenantID)
+		assert.Empty(t, context.ActorType)
+
+		data, err := json.Marshal(context)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "tenantId", "Empty scope fields should be omitted")
+		assert.NotContains(t, string(data), "attributes", "Empty scope fields should be omitted")
+	})
+}
@@ -0,0 +1,53 @@
+package event
+
+import "context"
+
+// Well known scope attributes.
+const (
+	RequestIDAttribute      = "requestId"
+	IdempotencyKeyAttribute = "idempotencyKey"
+)
+
+type scopeCtxKey struct{}
+
+// Scope is the tenant, actor type and attributes of the operation in progress.
+// It travels in the request context so publishers stamp it on every event they
+// emit without threading it through each command input.
+//
+// SubjectID (the authenticated user) and RequestHash (a digest of the request)
+// only serve the command bus, to scope idempotency keys; they are not stamped
+// on events.
+type Scope struct {
+	TenantID    string
+	ActorType   ActorType
+	Attributes  map[string]string
+	SubjectID   string
+	RequestHash string
+}
+
+func WithScope(ctx context.Context, scope Scope) context.Context {
+	return context.WithValue(ctx, scopeCtxKey{}, scope)
+}
+
+func ScopeFromContext(ctx context.Context) Scope {
+	if scope, ok := ctx.Value(scopeCtxKey{}).(Scope); ok {
+		return scope
+	}
+	return Scope{}
+}
+
+// ScopeOf rebuilds the scope an event was published with, so consumers handle
+// it on behalf of the same tenant and actor type.
+func ScopeOf(evt *Event) Scope {
+	return Scope{
+		TenantID:   evt.Context.TenantID,
+		ActorType:  evt.Context.ActorType,
+		Attributes: evt.Context.Attributes,
+	}
+}
+
+// ApplyScope stamps the scope carried by ctx on evt, keeping any value the
+// producer already set. Event buses call it before publishing.
+func ApplyScope(ctx context.Context, evt *Event) {
+	evt.Context = evt.Context.WithScope(ScopeFromContext(ctx))
+}
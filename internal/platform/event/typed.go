@@ -0,0 +1,137 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+// PayloadValidator is implemented by payloads that check their own invariants.
+// Typed definitions call it before publishing and after decoding.
+type PayloadValidator interface {
+	Validate() error
+}
+
+// PartitionKeyer is implemented by payloads whose events must be handled in
+// order per key, typically the aggregate ID.
+type PartitionKeyer interface {
+	PartitionKey() string
+}
+
+// TypedDefinition binds an event type, version and source to its payload type T,
+// so producers and consumers share a single place that knows how to build and
+// read the event.
+type TypedDefinition[T any] struct {
+	Type    EventType
+	Version EventVersion
+	Source  string
+}
+
+type TypedInput[T any] struct {
+	CorrelationID   types.UUID
+	UserID          types.NullableUUID // Author
+	TraceID         types.UUID         // OTEL
+	PreviousEventID types.NullableUUID
+	CausationID     types.NullableUUID
+	Payload         T
+}
+
+func NewTypedDefinition[T any](eventType EventType, version EventVersion, source string) TypedDefinition[T] {
+	return TypedDefinition[T]{
+		Type:    eventType,
+		Version: version,
+		Source:  source,
+	}
+}
+
+// Describe returns the catalog definition of this event, using a zero T as
+// the payload schema.
+func (d TypedDefinition[T]) Describe(stream, context, description string) Definition {
+	var payload T
+	return Definition{
+		Type:        d.Type,
+		Version:     d.Version,
+		Source:      d.Source,
+		Stream:      stream,
+		Context:     context,
+		Description: description,
+		Payload:     payload,
+	}
+}
+
+// New validates and marshals the payload into a new event of this definition.
+func (d TypedDefinition[T]) New(input TypedInput[T]) (*Event, error) {
+	if err := validatePayload(input.Payload); err != nil {
+		return nil, fmt.Errorf("invalid %s payload: %w", d.Type, err)
+	}
+
+	payloadBytes, err := json.Marshal(input.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s payload: %w", d.Type, err)
+	}
+
+	evt, err := NewEvent(EventInput{
+		EventType:       d.Type,
+		EventVersion:    d.Version,
+		Source:          d.Source,
+		CorrelationID:   input.CorrelationID,
+		UserID:          input.UserID,
+		TraceID:         input.TraceID,
+		PreviousEventID: input.PreviousEventID,
+		CausationID:     input.CausationID,
+		PartitionKey:    partitionKey(input.Payload),
+		Payload:         payloadBytes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &evt, nil
+}
+
+// Decode reads the payload of evt as T. The event must have this definition's
+// type and the same major version; minor versions only add fields and are
+// accepted.
+func (d TypedDefinition[T]) Decode(evt *Event) (T, error) {
+	var payload T
+
+	if evt == nil {
+		return payload, fmt.Errorf("cannot decode nil %s event", d.Type)
+	}
+	if evt.Header.EventType != d.Type {
+		return payload, fmt.Errorf("expected event type %s, got %s", d.Type, evt.Header.EventType)
+	}
+	if MajorVersion(evt.Header.SchemaVersion) != MajorVersion(d.Version) {
+		return payload, fmt.Errorf("unsupported %s version %s, expected %s", d.Type, evt.Header.SchemaVersion, d.Version)
+	}
+
+	if err := json.Unmarshal(evt.Payload, &payload); err != nil {
+		return payload, err
+	}
+	if err := validatePayload(payload); err != nil {
+		return payload, fmt.Errorf("invalid %s payload: %w", d.Type, err)
+	}
+
+	return payload, nil
+}
+
+// MajorVersion returns the major component of a "vMAJOR.MINOR.PATCH" version.
+func MajorVersion(version EventVersion) string {
+	return strings.SplitN(strings.TrimPrefix(string(version), "v"), ".", 2)[0]
+}
+
+func validatePayload(payload any) error {
+	if v, ok := payload.(PayloadValidator); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+func partitionKey(payload any) string {
+	if k, ok := payload.(PartitionKeyer); ok {
+		return k.PartitionKey()
+	}
+	return ""
+}
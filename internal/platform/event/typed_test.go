@@ -0,0 +1,95 @@
+package event
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type typedTestPayload struct {
+	ID     string `json:"id"`
+	Amount int    `json:"amount"`
+}
+
+func (p typedTestPayload) Validate() error {
+	if p.ID == "" {
+		return errors.New("id is required")
+	}
+	return nil
+}
+
+func (p typedTestPayload) PartitionKey() string {
+	return p.ID
+}
+
+var typedTestDefinition = NewTypedDefinition[typedTestPayload]("test.created", "v1.2.0", "TestService")
+
+func newTypedTestInput(payload typedTestPayload) TypedInput[typedTestPayload] {
+	return TypedInput[typedTestPayload]{
+		CorrelationID: types.MustNewUUID(),
+		TraceID:       types.MustNewUUID(),
+		Payload:       payload,
+	}
+}
+
+func TestTypedDefinition_New(t *testing.T) {
+	t.Run("Success_ShouldBuildEventFromDefinition", func(t *testing.T) {
+		evt, err := typedTestDefinition.New(newTypedTestInput(typedTestPayload{ID: "abc", Amount: 10}))
+		require.NoError(t, err, "New should not return an error for a valid payload")
+
+		assert.Equal(t, EventType("test.created"), evt.Header.EventType)
+		assert.Equal(t, EventVersion("v1.2.0"), evt.Header.SchemaVersion)
+		assert.Equal(t, "TestService", evt.Header.Source)
+		assert.JSONEq(t, `{"id":"abc","amount":10}`, string(evt.Payload))
+		assert.Equal(t, "abc", evt.Header.PartitionKey, "Partition key should come from the payload")
+	})
+
+	t.Run("Failure_ShouldRejectInvalidPayload", func(t *testing.T) {
+		_, err := typedTestDefinition.New(newTypedTestInput(typedTestPayload{}))
+		require.Error(t, err, "New should validate the payload")
+		assert.Contains(t, err.Error(), "id is required")
+	})
+}
+
+func TestTypedDefinition_Decode(t *testing.T) {
+	evt, err := typedTestDefinition.New(newTypedTestInput(typedTestPayload{ID: "abc", Amount: 10}))
+	require.NoError(t, err, "Setup: failed to build event")
+
+	t.Run("Success_ShouldDecodePayload", func(t *testing.T) {
+		payload, err := typedTestDefinition.Decode(evt)
+		require.NoError(t, err)
+		assert.Equal(t, typedTestPayload{ID: "abc", Amount: 10}, payload)
+	})
+
+	t.Run("Success_ShouldAcceptOtherMinorVersion", func(t *testing.T) {
+		older := *evt
+		older.Header.SchemaVersion = "v1.0.0"
+		_, err := typedTestDefinition.Decode(&older)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Failure_ShouldRejectOtherEventType", func(t *testing.T) {
+		other := *evt
+		other.Header.EventType = "test.updated"
+		_, err := typedTestDefinition.Decode(&other)
+		assert.Error(t, err)
+	})
+
+	t.Run("Failure_ShouldRejectOtherMajorVersion", func(t *testing.T) {
+		newer := *evt
+		newer.Header.SchemaVersion = "v2.0.0"
+		_, err := typedTestDefinition.Decode(&newer)
+		assert.Error(t, err)
+	})
+
+	t.Run("Failure_ShouldRejectInvalidPayload", func(t *testing.T) {
+		invalid := *evt
+		invalid.Payload = []byte(`{"amount":10}`)
+		_, err := typedTestDefinition.Decode(&invalid)
+		assert.Error(t, err)
+	})
+}
@@ -14,6 +14,13 @@ const (
 	CodeInternal     ErrorCode = "internal_error"
 	CodeUnauthorized ErrorCode = "unauthorized"
 	CodeForbidden    ErrorCode = "forbidden"
+
+	CodePreconditionFailed ErrorCode = "precondition_failed"
+
+	// CodeUnavailable and CodeTimeout report that a dependency could not be
+	// reached or did not answer in time, so the request may be retried.
+	CodeUnavailable ErrorCode = "unavailable"
+	CodeTimeout     ErrorCode = "timeout"
 )
 
 type MessageError struct {
@@ -87,6 +94,23 @@ func (e *MessageError) ToResponse() ErrorResponse {
 	return resp
 }
 
+// ToMessageError rebuilds the error described by a response, e.g. one received
+// from another service. The original wrapped error does not cross the wire.
+func (r ErrorResponse) ToMessageError() *MessageError {
+	e := &MessageError{
+		Message: r.Message,
+		Code:    ErrorCode(r.Code),
+		Context: r.Context,
+	}
+	if e.Code == "" {
+		e.Code = CodeInternal
+	}
+	for _, detail := range r.Details {
+		e.Details = append(e.Details, detail.ToMessageError())
+	}
+	return e
+}
+
 func (e *MessageError) HTTPStatus() int {
 	switch e.Code {
 	case CodeConflict:
@@ -99,6 +123,12 @@ func (e *MessageError) HTTPStatus() int {
 		return http.StatusUnauthorized
 	case CodeForbidden:
 		return http.StatusForbidden
+	case CodePreconditionFailed:
+		return http.StatusPreconditionFailed
+	case CodeUnavailable:
+		return http.StatusServiceUnavailable
+	case CodeTimeout:
+		return http.StatusGatewayTimeout
 	case CodeInternal:
 		return http.StatusInternalServerError
 	default:
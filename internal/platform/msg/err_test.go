@@ -83,6 +83,9 @@ func TestMessageError_HTTPStatus(t *testing.T) {
 		{"Internal Error", CodeInternal, http.StatusInternalServerError},
 		{"Unauthorized", CodeUnauthorized, http.StatusUnauthorized},
 		{"Forbidden", CodeForbidden, http.StatusForbidden},
+		{"Precondition Failed", CodePreconditionFailed, http.StatusPreconditionFailed},
+		{"Unavailable", CodeUnavailable, http.StatusServiceUnavailable},
+		{"Timeout", CodeTimeout, http.StatusGatewayTimeout},
 		{"Unknown Code", ErrorCode("SOME_NEW_CODE"), http.StatusInternalServerError},
 	}
 
@@ -141,3 +144,24 @@ func TestMessageError_ToResponse(t *testing.T) {
 		assert.Equal(t, map[string]any{"field": "password"}, response.Details[1].Context)
 	})
 }
+
+func TestErrorResponse_ToMessageError(t *testing.T) {
+	t.Run("Success: should rebuild code, message, context and details", func(t *testing.T) {
+		original := NewMessageError(errors.New("db down"), "User not found.", CodeNotFound, map[string]any{"user_id": "123"})
+		original.Details = []*MessageError{NewValidationError(nil, nil, "Invalid field.")}
+
+		rebuilt := original.ToResponse().ToMessageError()
+
+		assert.Equal(t, CodeNotFound, rebuilt.Code)
+		assert.Equal(t, "User not found.", rebuilt.Message)
+		assert.Equal(t, map[string]any{"user_id": "123"}, rebuilt.Context)
+		require.Len(t, rebuilt.Details, 1)
+		assert.Equal(t, CodeInvalid, rebuilt.Details[0].Code)
+		assert.Nil(t, rebuilt.Err, "The wrapped error should not cross the wire")
+	})
+
+	t.Run("Success: missing code should default to internal error", func(t *testing.T) {
+		rebuilt := ErrorResponse{Message: "boom"}.ToMessageError()
+		assert.Equal(t, CodeInternal, rebuilt.Code)
+	})
+}
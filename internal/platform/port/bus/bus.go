@@ -2,21 +2,93 @@ package bus
 
 import (
 	"context"
+	"errors"
 
 	"github.com/marcelofabianov/redtogreen/internal/platform/event"
 )
 
 type EventHandler func(ctx context.Context, event *event.Event) error
 
+// PermanentError marks a handler failure no redelivery can fix, such as a
+// payload that does not decode or a field that does not decrypt. Buses give up
+// on such an event at once; every other error is retried with backoff.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string { return e.Err.Error() }
+
+func (e *PermanentError) Unwrap() error { return e.Err }
+
+// Permanent marks err as a PermanentError. A nil err stays nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{Err: err}
+}
+
+// IsPermanent reports whether err, or an error it wraps, is a PermanentError.
+func IsPermanent(err error) bool {
+	var permanent *PermanentError
+	return errors.As(err, &permanent)
+}
+
 type EventBusPublisher interface {
 	Publish(ctx context.Context, event *event.Event) error
 }
 
 type EventBusSubscriber interface {
-	Subscribe(eventType event.EventType, handler EventHandler) error
+	Subscribe(eventType event.EventType, handler EventHandler, opts ...SubscribeOption) error
 }
 
 type EventBus interface {
 	EventBusPublisher
 	EventBusSubscriber
 }
+
+type SubscribeOptions struct {
+	// Partitions, when positive, makes the subscription ordered: events with
+	// the same partition key are handled one at a time and in order, while
+	// different keys are spread over this many concurrent workers.
+	Partitions int
+	// PartitionKey overrides the key taken from the event header.
+	PartitionKey func(evt *event.Event) string
+}
+
+type SubscribeOption func(*SubscribeOptions)
+
+// OrderedByKey serializes events per partition key across the given number of
+// concurrent partitions.
+func OrderedByKey(partitions int) SubscribeOption {
+	return func(o *SubscribeOptions) {
+		o.Partitions = partitions
+	}
+}
+
+// WithPartitionKey derives the partition key from the event, e.g. from a
+// payload field of events published without one.
+func WithPartitionKey(fn func(evt *event.Event) string) SubscribeOption {
+	return func(o *SubscribeOptions) {
+		o.PartitionKey = fn
+	}
+}
+
+func NewSubscribeOptions(opts ...SubscribeOption) SubscribeOptions {
+	var o SubscribeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func (o SubscribeOptions) Ordered() bool {
+	return o.Partitions > 0
+}
+
+func (o SubscribeOptions) KeyOf(evt *event.Event) string {
+	if o.PartitionKey != nil {
+		return o.PartitionKey(evt)
+	}
+	return evt.Header.PartitionKey
+}
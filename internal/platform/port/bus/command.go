@@ -0,0 +1,91 @@
+package bus
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+)
+
+type CommandType string
+
+// Command is anything that can be dispatched; its type selects the handler.
+type Command interface {
+	CommandType() CommandType
+}
+
+type CommandHandler func(ctx context.Context, cmd Command) (any, error)
+
+// CommandMiddleware wraps every handler of a CommandBus, outermost first.
+type CommandMiddleware func(next CommandHandler) CommandHandler
+
+type CommandDispatcher interface {
+	Dispatch(ctx context.Context, cmd Command) (any, error)
+}
+
+type CommandRegistry interface {
+	Register(commandType CommandType, handler CommandHandler) error
+}
+
+// CommandBus is the single entry point for application commands, shared by
+// HTTP, CLI and event triggered callers.
+type CommandBus interface {
+	CommandDispatcher
+	CommandRegistry
+}
+
+// ValidatableCommand is checked by the validation middleware before the
+// handler runs.
+type ValidatableCommand interface {
+	Validate() error
+}
+
+// AuthorizableCommand decides by itself whether the caller in ctx may run it.
+type AuthorizableCommand interface {
+	Authorize(ctx context.Context) error
+}
+
+// TransactionalCommand runs its handler inside a database transaction when
+// Transactional returns true.
+type TransactionalCommand interface {
+	Transactional() bool
+}
+
+// HandleCommand registers a typed handler for the commands of type C.
+func HandleCommand[C Command, R any](registry CommandRegistry, handler func(ctx context.Context, cmd C) (R, error)) error {
+	var zero C
+	commandType := zero.CommandType()
+
+	return registry.Register(commandType, func(ctx context.Context, cmd Command) (any, error) {
+		typed, ok := cmd.(C)
+		if !ok {
+			return nil, msg.NewInternalError(
+				fmt.Errorf("command %T does not match handler of %s", cmd, commandType),
+				map[string]any{"command_type": commandType},
+			)
+		}
+		return handler(ctx, typed)
+	})
+}
+
+// SendCommand dispatches cmd and returns its result as R.
+func SendCommand[R any](ctx context.Context, dispatcher CommandDispatcher, cmd Command) (R, error) {
+	var zero R
+
+	result, err := dispatcher.Dispatch(ctx, cmd)
+	if err != nil {
+		return zero, err
+	}
+	if result == nil {
+		return zero, nil
+	}
+
+	typed, ok := result.(R)
+	if !ok {
+		return zero, msg.NewInternalError(
+			fmt.Errorf("command %s returned %T, expected %T", cmd.CommandType(), result, zero),
+			map[string]any{"command_type": cmd.CommandType()},
+		)
+	}
+	return typed, nil
+}
@@ -0,0 +1,23 @@
+package bus
+
+import "context"
+
+// Health is a point in time view of the event bus connection.
+type Health struct {
+	Driver string `json:"driver"`
+	// Connected reports whether the bus reaches its broker or database.
+	Connected       bool   `json:"connected"`
+	ConnectionState string `json:"connectionState"`
+	// StreamingAvailable reports whether durable delivery works: JetStream on
+	// NATS, the LISTEN connection on Postgres.
+	StreamingAvailable bool   `json:"streamingAvailable"`
+	Error              string `json:"error,omitempty"`
+}
+
+func (h Health) Healthy() bool {
+	return h.Connected && h.StreamingAvailable
+}
+
+type HealthChecker interface {
+	Health(ctx context.Context) Health
+}
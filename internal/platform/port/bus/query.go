@@ -0,0 +1,82 @@
+package bus
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+)
+
+type QueryType string
+
+// QueryHandler answers a query with a JSON encoded response. Errors should be
+// *msg.MessageError so their code survives the trip back to the requester.
+type QueryHandler func(ctx context.Context, request json.RawMessage) (json.RawMessage, error)
+
+type QueryRequester interface {
+	Request(ctx context.Context, queryType QueryType, request json.RawMessage) (json.RawMessage, error)
+}
+
+type QueryResponder interface {
+	Respond(queryType QueryType, handler QueryHandler) error
+}
+
+// QueryBus lets bounded contexts ask each other questions synchronously
+// without importing each other's repositories.
+type QueryBus interface {
+	QueryRequester
+	QueryResponder
+}
+
+// QueryDefinition is the contract of a query: its type and the request and
+// response shapes shared by both sides.
+type QueryDefinition[Req, Res any] struct {
+	Type QueryType
+}
+
+func NewQueryDefinition[Req, Res any](queryType QueryType) QueryDefinition[Req, Res] {
+	return QueryDefinition[Req, Res]{Type: queryType}
+}
+
+// Ask sends req and decodes the response of def.
+func Ask[Req, Res any](ctx context.Context, requester QueryRequester, def QueryDefinition[Req, Res], req Req) (Res, error) {
+	var res Res
+
+	request, err := json.Marshal(req)
+	if err != nil {
+		return res, msg.NewValidationError(err, map[string]any{"query_type": def.Type}, "Invalid query request")
+	}
+
+	response, err := requester.Request(ctx, def.Type, request)
+	if err != nil {
+		return res, err
+	}
+
+	if err := json.Unmarshal(response, &res); err != nil {
+		return res, msg.NewInternalError(err, map[string]any{"query_type": def.Type})
+	}
+
+	return res, nil
+}
+
+// Answer registers a typed handler for def.
+func Answer[Req, Res any](responder QueryResponder, def QueryDefinition[Req, Res], handler func(ctx context.Context, req Req) (Res, error)) error {
+	return responder.Respond(def.Type, func(ctx context.Context, request json.RawMessage) (json.RawMessage, error) {
+		var req Req
+		if err := json.Unmarshal(request, &req); err != nil {
+			return nil, msg.NewValidationError(err, map[string]any{"query_type": def.Type}, "Invalid query request")
+		}
+
+		res, err := handler(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := json.Marshal(res)
+		if err != nil {
+			return nil, msg.NewInternalError(err, map[string]any{"query_type": def.Type})
+		}
+
+		return response, nil
+	})
+}
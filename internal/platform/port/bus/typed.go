@@ -0,0 +1,40 @@
+package bus
+
+import (
+	"context"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+)
+
+// TypedEventHandler receives the envelope together with its decoded payload.
+type TypedEventHandler[T any] func(ctx context.Context, evt *event.Event, payload T) error
+
+// Publish builds an event of def from input and publishes it.
+func Publish[T any](ctx context.Context, publisher EventBusPublisher, def event.TypedDefinition[T], input event.TypedInput[T]) error {
+	evt, err := def.New(input)
+	if err != nil {
+		return msg.NewValidationError(err, map[string]any{"event_type": def.Type}, "Invalid event payload")
+	}
+
+	return publisher.Publish(ctx, evt)
+}
+
+// Subscribe registers handler for def's event type, decoding and validating
+// the payload before it is called.
+func Subscribe[T any](subscriber EventBusSubscriber, def event.TypedDefinition[T], handler TypedEventHandler[T], opts ...SubscribeOption) error {
+	return subscriber.Subscribe(def.Type, Handler(def, handler), opts...)
+}
+
+// Handler adapts a typed handler to an EventHandler. Events whose payload
+// cannot be decoded are rejected with a permanent validation error.
+func Handler[T any](def event.TypedDefinition[T], handler TypedEventHandler[T]) EventHandler {
+	return func(ctx context.Context, evt *event.Event) error {
+		payload, err := def.Decode(evt)
+		if err != nil {
+			return Permanent(msg.NewValidationError(err, map[string]any{"event_type": def.Type}, "Invalid event payload"))
+		}
+
+		return handler(ctx, evt, payload)
+	}
+}
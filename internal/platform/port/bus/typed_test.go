@@ -0,0 +1,85 @@
+package bus_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/event"
+	"github.com/marcelofabianov/redtogreen/internal/platform/msg"
+	"github.com/marcelofabianov/redtogreen/internal/platform/port/bus"
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+type testPayload struct {
+	ID string `json:"id"`
+}
+
+var testDefinition = event.NewTypedDefinition[testPayload]("test.created", "v1.0.0", "TestService")
+
+// In-memory bus delivering published events to the subscribed handlers
+type mockEventBus struct {
+	handlers map[event.EventType]bus.EventHandler
+}
+
+func (m *mockEventBus) Publish(ctx context.Context, evt *event.Event) error {
+	if handler, ok := m.handlers[evt.Header.EventType]; ok {
+		return handler(ctx, evt)
+	}
+	return nil
+}
+
+func (m *mockEventBus) Subscribe(eventType event.EventType, handler bus.EventHandler, _ ...bus.SubscribeOption) error {
+	m.handlers[eventType] = handler
+	return nil
+}
+
+func TestTypedPublishSubscribe(t *testing.T) {
+	t.Run("Success: subscriber should receive the decoded payload", func(t *testing.T) {
+		b := &mockEventBus{handlers: make(map[event.EventType]bus.EventHandler)}
+
+		var received testPayload
+		require.NoError(t, bus.Subscribe(b, testDefinition, func(ctx context.Context, evt *event.Event, payload testPayload) error {
+			received = payload
+			return nil
+		}))
+
+		err := bus.Publish(context.Background(), b, testDefinition, event.TypedInput[testPayload]{
+			CorrelationID: types.MustNewUUID(),
+			TraceID:       types.MustNewUUID(),
+			Payload:       testPayload{ID: "abc"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, testPayload{ID: "abc"}, received)
+	})
+
+	t.Run("Failure: publish should reject an incomplete envelope", func(t *testing.T) {
+		b := &mockEventBus{handlers: make(map[event.EventType]bus.EventHandler)}
+
+		err := bus.Publish(context.Background(), b, testDefinition, event.TypedInput[testPayload]{Payload: testPayload{ID: "abc"}})
+		require.Error(t, err)
+
+		var msgErr *msg.MessageError
+		require.ErrorAs(t, err, &msgErr)
+		assert.Equal(t, msg.CodeInvalid, msgErr.Code)
+	})
+
+	t.Run("Failure: handler should not be called for malformed payloads", func(t *testing.T) {
+		called := false
+		handler := bus.Handler(testDefinition, func(ctx context.Context, evt *event.Event, payload testPayload) error {
+			called = true
+			return nil
+		})
+
+		evt := &event.Event{
+			Header:  event.EventHeader{EventType: testDefinition.Type, SchemaVersion: testDefinition.Version},
+			Payload: []byte(`{"id":`),
+		}
+		err := handler(context.Background(), evt)
+		require.Error(t, err)
+		assert.True(t, bus.IsPermanent(err), "A payload that does not decode should not be retried")
+		assert.False(t, called)
+	})
+}
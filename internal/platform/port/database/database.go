@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"database/sql"
+	"sync"
 )
 
 const (
@@ -14,3 +15,81 @@ type DB interface {
 	WithTransaction(ctx context.Context, opts *sql.TxOptions, fn func(tx *sql.Tx) error) error
 	Close() error
 }
+
+// Executor is what *sql.DB and *sql.Tx have in common, so repositories can
+// run the same statements inside or outside a transaction.
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type txCtxKey struct{}
+
+// WithTx stores tx in ctx so repositories called further down join it.
+func WithTx(ctx context.Context, tx *sql.Tx) context.Context {
+	return context.WithValue(ctx, txCtxKey{}, tx)
+}
+
+func TxFromContext(ctx context.Context) (*sql.Tx, bool) {
+	tx, ok := ctx.Value(txCtxKey{}).(*sql.Tx)
+	return tx, ok && tx != nil
+}
+
+// ExecutorFrom returns the transaction in ctx, or the connection of db when
+// there is none.
+func ExecutorFrom(ctx context.Context, db DB) Executor {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx
+	}
+	return db.Conn()
+}
+
+type afterCommitCtxKey struct{}
+
+type afterCommitHooks struct {
+	mu    sync.Mutex
+	hooks []func()
+}
+
+// InTransaction runs fn with a transaction of db stored in its context, or
+// joins the one ctx already carries. Hooks registered with AfterCommit run
+// once the transaction it started commits and are dropped if it rolls back.
+func InTransaction(ctx context.Context, db DB, fn func(ctx context.Context) error) error {
+	if _, inTx := TxFromContext(ctx); inTx {
+		return fn(ctx)
+	}
+
+	hooks := &afterCommitHooks{}
+	err := db.WithTransaction(ctx, nil, func(tx *sql.Tx) error {
+		return fn(context.WithValue(WithTx(ctx, tx), afterCommitCtxKey{}, hooks))
+	})
+	if err != nil {
+		return err
+	}
+
+	hooks.mu.Lock()
+	registered := hooks.hooks
+	hooks.hooks = nil
+	hooks.mu.Unlock()
+	for _, hook := range registered {
+		hook()
+	}
+
+	return nil
+}
+
+// AfterCommit defers fn until the transaction InTransaction stored in ctx
+// commits. It reports false when there is no such transaction, leaving the
+// caller to run fn right away.
+func AfterCommit(ctx context.Context, fn func()) bool {
+	hooks, ok := ctx.Value(afterCommitCtxKey{}).(*afterCommitHooks)
+	if !ok {
+		return false
+	}
+
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	hooks.hooks = append(hooks.hooks, fn)
+	return true
+}
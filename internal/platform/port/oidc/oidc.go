@@ -0,0 +1,49 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrNotConfigured is returned when the provider has no client
+	// credentials, so sign-in with it is disabled.
+	ErrNotConfigured = errors.New("identity provider is not configured")
+	// ErrExchange wraps any failure to redeem the authorization code or to
+	// verify the ID token that came back with it.
+	ErrExchange = errors.New("identity provider exchange failed")
+)
+
+// AuthURLInput binds the authorization request to the login attempt: the
+// state and nonce are echoed back and the verifier is only sent as its S256
+// challenge (PKCE, RFC 7636).
+type AuthURLInput struct {
+	State        string
+	Nonce        string
+	CodeVerifier string
+}
+
+type ExchangeInput struct {
+	Code         string
+	CodeVerifier string
+	Nonce        string
+}
+
+// Identity holds the verified ID token claims. Subject is stable per
+// provider; Email may change and is only trusted when EmailVerified is set.
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// Provider runs the OpenID Connect authorization code flow against one
+// identity provider.
+type Provider interface {
+	Name() string
+	AuthURL(ctx context.Context, input AuthURLInput) (string, error)
+	// Exchange redeems the code and verifies the ID token signature, issuer,
+	// audience, expiry and nonce. Errors wrap ErrNotConfigured or ErrExchange.
+	Exchange(ctx context.Context, input ExchangeInput) (Identity, error)
+}
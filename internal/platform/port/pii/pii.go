@@ -0,0 +1,70 @@
+package pii
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+// TagName marks payload struct fields that carry personal data, e.g. `pii:"true"`.
+const TagName = "pii"
+
+type KeyStore interface {
+	GetOrCreateKey(ctx context.Context, subjectID types.UUID) ([]byte, error)
+	GetKey(ctx context.Context, subjectID types.UUID) ([]byte, error)
+	DestroyKey(ctx context.Context, subjectID types.UUID) error
+}
+
+type EncryptPayloadInput struct {
+	SubjectID types.UUID
+	Payload   json.RawMessage
+	Fields    []string
+}
+
+type PayloadEncrypter interface {
+	EncryptPayload(ctx context.Context, input EncryptPayloadInput) (json.RawMessage, error)
+}
+
+type PayloadDecrypter interface {
+	DecryptPayload(ctx context.Context, payload json.RawMessage) (json.RawMessage, error)
+}
+
+type PayloadCipher interface {
+	PayloadEncrypter
+	PayloadDecrypter
+}
+
+type Shredder interface {
+	ForgetSubject(ctx context.Context, subjectID types.UUID) error
+}
+
+// SensitiveFields returns the JSON names of the fields of v tagged with `pii:"true"`.
+func SensitiveFields(v any) []string {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Tag.Get(TagName) != "true" {
+			continue
+		}
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, name)
+	}
+	return fields
+}
@@ -0,0 +1,59 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/marcelofabianov/redtogreen/internal/platform/types"
+)
+
+// TypeBearer is the token_type returned with access tokens (RFC 6750).
+const TypeBearer = "Bearer"
+
+var (
+	ErrInvalid = errors.New("invalid token")
+	ErrExpired = errors.New("token has expired")
+)
+
+// Claims holds the registered claims of an access token, the tenant its
+// subject acts for and the permissions granted when the token was issued.
+type Claims struct {
+	ID          string
+	Subject     types.UUID
+	TenantID    string
+	Issuer      string
+	Audience    []string
+	IssuedAt    time.Time
+	NotBefore   time.Time
+	ExpiresAt   time.Time
+	Permissions []string
+}
+
+func (c Claims) HasPermission(permission string) bool {
+	return slices.Contains(c.Permissions, permission)
+}
+
+type IssueInput struct {
+	Subject     types.UUID
+	TenantID    string
+	Permissions []string
+}
+
+type AccessToken struct {
+	Value     string
+	Type      string
+	ExpiresAt time.Time
+	Claims    Claims
+}
+
+type Issuer interface {
+	Issue(ctx context.Context, input IssueInput) (AccessToken, error)
+}
+
+// Verifier checks the signature and registered claims of a token. Errors wrap
+// ErrExpired for expired tokens and ErrInvalid for anything else.
+type Verifier interface {
+	Verify(ctx context.Context, value string) (Claims, error)
+}